@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/migrationarchive"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/viper"
+)
+
+// Sources of the source side of the validation, selected with --source
+const (
+	sourceAPI     = "api"     // The source API, the default
+	sourceArchive = "archive" // The extracted migration archive of --archive-path alone
+)
+
+// archiveSourceSelected reports whether --source archive was selected, failing on unknown sources
+func archiveSourceSelected() (bool, error) {
+	switch source := strings.ToLower(viper.GetString("SOURCE")); source {
+	case "", sourceAPI:
+		return false, nil
+	case sourceArchive:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported --source %q: use %s or %s", source, sourceAPI, sourceArchive)
+	}
+}
+
+// checkArchiveSourceVars validates the configuration of a validation against the migration archive alone,
+// which needs no source credentials
+func checkArchiveSourceVars() error {
+	if viper.GetBool("DRY_RUN") || viper.GetBool("STDIN") {
+		return fmt.Errorf("--source %s validates a single repository and does not support --dry-run or --stdin", sourceArchive)
+	}
+
+	archivePath := viper.GetString("ARCHIVE_PATH")
+	if archivePath == "" {
+		return fmt.Errorf("--source %s needs the extracted migration archive. Set it via --archive-path flag or GHMV_ARCHIVE_PATH environment variable", sourceArchive)
+	}
+	if info, err := os.Stat(archivePath); err != nil || !info.IsDir() {
+		return fmt.Errorf("archive path is not a directory: %s", archivePath)
+	}
+
+	for key, info := range requiredVars {
+		// The source is never queried: its organization and repository only select the repository of the archive
+		if key == "SOURCE_TOKEN" || (key == "TARGET_TOKEN" && viper.GetString("TARGET_APP_ID") != "") {
+			continue
+		}
+		if viper.GetString(key) == "" {
+			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable", key, info.flag, info.envVar)
+		}
+	}
+	return nil
+}
+
+// runArchiveValidation validates the target repository against the extracted migration archive of
+// --archive-path, without querying the source, e.g. after the source server was decommissioned
+func runArchiveValidation() {
+	if err := checkArchiveSourceVars(); err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+	targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+	sourceRepo := viper.GetString("SOURCE_REPO")
+	targetRepo := viper.GetString("TARGET_REPO")
+	archivePath := viper.GetString("ARCHIVE_PATH")
+
+	ghAPI, err := newTargetOnlyAPI()
+	if err != nil {
+		exitWithError("Failed to initialize target API", err)
+	}
+
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	signer := loadMarkdownReportSigner()
+	if _, err := acquireRunLock(targetOrganization + "/" + targetRepo); err != nil {
+		exitWithError("Migration validation failed", err)
+	}
+
+	// Organization archives hold several repositories, so only the entities of the source repository are counted
+	archiveSpinner, _ := pterm.DefaultSpinner.Start("Analyzing migration archive metrics...")
+	archiveMetrics, cached, err := migrationarchive.AnalyzeMigrationArchiveCached(archivePath, sourceOrganization+"/"+sourceRepo)
+	if err != nil {
+		archiveSpinner.Fail("Failed to analyze migration archive")
+		exitWithError("Migration validation failed", err)
+	}
+	analyzed := "analyzed"
+	if cached {
+		analyzed = "metrics read from " + migrationarchive.CacheFileName
+	}
+	archiveSpinner.Success(fmt.Sprintf("Migration archive %s - Issues: %d, PRs: %d, Protected Branches: %d, Releases: %d, Commit Comments: %d", analyzed,
+		archiveMetrics.Issues, archiveMetrics.PullRequests, archiveMetrics.ProtectedBranches, archiveMetrics.Releases, archiveMetrics.CommitComments))
+
+	migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+	migrationValidator.SetSourceDataFromExport(&validator.RepositoryData{
+		Owner:            sourceOrganization,
+		Name:             sourceRepo,
+		MigrationArchive: archiveMetrics,
+	})
+
+	results, err := migrationValidator.ValidateFromArchive(targetOrganization, targetRepo)
+	if err != nil {
+		exitWithError("Migration validation failed", err)
+	}
+
+	summary := migrationValidator.PrintValidationResults(results)
+	signReport(signer, viper.GetString("MARKDOWN_FILE"))
+	recordValidationHistory(migrationValidator, results)
+	recordAuditLog(ghAPI, api.TargetClient, "validate", migrationValidator, results)
+	publishValidationReport(ghAPI, migrationValidator, results)
+
+	exitOnUnavailableTarget(migrationValidator)
+	exitOnStrictFailure(migrationValidator, summary)
+}
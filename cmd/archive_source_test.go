@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestArchiveSourceSelected(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	for source, want := range map[string]bool{"": false, "api": false, "archive": true, "Archive": true} {
+		viper.Set("SOURCE", source)
+		selected, err := archiveSourceSelected()
+		if err != nil || selected != want {
+			t.Errorf("--source %q: expected %v, got %v (error: %v)", source, want, selected, err)
+		}
+	}
+
+	viper.Set("SOURCE", "export")
+	if _, err := archiveSourceSelected(); err == nil || !strings.Contains(err.Error(), `unsupported --source "export"`) {
+		t.Errorf("Expected an unsupported source error, got %v", err)
+	}
+}
+
+func TestCheckArchiveSourceVars(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	viper.Set("SOURCE_ORGANIZATION", "source-org")
+	viper.Set("SOURCE_REPO", "repo")
+	viper.Set("TARGET_ORGANIZATION", "target-org")
+	viper.Set("TARGET_REPO", "repo")
+	viper.Set("TARGET_TOKEN", "target-token")
+
+	if err := checkArchiveSourceVars(); err == nil || !strings.Contains(err.Error(), "--archive-path") {
+		t.Errorf("Expected the archive path to be required, got %v", err)
+	}
+
+	viper.Set("ARCHIVE_PATH", t.TempDir())
+	if err := checkArchiveSourceVars(); err != nil {
+		t.Errorf("Expected no source token to be needed, got %v", err)
+	}
+
+	viper.Set("TARGET_TOKEN", "")
+	if err := checkArchiveSourceVars(); err == nil || !strings.Contains(err.Error(), "TARGET_TOKEN is required") {
+		t.Errorf("Expected the target token to be required, got %v", err)
+	}
+
+	viper.Set("TARGET_TOKEN", "target-token")
+	viper.Set("STDIN", true)
+	if err := checkArchiveSourceVars(); err == nil || !strings.Contains(err.Error(), "--stdin") {
+		t.Errorf("Expected batches to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/audit"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyAuditLogCmd represents the verify-audit-log command
+var verifyAuditLogCmd = &cobra.Command{
+	Use:   "verify-audit-log <file>",
+	Short: "Check that an audit log written with --audit-log has not been modified",
+	Long: `Check the hash chain of an audit log written with --audit-log. Every line records the
+SHA-256 of the line before it, so removing, reordering or editing lines is detected.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		count, err := audit.Verify(args[0])
+		if err != nil {
+			fmt.Printf("Audit log verification failed after %d valid entries: %v\n", count, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Audit log %s is intact (%d entries)\n", args[0], count)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyAuditLogCmd)
+}
+
+// recordAuditLog appends the run to the --audit-log file, reading the login of the token used with clientType
+func recordAuditLog(ghAPI *api.GitHubAPI, clientType api.ClientType, command string, mv *validator.MigrationValidator, results []validator.ValidationResult) {
+	auditLog := viper.GetString("AUDIT_LOG")
+	if auditLog == "" {
+		return
+	}
+
+	summary := validator.Summarize(results)
+	entry := audit.Entry{
+		Timestamp:   time.Now().UTC(),
+		User:        currentUser(),
+		Command:     command,
+		Source:      fmt.Sprintf("%s/%s", mv.SourceData.Owner, mv.SourceData.Name),
+		Target:      fmt.Sprintf("%s/%s", mv.TargetData.Owner, mv.TargetData.Name),
+		Verdict:     summary.Verdict.String(),
+		Passed:      summary.Passed,
+		Failed:      summary.Failed,
+		Warnings:    summary.Warnings,
+		ToolVersion: currentVersion(),
+	}
+	entry.Host, _ = os.Hostname()
+	// GitHub App installation tokens have no login, which is left out
+	entry.GitHubLogin, _ = ghAPI.GetAuthenticatedLogin(clientType)
+
+	if err := audit.Append(auditLog, entry); err != nil {
+		fmt.Printf("Failed to write audit log: %v\n", err)
+	}
+}
+
+// currentUser returns the name of the operating system user running the tool
+func currentUser() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
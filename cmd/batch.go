@@ -0,0 +1,394 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// repositoryPair is the source and target repository of one validation of a batch
+type repositoryPair struct {
+	sourceOwner, sourceRepo string
+	targetOwner, targetRepo string
+}
+
+// batchValidateFunc validates one repository pair of a batch, stopping when ctx is done
+type batchValidateFunc func(ctx context.Context, pair repositoryPair) validator.RepositoryReport
+
+// batchOptions control when a batch stops validating a repository, or the whole batch
+type batchOptions struct {
+	maxFailures int           // Failed repositories after which the batch stops, 0 to validate every repository
+	repoTimeout time.Duration // Time limit of the validation of each repository, none when 0
+}
+
+// Output formats of the reports of a batch, selected with --output-format
+const (
+	outputFormatNDJSON = "ndjson" // One JSON object per line and repository
+	outputFormatText   = "text"   // The markdown report of each repository
+)
+
+// batchReportWriter writes the report of a repository of a batch as soon as it is validated
+type batchReportWriter func(report validator.RepositoryReport) error
+
+// newBatchReportWriter returns the writer of the reports of a batch in format to output
+func newBatchReportWriter(format string, output io.Writer) (batchReportWriter, error) {
+	switch format {
+	case "", outputFormatNDJSON:
+		return func(report validator.RepositoryReport) error {
+			data, err := report.JSONLine()
+			if err != nil {
+				return fmt.Errorf("failed to encode the report of %s: %v", report.Source, err)
+			}
+			_, err = fmt.Fprintf(output, "%s\n", data)
+			return err
+		}, nil
+	case outputFormatText:
+		return func(report validator.RepositoryReport) error {
+			if report.TimedOut() {
+				_, err := fmt.Fprintf(output, "Validation of %s: TIMEOUT (%v)\n\n", report.Source, report.Err)
+				return err
+			}
+			if report.Interrupted() {
+				_, err := fmt.Fprintf(output, "Validation of %s: INTERRUPTED (%v)\n\n", report.Source, report.Err)
+				return err
+			}
+			if report.Err != nil {
+				_, err := fmt.Fprintf(output, "Validation of %s failed: %v\n\n", report.Source, report.Err)
+				return err
+			}
+			_, err := fmt.Fprintf(output, "%s\n", report.Markdown)
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected %s or %s", format, outputFormatNDJSON, outputFormatText)
+	}
+}
+
+// writeWaveSummary writes the roll-up of a batch for the terminal to progress, and as a final JSON line after
+// the reports of the repositories to output when they are written as NDJSON
+func writeWaveSummary(format string, summary validator.WaveSummary, output, progress io.Writer) error {
+	fmt.Fprintln(progress)
+	if err := summary.WriteText(progress); err != nil {
+		return err
+	}
+	if format != "" && format != outputFormatNDJSON {
+		return nil
+	}
+
+	data, err := summary.JSONLine()
+	if err != nil {
+		return fmt.Errorf("failed to encode wave summary: %v", err)
+	}
+	_, err = fmt.Fprintf(output, "%s\n", data)
+	return err
+}
+
+// runStdinBatch validates the repository pairs read from stdin as they arrive, writing the report of each
+// repository to stdout in the --output-format format, followed by the wave summary of the batch. Progress
+// messages are written to stderr so stdout can be piped to other tools.
+func runStdinBatch() {
+	if err := checkBatchVars(); err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	writeReport, err := newBatchReportWriter(viper.GetString("OUTPUT_FORMAT"), os.Stdout)
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	options, err := getBatchOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	ghAPI, err := newGitHubAPI()
+	if err != nil {
+		exitWithError("Failed to initialize API clients", err)
+	}
+
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	validationOptions.Progress = os.Stderr
+
+	// The first Ctrl-C stops the batch and writes the reports so far, a second one exits immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	reports, err := validateBatch(ctx, os.Stdin, writeReport, options, func(ctx context.Context, pair repositoryPair) validator.RepositoryReport {
+		// Repositories are validated one at a time, so the retry statistics cover the current one only
+		api.ResetRetryStatistics()
+		held, err := acquireRunLock(pair.target())
+		if err != nil {
+			return validator.RepositoryReport{Source: pair.source(), Target: pair.target(), Err: err}
+		}
+		defer releaseRunLock(held)
+
+		migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+		results, err := migrationValidator.ValidateMigrationContext(ctx, pair.sourceOwner, pair.sourceRepo, pair.targetOwner, pair.targetRepo)
+		if err != nil {
+			return validator.RepositoryReport{Source: pair.source(), Target: pair.target(), Err: err}
+		}
+		return migrationValidator.RepositoryReport(results)
+	})
+	interrupted := errors.Is(err, api.ErrInterrupted)
+	if err != nil && !interrupted {
+		exitWithError("Failed to read repositories from stdin", err)
+	}
+	if interrupted {
+		fmt.Fprintf(os.Stderr, "Batch interrupted, writing the reports of the %d repositories processed so far\n", len(reports))
+	}
+
+	if err := writeWaveSummary(viper.GetString("OUTPUT_FORMAT"), validator.SummarizeWave(reports), os.Stdout, os.Stderr); err != nil {
+		exitWithError("Failed to write wave summary", err)
+	}
+
+	if markdownFile := viper.GetString("MARKDOWN_FILE"); markdownFile != "" {
+		if err := validator.WriteBatchMarkdownFile(reports, markdownFile); err != nil {
+			exitWithError("Failed to write markdown report", err)
+		}
+		fmt.Fprintf(os.Stderr, "Batch report written to %s\n", markdownFile)
+	}
+
+	if xlsxFile := viper.GetString("XLSX_FILE"); xlsxFile != "" {
+		if err := validator.WriteBatchXLSXFile(reports, xlsxFile); err != nil {
+			exitWithError("Failed to write XLSX report", err)
+		}
+		fmt.Fprintf(os.Stderr, "Batch XLSX report written to %s\n", xlsxFile)
+	}
+
+	if interrupted {
+		releaseRunLocks()
+		shutdownTelemetry()
+		os.Exit(exitInterrupted)
+	}
+
+	if viper.GetBool("STRICT_EXIT") {
+		for _, report := range reports {
+			if batchReportFailed(report) {
+				releaseRunLocks()
+				shutdownTelemetry()
+				os.Exit(exitValidationFailed)
+			}
+		}
+	}
+}
+
+// validateBatch validates the repository pair of every line of input, writing the report of each repository
+// as soon as it is validated. Empty lines and lines starting with # are skipped; lines that cannot be parsed
+// are reported as errors without stopping the batch. The batch stops once options.maxFailures repositories
+// failed, unless it is 0. When ctx is done, e.g. on Ctrl-C, the current validation is reported as interrupted
+// and the reports so far are returned with an error wrapping api.ErrInterrupted.
+func validateBatch(ctx context.Context, input io.Reader, writeReport batchReportWriter, options batchOptions, validate batchValidateFunc) ([]validator.RepositoryReport, error) {
+	sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+	targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+
+	// Lines are read in the background, so an interrupt does not wait for the next line of an interactive input
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+	lines := make(chan string)
+	var scanErr error
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(input)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-readCtx.Done():
+				return
+			}
+		}
+		scanErr = scanner.Err()
+	}()
+
+	var reports []validator.RepositoryReport
+	failures := 0
+	for {
+		var line string
+		var ok bool
+		select {
+		case line, ok = <-lines:
+		case <-ctx.Done():
+			return reports, fmt.Errorf("batch %w", api.ErrInterrupted)
+		}
+		if !ok {
+			return reports, scanErr
+		}
+		if ctx.Err() != nil {
+			return reports, fmt.Errorf("batch %w", api.ErrInterrupted)
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var report validator.RepositoryReport
+		pair, err := parseRepositoryPair(line, sourceOrganization, targetOrganization)
+		if err != nil {
+			report = validator.RepositoryReport{Source: line, Err: err}
+		} else {
+			report = validateWithTimeout(ctx, validate, pair, options.repoTimeout)
+		}
+		reports = append(reports, report)
+
+		if err := writeReport(report); err != nil {
+			return reports, err
+		}
+		if report.Interrupted() {
+			return reports, fmt.Errorf("batch %w", api.ErrInterrupted)
+		}
+
+		if batchReportFailed(report) {
+			failures++
+		}
+		if options.maxFailures > 0 && failures >= options.maxFailures {
+			fmt.Fprintf(os.Stderr, "Stopping the batch after %d failed repositories, the remaining repositories are not validated\n", failures)
+			return reports, nil
+		}
+	}
+}
+
+// batchReportFailed reports whether a repository of a batch failed: validations failed or it could not be validated
+func batchReportFailed(report validator.RepositoryReport) bool {
+	return report.Err != nil || report.Summary.Failed > 0
+}
+
+// validateWithTimeout validates pair, giving up once timeout has elapsed when it is set, or once ctx is done.
+// A validation that times out is reported with an error wrapping api.ErrTimeout, and one stopped by ctx with
+// an error wrapping api.ErrInterrupted. The requests of a cancelled validation are aborted, and
+// validateWithTimeout waits for it to return so that it never overlaps the validation of the next repository.
+func validateWithTimeout(ctx context.Context, validate batchValidateFunc, pair repositoryPair, timeout time.Duration) validator.RepositoryReport {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	done := make(chan validator.RepositoryReport, 1)
+	go func() {
+		done <- validate(ctx, pair)
+	}()
+
+	select {
+	case report := <-done:
+		return report
+	case <-ctx.Done():
+		<-done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return validator.RepositoryReport{Source: pair.source(), Target: pair.target(),
+				Err: fmt.Errorf("validation did not finish within %s: %w", timeout, api.ErrTimeout)}
+		}
+		return validator.RepositoryReport{Source: pair.source(), Target: pair.target(),
+			Err: fmt.Errorf("validation was stopped before it finished: %w", api.ErrInterrupted)}
+	}
+}
+
+// getBatchOptions builds the batch options from --fail-fast, --max-failures and --repo-timeout
+func getBatchOptions() (batchOptions, error) {
+	failFast := viper.GetBool("FAIL_FAST")
+	options := batchOptions{
+		maxFailures: viper.GetInt("MAX_FAILURES"),
+		repoTimeout: viper.GetDuration("REPO_TIMEOUT"),
+	}
+
+	if failFast && options.maxFailures != 0 {
+		return options, fmt.Errorf("--fail-fast and --max-failures are mutually exclusive")
+	}
+	if options.maxFailures < 0 {
+		return options, fmt.Errorf("--max-failures must not be negative, got %d", options.maxFailures)
+	}
+	if options.repoTimeout < 0 {
+		return options, fmt.Errorf("--repo-timeout must not be negative, got %s", options.repoTimeout)
+	}
+	if failFast {
+		options.maxFailures = 1
+	}
+	return options, nil
+}
+
+// parseRepositoryPair parses a line of batch input: a source repository followed by an optional target
+// repository, separated by whitespace or a comma. Repositories are OWNER/REPO, or REPO in the organization
+// set with --source-org or --target-org; a missing target has the name of the source in the target organization.
+func parseRepositoryPair(line, sourceOrganization, targetOrganization string) (repositoryPair, error) {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 || len(fields) > 2 {
+		return repositoryPair{}, fmt.Errorf("invalid line %q: expected SOURCE-OWNER/REPO [TARGET-OWNER/REPO]", line)
+	}
+
+	var pair repositoryPair
+	var err error
+	if pair.sourceOwner, pair.sourceRepo, err = parseBatchRepository(fields[0], sourceOrganization, "--source-org"); err != nil {
+		return repositoryPair{}, err
+	}
+
+	target := pair.sourceRepo
+	if len(fields) == 2 {
+		target = fields[1]
+	}
+	if pair.targetOwner, pair.targetRepo, err = parseBatchRepository(target, targetOrganization, "--target-org"); err != nil {
+		return repositoryPair{}, err
+	}
+	return pair, nil
+}
+
+// parseBatchRepository splits an OWNER/REPO value, or returns REPO in organization when value has no owner
+func parseBatchRepository(value, organization, organizationFlag string) (string, string, error) {
+	if strings.Contains(value, "/") {
+		return parseRepository(value)
+	}
+	if organization == "" {
+		return "", "", fmt.Errorf("invalid repository %q: expected OWNER/REPO, or set %s", value, organizationFlag)
+	}
+	return organization, value, nil
+}
+
+// source returns the OWNER/REPO of the source repository
+func (p repositoryPair) source() string {
+	return p.sourceOwner + "/" + p.sourceRepo
+}
+
+// target returns the OWNER/REPO of the target repository
+func (p repositoryPair) target() string {
+	return p.targetOwner + "/" + p.targetRepo
+}
+
+// checkBatchVars validates the configuration of a batch, whose repositories are read from its input
+func checkBatchVars() error {
+	for _, key := range []string{"SOURCE_TOKEN", "TARGET_TOKEN"} {
+		info := requiredVars[key]
+		if key == "TARGET_TOKEN" && targetReusesSourceCredentials() {
+			continue
+		}
+		if viper.GetString(key) == "" {
+			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable",
+				key, info.flag, info.envVar)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepositoryPair(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		expected      repositoryPair
+		expectedError string
+	}{
+		{name: "source and target", line: "source-org/repo target-org/new-repo",
+			expected: repositoryPair{"source-org", "repo", "target-org", "new-repo"}},
+		{name: "comma-separated", line: "source-org/repo,target-org/repo",
+			expected: repositoryPair{"source-org", "repo", "target-org", "repo"}},
+		{name: "target in the target organization", line: "source-org/repo",
+			expected: repositoryPair{"source-org", "repo", "default-target", "repo"}},
+		{name: "names only", line: "repo\tnew-repo",
+			expected: repositoryPair{"default-source", "repo", "default-target", "new-repo"}},
+		{name: "too many fields", line: "a/b c/d e/f",
+			expectedError: `invalid line "a/b c/d e/f": expected SOURCE-OWNER/REPO [TARGET-OWNER/REPO]`},
+		{name: "invalid repository", line: "a/b/c",
+			expectedError: `invalid repository "a/b/c": expected OWNER/REPO`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := parseRepositoryPair(tt.line, "default-source", "default-target")
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pair)
+		})
+	}
+
+	_, err := parseRepositoryPair("repo", "", "target-org")
+	assert.EqualError(t, err, `invalid repository "repo": expected OWNER/REPO, or set --source-org`)
+}
+
+func TestValidateBatch(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+	viper.Set("TARGET_ORGANIZATION", "target-org")
+
+	input := strings.NewReader("# repositories of wave 1\nsource-org/repo-a\n\nsource-org/missing\na/b c/d e/f\n")
+	var output bytes.Buffer
+	var validated []repositoryPair
+	writeReport, err := newBatchReportWriter("", &output)
+	require.NoError(t, err)
+
+	reports, err := validateBatch(context.Background(), input, writeReport, batchOptions{}, func(ctx context.Context, pair repositoryPair) validator.RepositoryReport {
+		validated = append(validated, pair)
+		if pair.sourceRepo == "missing" {
+			return validator.RepositoryReport{Source: pair.source(), Target: pair.target(), Err: fmt.Errorf("repository not found")}
+		}
+		return validator.RepositoryReport{Source: pair.source(), Target: pair.target(),
+			Summary: validator.Summary{Passed: 1, Verdict: validator.VerdictPassed}}
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, reports, 3)
+	assert.Equal(t, []repositoryPair{
+		{"source-org", "repo-a", "target-org", "repo-a"},
+		{"source-org", "missing", "target-org", "missing"},
+	}, validated)
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	require.Len(t, lines, 3, "one JSON line per repository")
+
+	var reported []map[string]interface{}
+	for _, line := range lines {
+		var report map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &report))
+		reported = append(reported, report)
+	}
+	assert.Equal(t, "target-org/repo-a", reported[0]["target"])
+	assert.Equal(t, "passed", reported[0]["summary"].(map[string]interface{})["verdict"])
+	assert.Equal(t, "repository not found", reported[1]["error"])
+	assert.Equal(t, "a/b c/d e/f", reported[2]["source"])
+	assert.Contains(t, reported[2]["error"], "invalid line")
+}
+
+func TestNewBatchReportWriter_Text(t *testing.T) {
+	var output bytes.Buffer
+	writeReport, err := newBatchReportWriter(outputFormatText, &output)
+	require.NoError(t, err)
+
+	require.NoError(t, writeReport(validator.RepositoryReport{Source: "source-org/repo-a", Markdown: "# Migration Validation Report\n"}))
+	require.NoError(t, writeReport(validator.RepositoryReport{Source: "source-org/repo-b", Err: fmt.Errorf("repository not found")}))
+
+	assert.Equal(t, "# Migration Validation Report\n\nValidation of source-org/repo-b failed: repository not found\n\n", output.String())
+
+	_, err = newBatchReportWriter("yaml", &output)
+	assert.EqualError(t, err, `unknown output format "yaml", expected ndjson or text`)
+}
+
+func TestWriteWaveSummary(t *testing.T) {
+	summary := validator.SummarizeWave([]validator.RepositoryReport{
+		{Source: "source-org/repo-a", Target: "target-org/repo-a", Summary: validator.Summary{Passed: 1}},
+		{Source: "source-org/repo-b", Target: "target-org/repo-b", Err: fmt.Errorf("repository not found")},
+	})
+
+	var output, progress bytes.Buffer
+	require.NoError(t, writeWaveSummary("", summary, &output, &progress))
+	assert.Contains(t, progress.String(), "Wave summary: 1 of 2 repositories passed (50.0%)")
+	assert.True(t, strings.HasPrefix(output.String(), `{"wave_summary":{"repositories":2,"passed":1,"pass_rate":50,`))
+	assert.True(t, strings.HasSuffix(output.String(), "}\n"))
+
+	output.Reset()
+	progress.Reset()
+	require.NoError(t, writeWaveSummary(outputFormatText, summary, &output, &progress))
+	assert.Empty(t, output.String(), "the JSON line only follows NDJSON reports")
+	assert.Contains(t, progress.String(), "Wave summary")
+}
+
+func TestValidateBatch_MaxFailures(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	input := strings.NewReader("source-org/repo-a target-org/repo-a\nsource-org/repo-b target-org/repo-b\nsource-org/repo-c target-org/repo-c\nsource-org/repo-d target-org/repo-d\n")
+	writeReport, err := newBatchReportWriter("", io.Discard)
+	require.NoError(t, err)
+
+	var validated []string
+	reports, err := validateBatch(context.Background(), input, writeReport, batchOptions{maxFailures: 2}, func(ctx context.Context, pair repositoryPair) validator.RepositoryReport {
+		validated = append(validated, pair.sourceRepo)
+		if pair.sourceRepo == "repo-a" {
+			return validator.RepositoryReport{Source: pair.source(), Summary: validator.Summary{Passed: 1, Verdict: validator.VerdictPassed}}
+		}
+		return validator.RepositoryReport{Source: pair.source(), Summary: validator.Summary{Failed: 1, Verdict: validator.VerdictFailed}}
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, reports, 3)
+	assert.Equal(t, []string{"repo-a", "repo-b", "repo-c"}, validated, "the batch stops at the second failure")
+}
+
+func TestValidateBatch_RepoTimeout(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	input := strings.NewReader("source-org/slow target-org/slow\nsource-org/fast target-org/fast\n")
+	var output bytes.Buffer
+	writeReport, err := newBatchReportWriter("", &output)
+	require.NoError(t, err)
+
+	var events []string
+	reports, err := validateBatch(context.Background(), input, writeReport, batchOptions{repoTimeout: 20 * time.Millisecond}, func(ctx context.Context, pair repositoryPair) validator.RepositoryReport {
+		if pair.sourceRepo == "slow" {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			events = append(events, "slow stopped")
+		}
+		events = append(events, pair.sourceRepo+" returned")
+		return validator.RepositoryReport{Source: pair.source(), Summary: validator.Summary{Passed: 1, Verdict: validator.VerdictPassed}}
+	})
+
+	require.NoError(t, err)
+	require.Len(t, reports, 2, "the batch continues after a timeout")
+	assert.True(t, reports[0].TimedOut())
+	assert.ErrorIs(t, reports[0].Err, api.ErrTimeout)
+	assert.NoError(t, reports[1].Err)
+	assert.Contains(t, output.String(), `"error":"validation did not finish within 20ms: timed out","error_code":"timeout"`)
+	assert.Equal(t, []string{"slow stopped", "slow returned", "fast returned"}, events, "the timed-out validation returns before the next one starts")
+}
+
+func TestValidateBatch_Interrupted(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	input := strings.NewReader("source-org/done target-org/done\nsource-org/slow target-org/slow\nsource-org/never target-org/never\n")
+	var output bytes.Buffer
+	writeReport, err := newBatchReportWriter("", &output)
+	require.NoError(t, err)
+
+	ctx, interrupt := context.WithCancel(context.Background())
+	defer interrupt()
+	var validated []string
+	reports, err := validateBatch(ctx, input, writeReport, batchOptions{}, func(ctx context.Context, pair repositoryPair) validator.RepositoryReport {
+		validated = append(validated, pair.sourceRepo)
+		if pair.sourceRepo == "slow" {
+			interrupt()
+			<-ctx.Done()
+		}
+		return validator.RepositoryReport{Source: pair.source(), Summary: validator.Summary{Passed: 1, Verdict: validator.VerdictPassed}}
+	})
+
+	require.ErrorIs(t, err, api.ErrInterrupted)
+	require.Len(t, reports, 2, "the reports completed before the interrupt are kept")
+	assert.NoError(t, reports[0].Err)
+	assert.True(t, reports[1].Interrupted())
+	assert.Equal(t, []string{"done", "slow"}, validated)
+	assert.Contains(t, output.String(), `"error_code":"interrupted"`)
+	assert.Equal(t, exitInterrupted, exitCode(err))
+}
+
+func TestGetBatchOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		failFast      bool
+		maxFailures   int
+		repoTimeout   string
+		expected      batchOptions
+		expectedError string
+	}{
+		{name: "whole batch", expected: batchOptions{}},
+		{name: "fail fast", failFast: true, expected: batchOptions{maxFailures: 1}},
+		{name: "max failures and timeout", maxFailures: 5, repoTimeout: "15m", expected: batchOptions{maxFailures: 5, repoTimeout: 15 * time.Minute}},
+		{name: "both", failFast: true, maxFailures: 5, expectedError: "--fail-fast and --max-failures are mutually exclusive"},
+		{name: "negative", maxFailures: -1, expectedError: "--max-failures must not be negative, got -1"},
+		{name: "negative timeout", repoTimeout: "-1m", expectedError: "--repo-timeout must not be negative, got -1m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetViperAndEnv()
+			defer resetViperAndEnv()
+			viper.Set("FAIL_FAST", tt.failFast)
+			viper.Set("MAX_FAILURES", tt.maxFailures)
+			viper.Set("REPO_TIMEOUT", tt.repoTimeout)
+
+			options, err := getBatchOptions()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/spf13/viper"
+)
+
+// sourceClientConfig returns the configuration of the source API clients
+func sourceClientConfig() api.ClientConfig {
+	download, _ := downloadConfig() // Checked by the commands downloading migration archives
+	return api.ClientConfig{
+		Token:          viper.GetString("SOURCE_TOKEN"),
+		Hostname:       viper.GetString("SOURCE_HOSTNAME"),
+		APIURL:         viper.GetString("SOURCE_API_URL"),
+		AppID:          viper.GetString("SOURCE_APP_ID"),
+		PrivateKey:     []byte(viper.GetString("SOURCE_PRIVATE_KEY")),
+		InstallationID: viper.GetInt64("SOURCE_INSTALLATION_ID"),
+		Retry:          retryConfig(),
+		LFSBatch:       lfsBatchConfig(),
+		LFSServer:      lfsServerConfig("SOURCE"),
+		MaxTreeEntries: max(viper.GetInt("MAX_TREE_ENTRIES"), 0),
+		Download:       download,
+		RecordDir:      viper.GetString("DEBUG_HTTP"),
+		ReplayDir:      viper.GetString("REPLAY_HTTP"),
+		UserAgent:      api.UserAgent(currentVersion(), "source", viper.GetString("RUN_ID")),
+		RunID:          viper.GetString("RUN_ID"),
+	}
+}
+
+// targetClientConfig returns the configuration of the target API clients
+func targetClientConfig() api.ClientConfig {
+	// Validating within one organization only needs the source credentials
+	if targetReusesSourceCredentials() {
+		config := sourceClientConfig()
+		config.LFSServer = lfsServerConfig("TARGET")
+		config.UserAgent = api.UserAgent(currentVersion(), "target", viper.GetString("RUN_ID"))
+		return config
+	}
+
+	return api.ClientConfig{
+		Token:          viper.GetString("TARGET_TOKEN"),
+		Hostname:       viper.GetString("TARGET_HOSTNAME"),
+		APIURL:         viper.GetString("TARGET_API_URL"),
+		AppID:          viper.GetString("TARGET_APP_ID"),
+		PrivateKey:     []byte(viper.GetString("TARGET_PRIVATE_KEY")),
+		InstallationID: viper.GetInt64("TARGET_INSTALLATION_ID"),
+		Retry:          retryConfig(),
+		LFSBatch:       lfsBatchConfig(),
+		LFSServer:      lfsServerConfig("TARGET"),
+		MaxTreeEntries: max(viper.GetInt("MAX_TREE_ENTRIES"), 0),
+		RecordDir:      viper.GetString("DEBUG_HTTP"),
+		ReplayDir:      viper.GetString("REPLAY_HTTP"),
+		UserAgent:      api.UserAgent(currentVersion(), "target", viper.GetString("RUN_ID")),
+		RunID:          viper.GetString("RUN_ID"),
+	}
+}
+
+// authMode returns how the clients of a side, SOURCE or TARGET, authenticate: "app" with a GitHub App, or "token"
+func authMode(side string) string {
+	if viper.GetString(side+"_APP_ID") != "" {
+		return "app"
+	}
+	return "token"
+}
+
+// lfsServerConfig returns the standalone LFS server of a side, SOURCE or TARGET, set with --source-lfs-url or
+// --target-lfs-url and their credentials
+func lfsServerConfig(side string) api.LFSServerConfig {
+	return api.LFSServerConfig{
+		URL:      viper.GetString(side + "_LFS_URL"),
+		Username: viper.GetString(side + "_LFS_USERNAME"),
+		Token:    viper.GetString(side + "_LFS_TOKEN"),
+	}
+}
+
+// targetReusesSourceCredentials reports whether the target is validated with the source credentials: when no
+// target token or GitHub App is configured and both repositories are in the same organization on the same instance
+func targetReusesSourceCredentials() bool {
+	if viper.GetString("TARGET_TOKEN") != "" || viper.GetString("TARGET_APP_ID") != "" {
+		return false
+	}
+
+	sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+	return sourceOrganization != "" &&
+		strings.EqualFold(sourceOrganization, viper.GetString("TARGET_ORGANIZATION")) &&
+		api.SameHostname(viper.GetString("SOURCE_HOSTNAME"), viper.GetString("TARGET_HOSTNAME")) &&
+		viper.GetString("SOURCE_API_URL") == viper.GetString("TARGET_API_URL")
+}
+
+// retryConfig returns the retry configuration set with --max-retries, --retry-backoff and --retry-jitter
+func retryConfig() api.RetryConfig {
+	defaults := api.DefaultRetryConfig()
+	viper.SetDefault("MAX_RETRIES", defaults.MaxRetries)
+	viper.SetDefault("RETRY_BACKOFF", defaults.Backoff)
+	viper.SetDefault("RETRY_JITTER", defaults.Jitter)
+
+	return api.RetryConfig{
+		MaxRetries: max(viper.GetInt("MAX_RETRIES"), 0),
+		Backoff:    viper.GetDuration("RETRY_BACKOFF"),
+		Jitter:     viper.GetDuration("RETRY_JITTER"),
+	}
+}
+
+// lfsBatchConfig returns the LFS batch configuration set with --lfs-batch-size and --lfs-concurrency
+func lfsBatchConfig() api.LFSBatchConfig {
+	defaults := api.DefaultLFSBatchConfig()
+	viper.SetDefault("LFS_BATCH_SIZE", defaults.ChunkSize)
+	viper.SetDefault("LFS_CONCURRENCY", defaults.Concurrency)
+
+	return api.LFSBatchConfig{
+		ChunkSize:   viper.GetInt("LFS_BATCH_SIZE"),
+		Concurrency: viper.GetInt("LFS_CONCURRENCY"),
+	}
+}
+
+// downloadConfig returns the migration archive download configuration set with --download-rate-limit and
+// --download-parts
+func downloadConfig() (api.DownloadConfig, error) {
+	rateLimit, err := api.ParseByteRate(viper.GetString("DOWNLOAD_RATE_LIMIT"))
+	if err != nil {
+		return api.DownloadConfig{}, fmt.Errorf("--download-rate-limit: %v", err)
+	}
+	return api.DownloadConfig{RateLimit: rateLimit, Parts: max(viper.GetInt("DOWNLOAD_PARTS"), 1)}, nil
+}
+
+// newGitHubAPI creates the source and target API clients
+func newGitHubAPI() (*api.GitHubAPI, error) {
+	return api.NewGitHubAPI(sourceClientConfig(), targetClientConfig())
+}
+
+// newSourceOnlyAPI creates the source API clients, for commands that only read the source
+func newSourceOnlyAPI() (*api.GitHubAPI, error) {
+	return api.NewSourceOnlyAPI(sourceClientConfig())
+}
+
+// newTargetOnlyAPI creates the target API clients, for commands that only read the target
+func newTargetOnlyAPI() (*api.GitHubAPI, error) {
+	return api.NewTargetOnlyAPI(targetClientConfig())
+}
+
+// rateLimitThreshold returns the remaining requests below which a low rate limit is reported (default 50)
+func rateLimitThreshold() int {
+	viper.SetDefault("RATE_LIMIT_THRESHOLD", 50)
+	return viper.GetInt("RATE_LIMIT_THRESHOLD")
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/spf13/viper"
+)
+
+func TestTargetReusesSourceCredentials(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	viper.Set("SOURCE_TOKEN", "source-token")
+	viper.Set("TARGET_TOKEN", "target-token")
+	viper.Set("SOURCE_ORGANIZATION", "my-org")
+	viper.Set("TARGET_ORGANIZATION", "my-org")
+	if targetReusesSourceCredentials() {
+		t.Error("Expected a configured target token to be used")
+	}
+
+	viper.Set("TARGET_TOKEN", "")
+	if !targetReusesSourceCredentials() {
+		t.Error("Expected the source credentials to be reused within one organization")
+	}
+	if config := targetClientConfig(); config.Token != "source-token" {
+		t.Errorf("Expected the target config to use the source token, got %q", config.Token)
+	}
+
+	viper.Set("TARGET_HOSTNAME", "github.example.com")
+	if targetReusesSourceCredentials() {
+		t.Error("Expected a target on another instance not to reuse the source credentials")
+	}
+
+	viper.Set("TARGET_HOSTNAME", nil)
+	viper.Set("TARGET_API_URL", "https://gateway.example.com/github")
+	if targetReusesSourceCredentials() {
+		t.Error("Expected a target behind another API URL not to reuse the source credentials")
+	}
+
+	viper.Set("TARGET_API_URL", nil)
+	viper.Set("TARGET_ORGANIZATION", "other-org")
+	if targetReusesSourceCredentials() {
+		t.Error("Expected a target in another organization not to reuse the source credentials")
+	}
+}
+
+func TestRetryConfig(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	if got := retryConfig(); got != api.DefaultRetryConfig() {
+		t.Errorf("retryConfig() = %+v, want the defaults %+v", got, api.DefaultRetryConfig())
+	}
+
+	viper.Set("MAX_RETRIES", 5)
+	viper.Set("RETRY_BACKOFF", "250ms")
+
+	expected := api.RetryConfig{MaxRetries: 5, Backoff: 250 * time.Millisecond, Jitter: api.DefaultRetryConfig().Jitter}
+	if got := retryConfig(); got != expected {
+		t.Errorf("retryConfig() = %+v, want %+v", got, expected)
+	}
+
+	viper.Set("MAX_RETRIES", -1)
+	if got := retryConfig(); got.MaxRetries != 0 {
+		t.Errorf("Expected a negative retry count to disable retries, got %d", got.MaxRetries)
+	}
+}
+
+func TestLFSBatchConfig(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	if got := lfsBatchConfig(); got != api.DefaultLFSBatchConfig() {
+		t.Errorf("lfsBatchConfig() = %+v, want the defaults %+v", got, api.DefaultLFSBatchConfig())
+	}
+
+	viper.Set("LFS_BATCH_SIZE", 500)
+	viper.Set("LFS_CONCURRENCY", 8)
+
+	expected := api.LFSBatchConfig{ChunkSize: 500, Concurrency: 8}
+	if got := lfsBatchConfig(); got != expected {
+		t.Errorf("lfsBatchConfig() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestLFSServerConfig(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	viper.Set("SOURCE_ORGANIZATION", "org")
+	viper.Set("TARGET_ORGANIZATION", "org")
+	viper.Set("SOURCE_TOKEN", "source-token")
+	viper.Set("SOURCE_LFS_URL", "https://artifactory.example.com/artifactory/api/lfs/lfs-local")
+	viper.Set("SOURCE_LFS_USERNAME", "deployer")
+	viper.Set("SOURCE_LFS_TOKEN", "lfs-token")
+
+	expected := api.LFSServerConfig{URL: "https://artifactory.example.com/artifactory/api/lfs/lfs-local", Username: "deployer", Token: "lfs-token"}
+	if got := sourceClientConfig().LFSServer; got != expected {
+		t.Errorf("sourceClientConfig().LFSServer = %+v, want %+v", got, expected)
+	}
+
+	// A target reusing the source credentials keeps its own LFS server
+	if got := targetClientConfig().LFSServer; got != (api.LFSServerConfig{}) {
+		t.Errorf("Expected the target not to use the source LFS server, got %+v", got)
+	}
+}
+
+func TestDownloadConfig(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	config, err := downloadConfig()
+	if err != nil || config.RateLimit != 0 || config.Parts != 1 {
+		t.Errorf("Expected unlimited single-request downloads by default, got %+v (error: %v)", config, err)
+	}
+
+	viper.Set("DOWNLOAD_RATE_LIMIT", "20MB/s")
+	viper.Set("DOWNLOAD_PARTS", 8)
+	config, err = downloadConfig()
+	if err != nil || config.RateLimit != 20000000 || config.Parts != 8 {
+		t.Errorf("Expected 20MB/s in 8 parts, got %+v (error: %v)", config, err)
+	}
+
+	viper.Set("DOWNLOAD_RATE_LIMIT", "fast")
+	if _, err := downloadConfig(); err == nil || !strings.Contains(err.Error(), "--download-rate-limit") {
+		t.Errorf("Expected an invalid rate error, got %v", err)
+	}
+}
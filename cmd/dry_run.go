@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// runDryRun prints the validation plan for the root command without querying the repositories
+func runDryRun() {
+	if err := checkRequiredVars(true); err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	migrationValidator := validator.NewWithOptions(nil, validationOptions)
+	plan := migrationValidator.PlanValidation(
+		viper.GetString("SOURCE_ORGANIZATION"), viper.GetString("SOURCE_REPO"),
+		viper.GetString("TARGET_ORGANIZATION"), viper.GetString("TARGET_REPO"),
+		false,
+	)
+	plan.Print()
+
+	// The rate limit check is the only authenticated call, and only made when credentials are configured
+	if viper.GetString("SOURCE_TOKEN") == "" || viper.GetString("TARGET_TOKEN") == "" {
+		fmt.Println("\nSkipping rate limit check: source and target tokens are not both configured")
+		return
+	}
+
+	ghAPI, err := newGitHubAPI()
+	if err != nil {
+		fmt.Printf("\nSkipping rate limit check: %v\n", err)
+		return
+	}
+	printRateLimitEstimate(ghAPI, plan, api.SourceClient, "source")
+	printRateLimitEstimate(ghAPI, plan, api.TargetClient, "target")
+}
+
+// printRateLimitEstimate compares the remaining rate limit of a client with the planned number of calls
+func printRateLimitEstimate(ghAPI *api.GitHubAPI, plan validator.ValidationPlan, clientType api.ClientType, side string) {
+	rateLimit, err := ghAPI.GetRateLimitStatus(context.Background(), clientType)
+	if err != nil {
+		fmt.Printf("Failed to check %s rate limit: %v\n", side, err)
+		return
+	}
+
+	estimated := plan.EstimatedCalls(side)
+	fmt.Printf("%s rate limit: %d remaining, resets at %s (estimated usage: %d)\n",
+		side, rateLimit.Remaining, rateLimit.ResetAt.Format("15:04:05 MST"), estimated)
+	if rateLimit.Remaining < estimated {
+		fmt.Printf("Warning: the %s rate limit is lower than the estimated number of API calls\n", side)
+	}
+}
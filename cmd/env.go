@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/output"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// envPrefix is the prefix of every environment variable read by the tool
+const envPrefix = "GHMV_"
+
+// envVariable describes a supported environment variable
+type envVariable struct {
+	name   string // Without the GHMV_ prefix
+	flag   string // Flag setting the same value, empty when there is none
+	secret bool
+}
+
+// envOnlyVariables are the supported environment variables that are not backed by a shared flag
+var envOnlyVariables = []envVariable{
+	{name: "SOURCE_APP_ID"},
+	{name: "SOURCE_PRIVATE_KEY", secret: true},
+	{name: "SOURCE_INSTALLATION_ID"},
+	{name: "TARGET_APP_ID"},
+	{name: "TARGET_PRIVATE_KEY", secret: true},
+	{name: "TARGET_INSTALLATION_ID"},
+	{name: "RATE_LIMIT_THRESHOLD"},
+	{name: "WEBHOOK_SECRET", flag: "--webhook-secret (serve)", secret: true},
+}
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List the supported GHMV_* environment variables",
+	Long: `List every environment variable the tool reads, whether it is set in the current
+environment and which flag sets the same value. Values of tokens, private keys and
+secrets are masked.
+
+Environment variables starting with GHMV_ that the tool does not recognize are reported
+as warnings, since a misspelled variable (for example GHMV_SOURCE_ORG) is otherwise ignored.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		variables := supportedEnvVariables()
+
+		tableData := [][]string{{"Variable", "Flag", "Value"}}
+		for _, variable := range variables {
+			value, set := os.LookupEnv(envPrefix + variable.name)
+			tableData = append(tableData, []string{envPrefix + variable.name, variable.flag, displayEnvValue(variable, value, set)})
+		}
+		output.RenderTable(tableData, false)
+
+		for _, name := range unrecognizedEnvVariables(os.Environ(), variables) {
+			message := fmt.Sprintf("%s is not a recognized variable and is ignored", name)
+			if suggestion := suggestEnvVariable(name, variables); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %s?)", suggestion)
+			}
+			pterm.Warning.Println(message)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+}
+
+// supportedEnvVariables returns the environment variables of the shared flags followed by the
+// environment-only variables
+func supportedEnvVariables() []envVariable {
+	variables := make([]envVariable, 0, len(sharedFlags)+len(envOnlyVariables))
+	for _, flag := range sharedFlags {
+		name := "--" + flag.name
+		if flag.shorthand != "" {
+			name += " / -" + flag.shorthand
+		}
+		variables = append(variables, envVariable{
+			name:   flag.viperKey,
+			flag:   name,
+			secret: strings.HasSuffix(flag.viperKey, "_TOKEN"),
+		})
+	}
+
+	return append(variables, envOnlyVariables...)
+}
+
+// displayEnvValue returns how a variable's value is shown, masking secrets
+func displayEnvValue(variable envVariable, value string, set bool) string {
+	if !set {
+		return "not set"
+	}
+	if variable.secret {
+		return "******** (set)"
+	}
+	return value
+}
+
+// unrecognizedEnvVariables returns the sorted names of GHMV_* variables in environ that are not supported
+func unrecognizedEnvVariables(environ []string, variables []envVariable) []string {
+	known := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		known[envPrefix+variable.name] = true
+	}
+
+	unrecognized := []string{}
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if strings.HasPrefix(name, envPrefix) && !known[name] {
+			unrecognized = append(unrecognized, name)
+		}
+	}
+
+	sort.Strings(unrecognized)
+	return unrecognized
+}
+
+// suggestEnvVariable returns the supported variable closest to name, or an empty string when none is close.
+// A supported variable that starts with name, such as GHMV_SOURCE_ORGANIZATION for GHMV_SOURCE_ORG, is preferred.
+func suggestEnvVariable(name string, variables []envVariable) string {
+	best, bestDistance := "", 4
+	for _, variable := range variables {
+		candidate := envPrefix + variable.name
+		if strings.HasPrefix(candidate, name) {
+			return candidate
+		}
+
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSupportedEnvVariables(t *testing.T) {
+	variables := map[string]envVariable{}
+	for _, variable := range supportedEnvVariables() {
+		variables[variable.name] = variable
+	}
+
+	if got := variables["SOURCE_ORGANIZATION"].flag; got != "--source-org / -s" {
+		t.Errorf("Expected SOURCE_ORGANIZATION to map to --source-org / -s, got %q", got)
+	}
+	if !variables["TARGET_TOKEN"].secret || !variables["SOURCE_PRIVATE_KEY"].secret {
+		t.Error("Expected tokens and private keys to be masked")
+	}
+	if _, ok := variables["TARGET_APP_ID"]; !ok {
+		t.Error("Expected environment-only variables to be listed")
+	}
+}
+
+func TestDisplayEnvValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		variable envVariable
+		value    string
+		set      bool
+		expected string
+	}{
+		{"not set", envVariable{name: "SOURCE_REPO"}, "", false, "not set"},
+		{"plain value", envVariable{name: "SOURCE_REPO"}, "my-repo", true, "my-repo"},
+		{"secret value", envVariable{name: "SOURCE_TOKEN", secret: true}, "ghp_xxx", true, "******** (set)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := displayEnvValue(tt.variable, tt.value, tt.set); got != tt.expected {
+				t.Errorf("displayEnvValue() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnrecognizedEnvVariables(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"GHMV_SOURCE_TOKEN=ghp_xxx",
+		"GHMV_TARGET_ORG=target-org",
+		"GHMV_SOURCE_ORG=source-org",
+	}
+
+	got := unrecognizedEnvVariables(environ, supportedEnvVariables())
+	expected := []string{"GHMV_SOURCE_ORG", "GHMV_TARGET_ORG"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unrecognizedEnvVariables() = %v, want %v", got, expected)
+	}
+}
+
+func TestSuggestEnvVariable(t *testing.T) {
+	variables := supportedEnvVariables()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"GHMV_SOURCE_ORG", "GHMV_SOURCE_ORGANIZATION"},
+		{"GHMV_TARGET_TOKN", "GHMV_TARGET_TOKEN"},
+		{"GHMV_SOURCE_PAT", ""},
+		{"GHMV_SOMETHING_ELSE", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestEnvVariable(tt.name, variables); got != tt.expected {
+				t.Errorf("suggestEnvVariable(%q) = %q, want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
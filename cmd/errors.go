@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// Exit codes, so scripts can tell the reason of a failed run apart without parsing the output
+const (
+	exitError            = 1 // Any error without a more specific code
+	exitValidationFailed = 2 // Validations failed and --strict-exit is set
+	exitAuth             = 3
+	exitNotFound         = 4
+	exitRateLimited      = 5
+	exitPartialData      = 6   // Some data could not be retrieved and --strict-exit is set
+	exitInterrupted      = 130 // Stopped by Ctrl-C, following the shell convention of 128 + SIGINT
+)
+
+// exitCode returns the exit code for err based on its kind
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, api.ErrAuth):
+		return exitAuth
+	case errors.Is(err, api.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, api.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, api.ErrPartialData):
+		return exitPartialData
+	case errors.Is(err, api.ErrInterrupted):
+		return exitInterrupted
+	default:
+		return exitError
+	}
+}
+
+// exitWithError prints message and err, then exits with the exit code of err
+func exitWithError(message string, err error) {
+	fmt.Printf("%s: %v\n", message, err)
+	releaseRunLocks()
+	shutdownTelemetry()
+	os.Exit(exitCode(err))
+}
+
+// exitOnUnavailableTarget exits with the exit code of the error that made the target unavailable, once its
+// results have been reported
+func exitOnUnavailableTarget(mv *validator.MigrationValidator) {
+	if err := mv.TargetUnavailableError(); err != nil {
+		exitWithError("Target repository is unavailable", err)
+	}
+}
+
+// exitOnStrictFailure exits when --strict-exit is set and the validation is not conclusive: with
+// exitPartialData when data is missing, as the results were computed without it, or with
+// exitValidationFailed when validations failed
+func exitOnStrictFailure(mv *validator.MigrationValidator, summary validator.Summary) {
+	if !viper.GetBool("STRICT_EXIT") {
+		return
+	}
+
+	if err := mv.PartialDataError(); err != nil {
+		exitWithError("Validation results are incomplete", err)
+	}
+	if summary.Failed > 0 {
+		releaseRunLocks()
+		shutdownTelemetry()
+		os.Exit(exitValidationFailed)
+	}
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"auth", fmt.Errorf("cannot access source repository: %w", api.ErrAuth), exitAuth},
+		{"not found", fmt.Errorf("failed to resolve: %w", api.ErrNotFound), exitNotFound},
+		{"rate limited", fmt.Errorf("failed to query: %w", api.ErrRateLimited), exitRateLimited},
+		{"partial data", fmt.Errorf("%w: failed to retrieve source tags", api.ErrPartialData), exitPartialData},
+		{"other", errors.New("failed to load export file"), exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.expected {
+				t.Errorf("exitCode() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
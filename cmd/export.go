@@ -4,16 +4,19 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"mona-actions/gh-migration-validator/internal/api"
 	"mona-actions/gh-migration-validator/internal/export"
 	"mona-actions/gh-migration-validator/internal/migrationarchive"
 	"mona-actions/gh-migration-validator/internal/validator"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -31,21 +34,23 @@ This command fetches and exports repository metadata including:
 - Commits count
 - Latest commit hash
 
-The data can be exported in JSON or CSV format with a timestamp.
+The data can be exported in JSON, CSV or XLSX format with a timestamp.
 
 Optionally, you can include migration archive data in the export by either:
 - Using --download to automatically download and extract a migration archive
 - Using --archive-path to specify an existing extracted migration archive directory
+- Using --archive-url to download the archive from a pre-signed URL, e.g. the Azure or AWS
+  blob storage URL the migration was run with, optionally verified with --archive-sha256
 
-When using --download, you can optionally specify --download-path to choose where 
+When using --download or --archive-url, you can optionally specify --download-path to choose where 
 the archive files are saved (defaults to ./migration-archives).
 
 The tool will automatically search for migrations containing the specified repository
 and allow you to select from multiple matches if available when downloading.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get parameters from flags
-		sourceOrganization := cmd.Flag("github-source-org").Value.String()
-		sourceToken := cmd.Flag("github-source-pat").Value.String()
+		sourceOrganization := cmd.Flag("source-org").Value.String()
+		sourceToken := cmd.Flag("source-token").Value.String()
 		ghHostname := cmd.Flag("source-hostname").Value.String()
 		sourceRepo := cmd.Flag("source-repo").Value.String()
 		outputFormat := cmd.Flag("format").Value.String()
@@ -53,6 +58,9 @@ and allow you to select from multiple matches if available when downloading.`,
 		download, _ := cmd.Flags().GetBool("download")
 		downloadPath := cmd.Flag("download-path").Value.String()
 		archivePath := cmd.Flag("archive-path").Value.String()
+		archiveURL := cmd.Flag("archive-url").Value.String()
+		archiveSHA256 := cmd.Flag("archive-sha256").Value.String()
+		noArchiveCache, _ := cmd.Flags().GetBool("no-archive-cache")
 		noLFS, _ := cmd.Flags().GetBool("no-lfs")
 
 		// Only set ENV variables if flag values are provided (not empty)
@@ -89,19 +97,29 @@ and allow you to select from multiple matches if available when downloading.`,
 		}
 
 		// Initialize API with source-only clients
-		ghAPI, err := api.NewSourceOnlyAPI()
+		ghAPI, err := newSourceOnlyAPI()
 		if err != nil {
 			fmt.Printf("Failed to initialize source API: %v\n", err)
 			os.Exit(1)
 		}
-		// Validate that --download and --archive-path are mutually exclusive
-		if download && archivePath != "" {
-			fmt.Printf("Error: --download and --archive-path flags are mutually exclusive. Please use only one.\n")
+		// Validate that a single migration archive source is used
+		if err := checkArchiveFlags(download, archivePath, archiveURL, archiveSHA256); err != nil {
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
 
 		// Create validator
-		migrationValidator := validator.New(ghAPI)
+		validationOptions, err := getValidationOptions()
+		if err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+		migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+		signer, err := loadReportSigner()
+		if err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Handle migration archive (either download or use existing path)
 		var archiveDir string
@@ -109,8 +127,13 @@ and allow you to select from multiple matches if available when downloading.`,
 			fmt.Println("Searching for migration archives...")
 			extractedPath, err := migrationarchive.DownloadAndExtractArchive(ghAPI, sourceOrganization, sourceRepo, downloadPath)
 			if err != nil {
-				fmt.Printf("Migration archive download failed: %v\n", err)
-				os.Exit(1)
+				exitWithError("Migration archive download failed", err)
+			}
+			archiveDir = extractedPath
+		} else if archiveURL != "" {
+			extractedPath, err := migrationarchive.DownloadAndExtractURL(ghAPI, archiveURL, archiveSHA256, sourceRepo, downloadPath)
+			if err != nil {
+				exitWithError("Migration archive download failed", err)
 			}
 			archiveDir = extractedPath
 		} else if archivePath != "" {
@@ -125,11 +148,12 @@ and allow you to select from multiple matches if available when downloading.`,
 
 		// Export the source repository data (with optional migration archive analysis)
 		timestamp := time.Now()
-		err = export.ExportSourceData(migrationValidator, sourceOrganization, sourceRepo, outputFormat, outputFile, timestamp, archiveDir)
+		tool := exportToolMetadata(cmd.Flags(), validationOptions)
+		exportFile, err := export.ExportSourceData(migrationValidator, sourceOrganization, sourceRepo, outputFormat, outputFile, timestamp, archiveDir, !noArchiveCache, tool)
 		if err != nil {
-			fmt.Printf("Export failed: %v\n", err)
-			os.Exit(1)
+			exitWithError("Export failed", err)
 		}
+		signReport(signer, exportFile)
 	},
 }
 
@@ -138,17 +162,12 @@ func init() {
 	rootCmd.AddCommand(exportCmd)
 
 	// Define flags specific to export command
-	exportCmd.Flags().StringP("github-source-org", "s", "", "Source Organization to export data from")
-	exportCmd.MarkFlagRequired("github-source-org")
-
-	exportCmd.Flags().StringP("github-source-pat", "a", "", "Source Organization GitHub token. Scopes: read:org, read:user, user:email")
-
-	exportCmd.Flags().StringP("source-hostname", "u", "", "GitHub Enterprise source hostname url (optional) Ex. https://github.example.com")
-
-	exportCmd.Flags().StringP("source-repo", "", "", "Source repository name to export (just the repo name, not owner/repo)")
+	addSharedFlags(exportCmd.Flags(), "source-org", "source-token", "source-hostname", "source-api-url", "source-repo", "no-lfs", "check-security", "check-truncation", "branches", "count-prs-as-issues",
+		"source-lfs-url", "source-lfs-username", "source-lfs-token", "check-lfs-locks", "rewritten-history", "download-rate-limit", "download-parts")
+	exportCmd.MarkFlagRequired("source-org")
 	exportCmd.MarkFlagRequired("source-repo")
 
-	exportCmd.Flags().StringP("format", "f", "json", "Output format: json or csv")
+	exportCmd.Flags().StringP("format", "f", "json", "Output format: json, csv or xlsx")
 
 	exportCmd.Flags().StringP("output", "o", "", "Output file path (if not provided, will use default naming)")
 
@@ -158,7 +177,11 @@ func init() {
 
 	exportCmd.Flags().StringP("archive-path", "p", "", "Path to an existing extracted migration archive directory (alternative to --download)")
 
-	exportCmd.Flags().Bool("no-lfs", false, "Skip LFS object validation")
+	exportCmd.Flags().String("archive-url", "", "Pre-signed URL to download the migration archive from, e.g. the Azure or AWS blob storage URL the migration was run with (alternative to --download)")
+
+	exportCmd.Flags().String("archive-sha256", "", "Expected SHA-256 checksum of the archive downloaded from --archive-url, in hexadecimal")
+
+	exportCmd.Flags().Bool("no-archive-cache", false, "Analyze the migration archive again instead of reusing the metrics cached in its metrics-cache.json")
 }
 
 // checkExportVars validates the configuration for export command
@@ -166,7 +189,7 @@ func checkExportVars() error {
 	// Check for source token
 	sourceToken := viper.GetString("SOURCE_TOKEN")
 	if sourceToken == "" {
-		return fmt.Errorf("source token is required. Set it via --github-source-pat flag or GHMV_SOURCE_TOKEN environment variable")
+		return fmt.Errorf("source token is required. Set it via --source-token flag or GHMV_SOURCE_TOKEN environment variable")
 	}
 
 	// Check source repository
@@ -175,6 +198,41 @@ func checkExportVars() error {
 		return fmt.Errorf("source repository is required. Set it via --source-repo flag")
 	}
 
+	if _, err := downloadConfig(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkArchiveFlags validates that at most one of --download, --archive-path and --archive-url is used, and
+// that --archive-url is an HTTP(S) URL whose archive --archive-sha256 can verify
+func checkArchiveFlags(download bool, archivePath, archiveURL, archiveSHA256 string) error {
+	sources := 0
+	for _, set := range []bool{download, archivePath != "", archiveURL != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("--download, --archive-path and --archive-url flags are mutually exclusive. Please use only one.")
+	}
+
+	if archiveSHA256 != "" {
+		if archiveURL == "" {
+			return fmt.Errorf("--archive-sha256 needs --archive-url")
+		}
+		if checksum, err := hex.DecodeString(strings.TrimSpace(archiveSHA256)); err != nil || len(checksum) != sha256.Size {
+			return fmt.Errorf("--archive-sha256 must be a SHA-256 checksum of 64 hexadecimal characters")
+		}
+	}
+	if archiveURL != "" {
+		// The URL is not included in errors, as its signature grants access to the archive
+		parsedURL, err := url.Parse(archiveURL)
+		if err != nil || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+			return fmt.Errorf("--archive-url must be an http or https URL")
+		}
+	}
 	return nil
 }
 
@@ -233,3 +291,28 @@ func validateArchivePath(archivePath string) error {
 
 	return nil
 }
+
+// exportToolMetadata returns the version, authentication, hostname, flags and options an export is taken with
+func exportToolMetadata(flags *pflag.FlagSet, options validator.ValidationOptions) *export.ToolMetadata {
+	return &export.ToolMetadata{
+		Version:  currentVersion(),
+		AuthMode: authMode("SOURCE"),
+		Hostname: viper.GetString("SOURCE_HOSTNAME"),
+		Flags:    changedFlags(flags),
+		Options:  export.NewExportOptions(options),
+	}
+}
+
+// changedFlags returns the flags set on the command line by name, with the values of tokens masked
+func changedFlags(flags *pflag.FlagSet) map[string]string {
+	changed := make(map[string]string)
+	flags.Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		// The signature of a pre-signed archive URL is a credential too
+		if strings.HasSuffix(flag.Name, "-token") || flag.Name == "archive-url" {
+			value = "********"
+		}
+		changed[flag.Name] = value
+	})
+	return changed
+}
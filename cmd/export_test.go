@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -19,23 +20,23 @@ func TestExportFlagValidation(t *testing.T) {
 	}{
 		{
 			name:           "both download and archive-path flags should fail",
-			args:           []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--download", "--archive-path", "/some/path"},
+			args:           []string{"--source-org", "test-org", "--source-repo", "test-repo", "--download", "--archive-path", "/some/path"},
 			expectedError:  true,
 			expectedErrMsg: "--download and --archive-path flags are mutually exclusive. Please use only one.",
 		},
 		{
 			name:          "download flag alone should be valid",
-			args:          []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--download"},
+			args:          []string{"--source-org", "test-org", "--source-repo", "test-repo", "--download"},
 			expectedError: false,
 		},
 		{
 			name:          "archive-path flag alone should be valid",
-			args:          []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--archive-path", "/some/path"},
+			args:          []string{"--source-org", "test-org", "--source-repo", "test-repo", "--archive-path", "/some/path"},
 			expectedError: false,
 		},
 		{
 			name:          "download-path with download should be valid",
-			args:          []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--download", "--download-path", "/custom/path"},
+			args:          []string{"--source-org", "test-org", "--source-repo", "test-repo", "--download", "--download-path", "/custom/path"},
 			expectedError: false,
 		},
 	}
@@ -69,14 +70,14 @@ func TestExportFlagValidation(t *testing.T) {
 			}
 
 			// Add all the flags
-			cmd.Flags().StringP("github-source-org", "s", "", "Source Organization")
+			cmd.Flags().StringP("source-org", "s", "", "Source Organization")
 			cmd.Flags().StringP("source-repo", "", "", "Source repository")
 			cmd.Flags().BoolP("download", "d", false, "Download and extract migration archive")
 			cmd.Flags().StringP("download-path", "", "", "Directory to download migration archives")
 			cmd.Flags().StringP("archive-path", "p", "", "Path to existing extracted archive")
 
 			// Mark required flags
-			cmd.MarkFlagRequired("github-source-org")
+			cmd.MarkFlagRequired("source-org")
 			cmd.MarkFlagRequired("source-repo")
 
 			// Capture output
@@ -115,21 +116,21 @@ func TestExportFlagValues(t *testing.T) {
 	}{
 		{
 			name:                 "download flag sets boolean correctly",
-			args:                 []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--download"},
+			args:                 []string{"--source-org", "test-org", "--source-repo", "test-repo", "--download"},
 			expectedDownload:     true,
 			expectedDownloadPath: "",
 			expectedArchivePath:  "",
 		},
 		{
 			name:                 "download-path flag sets string correctly",
-			args:                 []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--download", "--download-path", "/custom/path"},
+			args:                 []string{"--source-org", "test-org", "--source-repo", "test-repo", "--download", "--download-path", "/custom/path"},
 			expectedDownload:     true,
 			expectedDownloadPath: "/custom/path",
 			expectedArchivePath:  "",
 		},
 		{
 			name:                 "archive-path flag sets string correctly",
-			args:                 []string{"--github-source-org", "test-org", "--source-repo", "test-repo", "--archive-path", "/existing/path"},
+			args:                 []string{"--source-org", "test-org", "--source-repo", "test-repo", "--archive-path", "/existing/path"},
 			expectedDownload:     false,
 			expectedDownloadPath: "",
 			expectedArchivePath:  "/existing/path",
@@ -161,7 +162,7 @@ func TestExportFlagValues(t *testing.T) {
 			}
 
 			// Add flags
-			cmd.Flags().StringP("github-source-org", "s", "", "Source Organization")
+			cmd.Flags().StringP("source-org", "s", "", "Source Organization")
 			cmd.Flags().StringP("source-repo", "", "", "Source repository")
 			cmd.Flags().BoolP("download", "d", false, "Download and extract migration archive")
 			cmd.Flags().StringP("download-path", "", "", "Directory to download migration archives")
@@ -269,3 +270,69 @@ func BenchmarkValidateArchivePathOptimization(b *testing.B) {
 		}
 	}
 }
+
+func TestChangedFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	addSharedFlags(cmd.Flags(), "source-org", "source-token", "no-lfs", "branches")
+	if err := cmd.ParseFlags([]string{"--source-org", "my-org", "--source-token", "ghp_secret", "--no-lfs"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	changed := changedFlags(cmd.Flags())
+
+	expected := map[string]string{"source-org": "my-org", "source-token": "********", "no-lfs": "true"}
+	if fmt.Sprint(changed) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, changed)
+	}
+}
+
+func TestCheckArchiveFlags(t *testing.T) {
+	checksum := strings.Repeat("ab", 32)
+	tests := []struct {
+		name          string
+		download      bool
+		archivePath   string
+		archiveURL    string
+		archiveSHA256 string
+		expectedError string
+	}{
+		{name: "no archive"},
+		{name: "download", download: true},
+		{name: "archive url", archiveURL: "https://storage.blob.core.windows.net/archive.tar.gz?sig=secret", archiveSHA256: checksum},
+		{name: "download and archive url", download: true, archiveURL: "https://example.com/archive.tar.gz", expectedError: "mutually exclusive"},
+		{name: "archive path and archive url", archivePath: "/some/path", archiveURL: "https://example.com/archive.tar.gz", expectedError: "mutually exclusive"},
+		{name: "checksum without url", download: true, archiveSHA256: checksum, expectedError: "--archive-sha256 needs --archive-url"},
+		{name: "invalid checksum", archiveURL: "https://example.com/archive.tar.gz", archiveSHA256: "abc", expectedError: "64 hexadecimal characters"},
+		{name: "invalid url", archiveURL: "ftp://example.com/archive.tar.gz?sig=secret", expectedError: "--archive-url must be an http or https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkArchiveFlags(tt.download, tt.archivePath, tt.archiveURL, tt.archiveSHA256)
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+			if err != nil && strings.Contains(err.Error(), "secret") {
+				t.Errorf("Expected the archive URL to be left out of the error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestChangedFlags_ArchiveURL(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("archive-url", "", "")
+	if err := cmd.ParseFlags([]string{"--archive-url", "https://example.com/archive.tar.gz?sig=secret"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if changed := changedFlags(cmd.Flags()); changed["archive-url"] != "********" {
+		t.Errorf("Expected the archive URL to be masked, got %v", changed)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// flagKind is the value type of a shared flag
+type flagKind int
+
+const (
+	stringFlag flagKind = iota
+	boolFlag
+	intFlag
+	durationFlag
+)
+
+// sharedFlag describes a flag used by more than one command, so every command registers it
+// with the same name, shorthand, usage and Viper key
+type sharedFlag struct {
+	name       string
+	shorthand  string
+	kind       flagKind
+	usage      string
+	viperKey   string // Also the environment variable name without the GHMV_ prefix
+	deprecated string // Former flag name, kept as a hidden alias that prints a deprecation warning
+}
+
+// sharedFlags is the registry of flags shared across commands
+var sharedFlags = []sharedFlag{
+	{name: "source-org", shorthand: "s", kind: stringFlag, usage: "Source organization name", viperKey: "SOURCE_ORGANIZATION", deprecated: "github-source-org"},
+	{name: "target-org", shorthand: "t", kind: stringFlag, usage: "Target organization name", viperKey: "TARGET_ORGANIZATION", deprecated: "github-target-org"},
+	{name: "source-repo", kind: stringFlag, usage: "Source repository name (just the repo name, not owner/repo)", viperKey: "SOURCE_REPO"},
+	{name: "target-repo", kind: stringFlag, usage: "Target repository name (just the repo name, not owner/repo)", viperKey: "TARGET_REPO"},
+	{name: "source-token", shorthand: "a", kind: stringFlag, usage: "Source organization GitHub token. Scopes: read:org, read:user, user:email", viperKey: "SOURCE_TOKEN", deprecated: "github-source-pat"},
+	{name: "target-token", shorthand: "b", kind: stringFlag, usage: "Target organization GitHub token. Scopes: admin:org", viperKey: "TARGET_TOKEN", deprecated: "github-target-pat"},
+	{name: "source-hostname", shorthand: "u", kind: stringFlag, usage: "GitHub Enterprise source hostname url (optional) Ex. https://github.example.com or octocorp.ghe.com", viperKey: "SOURCE_HOSTNAME"},
+	{name: "target-hostname", shorthand: "v", kind: stringFlag, usage: "GitHub Enterprise target hostname url (optional) Ex. https://github.example.com or octocorp.ghe.com", viperKey: "TARGET_HOSTNAME"},
+	{name: "source-api-url", kind: stringFlag, usage: "Source REST API base URL used instead of the one derived from --source-hostname, e.g. of an API gateway (optional)", viperKey: "SOURCE_API_URL"},
+	{name: "target-api-url", kind: stringFlag, usage: "Target REST API base URL used instead of the one derived from the target hostname, e.g. of an API gateway (optional)", viperKey: "TARGET_API_URL"},
+	{name: "source-lfs-url", kind: stringFlag, usage: "LFS endpoint of a standalone source LFS server, e.g. Artifactory; {owner} and {repo} are replaced (optional)", viperKey: "SOURCE_LFS_URL"},
+	{name: "target-lfs-url", kind: stringFlag, usage: "LFS endpoint of a standalone target LFS server; {owner} and {repo} are replaced (optional)", viperKey: "TARGET_LFS_URL"},
+	{name: "source-lfs-username", kind: stringFlag, usage: "Username sent with --source-lfs-token as basic authentication (optional)", viperKey: "SOURCE_LFS_USERNAME"},
+	{name: "target-lfs-username", kind: stringFlag, usage: "Username sent with --target-lfs-token as basic authentication (optional)", viperKey: "TARGET_LFS_USERNAME"},
+	{name: "source-lfs-token", kind: stringFlag, usage: "Token of the standalone source LFS server, sent as a bearer token without --source-lfs-username (optional)", viperKey: "SOURCE_LFS_TOKEN"},
+	{name: "target-lfs-token", kind: stringFlag, usage: "Token of the standalone target LFS server, sent as a bearer token without --target-lfs-username (optional)", viperKey: "TARGET_LFS_TOKEN"},
+	{name: "source", kind: stringFlag, usage: "Where the source data comes from: api (default) or archive, the extracted migration archive of --archive-path alone, which needs no source token", viperKey: "SOURCE"},
+	{name: "archive-path", kind: stringFlag, usage: "Path to the extracted migration archive directory used by --source archive", viperKey: "ARCHIVE_PATH"},
+	{name: "markdown-table", shorthand: "m", kind: boolFlag, usage: "Print results as a markdown table", viperKey: "MARKDOWN_TABLE"},
+	{name: "markdown-file", kind: stringFlag, usage: "Write markdown output to the specified file (optional)", viperKey: "MARKDOWN_FILE"},
+	{name: "no-lfs", kind: boolFlag, usage: "Skip LFS object validation", viperKey: "NO_LFS"},
+	{name: "no-issue-offset", kind: boolFlag, usage: "Do not expect an additional migration log issue in the target", viperKey: "NO_ISSUE_OFFSET"},
+	{name: "issue-offset", kind: intFlag, usage: "Number of additional issues expected in the target (default: auto-detected from the migration log issue)", viperKey: "ISSUE_OFFSET"},
+	{name: "follow-renames", kind: boolFlag, usage: "Validate against the new name when a repository has been renamed", viperKey: "FOLLOW_RENAMES"},
+	{name: "stdin", kind: boolFlag, usage: "Validate the repository pairs read from stdin, one per line, writing a report per repository", viperKey: "STDIN"},
+	{name: "output-format", kind: stringFlag, usage: "Format of the report of each repository of a --stdin batch, written as soon as it is validated: ndjson (default) or text", viperKey: "OUTPUT_FORMAT"},
+	{name: "xlsx-file", kind: stringFlag, usage: "Write the reports of a --stdin batch to an XLSX workbook with a summary sheet and a results sheet (optional)", viperKey: "XLSX_FILE"},
+	{name: "fail-fast", kind: boolFlag, usage: "Stop a --stdin batch at the first repository that fails or cannot be validated", viperKey: "FAIL_FAST"},
+	{name: "max-failures", kind: intFlag, usage: "Stop a --stdin batch once this many repositories failed or could not be validated (default: 0, validate every repository)", viperKey: "MAX_FAILURES"},
+	{name: "repo-timeout", kind: durationFlag, usage: "Time limit of the validation of each repository of a --stdin batch, e.g. 15m; slower repositories are reported as timed out (default: none)", viperKey: "REPO_TIMEOUT"},
+	{name: "dry-run", kind: boolFlag, usage: "Show the API requests and metrics the validation would use without running it", viperKey: "DRY_RUN"},
+	{name: "strict-exit", kind: boolFlag, usage: "Exit with status 2 when validations fail", viperKey: "STRICT_EXIT"},
+	{name: "history-db", kind: stringFlag, usage: "Append validation results to the specified SQLite database (optional)", viperKey: "HISTORY_DB"},
+	{name: "audit-log", kind: stringFlag, usage: "Append a JSON line recording who ran which validation, when, and its verdict to the specified file (optional)", viperKey: "AUDIT_LOG"},
+	{name: "create-check-run", kind: boolFlag, usage: "Publish the validation result as a check run on the target repository's default branch head", viperKey: "CREATE_CHECK_RUN"},
+	{name: "publish-repo", kind: stringFlag, usage: "Commit the markdown and JSON reports to this OWNER/REPO reports repository with the target credentials (optional)", viperKey: "PUBLISH_REPO"},
+	{name: "max-retries", kind: intFlag, usage: "Retries for requests failing with a network error or 5xx response (default: 3)", viperKey: "MAX_RETRIES"},
+	{name: "retry-backoff", kind: durationFlag, usage: "Delay before the first retry, doubled for every further retry (default: 1s)", viperKey: "RETRY_BACKOFF"},
+	{name: "retry-jitter", kind: durationFlag, usage: "Maximum random delay added to every retry backoff (default: 500ms)", viperKey: "RETRY_JITTER"},
+	{name: "lfs-batch-size", kind: intFlag, usage: "LFS objects checked per LFS batch API request (default: 100)", viperKey: "LFS_BATCH_SIZE"},
+	{name: "max-tree-entries", kind: intFlag, usage: "Repository tree entries walked to discover LFS pointers before the LFS objects are reported as unavailable (default: 0, no limit)", viperKey: "MAX_TREE_ENTRIES"},
+	{name: "lfs-concurrency", kind: intFlag, usage: "LFS batch API requests sent at the same time (default: 4)", viperKey: "LFS_CONCURRENCY"},
+	{name: "download-rate-limit", kind: stringFlag, usage: "Bandwidth limit of migration archive downloads, e.g. 20MB/s or 512KiB/s (default: none)", viperKey: "DOWNLOAD_RATE_LIMIT"},
+	{name: "download-parts", kind: intFlag, usage: "Ranged requests downloading a large migration archive in parallel (default: 1)", viperKey: "DOWNLOAD_PARTS"},
+	{name: "check-security", kind: boolFlag, usage: "Compare Dependabot alerts, secret scanning and code scanning settings and open alerts (advisory, needs the security_events scope)", viperKey: "CHECK_SECURITY"},
+	{name: "check-lfs-locks", kind: boolFlag, usage: "Compare the paths of the LFS file locks, which are not migrated (advisory)", viperKey: "CHECK_LFS_LOCKS"},
+	{name: "check-truncation", kind: intFlag, usage: "Compare the bodies of the N longest issues and pull requests to detect bodies truncated by the migration (reads every source issue and pull request)", viperKey: "CHECK_TRUNCATION"},
+	{name: "count-prs-as-issues", kind: boolFlag, usage: "Count pull requests as issues, for sources whose issue counts include pull requests", viperKey: "COUNT_PRS_AS_ISSUES"},
+	{name: "transfer-mode", kind: boolFlag, usage: "Validate a repository transferred between organizations: skip the migration log issue and check the source name redirects to the target", viperKey: "TRANSFER_MODE"},
+	{name: "rewritten-history", kind: boolFlag, usage: "Validate a migration that intentionally rewrote history (BFG, git lfs migrate): report SHA mismatches as INFO and compare the authors and dates of the latest commits", viperKey: "REWRITTEN_HISTORY"},
+	{name: "branches", kind: stringFlag, usage: "Compare the head SHA and commit count of every branch (all) or of a comma-separated list of branches", viperKey: "BRANCHES"},
+	{name: "show-timings", kind: boolFlag, usage: "Add a Performance section with the time and API calls each metric fetch took", viperKey: "SHOW_TIMINGS"},
+	{name: "explain", kind: boolFlag, usage: "Add a Suggested Fixes section with the likely cause and next step of each failure", viperKey: "EXPLAIN"},
+	{name: "explain-rules", kind: stringFlag, usage: "YAML file of remediation rules used by --explain before the built-in rules (optional)", viperKey: "EXPLAIN_RULES"},
+	{name: "rules", kind: stringFlag, usage: "YAML file of validation rules: per-metric comparisons and known differences to ignore (optional)", viperKey: "RULES"},
+	{name: "ignore", kind: stringFlag, usage: "Comma-separated metrics whose failures and warnings are known differences, reported as INFO (optional)", viperKey: "IGNORE"},
+	{name: "plain", kind: boolFlag, usage: "Print reports without emoji, with PASS/FAIL/WARN/INFO status labels", viperKey: "PLAIN"},
+	{name: "quiet", kind: boolFlag, usage: "Do not report the progress of long operations, such as migration archive extraction", viperKey: "QUIET"},
+	{name: "no-color", kind: boolFlag, usage: "Print reports without colors (also disabled by the NO_COLOR environment variable)", viperKey: "NO_COLOR"},
+	{name: "debug-http", kind: stringFlag, usage: "Record failed API requests and their responses, without credentials, as JSON files in this directory (optional)", viperKey: "DEBUG_HTTP"},
+	{name: "replay-http", kind: stringFlag, usage: "Answer API requests with the recordings of a --debug-http directory instead of querying GitHub (optional)", viperKey: "REPLAY_HTTP"},
+	{name: "run-id", kind: stringFlag, usage: "ID of this validation run, sent in the User-Agent and X-GHMV-Run-Id header of every request so server admins can identify its traffic (optional)", viperKey: "RUN_ID"},
+	{name: "lock-dir", kind: stringFlag, usage: "Directory of the lock files keeping two runs from validating the same target repository at once (default: <temp dir>/gh-migration-validator/locks)", viperKey: "LOCK_DIR"},
+	{name: "force", kind: boolFlag, usage: "Validate even if another run holds the lock of the target repository, taking over stale locks", viperKey: "FORCE"},
+	{name: "no-update-check", kind: boolFlag, usage: "Do not check for a newer release of the extension (also disabled by GH_NO_UPDATE_NOTIFIER and in CI)", viperKey: "NO_UPDATE_CHECK"},
+	{name: "sign-report", kind: boolFlag, usage: "Write a detached signature of the markdown or export report next to it, as <report>.sig (needs --key)", viperKey: "SIGN_REPORT"},
+	{name: "key", kind: stringFlag, usage: "PEM private key (RSA, ECDSA or Ed25519) used by --sign-report, or public key used by verify-report", viperKey: "SIGNING_KEY"},
+	{name: "otel-endpoint", kind: stringFlag, usage: "Export traces and metrics over OTLP/HTTP to this collector URL, e.g. http://localhost:4318 (optional)", viperKey: "OTEL_ENDPOINT"},
+	{name: "profile", kind: stringFlag, usage: "Named profile from the config file to use for default flag values (optional)", viperKey: "PROFILE"},
+	{name: "config", kind: stringFlag, usage: "Path to the config file (default: ~/.config/gh-migration-validator/config.yaml)", viperKey: "CONFIG_FILE"},
+}
+
+// lookupSharedFlag returns the registry entry for a flag name
+func lookupSharedFlag(name string) (sharedFlag, bool) {
+	for _, flag := range sharedFlags {
+		if flag.name == name {
+			return flag, true
+		}
+	}
+	return sharedFlag{}, false
+}
+
+// addSharedFlags registers the named shared flags, and their deprecated aliases, on a flag set
+func addSharedFlags(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		definition, ok := lookupSharedFlag(name)
+		if !ok {
+			panic(fmt.Sprintf("unknown shared flag %q", name))
+		}
+
+		switch definition.kind {
+		case boolFlag:
+			flags.BoolP(definition.name, definition.shorthand, false, definition.usage)
+		case intFlag:
+			flags.IntP(definition.name, definition.shorthand, 0, definition.usage)
+		case durationFlag:
+			flags.DurationP(definition.name, definition.shorthand, 0, definition.usage)
+		default:
+			flags.StringP(definition.name, definition.shorthand, "", definition.usage)
+		}
+
+		if definition.deprecated != "" {
+			addDeprecatedAlias(flags, definition.deprecated, definition.name)
+		}
+	}
+}
+
+// addDeprecatedAlias registers a hidden flag sharing the value of flag name.
+// Using the alias prints a deprecation warning; syncDeprecatedAliases marks the flag itself as changed.
+func addDeprecatedAlias(flags *pflag.FlagSet, alias, name string) {
+	flag := flags.Lookup(name)
+	flags.Var(flag.Value, alias, flag.Usage)
+	flags.Lookup(alias).NoOptDefVal = flag.NoOptDefVal
+	flags.MarkDeprecated(alias, fmt.Sprintf("use --%s instead", name))
+}
+
+// syncDeprecatedAliases marks a flag as changed when it was set through its deprecated alias, and the
+// alias as changed when the flag was set, so either name is treated as given on the command line
+func syncDeprecatedAliases(flags *pflag.FlagSet) {
+	for _, definition := range sharedFlags {
+		if definition.deprecated == "" {
+			continue
+		}
+
+		flag := flags.Lookup(definition.name)
+		alias := flags.Lookup(definition.deprecated)
+		if flag == nil || alias == nil {
+			continue
+		}
+
+		if alias.Changed || flag.Changed {
+			alias.Changed = true
+			flag.Changed = true
+		}
+	}
+}
+
+// bindFlags binds every shared flag present in the flag set to its Viper key.
+// Priority: Flag value > Environment variable > Default value
+func bindFlags(flags *pflag.FlagSet) {
+	for _, definition := range sharedFlags {
+		if flag := flags.Lookup(definition.name); flag != nil {
+			viper.BindPFlag(definition.viperKey, flag)
+		}
+	}
+}
+
+// prepareFlags binds the flags of the command being executed to Viper, resolves deprecated aliases
+// and applies the selected profile
+func prepareFlags(cmd *cobra.Command) error {
+	bindFlags(cmd.Flags())
+
+	syncDeprecatedAliases(cmd.Flags())
+	if err := applyProfile(cmd); err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+	syncDeprecatedAliases(cmd.Flags())
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestAddSharedFlags_DeprecatedAlias(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := &cobra.Command{Use: "test"}
+	addSharedFlags(cmd.Flags(), "source-org", "source-token", "profile", "config")
+	bindFlags(cmd.Flags())
+
+	alias := cmd.Flags().Lookup("github-source-org")
+	if alias == nil {
+		t.Fatal("Expected --github-source-org to be registered as an alias")
+	}
+	if !alias.Hidden || alias.Deprecated == "" {
+		t.Errorf("Expected --github-source-org to be hidden and deprecated, got hidden=%v deprecated=%q", alias.Hidden, alias.Deprecated)
+	}
+
+	if err := cmd.ParseFlags([]string{"--github-source-org", "alias-org", "-a", "token"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if err := prepareFlags(cmd); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !cmd.Flags().Changed("source-org") {
+		t.Error("Expected --source-org to be marked as changed when set through its alias")
+	}
+	if got := viper.GetString("SOURCE_ORGANIZATION"); got != "alias-org" {
+		t.Errorf("Expected source organization from the deprecated alias, got %q", got)
+	}
+	if got := viper.GetString("SOURCE_TOKEN"); got != "token" {
+		t.Errorf("Expected source token from the shorthand, got %q", got)
+	}
+}
+
+func TestAddSharedFlags_UnknownFlag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unknown shared flag")
+		}
+	}()
+
+	cmd := &cobra.Command{Use: "test"}
+	addSharedFlags(cmd.Flags(), "no-such-flag")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"mona-actions/gh-migration-validator/internal/lock"
+
+	"github.com/spf13/viper"
+)
+
+// heldRunLocks are the repository locks held by this process, released before it exits
+var (
+	heldRunLocks     []*lock.Lock
+	heldRunLocksLock sync.Mutex // Timed out batch validations release their lock in the background
+)
+
+// defaultLockDir returns the directory of the repository locks when --lock-dir is not set. It is shared by the
+// users of the host, rather than per user, so that operators on the same jumpbox lock each other out; lock.Acquire
+// creates it writable by everyone, with the sticky bit.
+func defaultLockDir() string {
+	return filepath.Join(os.TempDir(), "gh-migration-validator", "locks")
+}
+
+// acquireRunLock locks the target repository OWNER/REPO of the target hostname, so no other run using the same
+// --lock-dir validates it at the same time. --force takes over a lock held by another run.
+func acquireRunLock(targetRepository string) (*lock.Lock, error) {
+	viper.SetDefault("LOCK_DIR", defaultLockDir())
+	hostname := viper.GetString("TARGET_HOSTNAME")
+	if hostname == "" {
+		hostname = "github.com"
+	}
+
+	held, err := lock.Acquire(viper.GetString("LOCK_DIR"), hostname+"/"+targetRepository, viper.GetString("RUN_ID"), viper.GetBool("FORCE"))
+	if err != nil {
+		return nil, fmt.Errorf("%w (use --force to override a stale lock)", err)
+	}
+	heldRunLocksLock.Lock()
+	heldRunLocks = append(heldRunLocks, held)
+	heldRunLocksLock.Unlock()
+	return held, nil
+}
+
+// releaseRunLock releases a lock taken with acquireRunLock
+func releaseRunLock(held *lock.Lock) {
+	heldRunLocksLock.Lock()
+	for i, other := range heldRunLocks {
+		if other == held {
+			heldRunLocks = append(heldRunLocks[:i], heldRunLocks[i+1:]...)
+			break
+		}
+	}
+	heldRunLocksLock.Unlock()
+
+	if err := held.Release(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to release the lock of %s: %v\n", held.Holder.Repository, err)
+	}
+}
+
+// releaseRunLocks releases every lock still held. It must run before the process exits.
+func releaseRunLocks() {
+	heldRunLocksLock.Lock()
+	held := heldRunLocks
+	heldRunLocks = nil
+	heldRunLocksLock.Unlock()
+
+	for _, l := range held {
+		if err := l.Release(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to release the lock of %s: %v\n", l.Holder.Repository, err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/lock"
+
+	"github.com/spf13/viper"
+)
+
+func TestAcquireRunLock(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	viper.Set("LOCK_DIR", t.TempDir())
+	viper.Set("TARGET_HOSTNAME", "github.example.com")
+
+	held, err := acquireRunLock("target-org/repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := filepath.Join(viper.GetString("LOCK_DIR"), "github.example.com_target-org_repo.lock"); held.Path != want {
+		t.Errorf("Expected the lock file %s, got %s", want, held.Path)
+	}
+
+	_, err = acquireRunLock("target-org/repo")
+	if !errors.Is(err, lock.ErrLocked) || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected a locked error suggesting --force, got %v", err)
+	}
+
+	viper.Set("FORCE", true)
+	if _, err := acquireRunLock("target-org/repo"); err != nil {
+		t.Errorf("Expected --force to take over the lock, got %v", err)
+	}
+
+	releaseRunLocks()
+	if len(heldRunLocks) != 0 {
+		t.Errorf("Expected every lock to be released, %d are held", len(heldRunLocks))
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/history"
+	"mona-actions/gh-migration-validator/internal/mannequins"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// mannequinsCmd represents the mannequins command
+var mannequinsCmd = &cobra.Command{
+	Use:   "mannequins",
+	Short: "Report mannequin reclamation progress in the target organization",
+	Long: `List the mannequins GitHub Enterprise Importer created in the target organization,
+the number of migrated issues and pull requests each one authored, and whether it has
+been reclaimed.
+
+With --history-db, every report is recorded so the reclamation progress of the
+organization is shown over time. With --csv-file, the mannequins are also written
+to a CSV file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+		csvFile := cmd.Flag("csv-file").Value.String()
+		noAuthoredItems, _ := cmd.Flags().GetBool("no-authored-items")
+
+		if err := checkMannequinsVars(); err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		ghAPI, err := newTargetOnlyAPI()
+		if err != nil {
+			exitWithError("Failed to initialize target API", err)
+		}
+
+		report, err := mannequins.Fetch(ghAPI, api.TargetClient, targetOrganization, !noAuthoredItems)
+		if err != nil {
+			exitWithError("Failed to retrieve mannequins", err)
+		}
+
+		report.Print(recordMannequinSnapshot(report))
+
+		if csvFile != "" {
+			if err := writeMannequinsCSV(report, csvFile); err != nil {
+				exitWithError("Failed to write CSV file", err)
+			}
+			fmt.Printf("Mannequins written to %s\n", csvFile)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mannequinsCmd)
+
+	mannequinsCmd.Flags().String("csv-file", "", "Write the mannequins to the specified CSV file (optional)")
+	mannequinsCmd.Flags().Bool("no-authored-items", false, "Skip counting the items authored by each mannequin, which takes one search request per mannequin")
+
+	addSharedFlags(mannequinsCmd.Flags(), "target-org", "target-token", "target-hostname", "target-api-url")
+}
+
+// checkMannequinsVars validates the configuration for the mannequins command
+func checkMannequinsVars() error {
+	for _, key := range []string{"TARGET_ORGANIZATION", "TARGET_TOKEN"} {
+		info := requiredVars[key]
+		if viper.GetString(key) == "" {
+			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable",
+				key, info.flag, info.envVar)
+		}
+	}
+	return nil
+}
+
+// recordMannequinSnapshot appends the reclamation progress of the report to the history database when one
+// is configured, and returns every snapshot recorded for the organization. Failures are reported but do not
+// prevent the report from being shown.
+func recordMannequinSnapshot(report *mannequins.Report) []history.MannequinSnapshot {
+	historyDB := viper.GetString("HISTORY_DB")
+	if historyDB == "" {
+		return nil
+	}
+
+	store, err := history.Open(historyDB)
+	if err != nil {
+		fmt.Printf("Failed to record mannequin history: %v\n", err)
+		return nil
+	}
+	defer store.Close()
+
+	if err := store.RecordMannequinSnapshot(report.Snapshot()); err != nil {
+		fmt.Printf("Failed to record mannequin history: %v\n", err)
+		return nil
+	}
+
+	snapshots, err := store.MannequinSnapshots(report.Organization)
+	if err != nil {
+		fmt.Printf("Failed to read mannequin history: %v\n", err)
+		return nil
+	}
+	return snapshots
+}
+
+// writeMannequinsCSV writes the mannequins of the report to path
+func writeMannequinsCSV(report *mannequins.Report, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return report.WriteCSV(file)
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/export"
+	"mona-actions/gh-migration-validator/internal/migrationarchive"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrationsCmd groups the commands of the source organization migrations (exports)
+var migrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "Inspect the migrations (exports) of the source organization",
+}
+
+// migrationsStatusCmd represents the migrations status command
+var migrationsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the state of a source organization migration, optionally waiting for its export",
+	Long: `Show the state of the source organization migration with the given ID: pending,
+exporting, exported or failed.
+
+With --wait, the migration is polled every --interval until it is exported, so its
+archive can be downloaded as soon as it is ready. With --export, the archive is then
+downloaded and extracted, and --source-repo is exported with it, as export --download
+would.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+		migrationID, _ := cmd.Flags().GetInt64("id")
+		wait, _ := cmd.Flags().GetBool("wait")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		exportArchive, _ := cmd.Flags().GetBool("export")
+		downloadPath := cmd.Flag("download-path").Value.String()
+
+		if err := checkMigrationsStatusVars(migrationID, wait, interval, exportArchive); err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		ghAPI, err := newSourceOnlyAPI()
+		if err != nil {
+			exitWithError("Failed to initialize source API", err)
+		}
+
+		if !wait {
+			migration, err := ghAPI.GetMigration(api.SourceClient, sourceOrganization, migrationID)
+			if err != nil {
+				exitWithError("Failed to get migration", err)
+			}
+			fmt.Println(describeMigration(migration))
+			return
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Waiting for migration %d to be exported...", migrationID))
+		migration, err := ghAPI.WaitForMigration(ctx, api.SourceClient, sourceOrganization, migrationID, interval, func(migration *api.MigrationInfo) {
+			spinner.UpdateText(fmt.Sprintf("Waiting for migration %d to be exported: %s", migrationID, migration.State))
+		})
+		if err != nil {
+			spinner.Fail(fmt.Sprintf("Migration %d was not exported", migrationID))
+			exitWithError("Failed to wait for migration", err)
+		}
+		spinner.Success(describeMigration(migration))
+
+		if exportArchive {
+			exportMigration(cmd, ghAPI, sourceOrganization, migrationID, downloadPath)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrationsCmd)
+	migrationsCmd.AddCommand(migrationsStatusCmd)
+
+	migrationsStatusCmd.Flags().Int64("id", 0, "ID of the source organization migration")
+	migrationsStatusCmd.MarkFlagRequired("id")
+	migrationsStatusCmd.Flags().Bool("wait", false, "Poll the migration until it is exported, failing if the export fails")
+	migrationsStatusCmd.Flags().Duration("interval", 30*time.Second, "Delay between two polls of --wait")
+	migrationsStatusCmd.Flags().Duration("timeout", 0, "Time limit of --wait, e.g. 2h (default: none)")
+	migrationsStatusCmd.Flags().Bool("export", false, "Once the migration is exported, download its archive and export --source-repo with it (needs --wait)")
+	migrationsStatusCmd.Flags().String("download-path", "", "Directory to download the migration archive to with --export (default: ./migration-archives)")
+
+	addSharedFlags(migrationsStatusCmd.Flags(), "source-org", "source-token", "source-hostname", "source-api-url", "source-repo",
+		"download-rate-limit", "download-parts")
+}
+
+// checkMigrationsStatusVars validates the configuration for the migrations status command
+func checkMigrationsStatusVars(migrationID int64, wait bool, interval time.Duration, exportArchive bool) error {
+	for _, key := range []string{"SOURCE_ORGANIZATION", "SOURCE_TOKEN"} {
+		info := requiredVars[key]
+		if viper.GetString(key) == "" {
+			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable",
+				key, info.flag, info.envVar)
+		}
+	}
+	if migrationID <= 0 {
+		return fmt.Errorf("--id must be a migration ID, got %d", migrationID)
+	}
+	if wait && interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+	if exportArchive && !wait {
+		return fmt.Errorf("--export needs --wait, so the migration is exported before its archive is downloaded")
+	}
+	if exportArchive && viper.GetString("SOURCE_REPO") == "" {
+		return fmt.Errorf("--export needs the repository to export. Set it via --source-repo flag")
+	}
+	_, err := downloadConfig()
+	return err
+}
+
+// describeMigration returns the state and repositories of a migration for display
+func describeMigration(migration *api.MigrationInfo) string {
+	return fmt.Sprintf("Migration %d: %s (created %s, updated %s) - repositories (%d): %s", migration.ID, migration.State,
+		migration.CreatedAt, migration.UpdatedAt, len(migration.Repositories), strings.Join(migration.Repositories, ", "))
+}
+
+// exportMigration downloads and extracts the archive of the exported migration, then exports --source-repo with
+// it, as export --download does
+func exportMigration(cmd *cobra.Command, ghAPI *api.GitHubAPI, sourceOrganization string, migrationID int64, downloadPath string) {
+	sourceRepo := viper.GetString("SOURCE_REPO")
+
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	archiveDir, err := migrationarchive.DownloadAndExtractMigration(ghAPI, sourceOrganization, sourceRepo, migrationID, downloadPath)
+	if err != nil {
+		exitWithError("Migration archive download failed", err)
+	}
+
+	migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+	tool := exportToolMetadata(cmd.Flags(), validationOptions)
+	if _, err := export.ExportSourceData(migrationValidator, sourceOrganization, sourceRepo, "json", "", time.Now(), archiveDir, true, tool); err != nil {
+		exitWithError("Export failed", err)
+	}
+}
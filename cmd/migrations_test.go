@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/spf13/viper"
+)
+
+func TestCheckMigrationsStatusVars(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	if err := checkMigrationsStatusVars(42, false, time.Second, false); err == nil || !strings.Contains(err.Error(), "SOURCE_ORGANIZATION is required") {
+		t.Errorf("Expected the source organization to be required, got %v", err)
+	}
+
+	viper.Set("SOURCE_ORGANIZATION", "source-org")
+	viper.Set("SOURCE_TOKEN", "source-token")
+	tests := []struct {
+		name          string
+		migrationID   int64
+		wait          bool
+		interval      time.Duration
+		exportArchive bool
+		sourceRepo    string
+		wantErr       string
+	}{
+		{name: "status", migrationID: 42},
+		{name: "wait", migrationID: 42, wait: true, interval: time.Minute},
+		{name: "wait and export", migrationID: 42, wait: true, interval: time.Minute, exportArchive: true, sourceRepo: "repo"},
+		{name: "missing ID", wantErr: "--id must be a migration ID"},
+		{name: "zero interval", migrationID: 42, wait: true, wantErr: "--interval must be positive"},
+		{name: "export without wait", migrationID: 42, exportArchive: true, sourceRepo: "repo", wantErr: "--export needs --wait"},
+		{name: "export without repository", migrationID: 42, wait: true, interval: time.Minute, exportArchive: true, wantErr: "--source-repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("SOURCE_REPO", tt.sourceRepo)
+			err := checkMigrationsStatusVars(tt.migrationID, tt.wait, tt.interval, tt.exportArchive)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDescribeMigration(t *testing.T) {
+	got := describeMigration(&api.MigrationInfo{ID: 42, State: "exporting", CreatedAt: "2025-10-13T14:49:08Z",
+		UpdatedAt: "2025-10-13T14:52:00Z", Repositories: []string{"repo", "other-repo"}})
+	want := "Migration 42: exporting (created 2025-10-13T14:49:08Z, updated 2025-10-13T14:52:00Z) - repositories (2): repo, other-repo"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
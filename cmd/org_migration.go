@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// orgMigrationCmd represents the org-migration command
+var orgMigrationCmd = &cobra.Command{
+	Use:   "org-migration",
+	Short: "Validate the organization-level entities of an organization migration",
+	Long: `Validate a GitHub Enterprise Importer organization migration by comparing the
+organization-level entities of the source and target organizations:
+- Repositories count
+- Teams count
+- Team memberships, in total and for every team that differs
+- Organization webhooks count
+- Organization projects count
+
+Repository contents are not compared; validate each repository with the root command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := checkOrgMigrationVars(); err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+		targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+
+		ghAPI, err := newGitHubAPI()
+		if err != nil {
+			exitWithError("Failed to initialize API clients", err)
+		}
+
+		validationOptions, err := getValidationOptions()
+		if err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+		migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+		results, err := migrationValidator.ValidateOrganizationMigration(sourceOrganization, targetOrganization)
+		if err != nil {
+			exitWithError("Organization migration validation failed", err)
+		}
+
+		summary := migrationValidator.PrintOrganizationResults(results)
+		exitOnStrictFailure(migrationValidator, summary)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orgMigrationCmd)
+
+	addSharedFlags(orgMigrationCmd.Flags(),
+		"source-org", "target-org", "source-token", "target-token", "source-hostname", "target-hostname", "source-api-url", "target-api-url",
+		"markdown-table", "markdown-file",
+	)
+}
+
+// checkOrgMigrationVars validates the configuration for the org-migration command
+func checkOrgMigrationVars() error {
+	for _, key := range []string{"SOURCE_ORGANIZATION", "TARGET_ORGANIZATION", "SOURCE_TOKEN", "TARGET_TOKEN"} {
+		info := requiredVars[key]
+		if viper.GetString(key) == "" {
+			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable",
+				key, info.flag, info.envVar)
+		}
+	}
+	return nil
+}
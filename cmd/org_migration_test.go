@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheckOrgMigrationVars(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	os.Setenv("GHMV_SOURCE_ORGANIZATION", "source-org")
+	os.Setenv("GHMV_TARGET_ORGANIZATION", "target-org")
+	os.Setenv("GHMV_SOURCE_TOKEN", "source-token")
+
+	err := checkOrgMigrationVars()
+	if err == nil || !strings.Contains(err.Error(), "TARGET_TOKEN is required") {
+		t.Errorf("Expected the target token to be required, got: %v", err)
+	}
+
+	// Repositories are not needed to validate an organization migration
+	os.Setenv("GHMV_TARGET_TOKEN", "target-token")
+	if err := checkOrgMigrationVars(); err != nil {
+		t.Errorf("Expected no error without repositories, got: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"path"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+)
+
+// publishIssueComment posts the markdown validation report as a comment on the target repository's migration log issue
+func publishIssueComment(ghAPI *api.GitHubAPI, mv *validator.MigrationValidator, results []validator.ValidationResult) error {
+	target := mv.TargetData
+	if target.MigrationLog == nil || !target.MigrationLog.Found {
+		return fmt.Errorf("no migration log issue found in %s/%s to comment on", target.Owner, target.Name)
+	}
+
+	if err := ghAPI.CreateIssueComment(api.TargetClient, target.Owner, target.Name, target.MigrationLog.IssueNumber, mv.MarkdownReport(results)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Validation report posted to %s\n", target.MigrationLog.IssueURL)
+	return nil
+}
+
+// checkRunName is the name of the check run published with --create-check-run
+const checkRunName = "Migration Validation"
+
+// maxCheckRunTextLength is the maximum length GitHub accepts for check run output text
+const maxCheckRunTextLength = 65535
+
+// checkRunTruncationNotice is appended to check run texts cut to maxCheckRunTextLength
+const checkRunTruncationNotice = "\n\n… report truncated, see --markdown-file for the full report\n"
+
+// truncateCheckRunText cuts text to maxCheckRunTextLength bytes, at a character boundary so that multi-byte
+// characters such as the report's emoji are not split, and appends checkRunTruncationNotice when it was cut
+func truncateCheckRunText(text string) string {
+	if len(text) <= maxCheckRunTextLength {
+		return text
+	}
+
+	cut := maxCheckRunTextLength - len(checkRunTruncationNotice)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + checkRunTruncationNotice
+}
+
+// publishCheckRun publishes the validation result as a check run on the head of the target repository's default branch
+func publishCheckRun(ghAPI *api.GitHubAPI, mv *validator.MigrationValidator, results []validator.ValidationResult) error {
+	target := mv.TargetData
+	if target.LatestCommitSHA == "" {
+		return fmt.Errorf("no default branch commit found in %s/%s to attach a check run to", target.Owner, target.Name)
+	}
+
+	summary := validator.Summarize(results)
+
+	report := api.CheckRunReport{
+		Name:    checkRunName,
+		HeadSHA: target.LatestCommitSHA,
+		Title:   fmt.Sprintf("%d passed, %d failed, %d warnings", summary.Passed, summary.Failed, summary.Warnings),
+		Summary: summary.Verdict.Message(),
+		Text:    mv.MarkdownReport(results),
+	}
+	report.Text = truncateCheckRunText(report.Text)
+
+	switch summary.Verdict {
+	case validator.VerdictFailed:
+		report.Conclusion = "failure"
+	case validator.VerdictWarnings:
+		report.Conclusion = "neutral"
+	default:
+		report.Conclusion = "success"
+	}
+
+	url, err := ghAPI.CreateCheckRun(api.TargetClient, target.Owner, target.Name, report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Check run published to %s\n", url)
+	return nil
+}
+
+// reportFileName is the name of the report files committed with --publish-repo, without extension
+const reportFileName = "validation-report"
+
+// publishReportRepository commits the markdown and JSON reports to the reports repository with the target
+// client, under <source owner>/<source repo>/<date>/ so every run is kept in the repository history
+func publishReportRepository(ghAPI *api.GitHubAPI, mv *validator.MigrationValidator, results []validator.ValidationResult, reportsRepo string) error {
+	owner, name, err := parseRepository(reportsRepo)
+	if err != nil {
+		return err
+	}
+
+	jsonReport, err := mv.JSONReport(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON report: %v", err)
+	}
+
+	source := mv.SourceData
+	dir := path.Join(source.Owner, source.Name, time.Now().UTC().Format("2006-01-02"))
+	message := fmt.Sprintf("Add validation report of %s/%s: %s", source.Owner, source.Name, validator.Summarize(results).Verdict)
+
+	url, err := ghAPI.CommitFile(api.TargetClient, owner, name, path.Join(dir, reportFileName+".md"), message, []byte(mv.MarkdownReport(results)))
+	if err != nil {
+		return err
+	}
+	if _, err := ghAPI.CommitFile(api.TargetClient, owner, name, path.Join(dir, reportFileName+".json"), message, jsonReport); err != nil {
+		return err
+	}
+
+	fmt.Printf("Validation report published to %s\n", url)
+	return nil
+}
+
+// publishValidationReport publishes the validation result as a check run when --create-check-run is set, and
+// commits the reports to the --publish-repo repository. Failures are reported but do not change the outcome
+// of the validation.
+func publishValidationReport(ghAPI *api.GitHubAPI, mv *validator.MigrationValidator, results []validator.ValidationResult) {
+	if reportsRepo := viper.GetString("PUBLISH_REPO"); reportsRepo != "" {
+		if err := publishReportRepository(ghAPI, mv, results, reportsRepo); err != nil {
+			fmt.Printf("Failed to publish validation report: %v\n", err)
+		}
+	}
+
+	// An unavailable target has no commit to attach a check run to
+	if !viper.GetBool("CREATE_CHECK_RUN") || mv.TargetUnavailableError() != nil {
+		return
+	}
+
+	if err := publishCheckRun(ghAPI, mv, results); err != nil {
+		fmt.Printf("Failed to create check run: %v\n", err)
+	}
+}
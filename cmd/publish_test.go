@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"mona-actions/gh-migration-validator/internal/migrationlog"
+	"mona-actions/gh-migration-validator/internal/validator"
+)
+
+func TestPublishCheckRun_RequiresDefaultBranchCommit(t *testing.T) {
+	mv := validator.New(nil)
+	mv.TargetData = &validator.RepositoryData{Owner: "target-org", Name: "empty-repo", IsEmpty: true}
+
+	err := publishCheckRun(nil, mv, nil)
+	if err == nil || !strings.Contains(err.Error(), "no default branch commit found in target-org/empty-repo") {
+		t.Errorf("Expected missing commit error, got: %v", err)
+	}
+}
+
+func TestPublishIssueComment_RequiresMigrationLogIssue(t *testing.T) {
+	mv := validator.New(nil)
+	mv.TargetData = &validator.RepositoryData{
+		Owner:        "target-org",
+		Name:         "target-repo",
+		MigrationLog: &migrationlog.MigrationLogMetrics{Found: false},
+	}
+
+	err := publishIssueComment(nil, mv, nil)
+	if err == nil || !strings.Contains(err.Error(), "no migration log issue found in target-org/target-repo") {
+		t.Errorf("Expected missing migration log issue error, got: %v", err)
+	}
+}
+
+func TestPublishReportRepository_InvalidRepository(t *testing.T) {
+	mv := validator.New(nil)
+	mv.SourceData = &validator.RepositoryData{Owner: "source-org", Name: "repo"}
+	mv.TargetData = &validator.RepositoryData{Owner: "target-org", Name: "repo"}
+
+	err := publishReportRepository(nil, mv, nil, "validation-reports")
+	if err == nil || !strings.Contains(err.Error(), `invalid repository "validation-reports": expected OWNER/REPO`) {
+		t.Errorf("Expected invalid repository error, got: %v", err)
+	}
+}
+
+func TestTruncateCheckRunText(t *testing.T) {
+	if text := "✅ short report"; truncateCheckRunText(text) != text {
+		t.Errorf("Expected short text to be kept, got: %q", truncateCheckRunText(text))
+	}
+
+	// Offset the emoji so that a byte cut would split one of them
+	text := "x" + strings.Repeat("✅", maxCheckRunTextLength)
+	truncated := truncateCheckRunText(text)
+	if len(truncated) > maxCheckRunTextLength {
+		t.Errorf("Expected at most %d bytes, got %d", maxCheckRunTextLength, len(truncated))
+	}
+	if !utf8.ValidString(truncated) {
+		t.Error("Expected truncated text to be valid UTF-8")
+	}
+	if !strings.HasSuffix(truncated, checkRunTruncationNotice) {
+		t.Errorf("Expected truncation notice, got: %q", truncated[len(truncated)-100:])
+	}
+}
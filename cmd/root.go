@@ -4,11 +4,20 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/config"
+	"mona-actions/gh-migration-validator/internal/history"
+	"mona-actions/gh-migration-validator/internal/output"
+	"mona-actions/gh-migration-validator/internal/output/terminal"
 	"mona-actions/gh-migration-validator/internal/validator"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -22,66 +31,139 @@ var rootCmd = &cobra.Command{
 This tool helps ensure that your migration from one GitHub organization to another
 has been completed successfully by comparing certain repositories resources
 between source and target organizations.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// Validate required variables (from either flags OR env vars)
-		if err := checkVars(); err != nil {
-			fmt.Printf("Configuration validation failed: %v\n", err)
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Resolve deprecated flag names and fill in flags that were not set on the command line from the selected profile
+		if err := prepareFlags(cmd); err != nil {
+			fmt.Printf("%v\n", err)
 			os.Exit(1)
 		}
 
-		// Read all values from Viper (single source of truth)
-		sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
-		targetOrganization := viper.GetString("TARGET_ORGANIZATION")
-		sourceRepo := viper.GetString("SOURCE_REPO")
-		targetRepo := viper.GetString("TARGET_REPO")
-
-		// Initialize API with both source and target clients
-		ghAPI, err := api.NewGitHubAPI()
-		if err != nil {
-			fmt.Printf("Failed to initialize API clients: %v\n", err)
+		if err := applyOutputSettings(); err != nil {
+			fmt.Printf("%v\n", err)
 			os.Exit(1)
 		}
 
-		// Create validator and run migration validation
-		migrationValidator := validator.New(ghAPI)
-		results, err := migrationValidator.ValidateMigration(sourceOrganization, sourceRepo, targetOrganization, targetRepo)
-		if err != nil {
-			fmt.Printf("Migration validation failed: %v\n", err)
+		if err := startTelemetry(); err != nil {
+			fmt.Printf("%v\n", err)
 			os.Exit(1)
 		}
+	},
+	Run: runValidation,
+}
 
-		// Print the validation results - always report what we found
-		migrationValidator.PrintValidationResults(results)
+// runValidation validates a migration using the repositories and options from the configuration
+func runValidation(cmd *cobra.Command, args []string) {
+	archiveSource, err := archiveSourceSelected()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	if archiveSource {
+		runArchiveValidation()
+		return
+	}
+	if viper.GetBool("DRY_RUN") {
+		runDryRun()
+		return
+	}
+	if viper.GetBool("STDIN") {
+		runStdinBatch()
+		return
+	}
+	if viper.GetString("OUTPUT_FORMAT") != "" {
+		fmt.Println("Configuration validation failed: --output-format selects the reports of --stdin batches")
+		os.Exit(1)
+	}
+	if viper.GetString("XLSX_FILE") != "" {
+		fmt.Println("Configuration validation failed: --xlsx-file writes the report of --stdin batches")
+		os.Exit(1)
+	}
 
-		if viper.GetBool("STRICT_EXIT") && validator.HasFailures(results) {
-			os.Exit(2)
-		}
-	},
+	// Validate required variables (from either flags OR env vars)
+	if err := checkVars(); err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Read all values from Viper (single source of truth)
+	sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+	targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+	sourceRepo := viper.GetString("SOURCE_REPO")
+	targetRepo := viper.GetString("TARGET_REPO")
+
+	if targetReusesSourceCredentials() {
+		fmt.Println("No target token set and both repositories are in the same organization, using the source credentials for both")
+	}
+
+	// Initialize API with both source and target clients
+	ghAPI, err := newGitHubAPI()
+	if err != nil {
+		exitWithError("Failed to initialize API clients", err)
+	}
+
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	signer := loadMarkdownReportSigner()
+	if _, err := acquireRunLock(targetOrganization + "/" + targetRepo); err != nil {
+		exitWithError("Migration validation failed", err)
+	}
+
+	// Create validator and run migration validation
+	migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+	results, err := migrationValidator.ValidateMigration(sourceOrganization, sourceRepo, targetOrganization, targetRepo)
+	if err != nil {
+		exitWithError("Migration validation failed", err)
+	}
+
+	// Print the validation results - always report what we found
+	summary := migrationValidator.PrintValidationResults(results)
+	signReport(signer, viper.GetString("MARKDOWN_FILE"))
+	recordValidationHistory(migrationValidator, results)
+	recordAuditLog(ghAPI, api.SourceClient, "validate", migrationValidator, results)
+	publishValidationReport(ghAPI, migrationValidator, results)
+
+	exitOnUnavailableTarget(migrationValidator)
+	exitOnStrictFailure(migrationValidator, summary)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
+	releaseRunLocks()
+	shutdownTelemetry()
 	if err != nil {
 		os.Exit(1)
 	}
+
+	// version --check reports newer releases itself
+	if cmd != versionCmd {
+		notifyNewerVersion()
+	}
 }
 
 func init() {
+	// Reports, spinners and log messages are drawn on the terminal
+	output.SetPrinter(terminal.Printer{})
+
 	// Define flags WITHOUT marking as required - validation happens in checkVars()
 	// This allows either flags OR environment variables to provide values
-	rootCmd.Flags().StringP("github-source-org", "s", "", "Source Organization to sync teams from")
-	rootCmd.Flags().StringP("github-target-org", "t", "", "Target Organization to sync teams from")
-	rootCmd.Flags().StringP("github-source-pat", "a", "", "Source Organization GitHub token. Scopes: read:org, read:user, user:email")
-	rootCmd.Flags().StringP("github-target-pat", "b", "", "Target Organization GitHub token. Scopes: admin:org")
-	rootCmd.Flags().StringP("source-hostname", "u", "", "GitHub Enterprise source hostname url (optional) Ex. https://github.example.com")
-	rootCmd.Flags().StringP("source-repo", "", "", "Source repository name to verify against (just the repo name, not owner/repo)")
-	rootCmd.Flags().StringP("target-repo", "", "", "Target repository name to verify against (just the repo name, not owner/repo)")
-	rootCmd.Flags().BoolP("markdown-table", "m", false, "Print results as a markdown table")
-	rootCmd.Flags().String("markdown-file", "", "Write markdown output to the specified file (optional)")
-	rootCmd.Flags().Bool("no-lfs", false, "Skip LFS object validation")
-	rootCmd.PersistentFlags().Bool("strict-exit", false, "Exit with status 2 when validations fail")
+	addSharedFlags(rootCmd.Flags(),
+		"source-org", "target-org", "source-token", "target-token", "source-hostname", "target-hostname", "source-api-url", "target-api-url",
+		"source-repo", "target-repo", "markdown-table", "markdown-file", "no-lfs", "no-issue-offset", "issue-offset", "follow-renames", "dry-run", "check-security",
+		"check-truncation", "branches", "count-prs-as-issues", "check-lfs-locks", "rewritten-history", "stdin", "output-format", "xlsx-file", "fail-fast", "max-failures", "repo-timeout",
+		"source-lfs-url", "target-lfs-url", "source-lfs-username", "target-lfs-username", "source-lfs-token", "target-lfs-token",
+		"source", "archive-path",
+	)
+	addSharedFlags(rootCmd.PersistentFlags(),
+		"strict-exit", "history-db", "audit-log", "profile", "config", "create-check-run", "publish-repo", "max-retries", "retry-backoff", "retry-jitter", "show-timings",
+		"lfs-batch-size", "lfs-concurrency", "max-tree-entries",
+		"otel-endpoint", "explain", "explain-rules", "rules", "ignore", "plain",
+		"no-color", "quiet", "debug-http", "replay-http", "run-id", "lock-dir", "force", "no-update-check", "sign-report", "key",
+	)
 
 	// Set environment variable prefix: GHMV (GitHub Migration Validator)
 	viper.SetEnvPrefix("GHMV")
@@ -89,17 +171,8 @@ func init() {
 
 	// Bind flags to Viper keys - this connects flags directly to Viper
 	// Priority: Flag value > Environment variable > Default value
-	viper.BindPFlag("SOURCE_ORGANIZATION", rootCmd.Flags().Lookup("github-source-org"))
-	viper.BindPFlag("TARGET_ORGANIZATION", rootCmd.Flags().Lookup("github-target-org"))
-	viper.BindPFlag("SOURCE_TOKEN", rootCmd.Flags().Lookup("github-source-pat"))
-	viper.BindPFlag("TARGET_TOKEN", rootCmd.Flags().Lookup("github-target-pat"))
-	viper.BindPFlag("SOURCE_HOSTNAME", rootCmd.Flags().Lookup("source-hostname"))
-	viper.BindPFlag("SOURCE_REPO", rootCmd.Flags().Lookup("source-repo"))
-	viper.BindPFlag("TARGET_REPO", rootCmd.Flags().Lookup("target-repo"))
-	viper.BindPFlag("MARKDOWN_TABLE", rootCmd.Flags().Lookup("markdown-table"))
-	viper.BindPFlag("MARKDOWN_FILE", rootCmd.Flags().Lookup("markdown-file"))
-	viper.BindPFlag("NO_LFS", rootCmd.Flags().Lookup("no-lfs"))
-	viper.BindPFlag("STRICT_EXIT", rootCmd.PersistentFlags().Lookup("strict-exit"))
+	bindFlags(rootCmd.Flags())
+	bindFlags(rootCmd.PersistentFlags())
 
 	// Bind environment variables explicitly for additional app authentication options
 	viper.BindEnv("SOURCE_PRIVATE_KEY")
@@ -110,6 +183,10 @@ func init() {
 	viper.BindEnv("TARGET_INSTALLATION_ID")
 	viper.BindEnv("MARKDOWN_FILE")
 	viper.BindEnv("STRICT_EXIT")
+	viper.BindEnv("HISTORY_DB")
+	viper.BindEnv("CREATE_CHECK_RUN")
+	viper.BindEnv("PROFILE")
+	viper.BindEnv("CONFIG_FILE")
 }
 
 // requiredConfig defines a required configuration with its flag and env var names
@@ -118,18 +195,30 @@ type requiredConfig struct {
 	envVar string
 }
 
+// requiredVars are the configurations required to validate a migration, with helpful error messages
+var requiredVars = map[string]requiredConfig{
+	"SOURCE_ORGANIZATION": {"--source-org / -s", "GHMV_SOURCE_ORGANIZATION"},
+	"TARGET_ORGANIZATION": {"--target-org / -t", "GHMV_TARGET_ORGANIZATION"},
+	"SOURCE_TOKEN":        {"--source-token / -a", "GHMV_SOURCE_TOKEN"},
+	"TARGET_TOKEN":        {"--target-token / -b", "GHMV_TARGET_TOKEN"},
+	"SOURCE_REPO":         {"--source-repo", "GHMV_SOURCE_REPO"},
+	"TARGET_REPO":         {"--target-repo", "GHMV_TARGET_REPO"},
+}
+
 func checkVars() error {
-	// Required configurations with helpful error messages
-	required := map[string]requiredConfig{
-		"SOURCE_ORGANIZATION": {"--github-source-org / -s", "GHMV_SOURCE_ORGANIZATION"},
-		"TARGET_ORGANIZATION": {"--github-target-org / -t", "GHMV_TARGET_ORGANIZATION"},
-		"SOURCE_TOKEN":        {"--github-source-pat / -a", "GHMV_SOURCE_TOKEN"},
-		"TARGET_TOKEN":        {"--github-target-pat / -b", "GHMV_TARGET_TOKEN"},
-		"SOURCE_REPO":         {"--source-repo", "GHMV_SOURCE_REPO"},
-		"TARGET_REPO":         {"--target-repo", "GHMV_TARGET_REPO"},
-	}
+	return checkRequiredVars(false)
+}
 
-	for key, info := range required {
+// checkRequiredVars validates the required configurations, skipping tokens when skipTokens is set
+func checkRequiredVars(skipTokens bool) error {
+	for key, info := range requiredVars {
+		if skipTokens && strings.HasSuffix(key, "_TOKEN") {
+			continue
+		}
+		// A target in the source organization on the same instance is validated with the source token
+		if key == "TARGET_TOKEN" && targetReusesSourceCredentials() {
+			continue
+		}
 		if viper.GetString(key) == "" {
 			return fmt.Errorf("%s is required. Set via %s flag or %s environment variable",
 				key, info.flag, info.envVar)
@@ -138,3 +227,174 @@ func checkVars() error {
 
 	return nil
 }
+
+// getValidationOptions builds the validator options from the configuration, so the validator never reads Viper.
+// The issue offset is left unset (auto-detected) unless --no-issue-offset or --issue-offset is provided.
+func getValidationOptions() (validator.ValidationOptions, error) {
+	options := validator.ValidationOptions{
+		FollowRenames:      viper.GetBool("FOLLOW_RENAMES"),
+		Explain:            viper.GetBool("EXPLAIN"),
+		NoLFS:              viper.GetBool("NO_LFS"),
+		CheckSecurity:      viper.GetBool("CHECK_SECURITY"),
+		CheckLFSLocks:      viper.GetBool("CHECK_LFS_LOCKS"),
+		CheckTruncation:    viper.GetInt("CHECK_TRUNCATION"),
+		Branches:           viper.GetString("BRANCHES"),
+		CountPRsAsIssues:   viper.GetBool("COUNT_PRS_AS_ISSUES"),
+		TransferMode:       viper.GetBool("TRANSFER_MODE"),
+		RewrittenHistory:   viper.GetBool("REWRITTEN_HISTORY"),
+		RateLimitThreshold: rateLimitThreshold(),
+		ShowTimings:        viper.GetBool("SHOW_TIMINGS"),
+		MarkdownTable:      viper.GetBool("MARKDOWN_TABLE"),
+		MarkdownFile:       viper.GetString("MARKDOWN_FILE"),
+	}
+
+	if rulesFile := viper.GetString("EXPLAIN_RULES"); rulesFile != "" {
+		rules, err := validator.LoadRemediationRules(rulesFile)
+		if err != nil {
+			return options, err
+		}
+		options.RemediationRules = rules
+	}
+
+	if rulesFile := viper.GetString("RULES"); rulesFile != "" {
+		rules, err := validator.LoadValidationRules(rulesFile)
+		if err != nil {
+			return options, err
+		}
+		options.ComparisonRules = rules.Comparisons
+		options.IgnoreRules = rules.Ignore
+	}
+	for _, metric := range strings.Split(viper.GetString("IGNORE"), ",") {
+		if metric = strings.TrimSpace(metric); metric != "" {
+			options.IgnoreRules = append(options.IgnoreRules, validator.IgnoreRule{Metric: metric, Reason: "ignored with --ignore"})
+		}
+	}
+
+	noIssueOffset := viper.GetBool("NO_ISSUE_OFFSET")
+	issueOffsetSet := viper.IsSet("ISSUE_OFFSET")
+
+	if noIssueOffset && issueOffsetSet {
+		return options, fmt.Errorf("--no-issue-offset and --issue-offset are mutually exclusive")
+	}
+
+	if noIssueOffset {
+		offset := 0
+		options.IssueOffset = &offset
+	} else if issueOffsetSet {
+		offset := viper.GetInt("ISSUE_OFFSET")
+		if offset < 0 {
+			return options, fmt.Errorf("--issue-offset must not be negative, got %d", offset)
+		}
+		options.IssueOffset = &offset
+	}
+
+	return options, nil
+}
+
+// recordValidationHistory appends the validation results to the SQLite history database when one is configured.
+// Failures are reported but do not change the outcome of the validation.
+func recordValidationHistory(mv *validator.MigrationValidator, results []validator.ValidationResult) {
+	historyDB := viper.GetString("HISTORY_DB")
+	if historyDB == "" {
+		return
+	}
+
+	store, err := history.Open(historyDB)
+	if err != nil {
+		fmt.Printf("Failed to record validation history: %v\n", err)
+		return
+	}
+	defer store.Close()
+
+	run := history.NewRun(
+		fmt.Sprintf("%s/%s", mv.SourceData.Owner, mv.SourceData.Name),
+		fmt.Sprintf("%s/%s", mv.TargetData.Owner, mv.TargetData.Name),
+		time.Now(),
+	)
+	if err := store.Record(run, results); err != nil {
+		fmt.Printf("Failed to record validation history: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Validation results recorded in %s (run %s)\n", historyDB, run.ID)
+}
+
+// applyProfile sets every flag of cmd that was not given on the command line to its value in the
+// selected profile. Profile keys are flag names; keys for flags the command does not have are ignored
+// so one profile can be shared by all commands.
+func applyProfile(cmd *cobra.Command) error {
+	profileName := viper.GetString("PROFILE")
+	if profileName == "" {
+		return nil
+	}
+
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	configFile, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	profile, err := configFile.Profile(profileName)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range profile.Values() {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q for %s in profile %q: %v", value, name, profileName, err)
+		}
+	}
+
+	return nil
+}
+
+// configFilePath returns the path of the config file, from --config or the default location
+func configFilePath() (string, error) {
+	if configPath := viper.GetString("CONFIG_FILE"); configPath != "" {
+		return configPath, nil
+	}
+	return config.DefaultPath()
+}
+
+// applyOutputSettings selects emoji-free output with --plain, colorless output with --no-color, hidden progress
+// with --quiet, and the status labels overridden in the labels section of the config file. A missing config
+// file is only an error when it was given with --config.
+func applyOutputSettings() error {
+	plain := viper.GetBool("PLAIN")
+	output.SetPlain(plain)
+	output.SetQuiet(viper.GetBool("QUIET"))
+
+	// NO_COLOR is the cross-tool convention for disabling colors, see https://no-color.org
+	if viper.GetBool("NO_COLOR") || os.Getenv("NO_COLOR") != "" {
+		pterm.DisableColor()
+	}
+
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	var overrides map[string]string
+	configFile, err := config.Load(configPath)
+	switch {
+	case err == nil:
+		overrides = configFile.Labels
+	case errors.Is(err, fs.ErrNotExist) && viper.GetString("CONFIG_FILE") == "":
+	default:
+		return err
+	}
+
+	if err := validator.SetStatusLabels(plain, overrides); err != nil {
+		return fmt.Errorf("invalid labels in %s: %w", configPath, err)
+	}
+	return nil
+}
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"mona-actions/gh-migration-validator/internal/validator"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -23,9 +27,16 @@ func resetViperAndEnv() {
 		"GHMV_SOURCE_REPO",
 		"GHMV_TARGET_REPO",
 		"GHMV_SOURCE_HOSTNAME",
+		"GHMV_TARGET_HOSTNAME",
 		"GHMV_MARKDOWN_TABLE",
 		"GHMV_MARKDOWN_FILE",
 		"GHMV_STRICT_EXIT",
+		"GHMV_NO_ISSUE_OFFSET",
+		"GHMV_ISSUE_OFFSET",
+		"GHMV_FOLLOW_RENAMES",
+		"GHMV_PROFILE",
+		"GHMV_CONFIG_FILE",
+		"GHMV_API_TOKEN",
 	}
 	for _, env := range envVars {
 		os.Unsetenv(env)
@@ -37,16 +48,7 @@ func setupViperWithFlags(cmd *cobra.Command) {
 	viper.SetEnvPrefix("GHMV")
 	viper.AutomaticEnv()
 
-	viper.BindPFlag("SOURCE_ORGANIZATION", cmd.Flags().Lookup("github-source-org"))
-	viper.BindPFlag("TARGET_ORGANIZATION", cmd.Flags().Lookup("github-target-org"))
-	viper.BindPFlag("SOURCE_TOKEN", cmd.Flags().Lookup("github-source-pat"))
-	viper.BindPFlag("TARGET_TOKEN", cmd.Flags().Lookup("github-target-pat"))
-	viper.BindPFlag("SOURCE_HOSTNAME", cmd.Flags().Lookup("source-hostname"))
-	viper.BindPFlag("SOURCE_REPO", cmd.Flags().Lookup("source-repo"))
-	viper.BindPFlag("TARGET_REPO", cmd.Flags().Lookup("target-repo"))
-	viper.BindPFlag("MARKDOWN_TABLE", cmd.Flags().Lookup("markdown-table"))
-	viper.BindPFlag("MARKDOWN_FILE", cmd.Flags().Lookup("markdown-file"))
-	viper.BindPFlag("STRICT_EXIT", cmd.Flags().Lookup("strict-exit"))
+	bindFlags(cmd.Flags())
 }
 
 // createTestCommand creates a fresh command with all flags for testing
@@ -58,16 +60,11 @@ func createTestCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringP("github-source-org", "s", "", "Source Organization")
-	cmd.Flags().StringP("github-target-org", "t", "", "Target Organization")
-	cmd.Flags().StringP("github-source-pat", "a", "", "Source token")
-	cmd.Flags().StringP("github-target-pat", "b", "", "Target token")
-	cmd.Flags().StringP("source-hostname", "u", "", "Source hostname")
-	cmd.Flags().StringP("source-repo", "", "", "Source repo")
-	cmd.Flags().StringP("target-repo", "", "", "Target repo")
-	cmd.Flags().BoolP("markdown-table", "m", false, "Markdown table")
-	cmd.Flags().String("markdown-file", "", "Markdown output file")
-	cmd.Flags().Bool("strict-exit", false, "Strict exit")
+	addSharedFlags(cmd.Flags(),
+		"source-org", "target-org", "source-token", "target-token", "source-hostname", "source-repo", "target-repo",
+		"markdown-table", "markdown-file", "strict-exit", "no-issue-offset", "issue-offset", "follow-renames",
+		"profile", "config",
+	)
 
 	return cmd
 }
@@ -104,10 +101,10 @@ func TestCheckVars_AllFlagsProvided(t *testing.T) {
 
 	// Set all flag values
 	cmd.SetArgs([]string{
-		"--github-source-org", "source-org",
-		"--github-target-org", "target-org",
-		"--github-source-pat", "source-token",
-		"--github-target-pat", "target-token",
+		"--source-org", "source-org",
+		"--target-org", "target-org",
+		"--source-token", "source-token",
+		"--target-token", "target-token",
 		"--source-repo", "source-repo",
 		"--target-repo", "target-repo",
 	})
@@ -134,8 +131,8 @@ func TestCheckVars_MixedFlagsAndEnvVars(t *testing.T) {
 
 	// Set remaining via flags
 	cmd.SetArgs([]string{
-		"--github-source-org", "source-org-from-flag",
-		"--github-target-org", "target-org-from-flag",
+		"--source-org", "source-org-from-flag",
+		"--target-org", "target-org-from-flag",
 	})
 
 	err := cmd.Execute()
@@ -161,7 +158,7 @@ func TestCheckVars_FlagsOverrideEnvVars(t *testing.T) {
 
 	// Override via flag
 	cmd.SetArgs([]string{
-		"--github-source-org", "org-from-flag",
+		"--source-org", "org-from-flag",
 	})
 	cmd.Execute()
 
@@ -194,7 +191,7 @@ func TestCheckVars_MissingSourceOrg(t *testing.T) {
 	if !strings.Contains(err.Error(), "SOURCE_ORGANIZATION") {
 		t.Errorf("Error should mention SOURCE_ORGANIZATION, got: %v", err)
 	}
-	if !strings.Contains(err.Error(), "--github-source-org") {
+	if !strings.Contains(err.Error(), "--source-org") {
 		t.Errorf("Error should mention the flag option, got: %v", err)
 	}
 	if !strings.Contains(err.Error(), "GHMV_SOURCE_ORGANIZATION") {
@@ -248,6 +245,31 @@ func TestCheckVars_MissingTargetToken(t *testing.T) {
 	}
 }
 
+func TestCheckVars_SameOrganizationReusesSourceToken(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	os.Setenv("GHMV_SOURCE_ORGANIZATION", "my-org")
+	os.Setenv("GHMV_TARGET_ORGANIZATION", "My-Org")
+	os.Setenv("GHMV_SOURCE_TOKEN", "token")
+	os.Setenv("GHMV_SOURCE_REPO", "repo")
+	os.Setenv("GHMV_TARGET_REPO", "repo-fork")
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	if err := checkVars(); err != nil {
+		t.Errorf("Expected no target token to be required within one organization, got: %v", err)
+	}
+
+	// Another instance needs its own token, even for an organization with the same name
+	os.Setenv("GHMV_TARGET_HOSTNAME", "github.example.com")
+	err := checkVars()
+	if err == nil || !strings.Contains(err.Error(), "TARGET_TOKEN") {
+		t.Errorf("Expected TARGET_TOKEN to be required on another instance, got: %v", err)
+	}
+}
+
 func TestCheckVars_MissingSourceRepo(t *testing.T) {
 	resetViperAndEnv()
 	defer resetViperAndEnv()
@@ -318,7 +340,7 @@ func TestViperPriority_FlagTakesPrecedence(t *testing.T) {
 	setupViperWithFlags(cmd)
 
 	// Set flag with different value
-	cmd.Flags().Set("github-source-org", "flag-value")
+	cmd.Flags().Set("source-org", "flag-value")
 
 	// Viper should return the flag value
 	actual := viper.GetString("SOURCE_ORGANIZATION")
@@ -425,3 +447,209 @@ func TestErrorMessageFormat(t *testing.T) {
 		t.Errorf("Error should mention environment variable option: %s", errMsg)
 	}
 }
+
+func TestGetValidationOptions_AutoDetectByDefault(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	options, err := getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.IssueOffset != nil {
+		t.Errorf("Expected issue offset to be auto-detected (nil), got %d", *options.IssueOffset)
+	}
+}
+
+func TestGetValidationOptions_NoIssueOffset(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+	cmd.Flags().Set("no-issue-offset", "true")
+
+	options, err := getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.IssueOffset == nil || *options.IssueOffset != 0 {
+		t.Errorf("Expected issue offset 0 with --no-issue-offset, got %v", options.IssueOffset)
+	}
+}
+
+func TestGetValidationOptions_IssueOffsetFromFlagAndEnv(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	os.Setenv("GHMV_ISSUE_OFFSET", "3")
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	options, err := getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.IssueOffset == nil || *options.IssueOffset != 3 {
+		t.Errorf("Expected issue offset 3 from env, got %v", options.IssueOffset)
+	}
+
+	cmd.Flags().Set("issue-offset", "5")
+
+	options, err = getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.IssueOffset == nil || *options.IssueOffset != 5 {
+		t.Errorf("Expected issue offset flag to override env, got %v", options.IssueOffset)
+	}
+}
+
+func TestGetValidationOptions_Ignore(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	t.Setenv("GHMV_IGNORE", "Releases, Webhooks,")
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	options, err := getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []validator.IgnoreRule{
+		{Metric: "Releases", Reason: "ignored with --ignore"},
+		{Metric: "Webhooks", Reason: "ignored with --ignore"},
+	}
+	if !reflect.DeepEqual(options.IgnoreRules, expected) {
+		t.Errorf("Expected ignore rules %v, got %v", expected, options.IgnoreRules)
+	}
+}
+
+func TestGetValidationOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+	}{
+		{name: "mutually exclusive flags", flags: map[string]string{"no-issue-offset": "true", "issue-offset": "2"}},
+		{name: "negative offset", flags: map[string]string{"issue-offset": "-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetViperAndEnv()
+			defer resetViperAndEnv()
+
+			cmd := createTestCommand()
+			setupViperWithFlags(cmd)
+			for flag, value := range tt.flags {
+				cmd.Flags().Set(flag, value)
+			}
+
+			if _, err := getValidationOptions(); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestGetValidationOptions_FollowRenames(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	options, err := getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.FollowRenames {
+		t.Error("Expected renames not to be followed by default")
+	}
+
+	cmd.Flags().Set("follow-renames", "true")
+
+	options, err = getValidationOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.FollowRenames {
+		t.Error("Expected --follow-renames to enable following renames")
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `profiles:
+  prod-ghes:
+    source-org: profile-source-org
+    target-org: profile-target-org
+    source-token: profile-source-token
+    issue-offset: 2
+    unknown-flag: ignored
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+	if err := cmd.ParseFlags([]string{"--profile", "prod-ghes", "--config", configPath, "--target-org", "flag-target-org"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if err := applyProfile(cmd); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := viper.GetString("SOURCE_ORGANIZATION"); got != "profile-source-org" {
+		t.Errorf("Expected source organization from profile, got %q", got)
+	}
+	if got := viper.GetString("SOURCE_TOKEN"); got != "profile-source-token" {
+		t.Errorf("Expected source token from profile, got %q", got)
+	}
+	if got := viper.GetString("TARGET_ORGANIZATION"); got != "flag-target-org" {
+		t.Errorf("Expected command line flag to win over profile, got %q", got)
+	}
+	if got := viper.GetInt("ISSUE_OFFSET"); got != 2 {
+		t.Errorf("Expected issue offset 2 from profile, got %d", got)
+	}
+}
+
+func TestApplyProfile_Errors(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("profiles:\n  cloud:\n    issue-offset: many\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	// No profile selected: nothing is loaded
+	if err := applyProfile(cmd); err != nil {
+		t.Errorf("Expected no error without a profile, got: %v", err)
+	}
+
+	os.Setenv("GHMV_CONFIG_FILE", configPath)
+	os.Setenv("GHMV_PROFILE", "missing")
+	if err := applyProfile(cmd); err == nil || !strings.Contains(err.Error(), `profile "missing" not found`) {
+		t.Errorf("Expected missing profile error, got: %v", err)
+	}
+
+	os.Setenv("GHMV_PROFILE", "cloud")
+	if err := applyProfile(cmd); err == nil || !strings.Contains(err.Error(), `invalid value "many" for issue-offset`) {
+		t.Errorf("Expected invalid value error, got: %v", err)
+	}
+}
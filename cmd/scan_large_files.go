@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/largefiles"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// scanLargeFilesCmd represents the scan-large-files command
+var scanLargeFilesCmd = &cobra.Command{
+	Use:   "scan-large-files",
+	Short: "List source repository files over GitHub's file size limits",
+	Long: `List the files of the source repository over GitHub's file size limits before
+migrating it. GitHub rejects files over 100 MB that are not tracked by Git LFS, which
+makes the migration to the target fail, and warns about files over 50 MB.
+
+By default the files of the default branch are listed through the API. With --git-dir,
+the full history of a local repository is scanned instead with the git command line
+client, such as a bare clone of the source repository or the repository of an extracted
+migration archive. Files over the limit anywhere in the history block the migration.
+
+Exits with status 1 when files over 100 MB are found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitDir := cmd.Flag("git-dir").Value.String()
+		sourceOrganization := viper.GetString("SOURCE_ORGANIZATION")
+		sourceRepo := viper.GetString("SOURCE_REPO")
+
+		var report *largefiles.Report
+		if gitDir != "" {
+			fmt.Printf("Scanning history of %s...\n", gitDir)
+			var err error
+			report, err = largefiles.FromGitDir(gitDir)
+			if err != nil {
+				exitWithError("Failed to scan repository", err)
+			}
+		} else {
+			if err := checkScanLargeFilesVars(sourceOrganization, sourceRepo); err != nil {
+				fmt.Printf("Configuration validation failed: %v\n", err)
+				os.Exit(1)
+			}
+
+			ghAPI, err := newSourceOnlyAPI()
+			if err != nil {
+				exitWithError("Failed to initialize source API", err)
+			}
+
+			report, err = largefiles.FromAPI(ghAPI, api.SourceClient, sourceOrganization, sourceRepo)
+			if err != nil {
+				exitWithError("Failed to scan repository", err)
+			}
+		}
+
+		report.Print()
+		if report.OverLimit() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanLargeFilesCmd)
+
+	scanLargeFilesCmd.Flags().String("git-dir", "", "Scan the full history of a local repository, e.g. a bare clone or the repository of an extracted migration archive, instead of the default branch through the API (optional)")
+
+	addSharedFlags(scanLargeFilesCmd.Flags(), "source-org", "source-repo", "source-token", "source-hostname", "source-api-url")
+}
+
+// checkScanLargeFilesVars validates the configuration for scanning the source repository through the API
+func checkScanLargeFilesVars(sourceOrganization, sourceRepo string) error {
+	if sourceOrganization == "" || sourceRepo == "" {
+		return fmt.Errorf("source organization and repository are required. Set them via --source-org and --source-repo flags, or scan a local repository with --git-dir")
+	}
+	if viper.GetString("SOURCE_TOKEN") == "" {
+		return fmt.Errorf("source token is required. Set it via --source-token flag or GHMV_SOURCE_TOKEN environment variable")
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/server"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run validations on demand through an HTTP API",
+	Long: `Start an HTTP server that runs migration validations asynchronously.
+
+Endpoints:
+- POST /validate    Queue a validation. The JSON body must contain source_organization,
+                    source_repo, target_organization and target_repo. Responds with 202
+                    and the job, including its ID.
+- GET /status/{id}  Return the job status (queued, running, completed, failed) and,
+                    once completed, the validation results as JSON.
+- GET /metrics      Prometheus metrics: validations run, passed, failed and errored, and the
+                    API requests, GraphQL cost and remaining rate limit of each client.
+- POST /webhook     Receive GitHub webhook deliveries from the target organization
+                    (enabled with --webhook-secret and --source-org). A validation
+                    is queued when a repository import completes and the report is posted
+                    as a comment on the repository's migration log issue.
+
+Every endpoint but POST /webhook requires the --api-token in an
+"Authorization: Bearer" header. Validations run one at a time using the source and
+target credentials the server was started with.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Get parameters from flags
+		listenAddress := cmd.Flag("listen").Value.String()
+		sourceToken := cmd.Flag("source-token").Value.String()
+		targetToken := cmd.Flag("target-token").Value.String()
+		sourceHostname := cmd.Flag("source-hostname").Value.String()
+		targetHostname := cmd.Flag("target-hostname").Value.String()
+
+		// Only set ENV variables if flag values are provided (not empty)
+		if sourceToken != "" {
+			os.Setenv("GHMV_SOURCE_TOKEN", sourceToken)
+		}
+		if targetToken != "" {
+			os.Setenv("GHMV_TARGET_TOKEN", targetToken)
+		}
+		if sourceHostname != "" {
+			os.Setenv("GHMV_SOURCE_HOSTNAME", sourceHostname)
+		}
+		if targetHostname != "" {
+			os.Setenv("GHMV_TARGET_HOSTNAME", targetHostname)
+		}
+		sourceOrganization := cmd.Flag("source-org").Value.String()
+		webhookSecret := cmd.Flag("webhook-secret").Value.String()
+		if sourceOrganization != "" {
+			os.Setenv("GHMV_SOURCE_ORGANIZATION", sourceOrganization)
+		}
+		if webhookSecret != "" {
+			os.Setenv("GHMV_WEBHOOK_SECRET", webhookSecret)
+		}
+		followRenames, _ := cmd.Flags().GetBool("follow-renames")
+		if followRenames {
+			os.Setenv("GHMV_FOLLOW_RENAMES", "true")
+		}
+		apiToken := cmd.Flag("api-token").Value.String()
+		if apiToken != "" {
+			os.Setenv("GHMV_API_TOKEN", apiToken)
+		}
+
+		// Bind ENV variables in Viper
+		viper.BindEnv("SOURCE_TOKEN")
+		viper.BindEnv("TARGET_TOKEN")
+		viper.BindEnv("SOURCE_HOSTNAME")
+		viper.BindEnv("TARGET_HOSTNAME")
+		viper.BindEnv("SOURCE_ORGANIZATION")
+		viper.BindEnv("WEBHOOK_SECRET")
+		viper.BindEnv("FOLLOW_RENAMES")
+		viper.BindEnv("NO_ISSUE_OFFSET")
+		viper.BindEnv("ISSUE_OFFSET")
+		viper.BindEnv("API_TOKEN")
+
+		if err := checkServeVars(); err != nil {
+			fmt.Printf("Serve configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Initialize API with both source and target clients, shared by all validations
+		ghAPI, err := newGitHubAPI()
+		if err != nil {
+			fmt.Printf("Failed to initialize API clients: %v\n", err)
+			os.Exit(1)
+		}
+
+		validationOptions, err := getValidationOptions()
+		if err != nil {
+			fmt.Printf("Serve configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		srv := server.New(func(req server.ValidationRequest) ([]validator.ValidationResult, error) {
+			// Jobs run one at a time, so the retry statistics in each summary cover that job only
+			api.ResetRetryStatistics()
+			migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+			results, err := migrationValidator.ValidateMigration(req.SourceOrganization, req.SourceRepo, req.TargetOrganization, req.TargetRepo)
+			if err != nil {
+				return nil, err
+			}
+
+			recordValidationHistory(migrationValidator, results)
+			recordAuditLog(ghAPI, api.SourceClient, "serve", migrationValidator, results)
+			publishValidationReport(ghAPI, migrationValidator, results)
+			if req.Trigger == server.TriggerWebhook {
+				if err := publishIssueComment(ghAPI, migrationValidator, results); err != nil {
+					fmt.Printf("Failed to post validation report: %v\n", err)
+				}
+			}
+			return results, nil
+		})
+
+		srv.RequireToken(viper.GetString("API_TOKEN"))
+		srv.AddMetrics(func() []server.Metric {
+			return apiMetrics(ghAPI)
+		})
+
+		if viper.GetString("WEBHOOK_SECRET") != "" {
+			srv.EnableWebhooks(server.WebhookConfig{
+				Secret:             []byte(viper.GetString("WEBHOOK_SECRET")),
+				SourceOrganization: viper.GetString("SOURCE_ORGANIZATION"),
+			})
+			fmt.Println("Webhook endpoint enabled at POST /webhook")
+		}
+		srv.Start()
+
+		if err := runHTTPServer(listenAddress, srv.Handler()); err != nil {
+			fmt.Printf("Server failed: %v\n", err)
+			os.Exit(1)
+		}
+		srv.Stop()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("listen", "127.0.0.1:8080", "Address to listen on; use e.g. :8080 to accept requests from other machines")
+	addSharedFlags(serveCmd.Flags(), "source-token", "target-token", "source-hostname", "target-hostname", "source-api-url", "target-api-url", "source-org", "follow-renames")
+	serveCmd.Flags().String("webhook-secret", "", "Secret used to verify webhook deliveries; enables POST /webhook (optional)")
+	serveCmd.Flags().String("api-token", "", "Bearer token clients must send to every endpoint but POST /webhook")
+}
+
+// checkServeVars validates the configuration for the serve command
+func checkServeVars() error {
+	if viper.GetString("SOURCE_TOKEN") == "" && viper.GetString("SOURCE_APP_ID") == "" {
+		return fmt.Errorf("source token is required. Set it via --source-token flag or GHMV_SOURCE_TOKEN environment variable")
+	}
+
+	if viper.GetString("TARGET_TOKEN") == "" && viper.GetString("TARGET_APP_ID") == "" {
+		return fmt.Errorf("target token is required. Set it via --target-token flag or GHMV_TARGET_TOKEN environment variable")
+	}
+
+	if viper.GetString("API_TOKEN") == "" {
+		return fmt.Errorf("API token is required. Set it via --api-token flag or GHMV_API_TOKEN environment variable")
+	}
+
+	if viper.GetString("WEBHOOK_SECRET") != "" && viper.GetString("SOURCE_ORGANIZATION") == "" {
+		return fmt.Errorf("source organization is required for webhooks. Set it via --source-org flag or GHMV_SOURCE_ORGANIZATION environment variable")
+	}
+
+	return nil
+}
+
+// apiMetrics returns the API requests, GraphQL cost and remaining rate limit of the source and target clients
+func apiMetrics(ghAPI *api.GitHubAPI) []server.Metric {
+	requests := server.Metric{Name: "ghmv_api_requests_total", Help: "HTTP requests made to the GitHub API", Type: server.MetricCounter}
+	cost := server.Metric{Name: "ghmv_graphql_cost_total", Help: "Rate limit cost of the GraphQL queries made", Type: server.MetricCounter}
+	rateLimit := server.Metric{Name: "ghmv_rate_limit_remaining", Help: "Requests remaining in the rate limit window", Type: server.MetricGauge}
+
+	for _, client := range []struct {
+		label string
+		typ   api.ClientType
+	}{{"source", api.SourceClient}, {"target", api.TargetClient}} {
+		labels := map[string]string{"client": client.label}
+		requests.Samples = append(requests.Samples, server.Sample{Labels: labels, Value: float64(ghAPI.RequestCount(client.typ))})
+		cost.Samples = append(cost.Samples, server.Sample{Labels: labels, Value: float64(ghAPI.GraphQLCost(client.typ))})
+		if remaining, ok := ghAPI.RateLimitRemaining(client.typ); ok {
+			rateLimit.Samples = append(rateLimit.Samples, server.Sample{Labels: labels, Value: float64(remaining)})
+		}
+	}
+	return []server.Metric{requests, cost, rateLimit}
+}
+
+// runHTTPServer serves handler on address until the process receives SIGINT or SIGTERM
+func runHTTPServer(address string, handler http.Handler) error {
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		fmt.Printf("Listening on %s\n", address)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
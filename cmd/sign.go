@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+
+	"mona-actions/gh-migration-validator/internal/signing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyReportCmd represents the verify-report command
+var verifyReportCmd = &cobra.Command{
+	Use:   "verify-report <report>",
+	Short: "Check a report against the signature written with --sign-report",
+	Long: `Check that a markdown or export report matches the detached signature written next to it
+with --sign-report, so a report edited after sign-off is detected.
+
+Pass the public key (or the private key) of the signer with --key. The signature is read
+from <report>.sig unless --signature is set. Signatures can also be checked with openssl:
+
+  openssl dgst -sha256 -verify public.pem -signature report.md.sig report.md`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		keyFile := viper.GetString("SIGNING_KEY")
+		if keyFile == "" {
+			fmt.Println("Report verification failed: the public key is required. Set it via --key flag or GHMV_SIGNING_KEY environment variable")
+			os.Exit(1)
+		}
+		publicKey, err := signing.LoadPublicKey(keyFile)
+		if err != nil {
+			exitWithError("Report verification failed", err)
+		}
+
+		signatureFile, _ := cmd.Flags().GetString("signature")
+		if signatureFile == "" {
+			signatureFile = args[0] + signing.SignatureExtension
+		}
+
+		if err := signing.VerifyFile(args[0], signatureFile, publicKey); err != nil {
+			fmt.Printf("Report verification failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Report %s matches its signature %s\n", args[0], signatureFile)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyReportCmd)
+	verifyReportCmd.Flags().String("signature", "", "Signature file (default: <report>.sig)")
+}
+
+// loadReportSigner returns the --key signer when --sign-report is set, or nil when reports are not signed
+func loadReportSigner() (crypto.Signer, error) {
+	if !viper.GetBool("SIGN_REPORT") {
+		return nil, nil
+	}
+
+	keyFile := viper.GetString("SIGNING_KEY")
+	if keyFile == "" {
+		return nil, fmt.Errorf("--sign-report needs a private key. Set it via --key flag or GHMV_SIGNING_KEY environment variable")
+	}
+	return signing.LoadPrivateKey(keyFile)
+}
+
+// loadMarkdownReportSigner returns the report signer of a validation, which signs the --markdown-file report,
+// and exits when signing is requested without a usable key or report file
+func loadMarkdownReportSigner() crypto.Signer {
+	signer, err := loadReportSigner()
+	if err == nil && signer != nil && viper.GetString("MARKDOWN_FILE") == "" {
+		err = fmt.Errorf("--sign-report signs the markdown report. Set it via --markdown-file flag or GHMV_MARKDOWN_FILE environment variable")
+	}
+	if err != nil {
+		fmt.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+	return signer
+}
+
+// signReport writes the detached signature of the report at path when signer is set
+func signReport(signer crypto.Signer, path string) {
+	if signer == nil || path == "" {
+		return
+	}
+
+	signatureFile, err := signing.SignFile(path, signer)
+	if err != nil {
+		exitWithError("Failed to sign report", err)
+	}
+	fmt.Printf("Report signature written to %s\n", signatureFile)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/telemetry"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// telemetryShutdownTimeout bounds how long exiting waits for pending spans and metrics to be exported
+const telemetryShutdownTimeout = 5 * time.Second
+
+// shutdownTelemetryProviders flushes the OpenTelemetry exporters, when --otel-endpoint is set
+var shutdownTelemetryProviders func(context.Context) error
+
+// startTelemetry starts exporting spans and metrics to the OTLP endpoint from --otel-endpoint, if any
+func startTelemetry() error {
+	endpoint := viper.GetString("OTEL_ENDPOINT")
+	if endpoint == "" || shutdownTelemetryProviders != nil {
+		return nil
+	}
+
+	shutdown, err := telemetry.Setup(context.Background(), endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to set up OpenTelemetry export: %v", err)
+	}
+	shutdownTelemetryProviders = shutdown
+	return nil
+}
+
+// shutdownTelemetry exports pending spans and metrics. It must run before the process exits.
+func shutdownTelemetry() {
+	if shutdownTelemetryProviders == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+	defer cancel()
+
+	if err := shutdownTelemetryProviders(ctx); err != nil {
+		fmt.Printf("Failed to export telemetry: %v\n", err)
+	}
+	shutdownTelemetryProviders = nil
+}
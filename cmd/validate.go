@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [SOURCE-OWNER/REPO TARGET-OWNER/REPO]",
+	Short: "Validate a repository migration",
+	Long: `Validate that a repository was migrated completely by comparing the source and target repositories.
+
+The repositories can be given as OWNER/REPO arguments:
+
+  gh migration-validator validate source-org/my-repo target-org/my-repo
+
+or with the same flags and environment variables as the root command
+(--source-org, --source-repo, --target-org, --target-repo).`,
+	Args: parseRepositoryArgs,
+	Run:  runValidation,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	// Share the root command's flags so both commands accept the same options and Viper bindings
+	validateCmd.Flags().AddFlagSet(rootCmd.Flags())
+}
+
+// repositoryFlags are the flags that positional OWNER/REPO arguments replace, in argument order
+var repositoryFlags = [][2]string{
+	{"source-org", "source-repo"},
+	{"target-org", "target-repo"},
+}
+
+// parseRepositoryArgs accepts either no arguments or a source and target OWNER/REPO, setting the
+// corresponding flags. It runs before the profile is applied so arguments take precedence over it.
+func parseRepositoryArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("expected SOURCE-OWNER/REPO and TARGET-OWNER/REPO arguments, got %d argument(s)", len(args))
+	}
+
+	for _, flags := range repositoryFlags {
+		for _, name := range flags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s cannot be combined with OWNER/REPO arguments", name)
+			}
+		}
+	}
+
+	for i, arg := range args {
+		owner, repo, err := parseRepository(arg)
+		if err != nil {
+			return err
+		}
+
+		if err := cmd.Flags().Set(repositoryFlags[i][0], owner); err != nil {
+			return err
+		}
+		if err := cmd.Flags().Set(repositoryFlags[i][1], repo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseRepository splits an OWNER/REPO string into its owner and repository name
+func parseRepository(value string) (string, string, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		hint := ""
+		if strings.Contains(value, "://") || len(parts) > 2 {
+			hint = " (hostnames are set with --source-hostname and --target-hostname)"
+		}
+		return "", "", fmt.Errorf("invalid repository %q: expected OWNER/REPO%s", value, hint)
+	}
+
+	return parts[0], parts[1], nil
+}
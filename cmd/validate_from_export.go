@@ -10,6 +10,7 @@ import (
 	"mona-actions/gh-migration-validator/internal/validator"
 	"os"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -39,8 +40,8 @@ The validation compares the same metrics as the standard validate command:
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get parameters from flags
 		exportFile := cmd.Flag("export-file").Value.String()
-		targetOrganization := cmd.Flag("github-target-org").Value.String()
-		targetToken := cmd.Flag("github-target-pat").Value.String()
+		targetOrganization := cmd.Flag("target-org").Value.String()
+		targetToken := cmd.Flag("target-token").Value.String()
 		targetHostname := cmd.Flag("target-hostname").Value.String()
 		targetRepo := cmd.Flag("target-repo").Value.String()
 		markdownTable, err := cmd.Flags().GetBool("markdown-table")
@@ -71,6 +72,17 @@ The validation compares the same metrics as the standard validate command:
 		if noLFS {
 			os.Setenv("GHMV_NO_LFS", "true")
 		}
+		noIssueOffset, _ := cmd.Flags().GetBool("no-issue-offset")
+		if noIssueOffset {
+			os.Setenv("GHMV_NO_ISSUE_OFFSET", "true")
+		}
+		if cmd.Flags().Changed("issue-offset") {
+			os.Setenv("GHMV_ISSUE_OFFSET", cmd.Flag("issue-offset").Value.String())
+		}
+		followRenames, _ := cmd.Flags().GetBool("follow-renames")
+		if followRenames {
+			os.Setenv("GHMV_FOLLOW_RENAMES", "true")
+		}
 
 		// Bind ENV variables in Viper (for optional parameters that can use env vars)
 		viper.BindEnv("TARGET_TOKEN")
@@ -81,9 +93,14 @@ The validation compares the same metrics as the standard validate command:
 		viper.BindEnv("MARKDOWN_TABLE")
 		viper.BindEnv("MARKDOWN_FILE")
 		viper.BindEnv("NO_LFS")
+		viper.BindEnv("NO_ISSUE_OFFSET")
+		viper.BindEnv("ISSUE_OFFSET")
+		viper.BindEnv("FOLLOW_RENAMES")
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		// Validate required parameters (using flag values directly for required flags)
-		if err := checkExportValidationVars(exportFile); err != nil {
+		if err := checkExportValidationVars(exportFile, dryRun); err != nil {
 			fmt.Printf("Export validation configuration failed: %v\n", err)
 			os.Exit(1)
 		}
@@ -95,15 +112,32 @@ The validation compares the same metrics as the standard validate command:
 			os.Exit(1)
 		}
 
+		if dryRun {
+			runExportDryRun(exportData, targetOrganization, targetRepo)
+			return
+		}
+
 		// Initialize API with target-only clients
-		ghAPI, err := api.NewTargetOnlyAPI()
+		ghAPI, err := newTargetOnlyAPI()
+		if err != nil {
+			exitWithError("Failed to initialize target API", err)
+		}
+
+		validationOptions, err := getValidationOptions()
 		if err != nil {
-			fmt.Printf("Failed to initialize target API: %v\n", err)
+			fmt.Printf("Export validation configuration failed: %v\n", err)
 			os.Exit(1)
 		}
+		for _, warning := range exportData.CompatibilityWarnings(validationOptions) {
+			pterm.Warning.Println(warning)
+		}
+		signer := loadMarkdownReportSigner()
+		if _, err := acquireRunLock(targetOrganization + "/" + targetRepo); err != nil {
+			exitWithError("Validation failed", err)
+		}
 
 		// Create validator and perform validation
-		migrationValidator := validator.New(ghAPI)
+		migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
 
 		// Set source data from export instead of fetching from API
 		// Copy migration archive data to repository data if it exists
@@ -114,16 +148,18 @@ The validation compares the same metrics as the standard validate command:
 		// Perform validation against target (now returns results directly)
 		results, err := migrationValidator.ValidateFromExport(targetOrganization, targetRepo)
 		if err != nil {
-			fmt.Printf("Validation failed: %v\n", err)
-			os.Exit(1)
+			exitWithError("Validation failed", err)
 		}
 
 		// Display results using existing method
-		migrationValidator.PrintValidationResults(results)
-
-		if viper.GetBool("STRICT_EXIT") && validator.HasFailures(results) {
-			os.Exit(2)
-		}
+		summary := migrationValidator.PrintValidationResults(results)
+		signReport(signer, viper.GetString("MARKDOWN_FILE"))
+		recordValidationHistory(migrationValidator, results)
+		recordAuditLog(ghAPI, api.TargetClient, "validate-from-export", migrationValidator, results)
+		publishValidationReport(ghAPI, migrationValidator, results)
+
+		exitOnUnavailableTarget(migrationValidator)
+		exitOnStrictFailure(migrationValidator, summary)
 	},
 }
 
@@ -135,23 +171,18 @@ func init() {
 	validateFromExportCmd.Flags().StringP("export-file", "e", "", "Path to the exported JSON file to use as source data")
 	validateFromExportCmd.MarkFlagRequired("export-file")
 
-	validateFromExportCmd.Flags().StringP("github-target-org", "t", "", "Target Organization to validate against")
-	validateFromExportCmd.MarkFlagRequired("github-target-org")
-
-	validateFromExportCmd.Flags().StringP("github-target-pat", "b", "", "Target Organization GitHub token. Scopes: read:org, read:user, user:email")
-
-	validateFromExportCmd.Flags().StringP("target-hostname", "v", "", "GitHub Enterprise target hostname url (optional) Ex. https://github.example.com")
-
-	validateFromExportCmd.Flags().String("target-repo", "", "Target repository name to validate (just the repo name, not owner/repo)")
+	addSharedFlags(validateFromExportCmd.Flags(),
+		"target-org", "target-token", "target-hostname", "target-api-url", "target-repo",
+		"markdown-table", "markdown-file", "no-lfs", "no-issue-offset", "issue-offset", "follow-renames", "dry-run", "check-security", "branches", "count-prs-as-issues",
+		"target-lfs-url", "target-lfs-username", "target-lfs-token", "check-lfs-locks", "transfer-mode", "rewritten-history",
+	)
+	validateFromExportCmd.MarkFlagRequired("target-org")
 	validateFromExportCmd.MarkFlagRequired("target-repo")
-
-	validateFromExportCmd.Flags().BoolP("markdown-table", "m", false, "Output results in markdown table format")
-	validateFromExportCmd.Flags().String("markdown-file", "", "Write markdown output to the specified file (optional)")
-	validateFromExportCmd.Flags().Bool("no-lfs", false, "Skip LFS object validation")
 }
 
-// checkExportValidationVars validates the configuration for validate-from-export command
-func checkExportValidationVars(exportFile string) error {
+// checkExportValidationVars validates the configuration for validate-from-export command.
+// The target token is not required for a dry run.
+func checkExportValidationVars(exportFile string, dryRun bool) error {
 	// Check export file is provided
 	if exportFile == "" {
 		return fmt.Errorf("export file is required. Set it via --export-file flag")
@@ -164,9 +195,39 @@ func checkExportValidationVars(exportFile string) error {
 
 	// Check for target token (can come from flag or environment variable)
 	targetToken := viper.GetString("TARGET_TOKEN")
-	if targetToken == "" {
-		return fmt.Errorf("target token is required. Set it via --github-target-pat flag or GHMV_TARGET_TOKEN environment variable")
+	if targetToken == "" && !dryRun {
+		return fmt.Errorf("target token is required. Set it via --target-token flag or GHMV_TARGET_TOKEN environment variable")
 	}
 
 	return nil
 }
+
+// runExportDryRun prints the validation plan for validate-from-export without querying the target repository
+func runExportDryRun(exportData *export.ExportData, targetOrganization, targetRepo string) {
+	validationOptions, err := getValidationOptions()
+	if err != nil {
+		fmt.Printf("Export validation configuration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	migrationValidator := validator.NewWithOptions(nil, validationOptions)
+	repositoryData := exportData.Repository
+	repositoryData.MigrationArchive = exportData.MigrationArchive
+	migrationValidator.SetSourceDataFromExport(&repositoryData)
+
+	plan := migrationValidator.PlanValidation(repositoryData.Owner, repositoryData.Name, targetOrganization, targetRepo, true)
+	plan.Print()
+
+	// The rate limit check is the only authenticated call, and only made when a target token is configured
+	if viper.GetString("TARGET_TOKEN") == "" {
+		fmt.Println("\nSkipping rate limit check: target token is not configured")
+		return
+	}
+
+	ghAPI, err := newTargetOnlyAPI()
+	if err != nil {
+		fmt.Printf("\nSkipping rate limit check: %v\n", err)
+		return
+	}
+	printRateLimitEstimate(ghAPI, plan, api.TargetClient, "target")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/svn"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// validateSVNCmd represents the validate-svn command
+var validateSVNCmd = &cobra.Command{
+	Use:   "validate-svn",
+	Short: "Validate a repository converted from Subversion with git-svn",
+	Long: `Validate a target repository converted from Subversion with git-svn against the
+Subversion repository it was converted from.
+
+The Subversion repository is read with the svn command line client, which must be
+installed, from its URL or from the path of a local svnsync mirror. The comparison covers:
+- Revisions of the trunk against the target commits count
+- Directories under tags against the target tags count
+
+git-svn does not map revisions and tags one to one, so both comparisons are advisory:
+differences are reported as warnings and never fail the validation.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svnURL := cmd.Flag("svn-url").Value.String()
+		layout := svn.Layout{
+			Trunk: cmd.Flag("svn-trunk").Value.String(),
+			Tags:  cmd.Flag("svn-tags").Value.String(),
+		}
+		targetOrganization := viper.GetString("TARGET_ORGANIZATION")
+		targetRepo := viper.GetString("TARGET_REPO")
+
+		if err := checkSVNValidationVars(svnURL, targetOrganization, targetRepo); err != nil {
+			fmt.Printf("SVN validation configuration failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Reading Subversion repository %s...\n", svnURL)
+		metrics, err := svn.FetchMetrics(svnURL, layout)
+		if err != nil {
+			exitWithError("Failed to read Subversion repository", err)
+		}
+
+		ghAPI, err := newTargetOnlyAPI()
+		if err != nil {
+			exitWithError("Failed to initialize target API", err)
+		}
+
+		validationOptions, err := getValidationOptions()
+		if err != nil {
+			fmt.Printf("Configuration validation failed: %v\n", err)
+			os.Exit(1)
+		}
+		signer := loadMarkdownReportSigner()
+		migrationValidator := validator.NewWithOptions(ghAPI, validationOptions)
+		results, err := migrationValidator.ValidateFromSVN(metrics, targetOrganization, targetRepo)
+		if err != nil {
+			exitWithError("Validation failed", err)
+		}
+
+		summary := migrationValidator.PrintValidationResults(results)
+		signReport(signer, viper.GetString("MARKDOWN_FILE"))
+		exitOnStrictFailure(migrationValidator, summary)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateSVNCmd)
+
+	validateSVNCmd.Flags().String("svn-url", "", "URL of the Subversion repository, or path of a local svnsync mirror")
+	validateSVNCmd.Flags().String("svn-trunk", svn.StandardLayout.Trunk, "Path of the trunk directory within the Subversion repository")
+	validateSVNCmd.Flags().String("svn-tags", svn.StandardLayout.Tags, "Path of the tags directory within the Subversion repository")
+	validateSVNCmd.MarkFlagRequired("svn-url")
+
+	addSharedFlags(validateSVNCmd.Flags(),
+		"target-org", "target-token", "target-hostname", "target-api-url", "target-repo",
+		"markdown-table", "markdown-file",
+	)
+	validateSVNCmd.MarkFlagRequired("target-org")
+	validateSVNCmd.MarkFlagRequired("target-repo")
+}
+
+// checkSVNValidationVars validates the configuration for the validate-svn command
+func checkSVNValidationVars(svnURL, targetOrganization, targetRepo string) error {
+	if svnURL == "" {
+		return fmt.Errorf("SVN repository is required. Set it via --svn-url flag")
+	}
+	if targetOrganization == "" || targetRepo == "" {
+		return fmt.Errorf("target organization and repository are required. Set them via --target-org and --target-repo flags")
+	}
+	if viper.GetString("TARGET_TOKEN") == "" {
+		return fmt.Errorf("target token is required. Set it via --target-token flag or GHMV_TARGET_TOKEN environment variable")
+	}
+	return nil
+}
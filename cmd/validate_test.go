@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		value         string
+		expectedOwner string
+		expectedRepo  string
+		expectedError string
+	}{
+		{value: "source-org/my-repo", expectedOwner: "source-org", expectedRepo: "my-repo"},
+		{value: "my-repo", expectedError: `invalid repository "my-repo": expected OWNER/REPO`},
+		{value: "source-org/", expectedError: "expected OWNER/REPO"},
+		{value: "/my-repo", expectedError: "expected OWNER/REPO"},
+		{value: "github.example.com/source-org/my-repo", expectedError: "hostnames are set with --source-hostname"},
+		{value: "https://github.com/source-org/my-repo", expectedError: "hostnames are set with --source-hostname"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			owner, repo, err := parseRepository(tt.value)
+
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got: %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if owner != tt.expectedOwner || repo != tt.expectedRepo {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.expectedOwner, tt.expectedRepo, owner, repo)
+			}
+		})
+	}
+}
+
+func TestValidateCmd_TargetHostname(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	for _, cmd := range []*cobra.Command{rootCmd, validateCmd} {
+		t.Run(cmd.Name(), func(t *testing.T) {
+			flag := cmd.Flags().Lookup("target-hostname")
+			if flag == nil {
+				t.Fatal("Expected --target-hostname to be registered")
+			}
+			defer func() {
+				flag.Value.Set("")
+				flag.Changed = false
+			}()
+
+			if err := cmd.ParseFlags([]string{"--target-hostname", "octocorp.ghe.com"}); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			bindFlags(cmd.Flags())
+
+			if hostname := viper.GetString("TARGET_HOSTNAME"); hostname != "octocorp.ghe.com" {
+				t.Errorf("Expected TARGET_HOSTNAME octocorp.ghe.com, got %q", hostname)
+			}
+		})
+	}
+}
+
+func TestParseRepositoryArgs(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	setupViperWithFlags(cmd)
+
+	if err := parseRepositoryArgs(cmd, []string{"source-org/source-repo", "target-org/target-repo"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"SOURCE_ORGANIZATION": "source-org",
+		"SOURCE_REPO":         "source-repo",
+		"TARGET_ORGANIZATION": "target-org",
+		"TARGET_REPO":         "target-repo",
+	}
+	for key, value := range expected {
+		if got := viper.GetString(key); got != value {
+			t.Errorf("Expected %s to be %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestParseRepositoryArgs_Errors(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+
+	cmd := createTestCommand()
+	if err := parseRepositoryArgs(cmd, nil); err != nil {
+		t.Errorf("Expected flags-only usage to be accepted, got: %v", err)
+	}
+
+	if err := parseRepositoryArgs(cmd, []string{"source-org/repo"}); err == nil || !strings.Contains(err.Error(), "got 1 argument(s)") {
+		t.Errorf("Expected argument count error, got: %v", err)
+	}
+
+	cmd.Flags().Set("target-repo", "other-repo")
+	if err := parseRepositoryArgs(cmd, []string{"source-org/repo", "target-org/repo"}); err == nil || !strings.Contains(err.Error(), "--target-repo cannot be combined") {
+		t.Errorf("Expected conflict error, got: %v", err)
+	}
+}
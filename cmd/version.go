@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/config"
+	"mona-actions/gh-migration-validator/internal/update"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// version is the released version, set at build time with
+// -ldflags "-X mona-actions/gh-migration-validator/cmd.version=v1.2.3"
+var version = ""
+
+// extensionManifest is the manifest gh writes next to the binary of an installed precompiled extension
+type extensionManifest struct {
+	Tag string `yaml:"tag"`
+}
+
+// updateCheckTimeout bounds the automatic check made after a command, so it never delays the tool noticeably
+const updateCheckTimeout = 2 * time.Second
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version and check for a newer release",
+	Long: `Print the version of the extension. With --check, also check whether a newer
+release has been published and how to upgrade.
+
+Other commands check for a newer release at most once a day and print a notice when
+one exists. Disable this with --no-update-check (or GHMV_NO_UPDATE_CHECK=true).`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("gh-migration-validator %s\n", currentVersion())
+
+		check, _ := cmd.Flags().GetBool("check")
+		if !check {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		release, err := update.LatestRelease(ctx, http.DefaultClient, update.DefaultAPIURL)
+		if err != nil {
+			exitWithError("Version check failed", err)
+		}
+
+		if update.Newer(currentVersion(), release.Version) {
+			printUpdateNotice(release)
+			return
+		}
+		fmt.Printf("Latest release: %s\n", release.Version)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().Bool("check", false, "Check whether a newer release has been published")
+}
+
+// currentVersion returns the version set at build time, the release tag gh installed, the module version of
+// a go install build, or dev
+func currentVersion() string {
+	if version != "" {
+		return version
+	}
+	if executable, err := os.Executable(); err == nil {
+		if tag := manifestTag(filepath.Join(filepath.Dir(executable), "manifest.yml")); tag != "" {
+			return tag
+		}
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// manifestTag returns the release tag of a gh extension manifest, or an empty string when there is none
+func manifestTag(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var manifest extensionManifest
+	if yaml.Unmarshal(data, &manifest) != nil {
+		return ""
+	}
+	return manifest.Tag
+}
+
+// updateCheckEnabled reports whether commands check for newer releases: not with --no-update-check, with
+// gh's GH_NO_UPDATE_NOTIFIER, in CI, or for development builds
+func updateCheckEnabled() bool {
+	if viper.GetBool("NO_UPDATE_CHECK") || os.Getenv("GH_NO_UPDATE_NOTIFIER") != "" || os.Getenv("CI") != "" {
+		return false
+	}
+	return currentVersion() != "dev"
+}
+
+// notifyNewerVersion prints a notice when a newer release exists, using the daily cached check
+func notifyNewerVersion() {
+	if !updateCheckEnabled() {
+		return
+	}
+
+	configPath, err := config.DefaultPath()
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	cachePath := filepath.Join(filepath.Dir(configPath), "update-check.json")
+	release, err := update.CachedLatestRelease(ctx, http.DefaultClient, update.DefaultAPIURL, cachePath, time.Now())
+	if err != nil {
+		// A failed check never affects the command
+		return
+	}
+
+	if update.Newer(currentVersion(), release.Version) {
+		fmt.Fprintln(os.Stderr)
+		printUpdateNotice(release)
+	}
+}
+
+// printUpdateNotice tells how to upgrade to a newer release
+func printUpdateNotice(release *update.Release) {
+	pterm.Info.WithWriter(os.Stderr).Printf("A new release of gh-migration-validator is available: %s → %s\n%s\nUpgrade with: gh extension upgrade gh-migration-validator\n",
+		currentVersion(), release.Version, release.URL)
+}
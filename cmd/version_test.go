@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestManifestTag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yml")
+	manifest := "owner: mona-actions\nname: gh-migration-validator\nhost: github.com\ntag: v1.4.0\nispinned: false\npath: /home/user/.local/share/gh/extensions/gh-migration-validator/gh-migration-validator\n"
+	if err := os.WriteFile(path, []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if tag := manifestTag(path); tag != "v1.4.0" {
+		t.Errorf("manifestTag() = %q, want v1.4.0", tag)
+	}
+	if tag := manifestTag(filepath.Join(t.TempDir(), "missing.yml")); tag != "" {
+		t.Errorf("Expected no tag without a manifest, got %q", tag)
+	}
+}
+
+func TestUpdateCheckEnabled(t *testing.T) {
+	resetViperAndEnv()
+	defer resetViperAndEnv()
+	t.Setenv("CI", "")
+	t.Setenv("GH_NO_UPDATE_NOTIFIER", "")
+
+	previous := version
+	defer func() { version = previous }()
+	version = "v1.2.3"
+
+	if !updateCheckEnabled() {
+		t.Error("Expected released versions to check for updates")
+	}
+
+	viper.Set("NO_UPDATE_CHECK", true)
+	if updateCheckEnabled() {
+		t.Error("Expected --no-update-check to disable the check")
+	}
+
+	viper.Set("NO_UPDATE_CHECK", false)
+	t.Setenv("CI", "true")
+	if updateCheckEnabled() {
+		t.Error("Expected no update check in CI")
+	}
+
+	t.Setenv("CI", "")
+	version = "dev"
+	if updateCheckEnabled() {
+		t.Error("Expected development builds not to check for updates")
+	}
+}
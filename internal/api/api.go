@@ -3,18 +3,18 @@ package api
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofri/go-github-ratelimit/github_ratelimit"
 	"github.com/google/go-github/v62/github"
 	"github.com/jferrl/go-githubauth"
 	"github.com/shurcooL/githubv4"
-	"github.com/spf13/viper"
 	"golang.org/x/oauth2"
 )
 
@@ -22,9 +22,21 @@ import (
 type ClientConfig struct {
 	Token          string
 	Hostname       string
+	APIURL         string // REST API base URL used instead of the one derived from Hostname, e.g. of an API gateway
 	AppID          string
 	PrivateKey     []byte
 	InstallationID int64
+	Retry          RetryConfig
+	LFSBatch       LFSBatchConfig
+	LFSServer      LFSServerConfig // Standalone LFS server storing the LFS objects, when set
+	MaxTreeEntries int             // Tree entries walked to discover LFS pointers before giving up, unlimited when 0
+	Download       DownloadConfig  // Rate limit and parallel parts of migration archive downloads
+	RecordDir      string          // Failed requests and their responses are recorded in this directory, when set
+	ReplayDir      string          // Requests are answered from the recordings in this directory instead of GitHub, when set
+	UserAgent      string          // User-Agent header of every request, the Go default when empty
+	RunID          string          // Sent in the X-GHMV-Run-Id header of every request, when set
+
+	state *clientState // Shared by the clients created with this configuration, when set
 }
 
 // ClientType represents the type of GitHub client to use
@@ -41,32 +53,30 @@ type GitHubAPI struct {
 	targetClient      *github.Client
 	sourceGraphClient *RateLimitAwareGraphQLClient
 	targetGraphClient *RateLimitAwareGraphQLClient
+	sourceState       *clientState
+	targetState       *clientState
+	sourceConfig      ClientConfig // Configurations the clients were created with, for the LFS batch API client
+	targetConfig      ClientConfig
 }
 
-// Helper functions for config creation
-func getSourceConfig() ClientConfig {
-	return ClientConfig{
-		Token:          viper.GetString("SOURCE_TOKEN"),
-		Hostname:       viper.GetString("SOURCE_HOSTNAME"),
-		AppID:          viper.GetString("SOURCE_APP_ID"),
-		PrivateKey:     []byte(viper.GetString("SOURCE_PRIVATE_KEY")),
-		InstallationID: viper.GetInt64("SOURCE_INSTALLATION_ID"),
-	}
+// clientState is what the transports of the REST, GraphQL and LFS clients of one side observe about their requests
+type clientState struct {
+	requests      atomic.Int64           // HTTP requests made
+	restFallbacks atomic.Int64           // Counts retrieved with the REST API because the GraphQL query failed
+	graphQLCost   atomic.Int64           // Rate limit cost of the GraphQL queries made
+	rateLimit     atomic.Pointer[int]    // Requests remaining in the rate limit window, from the last response
+	ssoURL        atomic.Pointer[string] // Last SAML single sign-on authorization URL returned by GitHub
 }
 
-func getTargetConfig() ClientConfig {
-	return ClientConfig{
-		Token:          viper.GetString("TARGET_TOKEN"),
-		Hostname:       viper.GetString("TARGET_HOSTNAME"),
-		AppID:          viper.GetString("TARGET_APP_ID"),
-		PrivateKey:     []byte(viper.GetString("TARGET_PRIVATE_KEY")),
-		InstallationID: viper.GetInt64("TARGET_INSTALLATION_ID"),
-	}
+// SameHostname reports whether two configured hostnames refer to the same GitHub instance
+func SameHostname(a, b string) bool {
+	return strings.EqualFold(webURL(a), webURL(b))
 }
 
 // NewSourceOnlyAPI creates a GitHubAPI instance with only source clients
-func NewSourceOnlyAPI() (*GitHubAPI, error) {
-	sourceConfig := getSourceConfig()
+func NewSourceOnlyAPI(sourceConfig ClientConfig) (*GitHubAPI, error) {
+	sourceState := &clientState{}
+	sourceConfig = withClientState(sourceConfig, sourceState)
 
 	sourceClient, err := newGitHubClient(sourceConfig)
 	if err != nil {
@@ -81,13 +91,16 @@ func NewSourceOnlyAPI() (*GitHubAPI, error) {
 	return &GitHubAPI{
 		sourceClient:      sourceClient,
 		sourceGraphClient: sourceGraphClient,
+		sourceState:       sourceState,
+		sourceConfig:      sourceConfig,
 		// target clients intentionally nil
 	}, nil
 }
 
 // NewTargetOnlyAPI creates a GitHubAPI instance with only target clients
-func NewTargetOnlyAPI() (*GitHubAPI, error) {
-	targetConfig := getTargetConfig()
+func NewTargetOnlyAPI(targetConfig ClientConfig) (*GitHubAPI, error) {
+	targetState := &clientState{}
+	targetConfig = withClientState(targetConfig, targetState)
 
 	targetClient, err := newGitHubClient(targetConfig)
 	if err != nil {
@@ -102,14 +115,18 @@ func NewTargetOnlyAPI() (*GitHubAPI, error) {
 	return &GitHubAPI{
 		targetClient:      targetClient,
 		targetGraphClient: targetGraphClient,
+		targetState:       targetState,
+		targetConfig:      targetConfig,
 		// source clients intentionally nil
 	}, nil
 }
 
 // NewGitHubAPI creates a GitHubAPI instance with both source and target clients
-func NewGitHubAPI() (*GitHubAPI, error) {
-	sourceConfig := getSourceConfig()
-	targetConfig := getTargetConfig()
+func NewGitHubAPI(sourceConfig, targetConfig ClientConfig) (*GitHubAPI, error) {
+	sourceState := &clientState{}
+	targetState := &clientState{}
+	sourceConfig = withClientState(sourceConfig, sourceState)
+	targetConfig = withClientState(targetConfig, targetState)
 
 	sourceClient, err := newGitHubClient(sourceConfig)
 	if err != nil {
@@ -136,14 +153,32 @@ func NewGitHubAPI() (*GitHubAPI, error) {
 		targetClient:      targetClient,
 		sourceGraphClient: sourceGraphClient,
 		targetGraphClient: targetGraphClient,
+		sourceState:       sourceState,
+		targetState:       targetState,
+		sourceConfig:      sourceConfig,
+		targetConfig:      targetConfig,
 	}, nil
 }
 
-// createAuthenticatedClient creates an HTTP client with proper authentication and rate limiting
+// createAuthenticatedClient creates an HTTP client with proper authentication, retries and rate limiting
 func createAuthenticatedClient(config ClientConfig) (*http.Client, error) {
+	base := withUserAgent(http.DefaultTransport, config)
+	if config.RecordDir != "" {
+		base = &recordingTransport{base: base, dir: config.RecordDir}
+	}
+	// The OAuth2 transports send their requests with the client in the context
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: base})
+
 	var httpClient *http.Client
 
-	if config.AppID != "" && len(config.PrivateKey) != 0 && config.InstallationID != 0 {
+	if config.ReplayDir != "" {
+		// Replayed requests are never sent, so no credentials are needed
+		replay, err := newReplayTransport(config.ReplayDir)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{Transport: replay}
+	} else if config.AppID != "" && len(config.PrivateKey) != 0 && config.InstallationID != 0 {
 		// GitHub App authentication
 		appIDInt, err := strconv.ParseInt(config.AppID, 10, 64)
 		if err != nil {
@@ -156,16 +191,22 @@ func createAuthenticatedClient(config ClientConfig) (*http.Client, error) {
 		}
 
 		installationToken := githubauth.NewInstallationTokenSource(config.InstallationID, appToken)
-		httpClient = oauth2.NewClient(context.Background(), installationToken)
+		httpClient = oauth2.NewClient(ctx, installationToken)
 	} else if config.Token != "" {
 		// Personal access token authentication
 		src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.Token})
-		httpClient = oauth2.NewClient(context.Background(), src)
+		httpClient = oauth2.NewClient(ctx, src)
 	} else {
 		return nil, fmt.Errorf("please provide either a token or GitHub App credentials")
 	}
 
-	rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(httpClient.Transport)
+	transport := httpClient.Transport
+	if config.state != nil {
+		transport = &stateTransport{base: transport, state: config.state}
+	}
+
+	// Retry transient failures below the rate limit waiter, so retries also wait for rate limit resets
+	rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(newRetryTransport(transport, config.Retry))
 	if err != nil {
 		return nil, err
 	}
@@ -183,12 +224,15 @@ func newGitHubClient(config ClientConfig) (*github.Client, error) {
 	client := github.NewClient(httpClient)
 
 	// Configure enterprise URL if hostname is provided
-	if config.Hostname != "" {
-		hostname := strings.TrimSuffix(config.Hostname, "/")
-		if !strings.HasPrefix(hostname, "https://") {
-			hostname = "https://" + hostname
+	if config.APIURL != "" {
+		// An API URL override is used as is, as WithEnterpriseURLs would append /api/v3/ to most gateway URLs
+		baseURL, err := url.Parse(config.restURL())
+		if err != nil {
+			return nil, fmt.Errorf("invalid API URL %q: %v", config.APIURL, err)
 		}
-		baseURL := fmt.Sprintf("%s/api/v3/", hostname)
+		client.BaseURL, client.UploadURL = baseURL, baseURL
+	} else if config.Hostname != "" {
+		baseURL := config.restURL()
 		client, err = client.WithEnterpriseURLs(baseURL, baseURL)
 		if err != nil {
 			return nil, fmt.Errorf("failed to configure enterprise URLs: %v", err)
@@ -200,6 +244,7 @@ func newGitHubClient(config ClientConfig) (*github.Client, error) {
 
 type RateLimitAwareGraphQLClient struct {
 	client *githubv4.Client
+	state  *clientState // Records the cost of the queries, when set
 }
 
 // newGitHubGraphQLClient creates a new GitHub GraphQL client based on the provided configuration
@@ -212,18 +257,15 @@ func newGitHubGraphQLClient(config ClientConfig) (*RateLimitAwareGraphQLClient,
 	var baseClient *githubv4.Client
 
 	// If hostname is provided, create enterprise client
-	if config.Hostname != "" {
-		hostname := strings.TrimSuffix(config.Hostname, "/")
-		if !strings.HasPrefix(hostname, "https://") {
-			hostname = "https://" + hostname
-		}
-		baseClient = githubv4.NewEnterpriseClient(hostname+"/api/graphql", httpClient)
+	if config.usesEnterpriseURLs() {
+		baseClient = githubv4.NewEnterpriseClient(config.graphQLEndpoint(), httpClient)
 	} else {
 		baseClient = githubv4.NewClient(httpClient)
 	}
 
 	return &RateLimitAwareGraphQLClient{
 		client: baseClient,
+		state:  config.state,
 	}, nil
 }
 
@@ -254,9 +296,7 @@ func (api *GitHubAPI) getRESTClient(clientType ClientType) (*github.Client, stri
 // ValidateRepoAccess validates that the client can access the specified repository
 // This performs a lightweight GraphQL query to verify authentication, SAML authorization,
 // and repository access permissions before attempting more expensive operations
-func (api *GitHubAPI) ValidateRepoAccess(clientType ClientType, owner, name string) error {
-	ctx := context.Background()
-
+func (api *GitHubAPI) ValidateRepoAccess(ctx context.Context, clientType ClientType, owner, name string) error {
 	var query struct {
 		Repository struct {
 			ID string
@@ -276,12 +316,29 @@ func (api *GitHubAPI) ValidateRepoAccess(clientType ClientType, owner, name stri
 	// Use the underlying client directly to skip rate limit check for this simple validation
 	err = client.client.Query(ctx, &query, variables)
 	if err != nil {
-		return err
+		return classifyError(err)
 	}
 
 	return nil
 }
 
+// ResolveRepository returns the canonical owner and name of a repository using REST API.
+// Renamed or transferred repositories redirect to their new location, so the returned
+// owner and name differ from the requested ones when the repository has moved.
+func (api *GitHubAPI) ResolveRepository(ctx context.Context, clientType ClientType, owner, name string) (string, string, error) {
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return "", "", err
+	}
+
+	repo, _, err := client.Repositories.Get(ctx, owner, name)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve %s repository %s/%s: %w", clientName, owner, name, classifyError(err))
+	}
+
+	return repo.GetOwner().GetLogin(), repo.GetName(), nil
+}
+
 // RateLimitInfo contains information about current rate limit status
 type RateLimitInfo struct {
 	Remaining int
@@ -289,9 +346,7 @@ type RateLimitInfo struct {
 }
 
 // GetRateLimitStatus returns the current rate limit status for the specified client
-func (api *GitHubAPI) GetRateLimitStatus(clientType ClientType) (*RateLimitInfo, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetRateLimitStatus(ctx context.Context, clientType ClientType) (*RateLimitInfo, error) {
 	var query struct {
 		RateLimit struct {
 			Remaining int
@@ -307,7 +362,7 @@ func (api *GitHubAPI) GetRateLimitStatus(clientType ClientType) (*RateLimitInfo,
 	// Use underlying client directly
 	err = client.client.Query(ctx, &query, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query %s rate limit: %w", clientName, err)
+		return nil, fmt.Errorf("failed to query %s rate limit: %w", clientName, classifyError(err))
 	}
 
 	return &RateLimitInfo{
@@ -332,19 +387,20 @@ func (c *RateLimitAwareGraphQLClient) Query(ctx context.Context, q interface{},
 
 		if rateLimitQuery.RateLimit.Remaining > 0 {
 			// Proceed with the actual query
-			return c.client.Query(ctx, q, variables)
+			return c.query(ctx, q, variables)
 		}
 
 		// Rate limited - wait silently until reset
 		sleepDuration := time.Until(rateLimitQuery.RateLimit.ResetAt.Time)
-		time.Sleep(sleepDuration)
+		if err := sleepContext(ctx, sleepDuration); err != nil {
+			return err
+		}
 	}
 }
 
-// GetIssueCount retrieves the total count of issues for a repository using GraphQL
-func (api *GitHubAPI) GetIssueCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
-
+// GetIssueCount retrieves the total count of issues for a repository using GraphQL. Unlike the REST issues
+// list, the GraphQL issues connection never includes pull requests, so the count excludes them.
+func (api *GitHubAPI) GetIssueCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner string
@@ -366,7 +422,10 @@ func (api *GitHubAPI) GetIssueCount(clientType ClientType, owner, name string) (
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query %s repository issue count: %v", clientName, err)
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository issue count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return searchCount(ctx, client, fmt.Sprintf("repo:%s/%s is:issue", owner, name))
+			})
 	}
 
 	return query.Repository.Issues.TotalCount, nil
@@ -381,9 +440,7 @@ type PRCounts struct {
 }
 
 // GetPRCounts retrieves the counts of pull requests by state for a repository using GraphQL
-func (api *GitHubAPI) GetPRCounts(clientType ClientType, owner, name string) (*PRCounts, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetPRCounts(ctx context.Context, clientType ClientType, owner, name string) (*PRCounts, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner string
@@ -411,7 +468,10 @@ func (api *GitHubAPI) GetPRCounts(clientType ClientType, owner, name string) (*P
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query %s repository PR counts: %v", clientName, err)
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository PR counts: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (*PRCounts, error) {
+				return restPRCounts(ctx, client, owner, name)
+			})
 	}
 
 	counts := &PRCounts{
@@ -427,9 +487,7 @@ func (api *GitHubAPI) GetPRCounts(clientType ClientType, owner, name string) (*P
 }
 
 // GetTagCount retrieves the total count of tags for a repository using GraphQL
-func (api *GitHubAPI) GetTagCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetTagCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner string
@@ -451,16 +509,17 @@ func (api *GitHubAPI) GetTagCount(clientType ClientType, owner, name string) (in
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query %s repository tag count: %v", clientName, err)
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository tag count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return restCount(ctx, client, fmt.Sprintf("repos/%s/%s/tags", owner, name))
+			})
 	}
 
 	return query.Repository.Refs.TotalCount, nil
 }
 
 // GetReleaseCount retrieves the total count of releases for a repository using GraphQL
-func (api *GitHubAPI) GetReleaseCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetReleaseCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner string
@@ -482,16 +541,75 @@ func (api *GitHubAPI) GetReleaseCount(clientType ClientType, owner, name string)
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query %s repository release count: %v", clientName, err)
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository release count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return restCount(ctx, client, fmt.Sprintf("repos/%s/%s/releases", owner, name))
+			})
 	}
 
 	return query.Repository.Releases.TotalCount, nil
 }
 
-// GetCommitCount retrieves the total count of commits on the default branch using GraphQL
-func (api *GitHubAPI) GetCommitCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
+// GetCommitCommentCount retrieves the total count of commit comments for a repository using GraphQL
+func (api *GitHubAPI) GetCommitCommentCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
+	var query struct {
+		Repository struct {
+			CommitComments struct {
+				TotalCount int
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
 
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository commit comment count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return restCount(ctx, client, fmt.Sprintf("repos/%s/%s/comments", owner, name))
+			})
+	}
+
+	return query.Repository.CommitComments.TotalCount, nil
+}
+
+// IsRepositoryEmpty reports whether a repository has no commits (and therefore no default branch) using GraphQL
+func (api *GitHubAPI) IsRepositoryEmpty(ctx context.Context, clientType ClientType, owner, name string) (bool, error) {
+	var query struct {
+		Repository struct {
+			NameWithOwner string
+			IsEmpty       bool
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return false, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return false, fmt.Errorf("failed to query %s repository empty state: %w", clientName, classifyError(err))
+	}
+
+	return query.Repository.IsEmpty, nil
+}
+
+// GetCommitCount retrieves the total count of commits on the default branch using GraphQL
+func (api *GitHubAPI) GetCommitCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner    string
@@ -519,16 +637,17 @@ func (api *GitHubAPI) GetCommitCount(clientType ClientType, owner, name string)
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query %s repository commit count: %v", clientName, err)
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository commit count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return restCount(ctx, client, fmt.Sprintf("repos/%s/%s/commits", owner, name))
+			})
 	}
 
 	return query.Repository.DefaultBranchRef.Target.Commit.History.TotalCount, nil
 }
 
 // GetLatestCommitHash retrieves the latest commit hash from the default branch using GraphQL
-func (api *GitHubAPI) GetLatestCommitHash(clientType ClientType, owner, name string) (string, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetLatestCommitHash(ctx context.Context, clientType ClientType, owner, name string) (string, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner    string
@@ -554,16 +673,49 @@ func (api *GitHubAPI) GetLatestCommitHash(clientType ClientType, owner, name str
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return "", fmt.Errorf("failed to query %s repository latest commit hash: %v", clientName, err)
+		return "", fmt.Errorf("failed to query %s repository latest commit hash: %w", clientName, classifyError(err))
 	}
 
 	return query.Repository.DefaultBranchRef.Target.Commit.OID, nil
 }
 
-// GetBranchProtectionRulesCount retrieves the total count of branch protection rules for a repository using GraphQL
-func (api *GitHubAPI) GetBranchProtectionRulesCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
+// GetTreeHash retrieves the SHA of the root tree of the default branch head using GraphQL. Identical trees
+// have identical contents, even when the commit SHAs differ.
+func (api *GitHubAPI) GetTreeHash(ctx context.Context, clientType ClientType, owner, name string) (string, error) {
+	var query struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Target struct {
+					Commit struct {
+						Tree struct {
+							OID string
+						}
+					} `graphql:"... on Commit"`
+				}
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
 
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return "", err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s repository tree hash: %w", clientName, classifyError(err))
+	}
+
+	return query.Repository.DefaultBranchRef.Target.Commit.Tree.OID, nil
+}
+
+// GetBranchProtectionRulesCount retrieves the total count of branch protection rules for a repository using GraphQL
+func (api *GitHubAPI) GetBranchProtectionRulesCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	var query struct {
 		Repository struct {
 			NameWithOwner         string
@@ -585,16 +737,14 @@ func (api *GitHubAPI) GetBranchProtectionRulesCount(clientType ClientType, owner
 
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query %s repository branch protection rules count: %v", clientName, err)
+		return 0, fmt.Errorf("failed to query %s repository branch protection rules count: %w", clientName, classifyError(err))
 	}
 
 	return query.Repository.BranchProtectionRules.TotalCount, nil
 }
 
 // GetWebhookCount retrieves the count of all webhooks (active and inactive) for a repository using REST API
-func (api *GitHubAPI) GetWebhookCount(clientType ClientType, owner, name string) (int, error) {
-	ctx := context.Background()
-
+func (api *GitHubAPI) GetWebhookCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
 	client, clientName, err := api.getRESTClient(clientType)
 	if err != nil {
 		return 0, err
@@ -607,7 +757,7 @@ func (api *GitHubAPI) GetWebhookCount(clientType ClientType, owner, name string)
 	for {
 		webhooks, resp, err := client.Repositories.ListHooks(ctx, owner, name, opts)
 		if err != nil {
-			return 0, fmt.Errorf("failed to query %s repository webhook count: %v", clientName, err)
+			return 0, fmt.Errorf("failed to query %s repository webhook count: %w", clientName, classifyError(err))
 		}
 
 		// Count all webhooks (both active and inactive)
@@ -622,6 +772,124 @@ func (api *GitHubAPI) GetWebhookCount(clientType ClientType, owner, name string)
 	return webhookCount, nil
 }
 
+// MigrationLogIssueTitle is the title GitHub Enterprise Importer gives the issue it creates
+// in the target repository to hold the migration log
+const MigrationLogIssueTitle = "Migration Log"
+
+// migrationLogIssueSearchWindow is how many of the most recently created issues are inspected
+// when looking for the migration log issue
+const migrationLogIssueSearchWindow = 10
+
+// MigrationLogIssue holds the migration log issue created by GitHub Enterprise Importer
+type MigrationLogIssue struct {
+	Number int
+	Title  string
+	Body   string
+	URL    string
+}
+
+// GetMigrationLogIssue looks for the migration log issue in a repository using GraphQL.
+// Imported issues keep their original creation dates, so the migration log issue is among the
+// most recently created issues right after a migration. Returns nil if no migration log issue is found.
+func (api *GitHubAPI) GetMigrationLogIssue(ctx context.Context, clientType ClientType, owner, name string) (*MigrationLogIssue, error) {
+	var query struct {
+		Repository struct {
+			NameWithOwner string
+			Issues        struct {
+				Nodes []struct {
+					Number int
+					Title  string
+					Body   string
+					URL    string
+				}
+			} `graphql:"issues(first: $first, orderBy: {field: CREATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+		"first": githubv4.Int(migrationLogIssueSearchWindow),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository migration log issue: %w", clientName, classifyError(err))
+	}
+
+	for _, issue := range query.Repository.Issues.Nodes {
+		if strings.Contains(strings.ToLower(issue.Title), strings.ToLower(MigrationLogIssueTitle)) {
+			return &MigrationLogIssue{
+				Number: issue.Number,
+				Title:  issue.Title,
+				Body:   issue.Body,
+				URL:    issue.URL,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CreateIssueComment adds a comment to an issue using REST API
+func (api *GitHubAPI) CreateIssueComment(clientType ClientType, owner, name string, number int, body string) error {
+	ctx := context.Background()
+
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Issues.CreateComment(ctx, owner, name, number, &github.IssueComment{Body: github.String(body)})
+	if err != nil {
+		return fmt.Errorf("failed to comment on %s issue %s/%s#%d: %w", clientName, owner, name, number, classifyError(err))
+	}
+
+	return nil
+}
+
+// CheckRunReport holds the content of a completed check run
+type CheckRunReport struct {
+	Name       string
+	HeadSHA    string
+	Conclusion string // "success", "neutral" or "failure"
+	Title      string
+	Summary    string
+	Text       string
+}
+
+// CreateCheckRun creates a completed check run on a commit using REST API and returns its URL
+func (api *GitHubAPI) CreateCheckRun(clientType ClientType, owner, name string, report CheckRunReport) (string, error) {
+	ctx := context.Background()
+
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return "", err
+	}
+
+	checkRun, _, err := client.Checks.CreateCheckRun(ctx, owner, name, github.CreateCheckRunOptions{
+		Name:       report.Name,
+		HeadSHA:    report.HeadSHA,
+		Status:     github.String("completed"),
+		Conclusion: github.String(report.Conclusion),
+		Output: &github.CheckRunOutput{
+			Title:   github.String(report.Title),
+			Summary: github.String(report.Summary),
+			Text:    github.String(report.Text),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s check run on %s/%s: %w", clientName, owner, name, classifyError(err))
+	}
+
+	return checkRun.GetHTMLURL(), nil
+}
+
 // ListOrganizationMigrations retrieves the list of organization migrations using REST API
 // Limited to the last 100 migrations
 func (api *GitHubAPI) ListOrganizationMigrations(clientType ClientType, org string) ([]*github.Migration, error) {
@@ -640,7 +908,7 @@ func (api *GitHubAPI) ListOrganizationMigrations(clientType ClientType, org stri
 	for {
 		migrations, resp, err := client.Migrations.ListMigrations(ctx, org, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list %s organization migrations: %v", clientName, err)
+			return nil, fmt.Errorf("failed to list %s organization migrations: %w", clientName, classifyError(err))
 		}
 
 		for _, migration := range migrations {
@@ -726,57 +994,53 @@ func (api *GitHubAPI) DownloadMigrationArchive(clientType ClientType, org string
 	// Step 1: Get the signed S3 URL from GitHub API
 	signedURL, err := client.Migrations.MigrationArchiveURL(ctx, org, migrationID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s migration archive URL: %v", clientName, err)
+		return "", fmt.Errorf("failed to get %s migration archive URL: %w", clientName, classifyError(err))
 	}
 
-	// Step 2: Use a plain HTTP client to download from the signed S3 URL
-	// Note: We don't need authentication for the signed URL - it's already authorized
-	httpClient := &http.Client{}
-	downloadResp, err := httpClient.Get(signedURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to download from signed URL: %v", err)
+	// Step 2: Download from the signed S3 URL
+	if err := downloadSignedURL(api.clientConfig(clientType), signedURL, outputPath); err != nil {
+		return "", err
 	}
-	defer downloadResp.Body.Close()
 
-	if downloadResp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download migration archive from S3: status %d", downloadResp.StatusCode)
+	return outputPath, nil
+}
+
+// DownloadArchiveURL downloads the migration archive at a pre-signed URL, such as the Azure or AWS blob storage
+// URL a migration was run with, and returns the file path
+func (api *GitHubAPI) DownloadArchiveURL(clientType ClientType, archiveURL, outputPath string) (string, error) {
+	if err := downloadSignedURL(api.clientConfig(clientType), archiveURL, outputPath); err != nil {
+		return "", err
 	}
+	return outputPath, nil
+}
+
+// downloadSignedURL downloads signedURL to outputPath, throttled and in parallel parts as configured
+func downloadSignedURL(config ClientConfig, signedURL, outputPath string) error {
+	// Note: We don't need authentication for the signed URL - it's already authorized
+	httpClient := &http.Client{Transport: withUserAgent(http.DefaultTransport, config)}
 
 	// Create the output file
 	file, err := os.Create(outputPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create output file: %v", err)
+		return fmt.Errorf("failed to create output file: %v", err)
 	}
 	defer file.Close()
 
-	// Copy the response body to the file
-	_, err = io.Copy(file, downloadResp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to save migration archive: %v", err)
+	if err := downloadToFile(httpClient, signedURL, file, config.Download); err != nil {
+		file.Close()
+		os.Remove(outputPath) // A partial archive cannot be extracted
+		return err
 	}
-
-	return outputPath, nil
+	return nil
 }
 
-// Helper function to get client config for a given client type
-func getClientConfigForType(clientType ClientType) ClientConfig {
+// clientConfig returns the configuration the clients of the given type were created with
+func (api *GitHubAPI) clientConfig(clientType ClientType) ClientConfig {
 	switch clientType {
 	case SourceClient:
-		return ClientConfig{
-			Token:          viper.GetString("SOURCE_TOKEN"),
-			Hostname:       viper.GetString("SOURCE_HOSTNAME"),
-			AppID:          viper.GetString("SOURCE_APP_ID"),
-			PrivateKey:     []byte(viper.GetString("SOURCE_PRIVATE_KEY")),
-			InstallationID: viper.GetInt64("SOURCE_INSTALLATION_ID"),
-		}
+		return api.sourceConfig
 	case TargetClient:
-		return ClientConfig{
-			Token:          viper.GetString("TARGET_TOKEN"),
-			Hostname:       viper.GetString("TARGET_HOSTNAME"),
-			AppID:          viper.GetString("TARGET_APP_ID"),
-			PrivateKey:     []byte(viper.GetString("TARGET_PRIVATE_KEY")),
-			InstallationID: viper.GetInt64("TARGET_INSTALLATION_ID"),
-		}
+		return api.targetConfig
 	default:
 		return ClientConfig{}
 	}
@@ -9,7 +9,6 @@ import (
 	"testing"
 
 	"github.com/google/go-github/v62/github"
-	"github.com/spf13/viper"
 )
 
 // MockUserAuthenticator implements UserAuthenticator for testing
@@ -25,10 +24,10 @@ func (m *MockUserAuthenticator) GetAuthenticatedUser(ctx context.Context) (*gith
 	return m.user, nil
 }
 
-func init() {
-	// Set up test configuration
-	viper.Set("SOURCE_TOKEN", "test-token")
-	viper.Set("TARGET_TOKEN", "test-token")
+// testConfig returns a client configuration authenticating with token. It does not retry, as tests without
+// a reachable server would otherwise retry every connection failure.
+func testConfig(token string) ClientConfig {
+	return ClientConfig{Token: token}
 }
 
 // createTestAPI creates a GitHubAPI instance with mocked clients for testing
@@ -60,32 +59,8 @@ func NewMockResponse(statusCode int, body string) *http.Response {
 }
 
 func TestNewGitHubAPI(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN":           viper.Get("SOURCE_TOKEN"),
-		"SOURCE_HOSTNAME":        viper.Get("SOURCE_HOSTNAME"),
-		"SOURCE_APP_ID":          viper.Get("SOURCE_APP_ID"),
-		"SOURCE_PRIVATE_KEY":     viper.Get("SOURCE_PRIVATE_KEY"),
-		"SOURCE_INSTALLATION_ID": viper.Get("SOURCE_INSTALLATION_ID"),
-		"TARGET_TOKEN":           viper.Get("TARGET_TOKEN"),
-		"TARGET_HOSTNAME":        viper.Get("TARGET_HOSTNAME"),
-		"TARGET_APP_ID":          viper.Get("TARGET_APP_ID"),
-		"TARGET_PRIVATE_KEY":     viper.Get("TARGET_PRIVATE_KEY"),
-		"TARGET_INSTALLATION_ID": viper.Get("TARGET_INSTALLATION_ID"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	// Test with both source and target tokens
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "test-target-token")
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig("test-target-token"))
 	if err != nil {
 		t.Errorf("NewGitHubAPI() error = %v", err)
 		return
@@ -111,24 +86,8 @@ func TestNewGitHubAPI(t *testing.T) {
 }
 
 func TestNewGitHubAPI_MissingSourceToken(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	// Set up config with missing source token
-	viper.Set("SOURCE_TOKEN", "") // Empty source token
-	viper.Set("TARGET_TOKEN", "test-target-token")
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig(""), testConfig("test-target-token"))
 	if err == nil {
 		t.Error("NewGitHubAPI() should have failed with missing source token")
 		return
@@ -139,24 +98,8 @@ func TestNewGitHubAPI_MissingSourceToken(t *testing.T) {
 }
 
 func TestNewGitHubAPI_MissingTargetToken(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	// Set up config with missing target token
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "") // Empty target token
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig(""))
 	if err == nil {
 		t.Error("NewGitHubAPI() should have failed with missing target token")
 		return
@@ -167,24 +110,8 @@ func TestNewGitHubAPI_MissingTargetToken(t *testing.T) {
 }
 
 func TestNewGitHubAPI_BothTokensMissing(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	// Set up config with both tokens missing
-	viper.Set("SOURCE_TOKEN", "") // Empty source token
-	viper.Set("TARGET_TOKEN", "") // Empty target token
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig(""), testConfig(""))
 	if err == nil {
 		t.Error("NewGitHubAPI() should have failed with both tokens missing")
 		return
@@ -195,24 +122,14 @@ func TestNewGitHubAPI_BothTokensMissing(t *testing.T) {
 }
 
 func TestAPI_MethodsWithMissingTokens(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
+	sourceOnly := func(source, _ ClientConfig) (*GitHubAPI, error) { return NewSourceOnlyAPI(source) }
+	targetOnly := func(_, target ClientConfig) (*GitHubAPI, error) { return NewTargetOnlyAPI(target) }
 
 	tests := []struct {
 		name         string
 		sourceToken  string
 		targetToken  string
-		apiFactory   func() (*GitHubAPI, error)
+		apiFactory   func(source, target ClientConfig) (*GitHubAPI, error)
 		testFunction func(*GitHubAPI) error
 		expectError  bool
 	}{
@@ -220,9 +137,9 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 			name:        "GetIssueCount with missing source token",
 			sourceToken: "",
 			targetToken: "test-target-token",
-			apiFactory:  NewSourceOnlyAPI,
+			apiFactory:  sourceOnly,
 			testFunction: func(api *GitHubAPI) error {
-				_, err := api.GetIssueCount(SourceClient, "owner", "repo")
+				_, err := api.GetIssueCount(context.Background(), SourceClient, "owner", "repo")
 				return err
 			},
 			expectError: true,
@@ -231,9 +148,9 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 			name:        "GetIssueCount with missing target token",
 			sourceToken: "test-source-token",
 			targetToken: "",
-			apiFactory:  NewTargetOnlyAPI,
+			apiFactory:  targetOnly,
 			testFunction: func(api *GitHubAPI) error {
-				_, err := api.GetIssueCount(TargetClient, "owner", "repo")
+				_, err := api.GetIssueCount(context.Background(), TargetClient, "owner", "repo")
 				return err
 			},
 			expectError: true,
@@ -242,9 +159,9 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 			name:        "GetPRCounts with missing source token",
 			sourceToken: "",
 			targetToken: "test-target-token",
-			apiFactory:  NewSourceOnlyAPI,
+			apiFactory:  sourceOnly,
 			testFunction: func(api *GitHubAPI) error {
-				_, err := api.GetPRCounts(SourceClient, "owner", "repo")
+				_, err := api.GetPRCounts(context.Background(), SourceClient, "owner", "repo")
 				return err
 			},
 			expectError: true,
@@ -253,9 +170,9 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 			name:        "GetPRCounts with missing target token",
 			sourceToken: "test-source-token",
 			targetToken: "",
-			apiFactory:  NewTargetOnlyAPI,
+			apiFactory:  targetOnly,
 			testFunction: func(api *GitHubAPI) error {
-				_, err := api.GetPRCounts(TargetClient, "owner", "repo")
+				_, err := api.GetPRCounts(context.Background(), TargetClient, "owner", "repo")
 				return err
 			},
 			expectError: true,
@@ -264,11 +181,8 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			viper.Set("SOURCE_TOKEN", tt.sourceToken)
-			viper.Set("TARGET_TOKEN", tt.targetToken)
-
 			// Use the explicit factory function specified in the test case
-			api, err := tt.apiFactory()
+			api, err := tt.apiFactory(testConfig(tt.sourceToken), testConfig(tt.targetToken))
 			if tt.expectError && err != nil {
 				// This is expected - the factory should fail with missing credentials
 				return
@@ -294,19 +208,6 @@ func TestAPI_MethodsWithMissingTokens(t *testing.T) {
 }
 
 func TestNewSourceOnlyAPI(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN":  viper.Get("SOURCE_TOKEN"),
-		"SOURCE_APP_ID": viper.Get("SOURCE_APP_ID"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	tests := []struct {
 		name        string
 		sourceToken string
@@ -335,10 +236,7 @@ func TestNewSourceOnlyAPI(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			viper.Set("SOURCE_TOKEN", tt.sourceToken)
-			viper.Set("SOURCE_APP_ID", tt.sourceAppID)
-
-			api, err := NewSourceOnlyAPI()
+			api, err := NewSourceOnlyAPI(ClientConfig{Token: tt.sourceToken, AppID: tt.sourceAppID})
 
 			if tt.wantError {
 				if err == nil {
@@ -378,19 +276,6 @@ func TestNewSourceOnlyAPI(t *testing.T) {
 }
 
 func TestNewTargetOnlyAPI(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"TARGET_TOKEN":  viper.Get("TARGET_TOKEN"),
-		"TARGET_APP_ID": viper.Get("TARGET_APP_ID"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
 	tests := []struct {
 		name        string
 		targetToken string
@@ -419,10 +304,7 @@ func TestNewTargetOnlyAPI(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			viper.Set("TARGET_TOKEN", tt.targetToken)
-			viper.Set("TARGET_APP_ID", tt.targetAppID)
-
-			api, err := NewTargetOnlyAPI()
+			api, err := NewTargetOnlyAPI(ClientConfig{Token: tt.targetToken, AppID: tt.targetAppID})
 
 			if tt.wantError {
 				if err == nil {
@@ -666,10 +548,6 @@ func TestRateLimitAwareGraphQLClient_Query(t *testing.T) {
 }
 
 func TestGetIssueCount(t *testing.T) {
-	// Create a mock API with test configuration
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -703,13 +581,12 @@ func TestGetIssueCount(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Reset and get fresh API instance
-
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API: %v", err)
 			}
 
-			count, err := api.GetIssueCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetIssueCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -729,9 +606,6 @@ func TestGetIssueCount(t *testing.T) {
 }
 
 func TestGetPRCounts(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -764,13 +638,12 @@ func TestGetPRCounts(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			counts, err := api.GetPRCounts(tt.clientType, tt.owner, tt.repo)
+			counts, err := api.GetPRCounts(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -795,9 +668,6 @@ func TestGetPRCounts(t *testing.T) {
 }
 
 func TestGetTagCount(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -830,13 +700,12 @@ func TestGetTagCount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			count, err := api.GetTagCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetTagCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -856,9 +725,6 @@ func TestGetTagCount(t *testing.T) {
 }
 
 func TestGetReleaseCount(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -891,13 +757,12 @@ func TestGetReleaseCount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			count, err := api.GetReleaseCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetReleaseCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -917,9 +782,6 @@ func TestGetReleaseCount(t *testing.T) {
 }
 
 func TestGetCommitCount(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -952,12 +814,12 @@ func TestGetCommitCount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			count, err := api.GetCommitCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetCommitCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -977,9 +839,6 @@ func TestGetCommitCount(t *testing.T) {
 }
 
 func TestGetLatestCommitHash(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -1012,12 +871,12 @@ func TestGetLatestCommitHash(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			hash, err := api.GetLatestCommitHash(tt.clientType, tt.owner, tt.repo)
+			hash, err := api.GetLatestCommitHash(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -1076,9 +935,6 @@ func TestPRCounts_TotalCalculation(t *testing.T) {
 }
 
 func TestGetBranchProtectionRulesCount(t *testing.T) {
-	viper.Set("SOURCE_TOKEN", "source-token")
-	viper.Set("TARGET_TOKEN", "target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -1111,12 +967,12 @@ func TestGetBranchProtectionRulesCount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("source-token"), testConfig("target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API client: %v", err)
 			}
 
-			count, err := api.GetBranchProtectionRulesCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetBranchProtectionRulesCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -1265,7 +1121,7 @@ func TestGitHubAPI_GetWebhookCount(t *testing.T) {
 			}
 
 			api := createTestAPI(mockTransport)
-			count, err := api.GetWebhookCount(tt.clientType, tt.owner, tt.repo)
+			count, err := api.GetWebhookCount(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			if tt.expectedError {
 				if err == nil {
@@ -1292,23 +1148,140 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	return m.roundTripFunc(req)
 }
 
-func TestValidateRepoAccess(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
+func TestResolveRepository(t *testing.T) {
+	tests := []struct {
+		name          string
+		responseBody  string
+		statusCode    int
+		expectedOwner string
+		expectedName  string
+		expectedError bool
+	}{
+		{
+			name:          "repository not renamed",
+			responseBody:  `{"name": "testrepo", "full_name": "testowner/testrepo", "owner": {"login": "testowner"}}`,
+			statusCode:    200,
+			expectedOwner: "testowner",
+			expectedName:  "testrepo",
+		},
+		{
+			name:          "repository renamed",
+			responseBody:  `{"name": "new-repo", "full_name": "new-owner/new-repo", "owner": {"login": "new-owner"}}`,
+			statusCode:    200,
+			expectedOwner: "new-owner",
+			expectedName:  "new-repo",
+		},
+		{
+			name:          "repository not found",
+			responseBody:  `{"message": "Not Found"}`,
+			statusCode:    404,
+			expectedError: true,
+		},
 	}
 
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if !strings.HasSuffix(req.URL.Path, "/repos/testowner/testrepo") {
+						t.Errorf("Expected repository API endpoint, got: %s", req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						Header:     make(http.Header),
+						Request:    req,
+					}, nil
+				},
+			}
 
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "test-target-token")
+			api := createTestAPI(mockTransport)
+			owner, name, err := api.ResolveRepository(context.Background(), TargetClient, "testowner", "testrepo")
 
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if owner != tt.expectedOwner || name != tt.expectedName {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.expectedOwner, tt.expectedName, owner, name)
+			}
+		})
+	}
+}
+
+func TestCreateIssueComment(t *testing.T) {
+	var receivedBody string
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/repos/testowner/testrepo/issues/7/comments") {
+				t.Errorf("Expected issue comments API endpoint, got: %s %s", req.Method, req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			receivedBody = string(body)
+			return &http.Response{
+				StatusCode: 201,
+				Body:       io.NopCloser(strings.NewReader(`{"id": 1}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		},
+	}
+
+	api := createTestAPI(mockTransport)
+	err := api.CreateIssueComment(TargetClient, "testowner", "testrepo", 7, "Validation passed")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(receivedBody, `"body":"Validation passed"`) {
+		t.Errorf("Expected comment body in request, got: %s", receivedBody)
+	}
+}
+
+func TestCreateCheckRun(t *testing.T) {
+	var receivedBody string
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/repos/testowner/testrepo/check-runs") {
+				t.Errorf("Expected check runs API endpoint, got: %s %s", req.Method, req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			receivedBody = string(body)
+			return &http.Response{
+				StatusCode: 201,
+				Body:       io.NopCloser(strings.NewReader(`{"id": 1, "html_url": "https://github.com/testowner/testrepo/runs/1"}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		},
+	}
+
+	api := createTestAPI(mockTransport)
+	url, err := api.CreateCheckRun(TargetClient, "testowner", "testrepo", CheckRunReport{
+		Name:       "Migration Validation",
+		HeadSHA:    "abc123",
+		Conclusion: "failure",
+		Title:      "1 failed",
+		Summary:    "Some data is missing in target",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if url != "https://github.com/testowner/testrepo/runs/1" {
+		t.Errorf("Expected check run URL, got: %s", url)
+	}
+	for _, expected := range []string{`"head_sha":"abc123"`, `"status":"completed"`, `"conclusion":"failure"`} {
+		if !strings.Contains(receivedBody, expected) {
+			t.Errorf("Expected %s in request, got: %s", expected, receivedBody)
+		}
+	}
+}
+
+func TestValidateRepoAccess(t *testing.T) {
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -1355,12 +1328,12 @@ func TestValidateRepoAccess(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig("test-target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API: %v", err)
 			}
 
-			err = api.ValidateRepoAccess(tt.clientType, tt.owner, tt.repo)
+			err = api.ValidateRepoAccess(context.Background(), tt.clientType, tt.owner, tt.repo)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -1374,29 +1347,13 @@ func TestValidateRepoAccess(t *testing.T) {
 }
 
 func TestValidateRepoAccess_InvalidClientType(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "test-target-token")
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig("test-target-token"))
 	if err != nil {
 		t.Fatalf("Failed to create API: %v", err)
 	}
 
 	// Test with invalid client type - should fail when getting client
-	err = api.ValidateRepoAccess(ClientType(999), "owner", "repo")
+	err = api.ValidateRepoAccess(context.Background(), ClientType(999), "owner", "repo")
 	if err == nil {
 		t.Error("ValidateRepoAccess() should have failed with invalid client type")
 	}
@@ -1408,22 +1365,6 @@ func TestValidateRepoAccess_InvalidClientType(t *testing.T) {
 }
 
 func TestGetRateLimitStatus(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "test-target-token")
-
 	tests := []struct {
 		name       string
 		clientType ClientType
@@ -1448,12 +1389,12 @@ func TestGetRateLimitStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			api, err := NewGitHubAPI()
+			api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig("test-target-token"))
 			if err != nil {
 				t.Fatalf("Failed to create API: %v", err)
 			}
 
-			info, err := api.GetRateLimitStatus(tt.clientType)
+			info, err := api.GetRateLimitStatus(context.Background(), tt.clientType)
 
 			gotError := err != nil
 			if gotError && !tt.wantError {
@@ -1481,29 +1422,13 @@ func TestGetRateLimitStatus(t *testing.T) {
 }
 
 func TestGetRateLimitStatus_InvalidClientType(t *testing.T) {
-	// Store original values
-	originalValues := map[string]interface{}{
-		"SOURCE_TOKEN": viper.Get("SOURCE_TOKEN"),
-		"TARGET_TOKEN": viper.Get("TARGET_TOKEN"),
-	}
-
-	// Restore original values after test
-	defer func() {
-		for key, value := range originalValues {
-			viper.Set(key, value)
-		}
-	}()
-
-	viper.Set("SOURCE_TOKEN", "test-source-token")
-	viper.Set("TARGET_TOKEN", "test-target-token")
-
-	api, err := NewGitHubAPI()
+	api, err := NewGitHubAPI(testConfig("test-source-token"), testConfig("test-target-token"))
 	if err != nil {
 		t.Fatalf("Failed to create API: %v", err)
 	}
 
 	// Test with invalid client type - should fail when getting client
-	info, err := api.GetRateLimitStatus(ClientType(999))
+	info, err := api.GetRateLimitStatus(context.Background(), ClientType(999))
 	if err == nil {
 		t.Error("GetRateLimitStatus() should have failed with invalid client type")
 	}
@@ -1517,3 +1442,33 @@ func TestGetRateLimitStatus_InvalidClientType(t *testing.T) {
 		t.Errorf("GetRateLimitStatus() error = %v, want error containing %q", err, expectedErrMsg)
 	}
 }
+
+func TestSameHostname(t *testing.T) {
+	if !SameHostname("https://GitHub.example.com/", "github.example.com") {
+		t.Error("Expected hostnames differing in scheme, case and trailing slash to match")
+	}
+	if SameHostname("", "github.example.com") {
+		t.Error("Expected github.com not to match an enterprise hostname")
+	}
+}
+
+func TestGetTreeHash(t *testing.T) {
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		return `{"data": {"repository": {"defaultBranchRef": {"target": {"tree": {"oid": "4b825dc642cb6eb9a060e54bf8d69288fbee4904"}}}}}}`
+	})
+
+	tree, err := api.GetTreeHash(context.Background(), SourceClient, "owner", "repo")
+
+	if err != nil {
+		t.Fatalf("GetTreeHash() unexpected error: %v", err)
+	}
+	if tree != "4b825dc642cb6eb9a060e54bf8d69288fbee4904" {
+		t.Errorf("GetTreeHash() = %q, want the tree of the default branch head", tree)
+	}
+}
+
+func TestSameHostname_DataResidency(t *testing.T) {
+	if !SameHostname("octocorp.ghe.com", "https://api.octocorp.ghe.com") {
+		t.Error("Expected a GHE.com tenant to match its API subdomain")
+	}
+}
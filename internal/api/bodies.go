@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// BodyLength is the length in characters of the body of an issue or pull request
+type BodyLength struct {
+	Number int `json:"number"`
+	Length int `json:"length"`
+}
+
+// bodyPage is a page of issues or pull requests with their bodies
+type bodyPage struct {
+	Nodes []struct {
+		Number int
+		Body   string
+	}
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   githubv4.String
+	}
+}
+
+// GetLargestBodies retrieves the count issues and pull requests with the longest bodies using GraphQL, longest
+// first. Every issue and pull request is read, 100 per request, as bodies cannot be sorted by length.
+func (api *GitHubAPI) GetLargestBodies(ctx context.Context, clientType ClientType, owner, name string, count int) ([]BodyLength, error) {
+	var issuesQuery struct {
+		Repository struct {
+			Items bodyPage `graphql:"issues(first: 100, after: $cursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	var pullRequestsQuery struct {
+		Repository struct {
+			Items bodyPage `graphql:"pullRequests(first: 100, after: $cursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodies []BodyLength
+	for _, kind := range []string{"issue", "pull request"} {
+		variables := map[string]interface{}{
+			"owner":  githubv4.String(owner),
+			"name":   githubv4.String(name),
+			"cursor": (*githubv4.String)(nil),
+		}
+
+		for {
+			var page *bodyPage
+			if kind == "issue" {
+				err = client.Query(ctx, &issuesQuery, variables)
+				page = &issuesQuery.Repository.Items
+			} else {
+				err = client.Query(ctx, &pullRequestsQuery, variables)
+				page = &pullRequestsQuery.Repository.Items
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query %s repository %s bodies: %w", clientName, kind, classifyError(err))
+			}
+
+			for _, node := range page.Nodes {
+				bodies = append(bodies, BodyLength{Number: node.Number, Length: utf8.RuneCountInString(node.Body)})
+			}
+
+			if !page.PageInfo.HasNextPage {
+				break
+			}
+			variables["cursor"] = githubv4.NewString(page.PageInfo.EndCursor)
+		}
+	}
+
+	sort.SliceStable(bodies, func(i, j int) bool {
+		return bodies[i].Length > bodies[j].Length
+	})
+	if len(bodies) > count {
+		bodies = bodies[:count]
+	}
+	return bodies, nil
+}
+
+// GetBodyLengths retrieves the body length of the issues and pull requests with the given numbers using
+// GraphQL, one request per number. Numbers that do not exist in the repository are left out.
+func (api *GitHubAPI) GetBodyLengths(ctx context.Context, clientType ClientType, owner, name string, numbers []int) ([]BodyLength, error) {
+	var query struct {
+		Repository struct {
+			IssueOrPullRequest *struct {
+				Issue struct {
+					Body string
+				} `graphql:"... on Issue"`
+				PullRequest struct {
+					Body string
+				} `graphql:"... on PullRequest"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]BodyLength, 0, len(numbers))
+	for _, number := range numbers {
+		variables := map[string]interface{}{
+			"owner":  githubv4.String(owner),
+			"name":   githubv4.String(name),
+			"number": githubv4.Int(number),
+		}
+
+		query.Repository.IssueOrPullRequest = nil
+		err = client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s repository body of #%d: %w", clientName, number, classifyError(err))
+		}
+
+		item := query.Repository.IssueOrPullRequest
+		if item == nil {
+			continue
+		}
+		body := item.Issue.Body
+		if body == "" {
+			body = item.PullRequest.Body
+		}
+		bodies = append(bodies, BodyLength{Number: number, Length: utf8.RuneCountInString(body)})
+	}
+
+	return bodies, nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLargestBodies(t *testing.T) {
+	api := createGraphQLTestAPI(func(query string, variables map[string]interface{}) string {
+		if strings.Contains(query, "pullRequests") {
+			return `{"data": {"repository": {"pullRequests": {
+				"nodes": [{"number": 3, "body": "` + strings.Repeat("p", 40) + `"}],
+				"pageInfo": {"hasNextPage": false, "endCursor": "pr-1"}}}}}`
+		}
+		if variables["cursor"] == nil {
+			return `{"data": {"repository": {"issues": {
+				"nodes": [{"number": 1, "body": "short"}, {"number": 2, "body": "` + strings.Repeat("é", 50) + `"}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "issue-1"}}}}}`
+		}
+		return `{"data": {"repository": {"issues": {
+			"nodes": [{"number": 4, "body": ""}],
+			"pageInfo": {"hasNextPage": false, "endCursor": "issue-2"}}}}}`
+	})
+
+	bodies, err := api.GetLargestBodies(context.Background(), SourceClient, "owner", "repo", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []BodyLength{{Number: 2, Length: 50}, {Number: 3, Length: 40}}, bodies,
+		"bodies are measured in characters and only the longest are kept")
+}
+
+func TestGetBodyLengths(t *testing.T) {
+	api := createGraphQLTestAPI(func(query string, variables map[string]interface{}) string {
+		switch variables["number"] {
+		case float64(2):
+			return `{"data": {"repository": {"issueOrPullRequest": {"body": "` + strings.Repeat("i", 30) + `"}}}}`
+		case float64(3):
+			return `{"data": {"repository": {"issueOrPullRequest": {"body": "` + strings.Repeat("p", 25) + `"}}}}`
+		}
+		return `{"data": {"repository": {"issueOrPullRequest": null}}}`
+	})
+
+	bodies, err := api.GetBodyLengths(context.Background(), SourceClient, "owner", "repo", []int{2, 3, 9})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []BodyLength{{Number: 2, Length: 30}, {Number: 3, Length: 25}}, bodies)
+}
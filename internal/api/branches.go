@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// BranchHead is the head commit of a branch and the number of commits in its history
+type BranchHead struct {
+	SHA     string `json:"sha"`
+	Commits int    `json:"commits"`
+}
+
+// GetBranchCount retrieves the total count of branches for a repository using GraphQL
+func (api *GitHubAPI) GetBranchCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
+	var query struct {
+		Repository struct {
+			Refs struct {
+				TotalCount int
+			} `graphql:"refs(refPrefix: \"refs/heads/\")"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return withRESTFallback(ctx, api, clientType, fmt.Errorf("failed to query %s repository branch count: %w", clientName, classifyError(err)),
+			func(ctx context.Context, client *github.Client) (int, error) {
+				return restCount(ctx, client, fmt.Sprintf("repos/%s/%s/branches", owner, name))
+			})
+	}
+
+	return query.Repository.Refs.TotalCount, nil
+}
+
+// GetBranches retrieves the head commit and commit count of every branch of a repository using GraphQL,
+// keyed by branch name
+func (api *GitHubAPI) GetBranches(ctx context.Context, clientType ClientType, owner, name string) (map[string]BranchHead, error) {
+	var query struct {
+		Repository struct {
+			Refs struct {
+				Nodes []struct {
+					Name   string
+					Target struct {
+						Commit struct {
+							Oid     string
+							History struct {
+								TotalCount int
+							}
+						} `graphql:"... on Commit"`
+					}
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"refs(refPrefix: \"refs/heads/\", first: 50, after: $cursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(name),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make(map[string]BranchHead)
+	for {
+		err = client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s repository branches: %w", clientName, classifyError(err))
+		}
+
+		for _, ref := range query.Repository.Refs.Nodes {
+			branches[ref.Name] = BranchHead{SHA: ref.Target.Commit.Oid, Commits: ref.Target.Commit.History.TotalCount}
+		}
+
+		if !query.Repository.Refs.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(query.Repository.Refs.PageInfo.EndCursor)
+	}
+
+	return branches, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBranches(t *testing.T) {
+	pages := []string{
+		`{"data": {"repository": {"refs": {
+			"nodes": [
+				{"name": "main", "target": {"oid": "aaa111", "history": {"totalCount": 120}}},
+				{"name": "develop", "target": {"oid": "bbb222", "history": {"totalCount": 135}}}],
+			"pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"}}}}}`,
+		`{"data": {"repository": {"refs": {
+			"nodes": [{"name": "release/1.0", "target": {"oid": "ccc333", "history": {"totalCount": 80}}}],
+			"pageInfo": {"hasNextPage": false, "endCursor": "cursor-2"}}}}}`,
+	}
+
+	var cursors []interface{}
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		cursors = append(cursors, variables["cursor"])
+		return pages[len(cursors)-1]
+	})
+
+	branches, err := api.GetBranches(context.Background(), SourceClient, "owner", "repo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]BranchHead{
+		"main":        {SHA: "aaa111", Commits: 120},
+		"develop":     {SHA: "bbb222", Commits: 135},
+		"release/1.0": {SHA: "ccc333", Commits: 80},
+	}, branches)
+	assert.Equal(t, []interface{}{nil, "cursor-1"}, cursors)
+}
+
+func TestGetBranchCount(t *testing.T) {
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		return `{"data": {"repository": {"refs": {"totalCount": 42}}}}`
+	})
+
+	count, err := api.GetBranchCount(context.Background(), SourceClient, "owner", "repo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+}
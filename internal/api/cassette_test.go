@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/testutil/recorder"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCassetteAPI returns an API whose source clients send their requests through the cassette name
+func newCassetteAPI(t *testing.T, name string) *GitHubAPI {
+	client := recorder.New(t, name).Client()
+	return &GitHubAPI{
+		sourceClient:      github.NewClient(client),
+		sourceGraphClient: &RateLimitAwareGraphQLClient{client: githubv4.NewClient(client)},
+		sourceState:       &clientState{},
+	}
+}
+
+func TestCassette_RepositoryCounts(t *testing.T) {
+	api := newCassetteAPI(t, "repository_counts")
+
+	empty, err := api.IsRepositoryEmpty(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.False(t, empty)
+
+	issues, err := api.GetIssueCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 1274, issues)
+
+	prs, err := api.GetPRCounts(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, &PRCounts{Open: 2231, Merged: 3, Closed: 487, Total: 2721}, prs)
+
+	tags, err := api.GetTagCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 0, tags)
+
+	releases, err := api.GetReleaseCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 0, releases)
+
+	branches, err := api.GetBranchCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 3, branches)
+
+	commits, err := api.GetCommitCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 3, commits)
+
+	comments, err := api.GetCommitCommentCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 7, comments)
+
+	sha, err := api.GetLatestCommitHash(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", sha)
+
+	assert.Equal(t, 0, api.RESTFallbackCount(SourceClient))
+}
+
+func TestCassette_RepositoryNotFound(t *testing.T) {
+	api := newCassetteAPI(t, "repository_not_found")
+
+	_, err := api.GetIssueCount(context.Background(), SourceClient, "octocat", "does-not-exist")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "Could not resolve to a Repository")
+}
+
+func TestCassette_RESTFallback(t *testing.T) {
+	api := newCassetteAPI(t, "rest_fallback")
+
+	tags, err := api.GetTagCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 12, tags)
+
+	issues, err := api.GetIssueCount(context.Background(), SourceClient, "octocat", "Hello-World")
+	require.NoError(t, err)
+	assert.Equal(t, 1274, issues)
+
+	assert.Equal(t, 2, api.RESTFallbackCount(SourceClient))
+}
+
+func TestCassette_WebhooksForbidden(t *testing.T) {
+	api := newCassetteAPI(t, "webhooks_forbidden")
+
+	_, err := api.GetWebhookCount(context.Background(), SourceClient, "octocat", "Hello-World")
+
+	assert.ErrorIs(t, err, ErrAuth)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// FailureCause describes why a request failed, in more detail than the error kind, so reports can
+// explain failed metrics and suggest what to do about them
+type FailureCause string
+
+const (
+	CauseNotFound           FailureCause = "not found"
+	CauseForbidden          FailureCause = "forbidden"
+	CauseUnauthorized       FailureCause = "unauthorized"
+	CauseSAMLEnforcement    FailureCause = "SAML enforcement"
+	CauseSecondaryRateLimit FailureCause = "secondary rate limit"
+	CauseRateLimit          FailureCause = "rate limit"
+	CauseEmptyRepository    FailureCause = "empty repository"
+	CauseUnknown            FailureCause = "error"
+)
+
+// Hint returns what the user can do about a request failing with the cause
+func (c FailureCause) Hint() string {
+	switch c {
+	case CauseNotFound:
+		return "Check the repository name and that the token can see the repository"
+	case CauseForbidden:
+		return "The token lacks the permission needed for this data, e.g. admin access for webhooks"
+	case CauseUnauthorized:
+		return "Check that the token is valid and has not expired"
+	case CauseSAMLEnforcement:
+		return "Authorize the token for SAML single sign-on in the organization"
+	case CauseSecondaryRateLimit:
+		return "Too many requests in a short time; wait a few minutes before retrying"
+	case CauseRateLimit:
+		return "Wait for the rate limit to reset, or use a GitHub App for a higher limit"
+	case CauseEmptyRepository:
+		return "The repository has no commits"
+	default:
+		return "See the logged error for details"
+	}
+}
+
+// ClassifyFailure returns the cause of a failed request, from the REST response or the GraphQL error message
+func ClassifyFailure(err error) FailureCause {
+	if err == nil {
+		return ""
+	}
+
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseRateLimitErr) {
+		return CauseSecondaryRateLimit
+	}
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return CauseRateLimit
+	}
+
+	if _, ok := responseSSOURL(err); ok {
+		return CauseSAMLEnforcement
+	}
+
+	// Messages identify SAML enforcement and secondary rate limits, which share status codes with other causes
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "saml"):
+		return CauseSAMLEnforcement
+	case strings.Contains(message, "secondary rate limit"):
+		return CauseSecondaryRateLimit
+	case strings.Contains(message, "rate limit"):
+		return CauseRateLimit
+	}
+
+	if cause := statusCause(failureStatusCode(err)); cause != "" {
+		return cause
+	}
+
+	// GraphQL errors only carry a message
+	switch {
+	case strings.Contains(message, "could not resolve to"):
+		return CauseNotFound
+	case strings.Contains(message, "git repository is empty"):
+		return CauseEmptyRepository
+	case strings.Contains(message, "401 unauthorized"), strings.Contains(message, "bad credentials"):
+		return CauseUnauthorized
+	case strings.Contains(message, "403 forbidden"), strings.Contains(message, "resource not accessible"):
+		return CauseForbidden
+	}
+
+	return CauseUnknown
+}
+
+// failureStatusCode returns the HTTP status code of the response err was returned for, or 0 when unknown
+func failureStatusCode(err error) int {
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil {
+		return responseErr.Response.StatusCode
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// statusCause returns the cause of an unsuccessful HTTP status code, or an empty cause when it is unknown
+func statusCause(statusCode int) FailureCause {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return CauseUnauthorized
+	case http.StatusForbidden:
+		return CauseForbidden
+	case http.StatusNotFound:
+		return CauseNotFound
+	case http.StatusConflict:
+		// GitHub answers 409 for commit and content requests to an empty repository
+		return CauseEmptyRepository
+	case http.StatusTooManyRequests:
+		return CauseSecondaryRateLimit
+	default:
+		return ""
+	}
+}
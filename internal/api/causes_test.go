@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected FailureCause
+	}{
+		{"REST not found", &github.ErrorResponse{Response: errorResponse(http.StatusNotFound)}, CauseNotFound},
+		{"REST forbidden", &github.ErrorResponse{Response: errorResponse(http.StatusForbidden), Message: "Must have admin rights to Repository."}, CauseForbidden},
+		{"REST SAML enforcement", &github.ErrorResponse{Response: errorResponse(http.StatusForbidden), Message: "Resource protected by organization SAML enforcement."}, CauseSAMLEnforcement},
+		{"REST unauthorized", &github.ErrorResponse{Response: errorResponse(http.StatusUnauthorized)}, CauseUnauthorized},
+		{"REST empty repository", &github.ErrorResponse{Response: errorResponse(http.StatusConflict), Message: "Git Repository is empty."}, CauseEmptyRepository},
+		{"REST rate limit", &github.RateLimitError{Response: errorResponse(http.StatusForbidden)}, CauseRateLimit},
+		{"REST secondary rate limit", &github.AbuseRateLimitError{Response: errorResponse(http.StatusForbidden)}, CauseSecondaryRateLimit},
+		{"GraphQL missing repository", errors.New("Could not resolve to a Repository with the name 'owner/repo'."), CauseNotFound},
+		{"GraphQL secondary rate limit", errors.New("You have exceeded a secondary rate limit. Please wait a few minutes before you try again."), CauseSecondaryRateLimit},
+		{"GraphQL SAML enforcement", errors.New("Resource protected by organization SAML enforcement."), CauseSAMLEnforcement},
+		{"GraphQL forbidden", errors.New(`non-200 OK status code: 403 Forbidden body: ""`), CauseForbidden},
+		{"LFS status", fmt.Errorf("wrapped: %w", classifyStatus(http.StatusNotFound, errors.New("LFS batch API returned status 404"))), CauseNotFound},
+		{"unknown", errors.New("connection reset by peer"), CauseUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyFailure(tt.err); got != tt.expected {
+				t.Errorf("ClassifyFailure() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if got := ClassifyFailure(nil); got != "" {
+		t.Errorf("Expected no cause for a nil error, got %q", got)
+	}
+}
+
+func TestFailureCause_Hint(t *testing.T) {
+	for _, cause := range []FailureCause{CauseNotFound, CauseForbidden, CauseUnauthorized, CauseSAMLEnforcement,
+		CauseSecondaryRateLimit, CauseRateLimit, CauseEmptyRepository, CauseUnknown} {
+		if cause.Hint() == "" {
+			t.Errorf("Expected a hint for %q", cause)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// CommitFile creates or updates a file on the default branch of a repository with a commit, using REST API,
+// and returns the URL of the file
+func (api *GitHubAPI) CommitFile(clientType ClientType, owner, name, path, message string, content []byte) (string, error) {
+	ctx := context.Background()
+
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return "", err
+	}
+
+	options := &github.RepositoryContentFileOptions{
+		Message: github.String(message),
+		Content: content,
+	}
+
+	// Updating a file needs the blob SHA of its current version
+	existing, _, _, err := client.Repositories.GetContents(ctx, owner, name, path, nil)
+	if err != nil && !errors.Is(classifyError(err), ErrNotFound) {
+		return "", fmt.Errorf("failed to read %s file %s in %s/%s: %w", clientName, path, owner, name, classifyError(err))
+	}
+	if existing != nil {
+		options.SHA = existing.SHA
+	}
+
+	var response *github.RepositoryContentResponse
+	if options.SHA != nil {
+		response, _, err = client.Repositories.UpdateFile(ctx, owner, name, path, options)
+	} else {
+		response, _, err = client.Repositories.CreateFile(ctx, owner, name, path, options)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to commit %s file %s to %s/%s: %w", clientName, path, owner, name, classifyError(err))
+	}
+
+	return response.GetContent().GetHTMLURL(), nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		getStatus   int
+		getBody     string
+		expectedSHA string
+	}{
+		{
+			name:      "new file",
+			getStatus: http.StatusNotFound,
+			getBody:   `{"message": "Not Found"}`,
+		},
+		{
+			name:        "existing file",
+			getStatus:   http.StatusOK,
+			getBody:     `{"type": "file", "path": "reports/report.md", "sha": "abc123"}`,
+			expectedSHA: "abc123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "/repos/org/reports/contents/reports/report.md", req.URL.Path)
+
+					statusCode, body := tt.getStatus, tt.getBody
+					if req.Method == http.MethodPut {
+						var options struct {
+							Message string `json:"message"`
+							Content string `json:"content"`
+							SHA     string `json:"sha"`
+						}
+						require.NoError(t, json.NewDecoder(req.Body).Decode(&options))
+						assert.Equal(t, "Add report", options.Message)
+						assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("# Report")), options.Content)
+						assert.Equal(t, tt.expectedSHA, options.SHA)
+
+						statusCode = http.StatusCreated
+						body = `{"content": {"html_url": "https://github.com/org/reports/blob/main/reports/report.md"}}`
+					}
+					return &http.Response{
+						StatusCode: statusCode,
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Header:     make(http.Header),
+						Request:    req,
+					}, nil
+				},
+			}
+
+			url, err := createTestAPI(mockTransport).CommitFile(TargetClient, "org", "reports", "reports/report.md", "Add report", []byte("# Report"))
+
+			require.NoError(t, err)
+			assert.Equal(t, "https://github.com/org/reports/blob/main/reports/report.md", url)
+		})
+	}
+}
+
+func TestCommitFile_Forbidden(t *testing.T) {
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Resource not accessible by integration"}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		},
+	}
+
+	_, err := createTestAPI(mockTransport).CommitFile(TargetClient, "org", "reports", "report.md", "Add report", []byte("# Report"))
+
+	assert.ErrorIs(t, err, ErrAuth)
+	assert.Contains(t, err.Error(), "failed to read target file report.md in org/reports")
+}
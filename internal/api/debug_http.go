@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HTTPRecording is a failed request and its response, as written by --debug-http and read by --replay-http
+type HTTPRecording struct {
+	RecordedAt      time.Time   `json:"recorded_at"`
+	Method          string      `json:"method"`
+	URL             string      `json:"url"`
+	RequestHeaders  http.Header `json:"request_headers,omitempty"`
+	RequestBody     string      `json:"request_body,omitempty"`
+	StatusCode      int         `json:"status_code,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+	Error           string      `json:"error,omitempty"` // Transport error, when no response was received
+}
+
+// redactedHeaders are replaced in recordings as they carry credentials
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// tokenPattern matches GitHub tokens and bearer credentials in URLs and bodies
+var tokenPattern = regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|(?i:bearer|token) [A-Za-z0-9._\-]{20,}`)
+
+const redacted = "REDACTED"
+
+// recordingSequence numbers recordings across clients, so files written in the same second keep their order
+var recordingSequence atomic.Int64
+
+// sanitize removes credentials from text
+func sanitize(text string) string {
+	return tokenPattern.ReplaceAllString(text, redacted)
+}
+
+// sanitizeHeaders returns a copy of header without credentials
+func sanitizeHeaders(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	sanitized := make(http.Header, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			sanitized.Add(name, sanitize(value))
+		}
+	}
+	for _, name := range redactedHeaders {
+		if sanitized.Get(name) != "" {
+			sanitized.Set(name, redacted)
+		}
+	}
+	return sanitized
+}
+
+// recordingTransport writes the failed requests sent through it and their responses to dir: transport
+// errors, unsuccessful status codes and GraphQL responses with errors
+type recordingTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.record(req, requestBody, nil, nil, err)
+		return resp, err
+	}
+
+	graphQL := strings.HasSuffix(req.URL.Path, "/graphql")
+	if resp.StatusCode < http.StatusBadRequest && !graphQL {
+		return resp, nil
+	}
+
+	responseBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if readErr != nil {
+		return resp, nil
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest || hasGraphQLErrors(responseBody) {
+		t.record(req, requestBody, resp, responseBody, nil)
+	}
+	return resp, nil
+}
+
+// record writes a sanitized recording to the directory; failing to write it never fails the request
+func (t *recordingTransport) record(req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte, err error) {
+	recording := HTTPRecording{
+		RecordedAt:     time.Now().UTC(),
+		Method:         req.Method,
+		URL:            sanitize(req.URL.String()),
+		RequestHeaders: sanitizeHeaders(req.Header),
+		RequestBody:    sanitize(string(requestBody)),
+	}
+	if resp != nil {
+		recording.StatusCode = resp.StatusCode
+		recording.ResponseHeaders = sanitizeHeaders(resp.Header)
+		recording.ResponseBody = sanitize(string(responseBody))
+	}
+	if err != nil {
+		recording.Error = sanitize(err.Error())
+	}
+
+	data, marshalErr := json.MarshalIndent(recording, "", "  ")
+	if marshalErr != nil {
+		return
+	}
+	name := fmt.Sprintf("%s-%04d-%s.json", recording.RecordedAt.Format("20060102T150405"), recordingSequence.Add(1), req.Method)
+	if os.MkdirAll(t.dir, 0o755) == nil {
+		os.WriteFile(filepath.Join(t.dir, name), data, 0o600)
+	}
+}
+
+// readRequestBody returns the body of req and a request that can still send it
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, req, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, req, nil
+}
+
+// hasGraphQLErrors reports whether a GraphQL response body carries errors, which are returned with status 200
+func hasGraphQLErrors(body []byte) bool {
+	var response struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(body, &response) == nil && len(response.Errors) > 0
+}
+
+// replayTransport answers requests with the recordings of a --debug-http directory instead of sending them.
+// Recordings are matched by method, path, query and body; a request recorded several times, e.g. because
+// it was retried, is answered with each recording in turn and then with the last one.
+type replayTransport struct {
+	mu         sync.Mutex
+	recordings map[string][]HTTPRecording
+	served     map[string]int
+}
+
+// replayRateLimitResponse answers the rate limit check made before every GraphQL query, which succeeds and
+// is therefore never recorded
+const replayRateLimitResponse = `{"data": {"rateLimit": {"remaining": 5000, "resetAt": "2099-01-01T00:00:00Z"}}}`
+
+// newReplayTransport loads the recordings of dir
+func newReplayTransport(dir string) (*replayTransport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no HTTP recordings found in %s", dir)
+	}
+
+	t := &replayTransport{recordings: make(map[string][]HTTPRecording), served: make(map[string]int)}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var recording HTTPRecording
+		if err := json.Unmarshal(data, &recording); err != nil {
+			return nil, fmt.Errorf("invalid HTTP recording %s: %v", file, err)
+		}
+		recordedURL, err := parseRecordedURL(recording.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL in HTTP recording %s: %v", file, err)
+		}
+
+		key := replayKey(recording.Method, recordedURL, recording.RequestBody)
+		t.recordings[key] = append(t.recordings[key], recording)
+	}
+	return t, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := replayKey(req.Method, req.URL.RequestURI(), string(body))
+	t.mu.Lock()
+	recordings := t.recordings[key]
+	index := min(t.served[key], len(recordings)-1)
+	t.served[key]++
+	t.mu.Unlock()
+
+	if len(recordings) == 0 {
+		if strings.HasSuffix(req.URL.Path, "/graphql") && strings.Contains(string(body), "{rateLimit{remaining,resetAt}}") {
+			return replayResponse(req, http.StatusOK, nil, replayRateLimitResponse), nil
+		}
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+
+	recording := recordings[index]
+	if recording.Error != "" {
+		return nil, fmt.Errorf("%s", recording.Error)
+	}
+	return replayResponse(req, recording.StatusCode, recording.ResponseHeaders, recording.ResponseBody), nil
+}
+
+// replayKey identifies a request by method, path with query, and body
+func replayKey(method, requestURI, body string) string {
+	return method + " " + requestURI + "\n" + body
+}
+
+// parseRecordedURL returns the path and query of a recorded URL
+func parseRecordedURL(rawURL string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	return req.URL.RequestURI(), nil
+}
+
+// replayResponse builds the response to req from a recording
+func replayResponse(req *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Header:     header.Clone(),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
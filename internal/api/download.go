@@ -0,0 +1,235 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DownloadConfig controls how migration archives are downloaded
+type DownloadConfig struct {
+	RateLimit int64 // Bytes per second across every request of a download, unlimited when 0
+	Parts     int   // Ranged requests downloading a large archive in parallel, a single request when 0 or 1
+}
+
+// minDownloadPartSize is the smallest part of a parallel download: archives smaller than two parts are
+// downloaded with a single request
+var minDownloadPartSize int64 = 16 << 20
+
+// throttleChunkSize is the most bytes read at once from a throttled download, so its rate stays even
+const throttleChunkSize = 32 << 10
+
+// byteRateUnits are the multipliers of the units of ParseByteRate, by upper-case unit
+var byteRateUnits = map[string]float64{
+	"": 1, "B": 1,
+	"K": 1e3, "KB": 1e3, "KIB": 1 << 10,
+	"M": 1e6, "MB": 1e6, "MIB": 1 << 20,
+	"G": 1e9, "GB": 1e9, "GIB": 1 << 30,
+}
+
+// ParseByteRate parses a bandwidth such as 20MB/s, 512KiB/s or 1000000 into bytes per second. KB, MB and GB
+// are powers of 1000, KiB, MiB and GiB powers of 1024. An empty value is no limit, returned as 0.
+func ParseByteRate(value string) (int64, error) {
+	trimmed := strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(value)), "/S")
+	if trimmed == "" {
+		return 0, nil
+	}
+
+	unitStart := strings.IndexFunc(trimmed, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if unitStart < 0 {
+		unitStart = len(trimmed)
+	}
+	number, err := strconv.ParseFloat(trimmed[:unitStart], 64)
+	multiplier, known := byteRateUnits[strings.TrimSpace(trimmed[unitStart:])]
+	if err != nil || !known || number <= 0 {
+		return 0, fmt.Errorf("invalid rate %q: use a number of bytes per second with an optional unit, e.g. 20MB/s", value)
+	}
+	return int64(number * multiplier), nil
+}
+
+// byteRateLimiter spaces out the bytes read by the requests of a download to stay under a rate
+type byteRateLimiter struct {
+	rate int64 // Bytes per second
+
+	mu   sync.Mutex
+	next time.Time // When the bytes read so far are paid for
+}
+
+// newByteRateLimiter returns a limiter of rate bytes per second, or nil when rate is 0, which limits nothing
+func newByteRateLimiter(rate int64) *byteRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &byteRateLimiter{rate: rate}
+}
+
+// wait blocks until reading n more bytes keeps the download under the rate
+func (l *byteRateLimiter) wait(n int) {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(float64(n) / float64(l.rate) * float64(time.Second)))
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
+
+// reader returns r read under the rate of the limiter, or r itself without a limiter
+func (l *byteRateLimiter) reader(r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &throttledReader{reader: r, limiter: l}
+}
+
+// throttledReader is a reader whose reads wait for the rate limiter of their download
+type throttledReader struct {
+	reader  io.Reader
+	limiter *byteRateLimiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > throttleChunkSize {
+		p = p[:throttleChunkSize]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.limiter.wait(n)
+	}
+	return n, err
+}
+
+// withoutURL returns the cause of a request error without the URL it wraps, whose signature grants access to
+// the archive and must not be logged
+func withoutURL(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
+
+// downloadToFile downloads url to file. With more than one part configured, archives at least two parts large
+// are downloaded with that many ranged requests in parallel, when the server supports ranges.
+func downloadToFile(httpClient *http.Client, url string, file *os.File, config DownloadConfig) error {
+	limiter := newByteRateLimiter(config.RateLimit)
+
+	if config.Parts > 1 {
+		size, err := rangedDownloadSize(httpClient, url)
+		if err != nil {
+			return err
+		}
+		if size >= 2*minDownloadPartSize {
+			return downloadParts(httpClient, url, file, size, config.Parts, limiter)
+		}
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download from signed URL: %v", withoutURL(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download migration archive: status %d", resp.StatusCode)
+	}
+	if _, err := io.Copy(file, limiter.reader(resp.Body)); err != nil {
+		return fmt.Errorf("failed to save migration archive: %v", err)
+	}
+	return nil
+}
+
+// rangedDownloadSize returns the size of the file at url when the server supports ranged requests, and 0
+// when it does not
+func rangedDownloadSize(httpClient *http.Client, url string) (int64, error) {
+	// Signed URLs are only valid for GET, so the first byte is requested instead of sending a HEAD request
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download from signed URL: %v", withoutURL(err))
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// Content-Range: bytes 0-0/SIZE
+		_, total, found := strings.Cut(resp.Header.Get("Content-Range"), "/")
+		size, err := strconv.ParseInt(total, 10, 64)
+		if !found || err != nil {
+			return 0, nil
+		}
+		return size, nil
+	case http.StatusOK:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("failed to download migration archive: status %d", resp.StatusCode)
+	}
+}
+
+// downloadParts downloads the size bytes at url to file with parts ranged requests in parallel
+func downloadParts(httpClient *http.Client, url string, file *os.File, size int64, parts int, limiter *byteRateLimiter) error {
+	partSize := max((size+int64(parts)-1)/int64(parts), minDownloadPartSize)
+	if err := file.Truncate(size); err != nil {
+		return fmt.Errorf("failed to save migration archive: %v", err)
+	}
+
+	var starts []int64
+	for start := int64(0); start < size; start += partSize {
+		starts = append(starts, start)
+	}
+	errs := make([]error, len(starts))
+
+	var wg sync.WaitGroup
+	for i, start := range starts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = downloadPart(httpClient, url, file, start, min(start+partSize, size)-1, limiter)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadPart downloads the bytes start to end, inclusive, of url to the same offsets of file
+func downloadPart(httpClient *http.Client, url string, file *os.File, start, end int64, limiter *byteRateLimiter) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download bytes %d-%d from signed URL: %v", start, end, withoutURL(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("failed to download bytes %d-%d of migration archive: status %d", start, end, resp.StatusCode)
+	}
+
+	written, err := io.Copy(io.NewOffsetWriter(file, start), limiter.reader(resp.Body))
+	if err != nil {
+		return fmt.Errorf("failed to save bytes %d-%d of migration archive: %v", start, end, err)
+	}
+	if want := end - start + 1; written != want {
+		return fmt.Errorf("failed to download bytes %d-%d of migration archive: got %d bytes", start, end, written)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteRate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"", 0},
+		{"1000000", 1000000},
+		{"20MB/s", 20000000},
+		{"20mb/s", 20000000},
+		{"512KiB/s", 512 << 10},
+		{"1.5 GB", 1500000000},
+		{"100B/s", 100},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteRate(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"fast", "20XB/s", "-5MB/s", "0"} {
+		_, err := ParseByteRate(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// newArchiveServer serves content with ranged request support, like signed S3 URLs, recording the Range headers
+func newArchiveServer(t *testing.T, content []byte) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ranges...)
+	}
+}
+
+func TestDownloadToFile_Parts(t *testing.T) {
+	defer func(size int64) { minDownloadPartSize = size }(minDownloadPartSize)
+	minDownloadPartSize = 1000
+
+	content := bytes.Repeat([]byte("0123456789"), 450)
+	server, ranges := newArchiveServer(t, content)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "archive.tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, downloadToFile(server.Client(), server.URL, file, DownloadConfig{Parts: 4}))
+	downloaded, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	// The probe, then 4 parts of 1125 bytes
+	assert.ElementsMatch(t, []string{"bytes=0-0", "bytes=0-1124", "bytes=1125-2249", "bytes=2250-3374", "bytes=3375-4499"}, ranges())
+}
+
+func TestDownloadToFile_SmallArchive(t *testing.T) {
+	content := []byte("small archive")
+	server, ranges := newArchiveServer(t, content)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "archive.tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, downloadToFile(server.Client(), server.URL, file, DownloadConfig{Parts: 4}))
+	downloaded, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.Equal(t, []string{"bytes=0-0", ""}, ranges(), "archives smaller than two parts are downloaded with a single request")
+}
+
+func TestDownloadToFile_RangesUnsupported(t *testing.T) {
+	defer func(size int64) { minDownloadPartSize = size }(minDownloadPartSize)
+	minDownloadPartSize = 10
+
+	content := bytes.Repeat([]byte("x"), 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "archive.tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, downloadToFile(server.Client(), server.URL, file, DownloadConfig{Parts: 4}))
+	downloaded, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+}
+
+func TestDownloadToFile_RateLimit(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 200<<10)
+	server, _ := newArchiveServer(t, content)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "archive.tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	start := time.Now()
+	require.NoError(t, downloadToFile(server.Client(), server.URL, file, DownloadConfig{RateLimit: 1 << 20}))
+	// 200KiB at 1MiB/s take about 200ms, less the first chunk read before waiting
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	info, err := file.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size())
+}
+
+func TestDownloadToFile_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "archive.tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	err = downloadToFile(server.Client(), server.URL, file, DownloadConfig{Parts: 4})
+	assert.EqualError(t, err, "failed to download migration archive: status 403")
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// Error kinds returned by the API clients and the validator. Match them with errors.Is.
+var (
+	// ErrAuth is returned when credentials are missing, invalid or lack access (including SAML enforcement)
+	ErrAuth = errors.New("authentication failed")
+	// ErrNotFound is returned when a repository or other resource does not exist or is not visible
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited is returned when a primary or secondary rate limit has been exceeded
+	ErrRateLimited = errors.New("rate limited")
+	// ErrPartialData is returned when some, but not all, of the requested data could be retrieved
+	ErrPartialData = errors.New("partial data")
+	// ErrTimeout is returned when a validation did not finish within its time limit
+	ErrTimeout = errors.New("timed out")
+	// ErrInterrupted is returned when a validation was stopped by an interrupt signal, e.g. Ctrl-C
+	ErrInterrupted = errors.New("interrupted")
+)
+
+// Error codes used in machine-readable output for each error kind
+const (
+	ErrorCodeAuth        = "auth"
+	ErrorCodeNotFound    = "not_found"
+	ErrorCodeRateLimited = "rate_limited"
+	ErrorCodePartialData = "partial_data"
+	ErrorCodeTimeout     = "timeout"
+	ErrorCodeInterrupted = "interrupted"
+	ErrorCodeUnknown     = "error"
+)
+
+// Error associates an API error with its kind. Its message is the message of the underlying error.
+type Error struct {
+	Kind       error
+	Err        error
+	StatusCode int // HTTP status code of the failed response, when known
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap allows errors.Is to match both the kind and the underlying error
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ErrorCode returns the machine-readable code of the kind of err, or ErrorCodeUnknown
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrAuth):
+		return ErrorCodeAuth
+	case errors.Is(err, ErrNotFound):
+		return ErrorCodeNotFound
+	case errors.Is(err, ErrRateLimited):
+		return ErrorCodeRateLimited
+	case errors.Is(err, ErrPartialData):
+		return ErrorCodePartialData
+	case errors.Is(err, ErrTimeout):
+		return ErrorCodeTimeout
+	case errors.Is(err, ErrInterrupted):
+		return ErrorCodeInterrupted
+	default:
+		return ErrorCodeUnknown
+	}
+}
+
+// classifyError wraps err with its kind when it can be determined from the REST response or the
+// GraphQL error message. Errors of an unknown kind are returned unchanged.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if kind := errorKind(err); kind != nil {
+		return &Error{Kind: kind, Err: err}
+	}
+	return err
+}
+
+// classifyStatus wraps err, returned for an unsuccessful HTTP response, with the kind of the status code
+func classifyStatus(statusCode int, err error) error {
+	if kind := statusKind(statusCode); kind != nil {
+		return &Error{Kind: kind, Err: err, StatusCode: statusCode}
+	}
+	return err
+}
+
+// statusKind returns the kind of an unsuccessful HTTP status code, or nil when it is unknown
+func statusKind(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// errorKind returns the kind of err, or nil when it is unknown
+func errorKind(err error) error {
+	var rateLimitErr *github.RateLimitError
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseRateLimitErr) {
+		return ErrRateLimited
+	}
+
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil {
+		if kind := statusKind(responseErr.Response.StatusCode); kind != nil {
+			return kind
+		}
+	}
+
+	// GraphQL errors only carry a message
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "rate limit"):
+		return ErrRateLimited
+	case strings.Contains(message, "could not resolve to"):
+		return ErrNotFound
+	case strings.Contains(message, "401 unauthorized"),
+		strings.Contains(message, "bad credentials"),
+		strings.Contains(message, "saml enforcement"),
+		strings.Contains(message, "403 forbidden"):
+		return ErrAuth
+	}
+
+	return nil
+}
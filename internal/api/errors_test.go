@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// errorResponse returns a REST response with the given status code, as attached to go-github errors
+func errorResponse(statusCode int) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	return &http.Response{StatusCode: statusCode, Request: req}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "REST unauthorized",
+			err:      &github.ErrorResponse{Response: errorResponse(http.StatusUnauthorized)},
+			expected: ErrAuth,
+		},
+		{
+			name:     "REST not found",
+			err:      &github.ErrorResponse{Response: errorResponse(http.StatusNotFound)},
+			expected: ErrNotFound,
+		},
+		{
+			name:     "REST rate limit",
+			err:      &github.RateLimitError{Response: errorResponse(http.StatusForbidden)},
+			expected: ErrRateLimited,
+		},
+		{
+			name:     "REST secondary rate limit",
+			err:      &github.AbuseRateLimitError{Response: errorResponse(http.StatusForbidden)},
+			expected: ErrRateLimited,
+		},
+		{
+			name:     "GraphQL missing repository",
+			err:      errors.New("Could not resolve to a Repository with the name 'owner/repo'."),
+			expected: ErrNotFound,
+		},
+		{
+			name:     "GraphQL bad credentials",
+			err:      errors.New(`non-200 OK status code: 401 Unauthorized body: "{\"message\":\"Bad credentials\"}"`),
+			expected: ErrAuth,
+		},
+		{
+			name:     "GraphQL SAML enforcement",
+			err:      errors.New("Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization."),
+			expected: ErrAuth,
+		},
+		{
+			name:     "GraphQL rate limit",
+			err:      errors.New("API rate limit exceeded for user ID 1."),
+			expected: ErrRateLimited,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("connection reset by peer"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified := classifyError(tt.err)
+
+			if classified.Error() != tt.err.Error() {
+				t.Errorf("Expected the message to be kept, got %q", classified.Error())
+			}
+			if !errors.Is(classified, tt.err) {
+				t.Error("Expected the classified error to wrap the original error")
+			}
+			if tt.expected == nil {
+				if classified != tt.err {
+					t.Errorf("Expected an unknown error to be returned unchanged, got %v", classified)
+				}
+				return
+			}
+			if !errors.Is(classified, tt.expected) {
+				t.Errorf("Expected error to match %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{fmt.Errorf("cannot access target repository: %w", classifyStatus(http.StatusForbidden, errors.New("forbidden"))), ErrorCodeAuth},
+		{fmt.Errorf("failed to query: %w", ErrNotFound), ErrorCodeNotFound},
+		{classifyStatus(http.StatusTooManyRequests, errors.New("too many requests")), ErrorCodeRateLimited},
+		{fmt.Errorf("%w: failed to retrieve target tags", ErrPartialData), ErrorCodePartialData},
+		{fmt.Errorf("validation did not finish within 1m0s: %w", ErrTimeout), ErrorCodeTimeout},
+		{classifyStatus(http.StatusInternalServerError, errors.New("server error")), ErrorCodeUnknown},
+		{errors.New("unknown"), ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.expected {
+			t.Errorf("ErrorCode(%v) = %q, want %q", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestResolveRepository_NotFoundError(t *testing.T) {
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		},
+	}
+
+	api := createTestAPI(mockTransport)
+	_, _, err := api.ResolveRepository(context.Background(), TargetClient, "testowner", "testrepo")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got: %v", err)
+	}
+}
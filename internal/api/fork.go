@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// GetForkParent retrieves the repository a repository was forked from using GraphQL, returning its owner/name
+// or an empty string when the repository is not a fork
+func (api *GitHubAPI) GetForkParent(ctx context.Context, clientType ClientType, owner, name string) (string, error) {
+	var query struct {
+		Repository struct {
+			IsFork bool
+			Parent *struct {
+				NameWithOwner string
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return "", err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s repository fork parent: %w", clientName, classifyError(err))
+	}
+
+	if !query.Repository.IsFork || query.Repository.Parent == nil {
+		return "", nil
+	}
+	return query.Repository.Parent.NameWithOwner, nil
+}
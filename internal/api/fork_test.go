@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetForkParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected string
+	}{
+		{
+			name:     "fork",
+			response: `{"data": {"repository": {"isFork": true, "parent": {"nameWithOwner": "upstream-org/project"}}}}`,
+			expected: "upstream-org/project",
+		},
+		{
+			name:     "not a fork",
+			response: `{"data": {"repository": {"isFork": false, "parent": null}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+				return tt.response
+			})
+
+			parent, err := api.GetForkParent(context.Background(), SourceClient, "owner", "repo")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, parent)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// resourceLimitMessages identify the GraphQL errors returned for queries whose cost exceeds GitHub's
+// resource limits, e.g. counting the connections of a very large repository in one query
+var resourceLimitMessages = []string{
+	"resource limits for this query exceeded",
+	"max_node_limit_exceeded",
+	"exceeds the maximum limit of",
+	"this may be the result of a timeout",
+}
+
+// isResourceLimitError reports whether err is a GraphQL error returned because the query was too costly
+func isResourceLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, limit := range resourceLimitMessages {
+		if strings.Contains(message, limit) {
+			return true
+		}
+	}
+	return false
+}
+
+// query runs q, requesting its rate limit cost along with it. A query exceeding the GraphQL resource limits
+// is split into one query per connection of its top-level field, which are run in turn.
+func (c *RateLimitAwareGraphQLClient) query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	err := c.queryWithCost(ctx, q, variables)
+	if !isResourceLimitError(err) {
+		return err
+	}
+
+	parts := splitQuery(q)
+	if len(parts) < 2 {
+		return fmt.Errorf("query exceeds the GraphQL resource limits and cannot be split further: %w", err)
+	}
+	for _, part := range parts {
+		if err := c.queryWithCost(ctx, part.query.Interface(), part.variables(variables)); err != nil {
+			if isResourceLimitError(err) {
+				return fmt.Errorf("query exceeds the GraphQL resource limits even when split into %d queries: %w", len(parts), err)
+			}
+			return err
+		}
+		part.merge(q)
+	}
+	return nil
+}
+
+// queryWithCost runs q with the rateLimit { cost } of the query requested in the same request, and adds the
+// cost to the client state
+func (c *RateLimitAwareGraphQLClient) queryWithCost(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	value := reflect.ValueOf(q)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return c.client.Query(ctx, q, variables)
+	}
+
+	// The query is embedded, so its fields are requested and decoded as if it were sent alone
+	type rateLimitCost struct {
+		Cost int
+	}
+	withCost := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Query", Type: value.Elem().Type(), Anonymous: true},
+		{Name: "RateLimit", Type: reflect.TypeOf(rateLimitCost{})},
+	}))
+
+	err := c.client.Query(ctx, withCost.Interface(), variables)
+	value.Elem().Set(withCost.Elem().Field(0))
+	if c.state != nil {
+		c.state.graphQLCost.Add(int64(withCost.Elem().Field(1).Interface().(rateLimitCost).Cost))
+	}
+	return err
+}
+
+// queryPart is a query requesting some of the fields of the top-level field of a split query
+type queryPart struct {
+	query  reflect.Value // Pointer to the part query
+	fields []int         // Indexes of the fields of the top-level field requested by the part
+}
+
+// splitQuery splits a query with one top-level field, such as repository, into one query per connection or
+// object requested in it; scalar fields are requested with the first part. Returns nil when the query
+// requests fewer than two connections.
+func splitQuery(q interface{}) []queryPart {
+	queryType := reflect.TypeOf(q)
+	if queryType.Kind() != reflect.Pointer || queryType.Elem().Kind() != reflect.Struct || queryType.Elem().NumField() != 1 {
+		return nil
+	}
+	top := queryType.Elem().Field(0)
+	if top.Type.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var scalars, objects []int
+	for i := 0; i < top.Type.NumField(); i++ {
+		if top.Type.Field(i).Type.Kind() == reflect.Struct {
+			objects = append(objects, i)
+		} else {
+			scalars = append(scalars, i)
+		}
+	}
+	if len(objects) < 2 {
+		return nil
+	}
+
+	parts := make([]queryPart, 0, len(objects))
+	for i, object := range objects {
+		fields := []int{object}
+		if i == 0 {
+			fields = append(append([]int(nil), scalars...), object)
+		}
+
+		partFields := make([]reflect.StructField, 0, len(fields))
+		for _, field := range fields {
+			partFields = append(partFields, top.Type.Field(field))
+		}
+		partTop := top
+		partTop.Type = reflect.StructOf(partFields)
+		parts = append(parts, queryPart{
+			query:  reflect.New(reflect.StructOf([]reflect.StructField{partTop})),
+			fields: fields,
+		})
+	}
+	return parts
+}
+
+// merge copies the fields requested by the part into q
+func (p queryPart) merge(q interface{}) {
+	top := reflect.ValueOf(q).Elem().Field(0)
+	partTop := p.query.Elem().Field(0)
+	for i, field := range p.fields {
+		top.Field(field).Set(partTop.Field(i))
+	}
+}
+
+// queryVariablePattern matches the variables used in the graphql tags of a query
+var queryVariablePattern = regexp.MustCompile(`\$(\w+)`)
+
+// variables returns the variables used by the part, as GraphQL rejects queries declaring unused variables
+func (p queryPart) variables(variables map[string]interface{}) map[string]interface{} {
+	used := make(map[string]interface{})
+	for _, name := range tagVariables(p.query.Type().Elem()) {
+		if value, ok := variables[name]; ok {
+			used[name] = value
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+	return used
+}
+
+// tagVariables returns the variables used in the graphql tags of t and of the types of its fields
+func tagVariables(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, match := range queryVariablePattern.FindAllStringSubmatch(field.Tag.Get("graphql"), -1) {
+			names = append(names, match[1])
+		}
+		names = append(names, tagVariables(field.Type)...)
+	}
+	return names
+}
+
+// GraphQLCost returns the cumulative rate limit cost of the GraphQL queries made so far with the given
+// client. It returns 0 for clients created without a client state.
+func (api *GitHubAPI) GraphQLCost(clientType ClientType) int {
+	if state := api.clientState(clientType); state != nil {
+		return int(state.graphQLCost.Load())
+	}
+	return 0
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLCost(t *testing.T) {
+	api := createGraphQLTestAPI(func(query string, variables map[string]interface{}) string {
+		assert.True(t, strings.HasSuffix(query, ",rateLimit{cost}}"), "the cost is requested with the query")
+		if strings.Contains(query, "pullRequests") {
+			return `{"data": {"repository": {"nameWithOwner": "owner/repo", "openPRs": {"totalCount": 1}, "mergedPRs": {"totalCount": 2}, "closedPRs": {"totalCount": 3}}, "rateLimit": {"cost": 3}}}`
+		}
+		return `{"data": {"repository": {"nameWithOwner": "owner/repo", "issues": {"totalCount": 5}}, "rateLimit": {"cost": 1}}}`
+	}, withSourceState())
+
+	issues, err := api.GetIssueCount(context.Background(), SourceClient, "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, 5, issues)
+
+	prs, err := api.GetPRCounts(context.Background(), SourceClient, "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, &PRCounts{Open: 1, Merged: 2, Closed: 3, Total: 6}, prs)
+
+	assert.Equal(t, 4, api.GraphQLCost(SourceClient))
+	assert.Equal(t, 0, api.GraphQLCost(TargetClient))
+}
+
+func TestGraphQLQuery_SplitsOnResourceLimits(t *testing.T) {
+	var queries []string
+	api := createGraphQLTestAPI(func(query string, variables map[string]interface{}) string {
+		queries = append(queries, query)
+		assert.Equal(t, map[string]interface{}{"owner": "owner", "name": "repo"}, variables)
+
+		switch {
+		case strings.Count(query, "pullRequests") > 1:
+			return `{"data": null, "errors": [{"type": "RESOURCE_LIMITS_EXCEEDED", "message": "Resource limits for this query exceeded."}]}`
+		case strings.Contains(query, "openPRs"):
+			assert.Contains(t, query, "nameWithOwner", "scalar fields are requested with the first part")
+			return `{"data": {"repository": {"nameWithOwner": "owner/repo", "openPRs": {"totalCount": 10}}, "rateLimit": {"cost": 2}}}`
+		case strings.Contains(query, "mergedPRs"):
+			return `{"data": {"repository": {"mergedPRs": {"totalCount": 20}}, "rateLimit": {"cost": 2}}}`
+		default:
+			return `{"data": {"repository": {"closedPRs": {"totalCount": 30}}, "rateLimit": {"cost": 2}}}`
+		}
+	}, withSourceState())
+
+	prs, err := api.GetPRCounts(context.Background(), SourceClient, "owner", "repo")
+
+	require.NoError(t, err)
+	assert.Equal(t, &PRCounts{Open: 10, Merged: 20, Closed: 30, Total: 60}, prs)
+	assert.Len(t, queries, 4, "the combined query and one query per connection")
+	assert.Equal(t, 6, api.GraphQLCost(SourceClient))
+}
+
+func TestGraphQLQuery_ResourceLimitsNotSplittable(t *testing.T) {
+	api := createGraphQLTestAPI(func(query string, variables map[string]interface{}) string {
+		return `{"data": null, "errors": [{"type": "MAX_NODE_LIMIT_EXCEEDED", "message": "This query requests up to 1,000,000 possible nodes which exceeds the maximum limit of 500,000."}]}`
+	}, withSourceState())
+	api.sourceClient = nil
+
+	_, err := api.GetIssueCount(context.Background(), SourceClient, "owner", "repo")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query exceeds the GraphQL resource limits and cannot be split further")
+}
+
+func TestIsResourceLimitError(t *testing.T) {
+	assert.True(t, isResourceLimitError(errors.New("Resource limits for this query exceeded.")))
+	assert.True(t, isResourceLimitError(errors.New("This query requests up to 600,000 possible nodes which exceeds the maximum limit of 500,000.")))
+	assert.True(t, isResourceLimitError(errors.New("Something went wrong while executing your query. This may be the result of a timeout, or it could be a GitHub bug.")))
+	assert.False(t, isResourceLimitError(errors.New("Could not resolve to a Repository with the name 'owner/repo'.")))
+	assert.False(t, isResourceLimitError(nil))
+}
+
+func TestSplitQuery(t *testing.T) {
+	var single struct {
+		Repository struct {
+			NameWithOwner string
+			Issues        struct {
+				TotalCount int
+			} `graphql:"issues(first: $first)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	assert.Nil(t, splitQuery(&single), "a query with one connection is not split")
+
+	var multiple struct {
+		Repository struct {
+			Issues struct {
+				TotalCount int
+			} `graphql:"issues(labels: $labels)"`
+			Refs struct {
+				TotalCount int
+			} `graphql:"refs(refPrefix: \"refs/tags/\")"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	parts := splitQuery(&multiple)
+	require.Len(t, parts, 2)
+
+	variables := map[string]interface{}{"owner": "owner", "name": "repo", "labels": []string{"bug"}}
+	assert.Equal(t, variables, parts[0].variables(variables))
+	assert.Equal(t, map[string]interface{}{"owner": "owner", "name": "repo"}, parts[1].variables(variables),
+		"variables used only by other parts are not declared")
+}
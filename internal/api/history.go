@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// HistorySampleSize is the number of most recent default branch commits whose authors and dates are compared
+// when history was rewritten
+const HistorySampleSize = 100
+
+// CommitIdentity is the author and authored date of a commit, which tools rewriting history such as
+// BFG or git lfs migrate keep while changing the commit SHA
+type CommitIdentity struct {
+	AuthorName   string    `json:"author_name"`
+	AuthorEmail  string    `json:"author_email"`
+	AuthoredDate time.Time `json:"authored_date"`
+}
+
+// GetCommitIdentities retrieves the author and authored date of the HistorySampleSize most recent commits
+// of the default branch using GraphQL, newest first
+func (api *GitHubAPI) GetCommitIdentities(ctx context.Context, clientType ClientType, owner, name string) ([]CommitIdentity, error) {
+	var query struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Target struct {
+					Commit struct {
+						History struct {
+							Nodes []struct {
+								AuthoredDate githubv4.DateTime
+								Author       *struct {
+									Name  string
+									Email string
+								}
+							}
+						} `graphql:"history(first: $first)"`
+					} `graphql:"... on Commit"`
+				}
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+		"first": githubv4.Int(HistorySampleSize),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository commit history: %w", clientName, classifyError(err))
+	}
+
+	nodes := query.Repository.DefaultBranchRef.Target.Commit.History.Nodes
+	identities := make([]CommitIdentity, 0, len(nodes))
+	for _, node := range nodes {
+		identity := CommitIdentity{AuthoredDate: node.AuthoredDate.UTC()}
+		if node.Author != nil {
+			identity.AuthorName, identity.AuthorEmail = node.Author.Name, node.Author.Email
+		}
+		identities = append(identities, identity)
+	}
+	return identities, nil
+}
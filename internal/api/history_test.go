@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetCommitIdentities(t *testing.T) {
+	var first interface{}
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		first = variables["first"]
+		return `{"data": {"repository": {"defaultBranchRef": {"target": {"history": {"nodes": [
+			{"authoredDate": "2025-03-02T10:00:00+01:00", "author": {"name": "Mona", "email": "mona@example.com"}},
+			{"authoredDate": "2025-03-01T09:00:00Z", "author": null}
+		]}}}}}}`
+	})
+
+	identities, err := api.GetCommitIdentities(context.Background(), SourceClient, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []CommitIdentity{
+		{AuthorName: "Mona", AuthorEmail: "mona@example.com", AuthoredDate: time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{AuthoredDate: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	if len(identities) != len(expected) {
+		t.Fatalf("Expected %d commits, got %d", len(expected), len(identities))
+	}
+	for i := range expected {
+		if identities[i] != expected[i] {
+			t.Errorf("Commit %d: expected %+v, got %+v", i+1, expected[i], identities[i])
+		}
+	}
+	if first != float64(HistorySampleSize) {
+		t.Errorf("Expected the %d most recent commits to be requested, got %v", HistorySampleSize, first)
+	}
+}
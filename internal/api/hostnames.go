@@ -0,0 +1,97 @@
+package api
+
+import (
+	"strings"
+)
+
+// dataResidencyDomain is the domain of the GitHub Enterprise Cloud tenants with data residency, e.g. octocorp.ghe.com
+const dataResidencyDomain = ".ghe.com"
+
+// hostnameURL returns the configured hostname as a URL without a trailing slash, https unless another scheme
+// is given, and https://github.com when no hostname is configured
+func hostnameURL(hostname string) string {
+	hostname = strings.TrimSuffix(hostname, "/")
+	switch {
+	case hostname == "":
+		return "https://github.com"
+	case strings.HasPrefix(hostname, "https://"), strings.HasPrefix(hostname, "http://"):
+		return hostname
+	default:
+		return "https://" + hostname
+	}
+}
+
+// IsDataResidencyHostname reports whether hostname is a GHE.com tenant with data residency, whose APIs are
+// served from its api. subdomain instead of under /api/ like GitHub Enterprise Server
+func IsDataResidencyHostname(hostname string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(hostnameURL(hostname), "https://"), "http://")
+	return strings.HasSuffix(strings.ToLower(host), dataResidencyDomain)
+}
+
+// webURL returns the URL of the web interface and git endpoints of hostname, also when the API subdomain of
+// a GHE.com tenant is configured
+func webURL(hostname string) string {
+	url := hostnameURL(hostname)
+	if IsDataResidencyHostname(hostname) {
+		url = strings.Replace(url, "://api.", "://", 1)
+	}
+	return url
+}
+
+// restBaseURL returns the REST API base URL of a configured hostname, with a trailing slash: the api.
+// subdomain of GHE.com tenants, or /api/v3/ of GitHub Enterprise Server
+func restBaseURL(hostname string) string {
+	if IsDataResidencyHostname(hostname) {
+		return strings.Replace(webURL(hostname), "://", "://api.", 1) + "/"
+	}
+	return hostnameURL(hostname) + "/api/v3/"
+}
+
+// graphQLURL returns the GraphQL endpoint of a configured hostname: the api. subdomain of GHE.com tenants, or
+// /api/graphql of GitHub Enterprise Server
+func graphQLURL(hostname string) string {
+	if IsDataResidencyHostname(hostname) {
+		return strings.Replace(webURL(hostname), "://", "://api.", 1) + "/graphql"
+	}
+	return hostnameURL(hostname) + "/api/graphql"
+}
+
+// restURL returns the REST API base URL of the configuration with a trailing slash: the --source-api-url or
+// --target-api-url override when set, or the URL derived from the hostname
+func (c ClientConfig) restURL() string {
+	if c.APIURL != "" {
+		return strings.TrimSuffix(c.APIURL, "/") + "/"
+	}
+	return restBaseURL(c.Hostname)
+}
+
+// graphQLEndpoint returns the GraphQL endpoint of the configuration. An API URL override ending in /api/v3,
+// as GitHub Enterprise Server behind a gateway, has its GraphQL API at /api/graphql; other API URLs at /graphql.
+func (c ClientConfig) graphQLEndpoint() string {
+	if c.APIURL != "" {
+		apiURL := strings.TrimSuffix(c.APIURL, "/")
+		if root, ok := strings.CutSuffix(apiURL, "/api/v3"); ok {
+			return root + "/api/graphql"
+		}
+		return apiURL + "/graphql"
+	}
+	return graphQLURL(c.Hostname)
+}
+
+// webRoot returns the URL of the git endpoints of the configuration, where LFS is served. An API URL override
+// is the web root without its /api/v3 path or its api. subdomain.
+func (c ClientConfig) webRoot() string {
+	if c.APIURL != "" {
+		apiURL := strings.TrimSuffix(c.APIURL, "/")
+		if root, ok := strings.CutSuffix(apiURL, "/api/v3"); ok {
+			return root
+		}
+		return strings.Replace(apiURL, "://api.", "://", 1)
+	}
+	return webURL(c.Hostname)
+}
+
+// usesEnterpriseURLs reports whether the configuration targets another instance than github.com
+func (c ClientConfig) usesEnterpriseURLs() bool {
+	return c.Hostname != "" || c.APIURL != ""
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestHostnameURLs(t *testing.T) {
+	tests := []struct {
+		hostname string
+		rest     string
+		graphQL  string
+		web      string
+	}{
+		{"github.example.com", "https://github.example.com/api/v3/", "https://github.example.com/api/graphql", "https://github.example.com"},
+		{"https://github.example.com/", "https://github.example.com/api/v3/", "https://github.example.com/api/graphql", "https://github.example.com"},
+		{"octocorp.ghe.com", "https://api.octocorp.ghe.com/", "https://api.octocorp.ghe.com/graphql", "https://octocorp.ghe.com"},
+		{"https://api.octocorp.ghe.com", "https://api.octocorp.ghe.com/", "https://api.octocorp.ghe.com/graphql", "https://octocorp.ghe.com"},
+		{"OctoCorp.GHE.com", "https://api.OctoCorp.GHE.com/", "https://api.OctoCorp.GHE.com/graphql", "https://OctoCorp.GHE.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			if got := restBaseURL(tt.hostname); got != tt.rest {
+				t.Errorf("restBaseURL() = %q, want %q", got, tt.rest)
+			}
+			if got := graphQLURL(tt.hostname); got != tt.graphQL {
+				t.Errorf("graphQLURL() = %q, want %q", got, tt.graphQL)
+			}
+			if got := webURL(tt.hostname); got != tt.web {
+				t.Errorf("webURL() = %q, want %q", got, tt.web)
+			}
+		})
+	}
+}
+
+func TestIsDataResidencyHostname(t *testing.T) {
+	if !IsDataResidencyHostname("https://octocorp.ghe.com/") {
+		t.Error("Expected a ghe.com tenant to use data residency")
+	}
+	if IsDataResidencyHostname("ghe.example.com") || IsDataResidencyHostname("") {
+		t.Error("Expected GitHub Enterprise Server and github.com not to use data residency")
+	}
+}
+
+func TestNewGitHubClient_DataResidency(t *testing.T) {
+	client, err := newGitHubClient(ClientConfig{Token: "token", Hostname: "octocorp.ghe.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.BaseURL.String() != "https://api.octocorp.ghe.com/" {
+		t.Errorf("Expected the REST API of the tenant, got %s", client.BaseURL)
+	}
+}
+
+func TestClientConfig_APIURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClientConfig
+		rest    string
+		graphQL string
+		web     string
+	}{
+		{"gateway in front of GHES", ClientConfig{Hostname: "github.example.com", APIURL: "https://gateway.example.com/ghes/api/v3/"},
+			"https://gateway.example.com/ghes/api/v3/", "https://gateway.example.com/ghes/api/graphql", "https://gateway.example.com/ghes"},
+		{"api subdomain", ClientConfig{APIURL: "https://api.proxy.example.com"},
+			"https://api.proxy.example.com/", "https://api.proxy.example.com/graphql", "https://proxy.example.com"},
+		{"hostname only", ClientConfig{Hostname: "github.example.com"},
+			"https://github.example.com/api/v3/", "https://github.example.com/api/graphql", "https://github.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.restURL(); got != tt.rest {
+				t.Errorf("restURL() = %q, want %q", got, tt.rest)
+			}
+			if got := tt.config.graphQLEndpoint(); got != tt.graphQL {
+				t.Errorf("graphQLEndpoint() = %q, want %q", got, tt.graphQL)
+			}
+			if got := tt.config.webRoot(); got != tt.web {
+				t.Errorf("webRoot() = %q, want %q", got, tt.web)
+			}
+		})
+	}
+}
+
+func TestNewGitHubClient_APIURL(t *testing.T) {
+	client, err := newGitHubClient(ClientConfig{Token: "token", APIURL: "https://gateway.example.com/github"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.BaseURL.String() != "https://gateway.example.com/github/" {
+		t.Errorf("Expected the API URL to be used as is, got %s", client.BaseURL)
+	}
+}
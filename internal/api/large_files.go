@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// LargeFile is a file of a repository with its size in bytes
+type LargeFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// GetLargeFiles lists the files of the default branch larger than minSize using the recursive tree REST API,
+// reporting whether the tree was truncated because it has too many entries to be listed in one response
+func (api *GitHubAPI) GetLargeFiles(clientType ClientType, owner, name string, minSize int64) ([]LargeFile, bool, error) {
+	ctx := context.Background()
+
+	defaultBranch, clientName, err := api.getDefaultBranchName(ctx, clientType, owner, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if defaultBranch == "" {
+		// Empty repositories have no files
+		return nil, false, nil
+	}
+
+	restClient, _, err := api.getRESTClient(clientType)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tree, _, err := restClient.Git.GetTree(ctx, owner, name, defaultBranch, true)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get %s repository tree: %w", clientName, classifyError(err))
+	}
+
+	var files []LargeFile
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" && int64(entry.GetSize()) > minSize {
+			files = append(files, LargeFile{Path: entry.GetPath(), Size: int64(entry.GetSize())})
+		}
+	}
+
+	return files, tree.GetTruncated(), nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLargeFiles(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var response string
+		switch req.URL.Path {
+		case "/graphql":
+			var body struct {
+				Query string `json:"query"`
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			response = rateLimitResponse
+			if !strings.HasPrefix(body.Query, "{rateLimit") {
+				response = `{"data": {"repository": {"defaultBranchRef": {"name": "main"}}}}`
+			}
+		case "/repos/owner/repo/git/trees/main":
+			if req.URL.Query().Get("recursive") == "" {
+				t.Error("Expected the tree to be listed recursively")
+			}
+			response = `{"sha": "tree", "truncated": true, "tree": [
+				{"path": "assets", "type": "tree", "sha": "assets"},
+				{"path": "assets/video.mp4", "type": "blob", "sha": "video", "size": 157286400},
+				{"path": "assets/logo.png", "type": "blob", "sha": "logo", "size": 20480},
+				{"path": "dist/bundle.zip", "type": "blob", "sha": "bundle", "size": 62914560}]}`
+		default:
+			t.Errorf("Unexpected API endpoint: %s", req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(response)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	})
+
+	client := &http.Client{Transport: transport}
+	api := &GitHubAPI{
+		sourceClient:      github.NewClient(client),
+		sourceGraphClient: &RateLimitAwareGraphQLClient{client: githubv4.NewClient(client)},
+	}
+
+	files, truncated, err := api.GetLargeFiles(SourceClient, "owner", "repo", 50<<20)
+
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, []LargeFile{
+		{Path: "assets/video.mp4", Size: 157286400},
+		{Path: "dist/bundle.zip", Size: 62914560},
+	}, files)
+}
@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v62/github"
 	"github.com/shurcooL/githubv4"
@@ -54,35 +56,15 @@ type LFSAction struct {
 
 // GetLFSObjects retrieves all LFS objects (OIDs) referenced in the repository
 // by first reading .gitattributes to find LFS-tracked patterns, then only
-// checking files that match those patterns for LFS pointer content
-func (api *GitHubAPI) GetLFSObjects(clientType ClientType, owner, name string) ([]LFSObject, error) {
-	ctx := context.Background()
-
+// checking files that match those patterns for LFS pointer content. The tree is walked
+// directory by directory when it is too large to be listed at once, reporting the entries
+// walked so far to progress when it is set.
+func (api *GitHubAPI) GetLFSObjects(ctx context.Context, clientType ClientType, owner, name string, progress TreeProgressFunc) ([]LFSObject, error) {
 	// First, get the default branch to know which ref to query
-	var repoQuery struct {
-		Repository struct {
-			DefaultBranchRef struct {
-				Name string
-			}
-		} `graphql:"repository(owner: $owner, name: $name)"`
-	}
-
-	variables := map[string]interface{}{
-		"owner": githubv4.String(owner),
-		"name":  githubv4.String(name),
-	}
-
-	client, clientName, err := api.getGraphQLClient(clientType)
+	defaultBranch, clientName, err := api.getDefaultBranchName(ctx, clientType, owner, name)
 	if err != nil {
 		return nil, err
 	}
-
-	err = client.Query(ctx, &repoQuery, variables)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query %s repository default branch: %v", clientName, err)
-	}
-
-	defaultBranch := repoQuery.Repository.DefaultBranchRef.Name
 	if defaultBranch == "" {
 		// Repository might be empty or have no default branch
 		return []LFSObject{}, nil
@@ -93,13 +75,14 @@ func (api *GitHubAPI) GetLFSObjects(clientType ClientType, owner, name string) (
 	if err != nil {
 		return nil, err
 	}
+	maxEntries := api.clientConfig(clientType).MaxTreeEntries
 
 	// Get LFS-tracked patterns from .gitattributes
 	lfsPatterns, err := api.getLFSPatternsFromGitAttributes(ctx, restClient, owner, name, defaultBranch)
 	if err != nil {
 		// If we can't read .gitattributes, fall back to checking all small files
 		// This is not an error - the repo might not use LFS or might not have .gitattributes
-		return api.getLFSObjectsFallback(ctx, restClient, owner, name, defaultBranch, clientName)
+		return api.getLFSObjectsFallback(ctx, restClient, owner, name, defaultBranch, clientName, maxEntries, progress)
 	}
 
 	// If no LFS patterns found, return empty list
@@ -107,73 +90,139 @@ func (api *GitHubAPI) GetLFSObjects(clientType ClientType, owner, name string) (
 		return []LFSObject{}, nil
 	}
 
-	lfsObjects := make([]LFSObject, 0)
-	seenOIDs := make(map[string]bool) // Track OIDs to deduplicate
-
-	// Get the repository tree recursively
-	tree, _, err := restClient.Git.GetTree(ctx, owner, name, defaultBranch, true)
+	pointers := newLFSPointerCollector(ctx, restClient, owner, name)
+	err = walkTree(ctx, restClient, owner, name, defaultBranch, maxEntries, progress, func(filePath string, entry *github.TreeEntry) error {
+		// Check if this file matches any LFS pattern
+		if matchesLFSPattern(filePath, lfsPatterns) {
+			pointers.add(entry)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get %s repository tree: %v", clientName, err)
+		return nil, fmt.Errorf("failed to get %s repository tree: %w", clientName, err)
 	}
 
-	// Process each file in the tree
-	for _, entry := range tree.Entries {
-		// Only process blob entries (files)
-		if entry.GetType() != "blob" {
-			continue
-		}
+	return pointers.objects, nil
+}
 
-		// Check if this file matches any LFS pattern
-		filePath := entry.GetPath()
-		if !matchesLFSPattern(filePath, lfsPatterns) {
-			continue
-		}
+// lfsPointerCollector collects the LFS objects of the pointer files of a repository, deduplicated by OID
+type lfsPointerCollector struct {
+	ctx         context.Context
+	restClient  *github.Client
+	owner, name string
+	objects     []LFSObject
+	seenOIDs    map[string]bool
+}
+
+// newLFSPointerCollector returns a collector of the LFS pointers of the repository
+func newLFSPointerCollector(ctx context.Context, restClient *github.Client, owner, name string) *lfsPointerCollector {
+	return &lfsPointerCollector{ctx: ctx, restClient: restClient, owner: owner, name: name,
+		objects: make([]LFSObject, 0), seenOIDs: make(map[string]bool)}
+}
+
+// add reads the blob of a tree entry and collects its LFS object when it is an LFS pointer file. Blobs that
+// cannot be read or decoded are skipped.
+func (c *lfsPointerCollector) add(entry *github.TreeEntry) {
+	// Get the blob content to extract the OID
+	blob, _, err := c.restClient.Git.GetBlob(c.ctx, c.owner, c.name, entry.GetSHA())
+	if err != nil {
+		// Skip files we can't read
+		return
+	}
 
-		// Get the blob content to extract the OID
-		blob, _, err := restClient.Git.GetBlob(ctx, owner, name, entry.GetSHA())
+	// Decode the blob content if it's base64 encoded
+	content := blob.GetContent()
+	if blob.GetEncoding() == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(content)
 		if err != nil {
-			// Skip files we can't read
-			continue
+			// Skip files we can't decode
+			return
 		}
+		content = string(decoded)
+	}
 
-		// Decode the blob content if it's base64 encoded
-		content := blob.GetContent()
-		if blob.GetEncoding() == "base64" {
-			decoded, err := base64.StdEncoding.DecodeString(content)
-			if err != nil {
-				// Skip files we can't decode
-				continue
-			}
-			content = string(decoded)
-		}
+	// If content is empty, the LFS pointer was not migrated properly
+	// LFS pointer files should always have content (version, oid, size)
+	if strings.TrimSpace(content) == "" {
+		// This could be a missing LFS object, but we can't extract the OID
+		// Skip for now as we can't add it to the validation list
+		return
+	}
 
-		// If content is empty, the LFS pointer was not migrated properly
-		// LFS pointer files should always have content (version, oid, size)
-		if strings.TrimSpace(content) == "" {
-			// This could be a missing LFS object, but we can't extract the OID
-			// Skip for now as we can't add it to the validation list
-			continue
+	// Check if this is an LFS pointer file and extract the OID
+	if lfsObj, isLFS := parseLFSPointer(content); isLFS {
+		// Deduplicate by OID
+		if !c.seenOIDs[lfsObj.OID] {
+			c.objects = append(c.objects, lfsObj)
+			c.seenOIDs[lfsObj.OID] = true
 		}
+	}
+}
+
+// GetLFSPatterns retrieves the LFS-tracked file patterns declared in .gitattributes on the default branch,
+// returning an empty list when the repository is empty or has no .gitattributes
+func (api *GitHubAPI) GetLFSPatterns(ctx context.Context, clientType ClientType, owner, name string) ([]string, error) {
+	defaultBranch, clientName, err := api.getDefaultBranchName(ctx, clientType, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	if defaultBranch == "" {
+		return []string{}, nil
+	}
 
-		// Check if this is an LFS pointer file and extract the OID
-		if lfsObj, isLFS := parseLFSPointer(content); isLFS {
-			// Deduplicate by OID
-			if !seenOIDs[lfsObj.OID] {
-				lfsObjects = append(lfsObjects, lfsObj)
-				seenOIDs[lfsObj.OID] = true
+	restClient, _, err := api.getRESTClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, err := api.getLFSPatternsFromGitAttributes(ctx, restClient, owner, name, defaultBranch)
+	if errors.Is(err, errGitAttributesNotFound) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s repository LFS patterns: %w", clientName, err)
+	}
+	return patterns, nil
+}
+
+// getDefaultBranchName returns the name of the default branch of a repository, or an empty string when the
+// repository has none, along with the name of the client used
+func (api *GitHubAPI) getDefaultBranchName(ctx context.Context, clientType ClientType, owner, name string) (string, string, error) {
+	var repoQuery struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Name string
 			}
-		}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = client.Query(ctx, &repoQuery, variables)
+	if err != nil {
+		return "", clientName, fmt.Errorf("failed to query %s repository default branch: %w", clientName, classifyError(err))
 	}
 
-	return lfsObjects, nil
+	return repoQuery.Repository.DefaultBranchRef.Name, clientName, nil
 }
 
+// errGitAttributesNotFound is returned when the repository has no .gitattributes file at its root
+var errGitAttributesNotFound = errors.New(".gitattributes not found")
+
 // getLFSPatternsFromGitAttributes reads .gitattributes and extracts LFS-tracked file patterns
 func (api *GitHubAPI) getLFSPatternsFromGitAttributes(ctx context.Context, restClient *github.Client, owner, name, ref string) ([]string, error) {
 	// Get the tree to find .gitattributes
 	tree, _, err := restClient.Git.GetTree(ctx, owner, name, ref, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository tree: %v", err)
+		return nil, fmt.Errorf("failed to get repository tree: %w", classifyError(err))
 	}
 
 	// Look for .gitattributes in the tree
@@ -186,13 +235,13 @@ func (api *GitHubAPI) getLFSPatternsFromGitAttributes(ctx context.Context, restC
 	}
 
 	if gitAttributesSHA == "" {
-		return nil, fmt.Errorf(".gitattributes not found")
+		return nil, errGitAttributesNotFound
 	}
 
 	// Get the blob content
 	blob, _, err := restClient.Git.GetBlob(ctx, owner, name, gitAttributesSHA)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get .gitattributes blob: %v", err)
+		return nil, fmt.Errorf("failed to get .gitattributes blob: %w", classifyError(err))
 	}
 
 	// Decode the content
@@ -268,65 +317,24 @@ func matchesLFSPattern(filePath string, patterns []string) bool {
 
 // getLFSObjectsFallback is the original implementation that checks all small files
 // Used as a fallback when .gitattributes cannot be read
-func (api *GitHubAPI) getLFSObjectsFallback(ctx context.Context, restClient *github.Client, owner, name, ref, clientName string) ([]LFSObject, error) {
-	lfsObjects := make([]LFSObject, 0)
-	seenOIDs := make(map[string]bool)
-
-	// Get the repository tree recursively
-	tree, _, err := restClient.Git.GetTree(ctx, owner, name, ref, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get %s repository tree: %v", clientName, err)
-	}
-
+func (api *GitHubAPI) getLFSObjectsFallback(ctx context.Context, restClient *github.Client, owner, name, ref, clientName string,
+	maxEntries int, progress TreeProgressFunc) ([]LFSObject, error) {
 	// LFS pointer files are always small (less than 200 bytes)
 	const maxLFSPointerSize = 200
 
-	// Process each file in the tree
-	for _, entry := range tree.Entries {
-		// Only process blob entries (files)
-		if entry.GetType() != "blob" {
-			continue
-		}
-
+	pointers := newLFSPointerCollector(ctx, restClient, owner, name)
+	err := walkTree(ctx, restClient, owner, name, ref, maxEntries, progress, func(filePath string, entry *github.TreeEntry) error {
 		// Skip files that are too large to be LFS pointers
-		if entry.Size != nil && *entry.Size > maxLFSPointerSize {
-			continue
-		}
-
-		// Get the blob content
-		blob, _, err := restClient.Git.GetBlob(ctx, owner, name, entry.GetSHA())
-		if err != nil {
-			// Skip files we can't read
-			continue
-		}
-
-		// Decode the blob content if it's base64 encoded
-		content := blob.GetContent()
-		if blob.GetEncoding() == "base64" {
-			decoded, err := base64.StdEncoding.DecodeString(content)
-			if err != nil {
-				// Skip files we can't decode
-				continue
-			}
-			content = string(decoded)
-		}
-
-		// If content is empty, skip
-		if strings.TrimSpace(content) == "" {
-			continue
-		}
-
-		// Check if this is an LFS pointer file
-		if lfsObj, isLFS := parseLFSPointer(content); isLFS {
-			// Deduplicate by OID
-			if !seenOIDs[lfsObj.OID] {
-				lfsObjects = append(lfsObjects, lfsObj)
-				seenOIDs[lfsObj.OID] = true
-			}
+		if entry.Size == nil || *entry.Size <= maxLFSPointerSize {
+			pointers.add(entry)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s repository tree: %w", clientName, err)
 	}
 
-	return lfsObjects, nil
+	return pointers.objects, nil
 }
 
 // parseLFSPointer parses a blob content to check if it's an LFS pointer file
@@ -375,27 +383,141 @@ func parseLFSPointer(content string) (LFSObject, bool) {
 	return LFSObject{OID: oid, Size: size}, true
 }
 
-// ValidateLFSObjects checks if the given LFS objects exist in the repository
-// using the Git LFS Batch API
-func (api *GitHubAPI) ValidateLFSObjects(clientType ClientType, owner, name string, objects []LFSObject) (int, int, error) {
+// Default LFS batch settings, used unless --lfs-batch-size or --lfs-concurrency are set
+const (
+	defaultLFSBatchSize   = 100
+	defaultLFSConcurrency = 4
+)
+
+// LFSBatchConfig controls how LFS objects are checked with the LFS batch API
+type LFSBatchConfig struct {
+	ChunkSize   int // Objects per batch request
+	Concurrency int // Batch requests sent at the same time
+}
+
+// DefaultLFSBatchConfig returns the LFS batch configuration used when none is configured
+func DefaultLFSBatchConfig() LFSBatchConfig {
+	return LFSBatchConfig{ChunkSize: defaultLFSBatchSize, Concurrency: defaultLFSConcurrency}
+}
+
+// withDefaults replaces unset or invalid settings with their defaults
+func (c LFSBatchConfig) withDefaults() LFSBatchConfig {
+	if c.ChunkSize <= 0 {
+		c.ChunkSize = defaultLFSBatchSize
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultLFSConcurrency
+	}
+	return c
+}
+
+// LFSBatchError reports the chunks of LFS objects whose batch request failed. The objects of the other
+// chunks were checked and are counted as usual.
+type LFSBatchError struct {
+	FailedChunks int   // Batch requests that failed
+	Chunks       int   // Batch requests sent
+	Unchecked    int   // Objects of the failed chunks, counted neither as existing nor as missing
+	Err          error // Error of the first failed chunk
+}
+
+func (e *LFSBatchError) Error() string {
+	return fmt.Sprintf("%d of %d LFS batch requests failed, %d objects not checked: %v", e.FailedChunks, e.Chunks, e.Unchecked, e.Err)
+}
+
+func (e *LFSBatchError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateLFSObjects checks if the given LFS objects exist in the repository using the Git LFS Batch API of
+// its GitHub instance, or of the standalone LFS server configured for the side.
+// The objects are sent in chunks, several at a time, and failed requests are retried by the client
+// transport. When some chunks still fail, the counts of the other chunks are returned with an *LFSBatchError.
+func (api *GitHubAPI) ValidateLFSObjects(ctx context.Context, clientType ClientType, owner, name string, objects []LFSObject) (int, int, error) {
 	if len(objects) == 0 {
 		return 0, 0, nil
 	}
 
-	config := getClientConfigForType(clientType)
+	config := api.clientConfig(clientType)
+	lfsURL := lfsEndpoint(config, owner, name) + "/objects/batch"
+
+	// Execute the requests using authenticated client
+	httpClient, err := createLFSClient(config)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create authenticated client: %v", err)
+	}
+
+	return checkLFSChunks(objects, config.LFSBatch.withDefaults(), func(chunk []LFSObject) (int, int, error) {
+		return requestLFSBatch(ctx, httpClient, lfsURL, chunk)
+	})
+}
+
+// lfsEndpoint returns the LFS endpoint of a repository: its standalone LFS server when one is configured for
+// the side, or the LFS endpoint of its GitHub instance
+func lfsEndpoint(config ClientConfig, owner, name string) string {
+	if config.LFSServer.URL != "" {
+		endpoint := strings.NewReplacer("{owner}", owner, "{repo}", name).Replace(config.LFSServer.URL)
+		return strings.TrimSuffix(endpoint, "/")
+	}
 
-	// Construct the LFS batch API URL
-	var lfsURL string
-	if config.Hostname != "" {
-		hostname := strings.TrimSuffix(config.Hostname, "/")
-		if !strings.HasPrefix(hostname, "https://") {
-			hostname = "https://" + hostname
+	return fmt.Sprintf("%s/%s/%s.git/info/lfs", config.webRoot(), owner, name)
+}
+
+// checkLFSChunks splits objects into chunks of config.ChunkSize, checks up to config.Concurrency chunks at a
+// time with check, and adds up their existing and missing counts
+func checkLFSChunks(objects []LFSObject, config LFSBatchConfig, check func([]LFSObject) (int, int, error)) (int, int, error) {
+	var chunks [][]LFSObject
+	for start := 0; start < len(objects); start += config.ChunkSize {
+		chunks = append(chunks, objects[start:min(start+config.ChunkSize, len(objects))])
+	}
+
+	type chunkResult struct {
+		existing, missing int
+		err               error
+	}
+	results := make([]chunkResult, len(chunks))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, config.Concurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			existing, missing, err := check(chunk)
+			results[i] = chunkResult{existing: existing, missing: missing, err: err}
+		}()
+	}
+	wg.Wait()
+
+	existingCount, missingCount := 0, 0
+	var batchErr *LFSBatchError
+	for i, result := range results {
+		if result.err != nil {
+			if batchErr == nil {
+				batchErr = &LFSBatchError{Chunks: len(chunks), Err: result.err}
+			}
+			batchErr.FailedChunks++
+			batchErr.Unchecked += len(chunks[i])
+			continue
 		}
-		lfsURL = fmt.Sprintf("%s/%s/%s.git/info/lfs/objects/batch", hostname, owner, name)
-	} else {
-		lfsURL = fmt.Sprintf("https://github.com/%s/%s.git/info/lfs/objects/batch", owner, name)
+		existingCount += result.existing
+		missingCount += result.missing
 	}
 
+	// A single request keeps its error as it was
+	if batchErr != nil && len(chunks) == 1 {
+		return 0, 0, batchErr.Err
+	}
+	if batchErr != nil {
+		return existingCount, missingCount, batchErr
+	}
+	return existingCount, missingCount, nil
+}
+
+// requestLFSBatch sends one LFS batch request for objects and counts the objects that exist and are missing
+func requestLFSBatch(ctx context.Context, httpClient *http.Client, lfsURL string, objects []LFSObject) (int, int, error) {
 	// Create the batch request
 	batchReq := LFSBatchRequest{
 		Operation: "download",
@@ -409,7 +531,7 @@ func (api *GitHubAPI) ValidateLFSObjects(clientType ClientType, owner, name stri
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("POST", lfsURL, bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", lfsURL, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to create LFS batch request: %v", err)
 	}
@@ -417,12 +539,6 @@ func (api *GitHubAPI) ValidateLFSObjects(clientType ClientType, owner, name stri
 	req.Header.Set("Accept", "application/vnd.git-lfs+json")
 	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
 
-	// Execute the request using authenticated client
-	httpClient, err := createAuthenticatedClient(config)
-	if err != nil {
-		return 0, 0, fmt.Errorf("failed to create authenticated client: %v", err)
-	}
-
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to execute LFS batch request: %v", err)
@@ -431,7 +547,8 @@ func (api *GitHubAPI) ValidateLFSObjects(clientType ClientType, owner, name stri
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return 0, 0, fmt.Errorf("LFS batch API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		err := fmt.Errorf("LFS batch API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return 0, 0, classifyStatus(resp.StatusCode, err)
 	}
 
 	// Parse the response
@@ -465,8 +582,8 @@ func (api *GitHubAPI) ValidateLFSObjects(clientType ClientType, owner, name stri
 }
 
 // GetLFSObjectCount is a convenience method that gets LFS objects and returns the count
-func (api *GitHubAPI) GetLFSObjectCount(clientType ClientType, owner, name string) (int, error) {
-	objects, err := api.GetLFSObjects(clientType, owner, name)
+func (api *GitHubAPI) GetLFSObjectCount(ctx context.Context, clientType ClientType, owner, name string, progress TreeProgressFunc) (int, error) {
+	objects, err := api.GetLFSObjects(ctx, clientType, owner, name, progress)
 	if err != nil {
 		return 0, err
 	}
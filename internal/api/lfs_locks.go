@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// LFSLock is a file locked with the Git LFS locking API
+type LFSLock struct {
+	ID       string    `json:"id"`
+	Path     string    `json:"path"`
+	LockedAt time.Time `json:"locked_at"`
+	Owner    struct {
+		Name string `json:"name"`
+	} `json:"owner"`
+}
+
+// lfsLocksPageSize is the number of locks requested per page
+const lfsLocksPageSize = 100
+
+// lfsLocksResponse is a page of the LFS locks list
+type lfsLocksResponse struct {
+	Locks      []LFSLock `json:"locks"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+// GetLFSLocks lists the LFS file locks of the repository, from its GitHub instance or the standalone LFS
+// server configured for the side
+func (api *GitHubAPI) GetLFSLocks(ctx context.Context, clientType ClientType, owner, name string) ([]LFSLock, error) {
+	config := api.clientConfig(clientType)
+	locksURL := lfsEndpoint(config, owner, name) + "/locks"
+
+	httpClient, err := createLFSClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticated client: %v", err)
+	}
+
+	locks := make([]LFSLock, 0)
+	cursor := ""
+	for {
+		query := url.Values{"limit": {fmt.Sprint(lfsLocksPageSize)}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		page, err := requestLFSLocks(ctx, httpClient, locksURL+"?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, page.Locks...)
+
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return locks, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// requestLFSLocks requests one page of the LFS locks list
+func requestLFSLocks(ctx context.Context, httpClient *http.Client, locksURL string) (*lfsLocksResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, locksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LFS locks request: %v", err)
+	}
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute LFS locks request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("LFS locks API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, classifyStatus(resp.StatusCode, err)
+	}
+
+	var page lfsLocksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode LFS locks response: %v", err)
+	}
+	return &page, nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLFSLocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/org/repo.git/info/lfs/locks", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "application/vnd.git-lfs+json", r.Header.Get("Accept"))
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"locks": [{"id": "1", "path": "art/hero.psd", "locked_at": "2026-01-02T15:04:05Z", "owner": {"name": "octocat"}}], "next_cursor": "page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"locks": [{"id": "2", "path": "art/villain.psd", "owner": {"name": "hubot"}}]}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{targetConfig: ClientConfig{Token: "token", Hostname: server.URL}}
+	locks, err := api.GetLFSLocks(context.Background(), TargetClient, "org", "repo")
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	assert.Equal(t, "art/hero.psd", locks[0].Path)
+	assert.Equal(t, "octocat", locks[0].Owner.Name)
+	assert.Equal(t, "art/villain.psd", locks[1].Path)
+}
+
+func TestGetLFSLocks_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Must have push access to view locks"}`)
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{sourceConfig: ClientConfig{Token: "token", Hostname: server.URL}}
+	_, err := api.GetLFSLocks(context.Background(), SourceClient, "org", "repo")
+	assert.ErrorIs(t, err, ErrAuth)
+	assert.ErrorContains(t, err, "LFS locks API returned status 403")
+}
+
+func TestGetLFSLocks_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request is sent once the context is cancelled")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	api := &GitHubAPI{targetConfig: ClientConfig{Token: "token", Hostname: server.URL}}
+	_, err := api.GetLFSLocks(ctx, TargetClient, "org", "repo")
+	assert.ErrorContains(t, err, "context canceled")
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LFSServerConfig is a standalone LFS server, such as Artifactory or lfs-test-server, storing the LFS objects
+// of a side instead of its GitHub instance
+type LFSServerConfig struct {
+	// URL is the LFS endpoint of the repository, as set with lfs.url in Git, e.g.
+	// https://artifactory.example.com/artifactory/api/lfs/lfs-local. {owner} and {repo} are replaced with the
+	// repository owner and name.
+	URL      string
+	Username string // Sent with Token as basic authentication, when set
+	Token    string // Sent as a bearer token, or as the basic authentication password with Username
+}
+
+// LFSServerConfigured reports whether the LFS objects of a side are checked on a standalone LFS server
+func (api *GitHubAPI) LFSServerConfigured(clientType ClientType) bool {
+	return api.clientConfig(clientType).LFSServer.URL != ""
+}
+
+// createLFSClient returns the client sending LFS API requests: the GitHub client, or for a standalone LFS
+// server a client with the LFS server credentials only, so GitHub credentials are never sent to it
+func createLFSClient(config ClientConfig) (*http.Client, error) {
+	if config.LFSServer.URL == "" {
+		return createAuthenticatedClient(config)
+	}
+
+	transport := withUserAgent(http.DefaultTransport, config)
+	if config.ReplayDir != "" {
+		replay, err := newReplayTransport(config.ReplayDir)
+		if err != nil {
+			return nil, err
+		}
+		transport = replay
+	} else if config.RecordDir != "" {
+		transport = &recordingTransport{base: transport, dir: config.RecordDir}
+	}
+
+	transport = &lfsServerTransport{base: transport, server: config.LFSServer}
+	if config.state != nil {
+		transport = &stateTransport{base: transport, state: config.state}
+	}
+	return &http.Client{Transport: newRetryTransport(transport, config.Retry)}, nil
+}
+
+// lfsServerTransport authenticates requests to a standalone LFS server
+type lfsServerTransport struct {
+	base   http.RoundTripper
+	server LFSServerConfig
+}
+
+func (t *lfsServerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.server.Token == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	if t.server.Username != "" {
+		req.SetBasicAuth(t.server.Username, t.server.Token)
+	} else {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.server.Token))
+	}
+	return t.base.RoundTrip(req)
+}
@@ -1,9 +1,23 @@
 package api
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseLFSPointer_ValidPointer(t *testing.T) {
@@ -434,3 +448,188 @@ func TestLFSBatchResponse_ValidationLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLFSPatterns(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultBranch string
+		tree          string
+		expected      []string
+	}{
+		{
+			name:          "patterns from .gitattributes",
+			defaultBranch: "main",
+			tree:          `{"sha": "tree", "tree": [{"path": ".gitattributes", "type": "blob", "sha": "attributes"}, {"path": "README.md", "type": "blob", "sha": "readme"}]}`,
+			expected:      []string{"*.psd", "assets/**"},
+		},
+		{
+			name:          "no .gitattributes",
+			defaultBranch: "main",
+			tree:          `{"sha": "tree", "tree": [{"path": "README.md", "type": "blob", "sha": "readme"}]}`,
+			expected:      []string{},
+		},
+		{
+			name:     "empty repository",
+			expected: []string{},
+		},
+	}
+
+	attributes := base64.StdEncoding.EncodeToString([]byte("*.psd filter=lfs diff=lfs merge=lfs -text\n*.txt text\nassets/** filter=lfs diff=lfs merge=lfs -text\n"))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				var response string
+				switch req.URL.Path {
+				case "/graphql":
+					var body struct {
+						Query string `json:"query"`
+					}
+					json.NewDecoder(req.Body).Decode(&body)
+					response = rateLimitResponse
+					if !strings.HasPrefix(body.Query, "{rateLimit") {
+						response = fmt.Sprintf(`{"data": {"repository": {"defaultBranchRef": {"name": %q}}}}`, tt.defaultBranch)
+					}
+				case "/repos/owner/repo/git/trees/main":
+					response = tt.tree
+				case "/repos/owner/repo/git/blobs/attributes":
+					response = fmt.Sprintf(`{"sha": "attributes", "encoding": "base64", "content": %q}`, attributes)
+				default:
+					t.Errorf("Unexpected API endpoint: %s", req.URL.Path)
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(response)),
+					Header:     make(http.Header),
+					Request:    req,
+				}, nil
+			})
+
+			client := &http.Client{Transport: transport}
+			api := &GitHubAPI{
+				sourceClient:      github.NewClient(client),
+				sourceGraphClient: &RateLimitAwareGraphQLClient{client: githubv4.NewClient(client)},
+			}
+
+			patterns, err := api.GetLFSPatterns(context.Background(), SourceClient, "owner", "repo")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, patterns)
+		})
+	}
+}
+
+// lfsObjects returns count LFS objects with distinct OIDs
+func lfsObjects(count int) []LFSObject {
+	objects := make([]LFSObject, count)
+	for i := range objects {
+		objects[i] = LFSObject{OID: fmt.Sprintf("%064d", i), Size: int64(i)}
+	}
+	return objects
+}
+
+func TestCheckLFSChunks(t *testing.T) {
+	var mu sync.Mutex
+	var chunkSizes []int
+	active, maxActive := 0, 0
+
+	existing, missing, err := checkLFSChunks(lfsObjects(250), LFSBatchConfig{ChunkSize: 100, Concurrency: 2}, func(chunk []LFSObject) (int, int, error) {
+		mu.Lock()
+		chunkSizes = append(chunkSizes, len(chunk))
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return len(chunk) - 1, 1, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 247, existing)
+	assert.Equal(t, 3, missing)
+	assert.ElementsMatch(t, []int{100, 100, 50}, chunkSizes)
+	assert.LessOrEqual(t, maxActive, 2, "no more chunks than the concurrency are checked at a time")
+}
+
+func TestCheckLFSChunks_FailedChunks(t *testing.T) {
+	chunkErr := fmt.Errorf("LFS batch API returned status 429: %w", ErrRateLimited)
+
+	existing, missing, err := checkLFSChunks(lfsObjects(25), LFSBatchConfig{ChunkSize: 10, Concurrency: 4}, func(chunk []LFSObject) (int, int, error) {
+		if chunk[0].OID == fmt.Sprintf("%064d", 10) {
+			return 0, 0, chunkErr
+		}
+		return len(chunk), 0, nil
+	})
+
+	assert.Equal(t, 15, existing, "the objects of the other chunks are counted")
+	assert.Equal(t, 0, missing)
+
+	var batchErr *LFSBatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 1, batchErr.FailedChunks)
+	assert.Equal(t, 3, batchErr.Chunks)
+	assert.Equal(t, 10, batchErr.Unchecked)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, "1 of 3 LFS batch requests failed, 10 objects not checked: LFS batch API returned status 429: "+ErrRateLimited.Error(), err.Error())
+}
+
+func TestCheckLFSChunks_SingleChunkError(t *testing.T) {
+	chunkErr := fmt.Errorf("LFS batch API returned status 404: %w", ErrNotFound)
+
+	_, _, err := checkLFSChunks(lfsObjects(5), DefaultLFSBatchConfig(), func(chunk []LFSObject) (int, int, error) {
+		return 0, 0, chunkErr
+	})
+	assert.Equal(t, chunkErr, err, "a single request keeps its error")
+}
+
+func TestLFSEndpoint(t *testing.T) {
+	assert.Equal(t, "https://github.com/org/repo.git/info/lfs", lfsEndpoint(ClientConfig{}, "org", "repo"))
+	assert.Equal(t, "https://github.example.com/org/repo.git/info/lfs", lfsEndpoint(ClientConfig{Hostname: "github.example.com/"}, "org", "repo"))
+	assert.Equal(t, "http://localhost:8080/org/repo.git/info/lfs", lfsEndpoint(ClientConfig{Hostname: "http://localhost:8080"}, "org", "repo"))
+	assert.Equal(t, "https://octocorp.ghe.com/org/repo.git/info/lfs", lfsEndpoint(ClientConfig{Hostname: "api.octocorp.ghe.com"}, "org", "repo"))
+	assert.Equal(t, "https://artifactory.example.com/artifactory/api/lfs/lfs-local",
+		lfsEndpoint(ClientConfig{LFSServer: LFSServerConfig{URL: "https://artifactory.example.com/artifactory/api/lfs/lfs-local/"}}, "org", "repo"))
+	assert.Equal(t, "https://lfs.example.com/org/repo",
+		lfsEndpoint(ClientConfig{Hostname: "github.example.com", LFSServer: LFSServerConfig{URL: "https://lfs.example.com/{owner}/{repo}"}}, "org", "repo"))
+}
+
+func TestValidateLFSObjects_Chunked(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/org/repo.git/info/lfs/objects/batch", r.URL.Path)
+
+		var batch LFSBatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		assert.LessOrEqual(t, len(batch.Objects), 2)
+
+		response := LFSBatchResponse{}
+		for _, object := range batch.Objects {
+			responseObject := LFSBatchObject{OID: object.OID, Size: object.Size}
+			if object.Size%2 == 0 {
+				responseObject.Actions = map[string]LFSAction{"download": {Href: "https://lfs.example.com/" + object.OID}}
+			} else {
+				responseObject.Error = &LFSBatchObjectError{Code: 404, Message: "Object does not exist"}
+			}
+			response.Objects = append(response.Objects, responseObject)
+		}
+		w.Header().Set("Content-Type", "application/vnd.git-lfs+json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	api := &GitHubAPI{targetConfig: ClientConfig{
+		Token:    "token",
+		Hostname: server.URL,
+		LFSBatch: LFSBatchConfig{ChunkSize: 2, Concurrency: 2},
+	}}
+
+	existing, missing, err := api.ValidateLFSObjects(context.Background(), TargetClient, "org", "repo", lfsObjects(5))
+	require.NoError(t, err)
+	assert.Equal(t, 3, existing)
+	assert.Equal(t, 2, missing)
+	assert.Equal(t, int32(3), requests.Load())
+}
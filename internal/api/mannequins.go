@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// Mannequin is a placeholder user GitHub Enterprise Importer creates for every source user whose
+// migrated activity still has to be attributed to a user of the target organization
+type Mannequin struct {
+	Login     string
+	Email     string
+	CreatedAt time.Time
+	Claimant  string // Login of the user the mannequin was reclaimed by, empty while unclaimed
+}
+
+// ListMannequins retrieves every mannequin of an organization using GraphQL
+func (api *GitHubAPI) ListMannequins(clientType ClientType, org string) ([]Mannequin, error) {
+	ctx := context.Background()
+
+	var query struct {
+		Organization struct {
+			Mannequins struct {
+				Nodes []struct {
+					Login     string
+					Email     string
+					CreatedAt githubv4.DateTime
+					Claimant  *struct {
+						Login string
+					}
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"mannequins(first: 100, after: $cursor)"`
+		} `graphql:"organization(login: $login)"`
+	}
+
+	variables := map[string]interface{}{
+		"login":  githubv4.String(org),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	var mannequins []Mannequin
+	for {
+		err = client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s organization mannequins: %w", clientName, classifyError(err))
+		}
+
+		for _, node := range query.Organization.Mannequins.Nodes {
+			mannequin := Mannequin{Login: node.Login, Email: node.Email, CreatedAt: node.CreatedAt.Time}
+			if node.Claimant != nil {
+				mannequin.Claimant = node.Claimant.Login
+			}
+			mannequins = append(mannequins, mannequin)
+		}
+
+		if !query.Organization.Mannequins.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(query.Organization.Mannequins.PageInfo.EndCursor)
+	}
+
+	return mannequins, nil
+}
+
+// GetAuthoredItemCount retrieves the number of issues and pull requests authored by login
+// across the repositories of an organization using the GraphQL search API
+func (api *GitHubAPI) GetAuthoredItemCount(clientType ClientType, org, login string) (int, error) {
+	ctx := context.Background()
+
+	var query struct {
+		Search struct {
+			IssueCount int
+		} `graphql:"search(query: $query, type: ISSUE, first: 0)"`
+	}
+
+	variables := map[string]interface{}{
+		"query": githubv4.String(fmt.Sprintf("org:%s author:%s", org, login)),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s items authored by %s: %w", clientName, login, classifyError(err))
+	}
+
+	return query.Search.IssueCount, nil
+}
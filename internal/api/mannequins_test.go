@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestListMannequins(t *testing.T) {
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		return `{"data": {"organization": {"mannequins": {
+			"nodes": [
+				{"login": "octocat-1234", "email": "octocat@example.com", "createdAt": "2025-03-01T10:00:00Z", "claimant": {"login": "octocat"}},
+				{"login": "hubot-5678", "email": "", "createdAt": "2025-03-01T10:00:00Z", "claimant": null}
+			],
+			"pageInfo": {"hasNextPage": false, "endCursor": "cursor-1"}}}}}`
+	})
+
+	mannequins, err := api.ListMannequins(SourceClient, "target-org")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(mannequins) != 2 {
+		t.Fatalf("Expected 2 mannequins, got %d", len(mannequins))
+	}
+	if mannequins[0].Login != "octocat-1234" || mannequins[0].Claimant != "octocat" {
+		t.Errorf("Expected the reclaimed mannequin to carry its claimant, got %+v", mannequins[0])
+	}
+	if mannequins[1].Claimant != "" {
+		t.Errorf("Expected an unclaimed mannequin without claimant, got %+v", mannequins[1])
+	}
+}
+
+func TestGetAuthoredItemCount(t *testing.T) {
+	var searchQuery interface{}
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		searchQuery = variables["query"]
+		return `{"data": {"search": {"issueCount": 42}}}`
+	})
+
+	count, err := api.GetAuthoredItemCount(SourceClient, "target-org", "hubot-5678")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected 42 authored items, got %d", count)
+	}
+	if searchQuery != "org:target-org author:hubot-5678" {
+		t.Errorf("Unexpected search query %v", searchQuery)
+	}
+}
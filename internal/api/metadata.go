@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// RepositoryMetadata is the description, homepage and topics of a repository, which teams rely on to find it
+type RepositoryMetadata struct {
+	Description string   `json:"description,omitempty"`
+	HomepageURL string   `json:"homepage_url,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+}
+
+// GetRepositoryMetadata retrieves the description, homepage URL and topics of a repository using GraphQL
+func (api *GitHubAPI) GetRepositoryMetadata(ctx context.Context, clientType ClientType, owner, name string) (*RepositoryMetadata, error) {
+	// Repositories have at most 20 topics
+	var query struct {
+		Repository struct {
+			Description      string
+			HomepageURL      string `graphql:"homepageUrl"`
+			RepositoryTopics struct {
+				Nodes []struct {
+					Topic struct {
+						Name string
+					}
+				}
+			} `graphql:"repositoryTopics(first: 20)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository metadata: %w", clientName, classifyError(err))
+	}
+
+	metadata := &RepositoryMetadata{
+		Description: query.Repository.Description,
+		HomepageURL: query.Repository.HomepageURL,
+	}
+	for _, node := range query.Repository.RepositoryTopics.Nodes {
+		metadata.Topics = append(metadata.Topics, node.Topic.Name)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRepositoryMetadata(t *testing.T) {
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		return `{"data": {"repository": {"description": "Payments service", "homepageUrl": "https://docs.example.com/payments",
+			"repositoryTopics": {"nodes": [{"topic": {"name": "payments"}}, {"topic": {"name": "go"}}]}}}}`
+	})
+
+	metadata, err := api.GetRepositoryMetadata(context.Background(), SourceClient, "owner", "repo")
+
+	require.NoError(t, err)
+	assert.Equal(t, &RepositoryMetadata{
+		Description: "Payments service",
+		HomepageURL: "https://docs.example.com/payments",
+		Topics:      []string{"payments", "go"},
+	}, metadata)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// States of an organization migration (export) reported by the migrations API
+const (
+	MigrationStateExported = "exported" // The archive is ready to be downloaded
+	MigrationStateFailed   = "failed"
+)
+
+// GetMigration returns the state and repositories of the organization migration with the given ID
+func (api *GitHubAPI) GetMigration(clientType ClientType, org string, migrationID int64) (*MigrationInfo, error) {
+	ctx := context.Background()
+
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	migration, _, err := client.Migrations.MigrationStatus(ctx, org, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s migration %d: %w", clientName, migrationID, classifyError(err))
+	}
+
+	repositories := make([]string, 0, len(migration.Repositories))
+	for _, repo := range migration.Repositories {
+		repositories = append(repositories, repo.GetName())
+	}
+	return &MigrationInfo{
+		ID:           migration.GetID(),
+		CreatedAt:    migration.GetCreatedAt(),
+		UpdatedAt:    migration.GetUpdatedAt(),
+		State:        migration.GetState(),
+		Repositories: repositories,
+	}, nil
+}
+
+// WaitForMigration polls the organization migration with the given ID every interval until it is exported,
+// calling progress with every state read when it is set. It fails when the migration failed, or when ctx is
+// done first.
+func (api *GitHubAPI) WaitForMigration(ctx context.Context, clientType ClientType, org string, migrationID int64,
+	interval time.Duration, progress func(migration *MigrationInfo)) (*MigrationInfo, error) {
+	for {
+		migration, err := api.GetMigration(clientType, org, migrationID)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(migration)
+		}
+
+		switch migration.State {
+		case MigrationStateExported:
+			return migration, nil
+		case MigrationStateFailed:
+			return migration, fmt.Errorf("migration %d of %s failed", migrationID, org)
+		}
+
+		select {
+		case <-ctx.Done():
+			return migration, fmt.Errorf("migration %d of %s is still %s: %w", migrationID, org, migration.State, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
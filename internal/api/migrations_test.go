@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMigrationsTestAPI returns a source-only API whose migration 42 of source-org goes through the given states
+func newMigrationsTestAPI(t *testing.T, states ...string) (*GitHubAPI, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orgs/source-org/migrations/42", r.URL.Path)
+		state := states[min(requests, len(states)-1)]
+		requests++
+		fmt.Fprintf(w, `{"id": 42, "state": %q, "repositories": [{"name": "repo"}, {"name": "other-repo"}]}`, state)
+	}))
+	t.Cleanup(server.Close)
+
+	ghAPI, err := NewSourceOnlyAPI(ClientConfig{Token: "token", APIURL: server.URL})
+	require.NoError(t, err)
+	return ghAPI, &requests
+}
+
+func TestGetMigration(t *testing.T) {
+	ghAPI, _ := newMigrationsTestAPI(t, "exporting")
+
+	migration, err := ghAPI.GetMigration(SourceClient, "source-org", 42)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), migration.ID)
+	assert.Equal(t, "exporting", migration.State)
+	assert.Equal(t, []string{"repo", "other-repo"}, migration.Repositories)
+}
+
+func TestWaitForMigration(t *testing.T) {
+	ghAPI, requests := newMigrationsTestAPI(t, "pending", "exporting", MigrationStateExported)
+
+	var states []string
+	migration, err := ghAPI.WaitForMigration(context.Background(), SourceClient, "source-org", 42, time.Millisecond,
+		func(migration *MigrationInfo) { states = append(states, migration.State) })
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStateExported, migration.State)
+	assert.Equal(t, []string{"pending", "exporting", "exported"}, states)
+	assert.Equal(t, 3, *requests)
+}
+
+func TestWaitForMigration_Failed(t *testing.T) {
+	ghAPI, _ := newMigrationsTestAPI(t, "exporting", MigrationStateFailed)
+
+	_, err := ghAPI.WaitForMigration(context.Background(), SourceClient, "source-org", 42, time.Millisecond, nil)
+	assert.EqualError(t, err, "migration 42 of source-org failed")
+}
+
+func TestWaitForMigration_Timeout(t *testing.T) {
+	ghAPI, _ := newMigrationsTestAPI(t, "exporting")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := ghAPI.WaitForMigration(ctx, SourceClient, "source-org", 42, time.Millisecond, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "migration 42 of source-org is still exporting")
+}
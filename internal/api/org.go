@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// GetOrganizationRepositoryCount retrieves the total count of repositories in an organization using GraphQL
+func (api *GitHubAPI) GetOrganizationRepositoryCount(ctx context.Context, clientType ClientType, org string) (int, error) {
+	var query struct {
+		Organization struct {
+			Repositories struct {
+				TotalCount int
+			}
+		} `graphql:"organization(login: $login)"`
+	}
+
+	variables := map[string]interface{}{
+		"login": githubv4.String(org),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s organization repository count: %w", clientName, classifyError(err))
+	}
+
+	return query.Organization.Repositories.TotalCount, nil
+}
+
+// GetOrganizationTeams retrieves the teams of an organization using GraphQL,
+// returning the number of direct members of each team keyed by team slug
+func (api *GitHubAPI) GetOrganizationTeams(ctx context.Context, clientType ClientType, org string) (map[string]int, error) {
+	var query struct {
+		Organization struct {
+			Teams struct {
+				Nodes []struct {
+					Slug    string
+					Members struct {
+						TotalCount int
+					} `graphql:"members(membership: IMMEDIATE)"`
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"teams(first: 100, after: $cursor)"`
+		} `graphql:"organization(login: $login)"`
+	}
+
+	variables := map[string]interface{}{
+		"login":  githubv4.String(org),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]int)
+	for {
+		err = client.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s organization teams: %w", clientName, classifyError(err))
+		}
+
+		for _, team := range query.Organization.Teams.Nodes {
+			teams[team.Slug] = team.Members.TotalCount
+		}
+
+		if !query.Organization.Teams.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(query.Organization.Teams.PageInfo.EndCursor)
+	}
+
+	return teams, nil
+}
+
+// GetOrganizationProjectCount retrieves the total count of projects owned by an organization using GraphQL
+func (api *GitHubAPI) GetOrganizationProjectCount(ctx context.Context, clientType ClientType, org string) (int, error) {
+	var query struct {
+		Organization struct {
+			ProjectsV2 struct {
+				TotalCount int
+			}
+		} `graphql:"organization(login: $login)"`
+	}
+
+	variables := map[string]interface{}{
+		"login": githubv4.String(org),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s organization project count: %w", clientName, classifyError(err))
+	}
+
+	return query.Organization.ProjectsV2.TotalCount, nil
+}
+
+// GetOrganizationWebhookCount retrieves the total count of webhooks configured on an organization using the REST API
+func (api *GitHubAPI) GetOrganizationWebhookCount(ctx context.Context, clientType ClientType, org string) (int, error) {
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+	var webhookCount int
+
+	for {
+		webhooks, resp, err := client.Organizations.ListHooks(ctx, org, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query %s organization webhook count: %w", clientName, classifyError(err))
+		}
+
+		webhookCount += len(webhooks)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return webhookCount, nil
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// rateLimitResponse answers the rate limit check made before every GraphQL query
+const rateLimitResponse = `{"data": {"rateLimit": {"remaining": 5000, "resetAt": "2025-01-01T00:00:00Z"}}}`
+
+// graphQLTestOption customizes the GitHubAPI instance created by createGraphQLTestAPI
+type graphQLTestOption func(api *GitHubAPI)
+
+// withSourceState gives the source client a client state, which tracks e.g. the GraphQL query cost
+func withSourceState() graphQLTestOption {
+	return func(api *GitHubAPI) {
+		api.sourceState = &clientState{}
+		api.sourceGraphClient.state = api.sourceState
+	}
+}
+
+// createGraphQLTestAPI creates a GitHubAPI instance whose source GraphQL client answers the rate limit
+// check itself and every other query with the given function
+func createGraphQLTestAPI(respond func(query string, variables map[string]interface{}) string, options ...graphQLTestOption) *GitHubAPI {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+
+		response := rateLimitResponse
+		if !strings.HasPrefix(body.Query, "{rateLimit") {
+			response = respond(body.Query, body.Variables)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(response)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	client := &http.Client{Transport: transport}
+	api := &GitHubAPI{sourceGraphClient: &RateLimitAwareGraphQLClient{client: githubv4.NewClient(client)}}
+	for _, option := range options {
+		option(api)
+	}
+	return api
+}
+
+func TestGetOrganizationTeams(t *testing.T) {
+	pages := []string{
+		`{"data": {"organization": {"teams": {
+			"nodes": [{"slug": "admins", "members": {"totalCount": 2}}, {"slug": "developers", "members": {"totalCount": 15}}],
+			"pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"}}}}}`,
+		`{"data": {"organization": {"teams": {
+			"nodes": [{"slug": "readers", "members": {"totalCount": 0}}],
+			"pageInfo": {"hasNextPage": false, "endCursor": "cursor-2"}}}}}`,
+	}
+
+	var cursors []interface{}
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		cursors = append(cursors, variables["cursor"])
+		return pages[len(cursors)-1]
+	})
+
+	teams, err := api.GetOrganizationTeams(context.Background(), SourceClient, "source-org")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]int{"admins": 2, "developers": 15, "readers": 0}
+	if len(teams) != len(expected) {
+		t.Fatalf("Expected %d teams, got %v", len(expected), teams)
+	}
+	for slug, members := range expected {
+		if teams[slug] != members {
+			t.Errorf("Expected %d members in %s, got %d", members, slug, teams[slug])
+		}
+	}
+	if len(cursors) != 2 || cursors[0] != nil || cursors[1] != "cursor-1" {
+		t.Errorf("Expected the second page to be requested after cursor-1, got cursors %v", cursors)
+	}
+}
+
+func TestGetOrganizationWebhookCount(t *testing.T) {
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.URL.Path, "/orgs/source-org/hooks") {
+				t.Errorf("Expected organization webhook API endpoint, got: %s", req.URL.Path)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`[{"id": 1, "name": "web"}, {"id": 2, "name": "web"}]`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	count, err := createTestAPI(mockTransport).GetOrganizationWebhookCount(context.Background(), SourceClient, "source-org")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 webhooks, got %d", count)
+	}
+}
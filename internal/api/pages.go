@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PagesConfig holds the GitHub Pages configuration of a repository
+type PagesConfig struct {
+	BuildType    string `json:"build_type,omitempty"` // "legacy" when built from a branch, "workflow" when built by GitHub Actions
+	Branch       string `json:"branch,omitempty"`
+	Path         string `json:"path,omitempty"`
+	CustomDomain string `json:"custom_domain,omitempty"`
+}
+
+// GetPagesConfig retrieves the GitHub Pages configuration of a repository using the REST API,
+// returning nil when Pages is not enabled
+func (api *GitHubAPI) GetPagesConfig(ctx context.Context, clientType ClientType, owner, name string) (*PagesConfig, error) {
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, _, err := client.Repositories.GetPagesInfo(ctx, owner, name)
+	if err = classifyError(err); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query %s repository Pages configuration: %w", clientName, err)
+	}
+
+	return &PagesConfig{
+		BuildType:    pages.GetBuildType(),
+		Branch:       pages.GetSource().GetBranch(),
+		Path:         pages.GetSource().GetPath(),
+		CustomDomain: pages.GetCNAME(),
+	}, nil
+}
+
+// String describes the configuration, e.g. "main /docs, custom domain docs.example.com"
+func (c *PagesConfig) String() string {
+	if c == nil {
+		return "Disabled"
+	}
+
+	description := "GitHub Actions"
+	if c.BuildType != "workflow" {
+		description = fmt.Sprintf("%s %s", c.Branch, c.Path)
+	}
+	if c.CustomDomain != "" {
+		description += ", custom domain " + c.CustomDomain
+	}
+	return description
+}
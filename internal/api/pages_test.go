@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetPagesConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		responseBody  string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:         "branch build with custom domain",
+			statusCode:   http.StatusOK,
+			responseBody: `{"build_type": "legacy", "cname": "docs.example.com", "source": {"branch": "main", "path": "/docs"}}`,
+			expected:     "main /docs, custom domain docs.example.com",
+		},
+		{
+			name:         "workflow build",
+			statusCode:   http.StatusOK,
+			responseBody: `{"build_type": "workflow", "source": {"branch": "main", "path": "/"}}`,
+			expected:     "GitHub Actions",
+		},
+		{
+			name:         "pages not enabled",
+			statusCode:   http.StatusNotFound,
+			responseBody: `{"message": "Not Found"}`,
+			expected:     "Disabled",
+		},
+		{
+			name:          "server error",
+			statusCode:    http.StatusInternalServerError,
+			responseBody:  `{"message": "Server Error"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != "/repos/owner/repo/pages" {
+						t.Errorf("Expected Pages API endpoint, got: %s", req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						Header:     make(http.Header),
+						Request:    req,
+					}, nil
+				},
+			}
+
+			config, err := createTestAPI(mockTransport).GetPagesConfig(context.Background(), SourceClient, "owner", "repo")
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := config.String(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
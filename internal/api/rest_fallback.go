@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// restCount counts the items of a list endpoint, e.g. "repos/owner/name/tags", with a single request: a page
+// holds one item, so the number of the last page in the Link header is the item count
+func restCount(ctx context.Context, client *github.Client, path string) (int, error) {
+	req, err := client.NewRequest("GET", path+"?per_page=1", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var items []json.RawMessage
+	resp, err := client.Do(ctx, req, &items)
+	if err != nil {
+		return 0, err
+	}
+	if resp.LastPage > 0 {
+		return resp.LastPage, nil
+	}
+	// Without a Link header the first page is the only one
+	return len(items), nil
+}
+
+// searchCount returns the total count of the issues and pull requests matching a search query. Search results
+// are served from an index, so items created in the last minutes may be missing.
+func searchCount(ctx context.Context, client *github.Client, query string) (int, error) {
+	result, _, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return 0, err
+	}
+	return result.GetTotal(), nil
+}
+
+// withRESTFallback counts data with the REST API when its GraphQL query failed with graphQLErr, e.g. on
+// instances restricting GraphQL for the token. graphQLErr is returned when the REST API fails too, or when the
+// repository does not exist. Successful fallbacks are recorded in the client state.
+func withRESTFallback[T any](ctx context.Context, api *GitHubAPI, clientType ClientType, graphQLErr error, rest func(ctx context.Context, client *github.Client) (T, error)) (T, error) {
+	var zero T
+	if errors.Is(graphQLErr, ErrNotFound) {
+		return zero, graphQLErr
+	}
+
+	client, _, err := api.getRESTClient(clientType)
+	if err != nil || client == nil {
+		return zero, graphQLErr
+	}
+
+	value, err := rest(ctx, client)
+	if err != nil {
+		return zero, fmt.Errorf("%w (REST fallback failed: %v)", graphQLErr, err)
+	}
+
+	if state := api.clientState(clientType); state != nil {
+		state.restFallbacks.Add(1)
+	}
+	return value, nil
+}
+
+// restPRCounts counts the pull requests of a repository by state with the search API
+func restPRCounts(ctx context.Context, client *github.Client, owner, name string) (*PRCounts, error) {
+	counts := &PRCounts{}
+	for _, state := range []struct {
+		qualifiers string
+		count      *int
+	}{
+		{"is:open", &counts.Open},
+		{"is:merged", &counts.Merged},
+		{"is:closed is:unmerged", &counts.Closed},
+	} {
+		count, err := searchCount(ctx, client, fmt.Sprintf("repo:%s/%s is:pr %s", owner, name, state.qualifiers))
+		if err != nil {
+			return nil, err
+		}
+		*state.count = count
+	}
+
+	counts.Total = counts.Open + counts.Merged + counts.Closed
+	return counts, nil
+}
+
+// RESTFallbackCount returns the number of counts retrieved so far with the REST API because the GraphQL query
+// failed. It returns 0 for clients created without a client state.
+func (api *GitHubAPI) RESTFallbackCount(clientType ClientType) int {
+	if state := api.clientState(clientType); state != nil {
+		return int(state.restFallbacks.Load())
+	}
+	return 0
+}
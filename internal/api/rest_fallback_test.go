@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+// createRESTFallbackTestAPI returns an API whose GraphQL queries fail with graphQLError and whose REST
+// requests are answered by respond
+func createRESTFallbackTestAPI(graphQLError string, respond func(req *http.Request) *http.Response) *GitHubAPI {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/graphql" {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"errors": [{"message": "` + graphQLError + `"}]}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		resp := respond(req)
+		resp.Request = req
+		return resp, nil
+	})
+
+	client := &http.Client{Transport: transport}
+	return &GitHubAPI{
+		sourceClient:      github.NewClient(client),
+		sourceGraphClient: &RateLimitAwareGraphQLClient{client: githubv4.NewClient(client)},
+		sourceState:       &clientState{},
+	}
+}
+
+func jsonResponse(body string, header http.Header) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: header}
+}
+
+func TestRESTFallback_LinkHeaderCount(t *testing.T) {
+	api := createRESTFallbackTestAPI("Resource not accessible by integration", func(req *http.Request) *http.Response {
+		assert.Equal(t, "/repos/owner/repo/tags", req.URL.Path)
+		assert.Equal(t, "1", req.URL.Query().Get("per_page"))
+		header := make(http.Header)
+		header.Set("Link", `<https://api.github.com/repositories/1/tags?per_page=1&page=2>; rel="next", `+
+			`<https://api.github.com/repositories/1/tags?per_page=1&page=42>; rel="last"`)
+		return jsonResponse(`[{"name": "v1.0.0"}]`, header)
+	})
+
+	count, err := api.GetTagCount(context.Background(), SourceClient, "owner", "repo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+	assert.Equal(t, 1, api.RESTFallbackCount(SourceClient))
+}
+
+func TestRESTFallback_SinglePage(t *testing.T) {
+	api := createRESTFallbackTestAPI("Resource not accessible by integration", func(req *http.Request) *http.Response {
+		return jsonResponse(`[{"name": "main"}]`, nil)
+	})
+
+	count, err := api.GetBranchCount(context.Background(), SourceClient, "owner", "repo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRESTFallback_SearchCounts(t *testing.T) {
+	var queries []string
+	api := createRESTFallbackTestAPI("Resource not accessible by integration", func(req *http.Request) *http.Response {
+		assert.Equal(t, "/search/issues", req.URL.Path)
+		query := req.URL.Query().Get("q")
+		queries = append(queries, query)
+		totals := map[string]string{
+			"repo:owner/repo is:issue":                    "7",
+			"repo:owner/repo is:pr is:open":               "2",
+			"repo:owner/repo is:pr is:merged":             "5",
+			"repo:owner/repo is:pr is:closed is:unmerged": "1",
+		}
+		return jsonResponse(`{"total_count": `+totals[query]+`, "items": []}`, nil)
+	})
+
+	issues, err := api.GetIssueCount(context.Background(), SourceClient, "owner", "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, issues)
+
+	prs, err := api.GetPRCounts(context.Background(), SourceClient, "owner", "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, &PRCounts{Open: 2, Merged: 5, Closed: 1, Total: 8}, prs)
+
+	assert.Len(t, queries, 4)
+	assert.Equal(t, 2, api.RESTFallbackCount(SourceClient))
+}
+
+func TestRESTFallback_NotFoundIsNotRetried(t *testing.T) {
+	api := createRESTFallbackTestAPI("Could not resolve to a Repository with the name 'owner/repo'.", func(req *http.Request) *http.Response {
+		t.Errorf("Expected no REST request for a missing repository, got %s", req.URL.Path)
+		return jsonResponse(`[]`, nil)
+	})
+
+	_, err := api.GetReleaseCount(context.Background(), SourceClient, "owner", "repo")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 0, api.RESTFallbackCount(SourceClient))
+}
+
+func TestRESTFallback_RESTFailureKeepsGraphQLError(t *testing.T) {
+	api := createRESTFallbackTestAPI("403 Forbidden", func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusForbidden,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Forbidden"}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	_, err := api.GetCommitCommentCount(context.Background(), SourceClient, "owner", "repo")
+
+	assert.ErrorIs(t, err, ErrAuth)
+	assert.Contains(t, err.Error(), "REST fallback failed")
+	assert.Equal(t, 0, api.RESTFallbackCount(SourceClient))
+}
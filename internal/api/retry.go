@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Default retry settings, used unless --max-retries, --retry-backoff or --retry-jitter are set
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = time.Second
+	defaultRetryJitter  = 500 * time.Millisecond
+)
+
+// RetryConfig controls how requests failing with a transient error are retried
+type RetryConfig struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retrying
+	Backoff    time.Duration // Delay before the first retry, doubled for every further retry
+	Jitter     time.Duration // Maximum random delay added to every backoff
+}
+
+// DefaultRetryConfig returns the retry configuration used when none is configured
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{MaxRetries: defaultMaxRetries, Backoff: defaultRetryBackoff, Jitter: defaultRetryJitter}
+}
+
+// RetryStats summarizes the retries made since the statistics were last reset
+type RetryStats struct {
+	Retries   int // Retry attempts
+	Recovered int // Requests that succeeded after being retried
+	Failed    int // Requests that still failed with a transient error after the last retry
+}
+
+// retryCounters are shared by all clients so the statistics cover every request of a run
+var retryCounters struct {
+	retries   atomic.Int64
+	recovered atomic.Int64
+	failed    atomic.Int64
+}
+
+// RetryStatistics returns the retries made since the start of the run or the last ResetRetryStatistics
+func RetryStatistics() RetryStats {
+	return RetryStats{
+		Retries:   int(retryCounters.retries.Load()),
+		Recovered: int(retryCounters.recovered.Load()),
+		Failed:    int(retryCounters.failed.Load()),
+	}
+}
+
+// ResetRetryStatistics clears the retry statistics, for callers running several validations in one process
+func ResetRetryStatistics() {
+	retryCounters.retries.Store(0)
+	retryCounters.recovered.Store(0)
+	retryCounters.failed.Store(0)
+}
+
+// retryTransport retries requests failing with a network error or a 5xx response.
+// Only requests without side effects are retried: idempotent methods, GraphQL queries and LFS batch lookups.
+type retryTransport struct {
+	base   http.RoundTripper
+	config RetryConfig
+}
+
+// newRetryTransport wraps base with retries configured by config
+func newRetryTransport(base http.RoundTripper, config RetryConfig) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, config: config}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.config.MaxRetries == 0 || !isRetryableRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(attemptReq)
+
+		if !isTransientFailure(req.Context(), resp, err) {
+			if attempt > 0 {
+				retryCounters.recovered.Add(1)
+			}
+			return resp, err
+		}
+		if attempt == t.config.MaxRetries {
+			retryCounters.failed.Add(1)
+			return resp, err
+		}
+
+		// Release the connection of the failed attempt before retrying
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), t.backoff(attempt)); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		retryCounters.retries.Add(1)
+	}
+}
+
+// backoff returns the delay before retry number attempt+1: exponential backoff plus random jitter
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.config.Backoff << attempt
+	if t.config.Jitter > 0 {
+		delay += rand.N(t.config.Jitter)
+	}
+	return delay
+}
+
+// isRetryableRequest reports whether req can be sent again without side effects
+func isRetryableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		// The GraphQL API is only used for queries, and LFS batch requests only look up objects
+		path := req.URL.Path
+		if !strings.HasSuffix(path, "/graphql") && !strings.HasSuffix(path, "/info/lfs/objects/batch") {
+			return false
+		}
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	default:
+		return false
+	}
+}
+
+// isTransientFailure reports whether an attempt failed with an error worth retrying: a network error
+// or a 500, 502, 503 or 504 response. Cancellation and other status codes are permanent.
+func isTransientFailure(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	return retryReq, nil
+}
+
+// sleepContext waits for d, returning early with the context error when ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFlakyServer returns a server responding with the given status codes in order, then 200,
+// and records the request bodies it received
+func newFlakyServer(t *testing.T, statuses ...int) (*httptest.Server, *[]string) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) <= len(statuses) {
+			w.WriteHeader(statuses[len(bodies)-1])
+			return
+		}
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func testRetryClient() *http.Client {
+	return &http.Client{Transport: newRetryTransport(nil, RetryConfig{MaxRetries: 2, Backoff: time.Millisecond})}
+}
+
+func TestRetryTransport_RetriesTransientFailures(t *testing.T) {
+	ResetRetryStatistics()
+	defer ResetRetryStatistics()
+
+	server, bodies := newFlakyServer(t, http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	resp, err := testRetryClient().Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query": "{}"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the last attempt to succeed, got status %d", resp.StatusCode)
+	}
+	if len(*bodies) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(*bodies))
+	}
+	for _, body := range *bodies {
+		if body != `{"query": "{}"}` {
+			t.Errorf("Expected the request body to be sent with every attempt, got %q", body)
+		}
+	}
+
+	expected := RetryStats{Retries: 2, Recovered: 1}
+	if stats := RetryStatistics(); stats != expected {
+		t.Errorf("RetryStatistics() = %+v, want %+v", stats, expected)
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	ResetRetryStatistics()
+	defer ResetRetryStatistics()
+
+	server, bodies := newFlakyServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	resp, err := testRetryClient().Get(server.URL + "/repos/owner/repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected the last failed response, got status %d", resp.StatusCode)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(*bodies))
+	}
+
+	expected := RetryStats{Retries: 2, Failed: 1}
+	if stats := RetryStatistics(); stats != expected {
+		t.Errorf("RetryStatistics() = %+v, want %+v", stats, expected)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryPermanentFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"not found", http.MethodGet, "/repos/owner/repo", http.StatusNotFound},
+		{"unauthorized", http.MethodPost, "/graphql", http.StatusUnauthorized},
+		{"REST mutation", http.MethodPost, "/repos/owner/repo/issues/1/comments", http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetRetryStatistics()
+			defer ResetRetryStatistics()
+
+			server, bodies := newFlakyServer(t, tt.status)
+
+			req, _ := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader("{}"))
+			resp, err := testRetryClient().Do(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if len(*bodies) != 1 {
+				t.Errorf("Expected a single attempt, got %d", len(*bodies))
+			}
+			if stats := RetryStatistics(); stats != (RetryStats{}) {
+				t.Errorf("Expected no retries, got %+v", stats)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RetriesNetworkErrors(t *testing.T) {
+	ResetRetryStatistics()
+	defer ResetRetryStatistics()
+
+	attempts := 0
+	transport := newRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return NewMockResponse(http.StatusOK, "{}"), nil
+	}), RetryConfig{MaxRetries: 3, Backoff: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
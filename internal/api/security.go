@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// SecurityFeature is whether a security feature is enabled on a repository and its open alerts when it is
+type SecurityFeature struct {
+	Enabled    bool `json:"enabled"`
+	OpenAlerts int  `json:"open_alerts"`
+}
+
+// SecurityStatus holds the security features of a repository. None of them, nor their alerts, are migrated:
+// they have to be enabled on the target repository, where alerts are raised again by new scans.
+type SecurityStatus struct {
+	DependabotAlerts SecurityFeature `json:"dependabot_alerts"`
+	SecretScanning   SecurityFeature `json:"secret_scanning"`
+	CodeScanning     SecurityFeature `json:"code_scanning"`
+}
+
+// GetSecurityStatus retrieves whether Dependabot alerts, secret scanning and code scanning are enabled on a
+// repository, and the number of open alerts of each enabled feature, using the REST API
+func (api *GitHubAPI) GetSecurityStatus(ctx context.Context, clientType ClientType, owner, name string) (*SecurityStatus, error) {
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SecurityStatus{}
+
+	status.DependabotAlerts.Enabled, _, err = client.Repositories.GetVulnerabilityAlerts(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository Dependabot alerts status: %w", clientName, classifyError(err))
+	}
+	if status.DependabotAlerts.Enabled {
+		if status.DependabotAlerts.OpenAlerts, err = countDependabotAlerts(ctx, client, owner, name); err != nil {
+			return nil, fmt.Errorf("failed to query %s repository Dependabot alerts: %w", clientName, classifyError(err))
+		}
+	}
+
+	repo, _, err := client.Repositories.Get(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository security settings: %w", clientName, classifyError(err))
+	}
+	status.SecretScanning.Enabled = repo.GetSecurityAndAnalysis().GetSecretScanning().GetStatus() == "enabled"
+	if status.SecretScanning.Enabled {
+		if status.SecretScanning.OpenAlerts, err = countSecretScanningAlerts(ctx, client, owner, name); err != nil {
+			return nil, fmt.Errorf("failed to query %s repository secret scanning alerts: %w", clientName, classifyError(err))
+		}
+	}
+
+	// Code scanning has no setting to read: listing alerts returns 404 until the repository has an analysis
+	status.CodeScanning.OpenAlerts, err = countCodeScanningAlerts(ctx, client, owner, name)
+	if err = classifyError(err); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to query %s repository code scanning alerts: %w", clientName, err)
+	}
+	status.CodeScanning.Enabled = err == nil
+
+	return status, nil
+}
+
+// countDependabotAlerts counts the open Dependabot alerts of a repository, paginating by cursor
+func countDependabotAlerts(ctx context.Context, client *github.Client, owner, name string) (int, error) {
+	state := "open"
+	opts := &github.ListAlertsOptions{State: &state, ListCursorOptions: github.ListCursorOptions{PerPage: 100}}
+	count := 0
+
+	for {
+		alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, name, opts)
+		if err != nil {
+			return 0, err
+		}
+		count += len(alerts)
+
+		if resp.After == "" {
+			return count, nil
+		}
+		opts.ListCursorOptions.After = resp.After
+	}
+}
+
+// countSecretScanningAlerts counts the open secret scanning alerts of a repository
+func countSecretScanningAlerts(ctx context.Context, client *github.Client, owner, name string) (int, error) {
+	opts := &github.SecretScanningAlertListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	count := 0
+
+	for {
+		alerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, name, opts)
+		if err != nil {
+			return 0, err
+		}
+		count += len(alerts)
+
+		if resp.NextPage == 0 {
+			return count, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+}
+
+// countCodeScanningAlerts counts the open code scanning alerts of a repository
+func countCodeScanningAlerts(ctx context.Context, client *github.Client, owner, name string) (int, error) {
+	opts := &github.AlertListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	count := 0
+
+	for {
+		alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, name, opts)
+		if err != nil {
+			return 0, err
+		}
+		count += len(alerts)
+
+		if resp.NextPage == 0 {
+			return count, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+}
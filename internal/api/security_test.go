@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSecurityStatus(t *testing.T) {
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		"/repos/owner/repo/vulnerability-alerts":   {http.StatusNoContent, ""},
+		"/repos/owner/repo/dependabot/alerts":      {http.StatusOK, `[{"number": 1}, {"number": 2}, {"number": 3}]`},
+		"/repos/owner/repo":                        {http.StatusOK, `{"security_and_analysis": {"secret_scanning": {"status": "enabled"}}}`},
+		"/repos/owner/repo/secret-scanning/alerts": {http.StatusOK, `[{"number": 4}]`},
+		"/repos/owner/repo/code-scanning/alerts":   {http.StatusNotFound, `{"message": "no analysis found"}`},
+	}
+
+	mockTransport := &mockRoundTripper{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			response, ok := responses[req.URL.Path]
+			if !ok {
+				t.Fatalf("Unexpected request to %s", req.URL.Path)
+			}
+			if strings.HasSuffix(req.URL.Path, "/alerts") && req.URL.Query().Get("state") != "open" {
+				t.Errorf("Expected only open alerts to be listed, got %s", req.URL.RawQuery)
+			}
+
+			return &http.Response{
+				StatusCode: response.status,
+				Body:       io.NopCloser(strings.NewReader(response.body)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		},
+	}
+
+	status, err := createTestAPI(mockTransport).GetSecurityStatus(context.Background(), TargetClient, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := SecurityStatus{
+		DependabotAlerts: SecurityFeature{Enabled: true, OpenAlerts: 3},
+		SecretScanning:   SecurityFeature{Enabled: true, OpenAlerts: 1},
+		CodeScanning:     SecurityFeature{Enabled: false},
+	}
+	if *status != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *status)
+	}
+}
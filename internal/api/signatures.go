@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// SignatureSampleSize is the number of most recent default branch commits whose signatures are checked
+const SignatureSampleSize = 100
+
+// SignatureStats counts the signed and verified commits among the most recent commits of the default branch
+type SignatureStats struct {
+	Commits  int `json:"commits"`
+	Signed   int `json:"signed"`
+	Verified int `json:"verified"` // Signed commits whose signature GitHub verified against a key of the signer
+}
+
+// VerifiedPercent returns the share of checked commits with a verified signature
+func (s *SignatureStats) VerifiedPercent() float64 {
+	if s.Commits == 0 {
+		return 0
+	}
+	return float64(s.Verified) * 100 / float64(s.Commits)
+}
+
+// GetSignatureStats retrieves the signature status of the SignatureSampleSize most recent commits
+// of the default branch using GraphQL
+func (api *GitHubAPI) GetSignatureStats(ctx context.Context, clientType ClientType, owner, name string) (*SignatureStats, error) {
+	var query struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Target struct {
+					Commit struct {
+						History struct {
+							Nodes []struct {
+								Signature *struct {
+									IsValid bool
+								}
+							}
+						} `graphql:"history(first: $first)"`
+					} `graphql:"... on Commit"`
+				}
+			}
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+		"first": githubv4.Int(SignatureSampleSize),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository commit signatures: %w", clientName, classifyError(err))
+	}
+
+	stats := &SignatureStats{}
+	for _, commit := range query.Repository.DefaultBranchRef.Target.Commit.History.Nodes {
+		stats.Commits++
+		if commit.Signature == nil {
+			continue
+		}
+		stats.Signed++
+		if commit.Signature.IsValid {
+			stats.Verified++
+		}
+	}
+
+	return stats, nil
+}
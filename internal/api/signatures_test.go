@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSignatureStats(t *testing.T) {
+	var first interface{}
+	api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+		first = variables["first"]
+		return `{"data": {"repository": {"defaultBranchRef": {"target": {"history": {"nodes": [
+			{"signature": {"isValid": true}},
+			{"signature": {"isValid": false}},
+			{"signature": null},
+			{"signature": {"isValid": true}}
+		]}}}}}}`
+	})
+
+	stats, err := api.GetSignatureStats(context.Background(), SourceClient, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := SignatureStats{Commits: 4, Signed: 3, Verified: 2}
+	if *stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *stats)
+	}
+	if stats.VerifiedPercent() != 50 {
+		t.Errorf("Expected 50%% verified, got %v", stats.VerifiedPercent())
+	}
+	if first != float64(SignatureSampleSize) {
+		t.Errorf("Expected the %d most recent commits to be requested, got %v", SignatureSampleSize, first)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// ssoHeader is set by GitHub on responses to requests whose token is not authorized for SAML single sign-on
+const ssoHeader = "X-GitHub-SSO"
+
+// tokenSettingsURL is where personal access tokens are authorized for SAML single sign-on
+const tokenSettingsURL = "https://github.com/settings/tokens"
+
+// SSOError is returned when a token is not authorized for an organization enforcing SAML single sign-on.
+// Every request for the organization's data fails until the token is authorized, so validation stops.
+type SSOError struct {
+	Organization     string
+	AuthorizationURL string // URL authorizing the token for the organization, when GitHub returned one
+	Err              error
+}
+
+func (e *SSOError) Error() string {
+	message := fmt.Sprintf("the token is not authorized for the %s organization, which enforces SAML single sign-on", e.Organization)
+	if e.AuthorizationURL != "" {
+		return fmt.Sprintf("%s: authorize it at %s and run the validation again", message, e.AuthorizationURL)
+	}
+	return fmt.Sprintf("%s: authorize it with \"Configure SSO\" at %s and run the validation again", message, tokenSettingsURL)
+}
+
+// Unwrap allows errors.Is to match ErrAuth and the underlying error
+func (e *SSOError) Unwrap() []error {
+	return []error{ErrAuth, e.Err}
+}
+
+// SSOError returns an *SSOError when err was caused by SAML single sign-on enforcement in organization,
+// including the authorization URL GitHub returned to the given client, or nil for any other error
+func (api *GitHubAPI) SSOError(clientType ClientType, organization string, err error) error {
+	var ssoErr *SSOError
+	if errors.As(err, &ssoErr) {
+		return ssoErr
+	}
+	if !isSSOFailure(err) {
+		return nil
+	}
+
+	ssoErr = &SSOError{Organization: organization, Err: err}
+	if url, ok := responseSSOURL(err); ok {
+		ssoErr.AuthorizationURL = url
+	} else if state := api.clientState(clientType); state != nil {
+		if url := state.ssoURL.Load(); url != nil {
+			ssoErr.AuthorizationURL = *url
+		}
+	}
+	return ssoErr
+}
+
+// isSSOFailure reports whether err was caused by SAML single sign-on enforcement
+func isSSOFailure(err error) bool {
+	if _, ok := responseSSOURL(err); ok {
+		return true
+	}
+	return ClassifyFailure(err) == CauseSAMLEnforcement
+}
+
+// responseSSOURL returns the authorization URL from the SSO header of the REST response err was returned for
+func responseSSOURL(err error) (string, bool) {
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil {
+		return ssoAuthorizationURL(responseErr.Response.Header)
+	}
+	return "", false
+}
+
+// ssoAuthorizationURL parses an SSO header of the form "required; url=https://github.com/orgs/ORG/sso?..."
+func ssoAuthorizationURL(header http.Header) (string, bool) {
+	value := header.Get(ssoHeader)
+	if !strings.HasPrefix(value, "required") {
+		return "", false
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		if url, ok := strings.CutPrefix(strings.TrimSpace(part), "url="); ok {
+			return url, true
+		}
+	}
+	return "", true
+}
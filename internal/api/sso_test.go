@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+)
+
+const testSSOURL = "https://github.com/orgs/octo-org/sso?authorization_request=abc123"
+
+func TestSSOAuthorizationURL(t *testing.T) {
+	header := http.Header{}
+	if _, ok := ssoAuthorizationURL(header); ok {
+		t.Error("Expected no SSO requirement without the header")
+	}
+
+	header.Set(ssoHeader, "partial-results; organizations=21955855")
+	if _, ok := ssoAuthorizationURL(header); ok {
+		t.Error("Expected partial results not to be reported as an SSO requirement")
+	}
+
+	header.Set(ssoHeader, "required; url="+testSSOURL)
+	url, ok := ssoAuthorizationURL(header)
+	if !ok || url != testSSOURL {
+		t.Errorf("ssoAuthorizationURL() = %q, %v, want %q, true", url, ok, testSSOURL)
+	}
+}
+
+func TestGitHubAPI_SSOError(t *testing.T) {
+	t.Run("REST response with SSO header", func(t *testing.T) {
+		resp := errorResponse(http.StatusForbidden)
+		resp.Header = http.Header{}
+		resp.Header.Set(ssoHeader, "required; url="+testSSOURL)
+
+		err := (&GitHubAPI{}).SSOError(SourceClient, "octo-org", &github.ErrorResponse{Response: resp})
+
+		var ssoErr *SSOError
+		if !errors.As(err, &ssoErr) {
+			t.Fatalf("Expected an *SSOError, got %v", err)
+		}
+		if ssoErr.AuthorizationURL != testSSOURL {
+			t.Errorf("Expected the authorization URL from the header, got %q", ssoErr.AuthorizationURL)
+		}
+		if !errors.Is(err, ErrAuth) {
+			t.Error("Expected the SSO error to be an authentication error")
+		}
+		if !strings.Contains(err.Error(), "authorize it at "+testSSOURL) {
+			t.Errorf("Expected the message to tell where to authorize the token, got %q", err.Error())
+		}
+	})
+
+	t.Run("GraphQL error with URL seen by the transport", func(t *testing.T) {
+		state := &clientState{}
+		url := testSSOURL
+		state.ssoURL.Store(&url)
+		api := &GitHubAPI{targetState: state}
+
+		err := api.SSOError(TargetClient, "octo-org", errors.New("Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization."))
+
+		var ssoErr *SSOError
+		if !errors.As(err, &ssoErr) || ssoErr.AuthorizationURL != testSSOURL {
+			t.Fatalf("Expected an *SSOError with the recorded URL, got %v", err)
+		}
+	})
+
+	t.Run("GraphQL error without URL", func(t *testing.T) {
+		var api *GitHubAPI
+		err := api.SSOError(SourceClient, "octo-org", errors.New("Resource protected by organization SAML enforcement."))
+		if err == nil || !strings.Contains(err.Error(), tokenSettingsURL) {
+			t.Errorf("Expected the message to point to the token settings, got %v", err)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		if err := (&GitHubAPI{}).SSOError(SourceClient, "octo-org", &github.ErrorResponse{Response: errorResponse(http.StatusNotFound)}); err != nil {
+			t.Errorf("Expected no SSO error, got %v", err)
+		}
+	})
+}
+
+func TestStateTransport_RecordsSSOURL(t *testing.T) {
+	state := &clientState{}
+	transport := &stateTransport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp := NewMockResponse(http.StatusOK, `{"errors": [{"type": "FORBIDDEN"}]}`)
+			resp.Header.Set(ssoHeader, "required; url="+testSSOURL)
+			return resp, nil
+		}),
+		state: state,
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if url := state.ssoURL.Load(); url == nil || *url != testSSOURL {
+		t.Errorf("Expected the transport to record the authorization URL, got %v", url)
+	}
+}
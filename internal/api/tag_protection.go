@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// GetProtectedTagRuleCount retrieves the count of rules protecting tags of a repository using the REST API:
+// legacy tag protection patterns plus repository rulesets targeting tags, which replaced them
+func (api *GitHubAPI) GetProtectedTagRuleCount(ctx context.Context, clientType ClientType, owner, name string) (int, error) {
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return 0, err
+	}
+
+	// Tag protection patterns were retired on GitHub.com, and rulesets are unavailable on older GHES versions
+	patterns, resp, err := client.Repositories.ListTagProtection(ctx, owner, name)
+	if err != nil && !isRetiredEndpoint(resp) {
+		return 0, fmt.Errorf("failed to query %s repository tag protection: %w", clientName, classifyError(err))
+	}
+
+	rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, name, false)
+	if err != nil && !isRetiredEndpoint(resp) {
+		return 0, fmt.Errorf("failed to query %s repository rulesets: %w", clientName, classifyError(err))
+	}
+
+	count := len(patterns)
+	for _, ruleset := range rulesets {
+		if ruleset.GetTarget() == "tag" {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// isRetiredEndpoint reports whether resp shows the endpoint does not exist on the instance
+func isRetiredEndpoint(resp *github.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone)
+}
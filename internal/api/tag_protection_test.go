@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetProtectedTagRuleCount(t *testing.T) {
+	tests := []struct {
+		name           string
+		patternsStatus int
+		patternsBody   string
+		rulesetsStatus int
+		rulesetsBody   string
+		expected       int
+		expectedError  bool
+	}{
+		{
+			name:           "patterns and tag rulesets",
+			patternsStatus: http.StatusOK,
+			patternsBody:   `[{"id": 1, "pattern": "v*"}]`,
+			rulesetsStatus: http.StatusOK,
+			rulesetsBody:   `[{"id": 1, "name": "releases", "target": "tag"}, {"id": 2, "name": "main", "target": "branch"}]`,
+			expected:       2,
+		},
+		{
+			name:           "tag protection retired",
+			patternsStatus: http.StatusGone,
+			patternsBody:   `{"message": "Gone"}`,
+			rulesetsStatus: http.StatusOK,
+			rulesetsBody:   `[{"id": 1, "name": "releases", "target": "tag"}]`,
+			expected:       1,
+		},
+		{
+			name:           "rulesets unavailable",
+			patternsStatus: http.StatusOK,
+			patternsBody:   `[{"id": 1, "pattern": "v*"}, {"id": 2, "pattern": "release-*"}]`,
+			rulesetsStatus: http.StatusNotFound,
+			rulesetsBody:   `{"message": "Not Found"}`,
+			expected:       2,
+		},
+		{
+			name:           "server error",
+			patternsStatus: http.StatusInternalServerError,
+			patternsBody:   `{"message": "Server Error"}`,
+			rulesetsStatus: http.StatusOK,
+			rulesetsBody:   `[]`,
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransport := &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					statusCode, body := tt.rulesetsStatus, tt.rulesetsBody
+					switch req.URL.Path {
+					case "/repos/owner/repo/tags/protection":
+						statusCode, body = tt.patternsStatus, tt.patternsBody
+					case "/repos/owner/repo/rulesets":
+					default:
+						t.Errorf("Unexpected API endpoint: %s", req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: statusCode,
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Header:     make(http.Header),
+						Request:    req,
+					}, nil
+				},
+			}
+
+			count, err := createTestAPI(mockTransport).GetProtectedTagRuleCount(context.Background(), SourceClient, "owner", "repo")
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d protected tag rules, got %d", tt.expected, count)
+			}
+		})
+	}
+}
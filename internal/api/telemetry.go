@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// stateTransport records the requests sent through it in the client state, including retries and
+// rate limit checks, the remaining rate limit and the SAML single sign-on authorization URLs returned by GitHub
+type stateTransport struct {
+	base  http.RoundTripper
+	state *clientState
+}
+
+func (t *stateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.state.requests.Add(1)
+
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		if url, ok := ssoAuthorizationURL(resp.Header); ok {
+			t.state.ssoURL.Store(&url)
+		}
+		if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+			t.state.rateLimit.Store(&remaining)
+		}
+	}
+	return resp, err
+}
+
+// withClientState returns config with the requests made by its clients recorded in state
+func withClientState(config ClientConfig, state *clientState) ClientConfig {
+	config.state = state
+	return config
+}
+
+// RequestCount returns the number of HTTP requests made so far with the given client.
+// It returns 0 for clients created without a client state.
+func (api *GitHubAPI) RequestCount(clientType ClientType) int {
+	if state := api.clientState(clientType); state != nil {
+		return int(state.requests.Load())
+	}
+	return 0
+}
+
+// RateLimitRemaining returns the requests remaining in the rate limit window of the given client, as reported
+// by the last response. It returns false when no response reported it yet.
+func (api *GitHubAPI) RateLimitRemaining(clientType ClientType) (int, bool) {
+	if state := api.clientState(clientType); state != nil {
+		if remaining := state.rateLimit.Load(); remaining != nil {
+			return *remaining, true
+		}
+	}
+	return 0, false
+}
+
+// clientState returns the state of the given client, or nil when it has none
+func (api *GitHubAPI) clientState(clientType ClientType) *clientState {
+	if api == nil {
+		return nil
+	}
+
+	switch clientType {
+	case SourceClient:
+		return api.sourceState
+	case TargetClient:
+		return api.targetState
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStateTransport_CountsRequests(t *testing.T) {
+	state := &clientState{}
+	transport := &stateTransport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return NewMockResponse(http.StatusOK, "{}"), nil
+		}),
+		state: state,
+	}
+
+	for range 3 {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	api := &GitHubAPI{sourceState: state}
+	if got := api.RequestCount(SourceClient); got != 3 {
+		t.Errorf("RequestCount(SourceClient) = %d, want 3", got)
+	}
+	if got := api.RequestCount(TargetClient); got != 0 {
+		t.Errorf("Expected no requests counted for a client without a counter, got %d", got)
+	}
+}
+
+func TestStateTransport_RecordsRateLimitRemaining(t *testing.T) {
+	state := &clientState{}
+	remaining := "4999"
+	transport := &stateTransport{
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp := NewMockResponse(http.StatusOK, "{}")
+			if remaining != "" {
+				resp.Header.Set("X-RateLimit-Remaining", remaining)
+			}
+			return resp, nil
+		}),
+		state: state,
+	}
+	api := &GitHubAPI{sourceState: state}
+
+	if _, ok := api.RateLimitRemaining(SourceClient); ok {
+		t.Errorf("Expected no rate limit before the first response")
+	}
+
+	for _, header := range []string{"4999", ""} {
+		remaining = header
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo", nil)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if got, ok := api.RateLimitRemaining(SourceClient); !ok || got != 4999 {
+		t.Errorf("RateLimitRemaining(SourceClient) = %d, %v, want 4999, true", got, ok)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// TemplateFile is a file of the issue templates directory, with its git blob SHA
+type TemplateFile struct {
+	Name string `json:"name"`
+	SHA  string `json:"sha"`
+}
+
+// GetIssueTemplates retrieves the files of .github/ISSUE_TEMPLATE on the default branch using GraphQL, sorted
+// by name. It returns an empty list when the repository is empty or has no issue templates directory.
+func (api *GitHubAPI) GetIssueTemplates(ctx context.Context, clientType ClientType, owner, name string) ([]TemplateFile, error) {
+	var query struct {
+		Repository struct {
+			Object *struct {
+				Tree struct {
+					Entries []struct {
+						Name string
+						Oid  string
+						Type string
+					}
+				} `graphql:"... on Tree"`
+			} `graphql:"object(expression: \"HEAD:.github/ISSUE_TEMPLATE\")"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	client, clientName, err := api.getGraphQLClient(clientType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s repository issue templates: %w", clientName, classifyError(err))
+	}
+
+	templates := []TemplateFile{}
+	if query.Repository.Object == nil {
+		return templates, nil
+	}
+	for _, entry := range query.Repository.Object.Tree.Entries {
+		// Templates are files; nested directories are not used by GitHub
+		if entry.Type == "blob" {
+			templates = append(templates, TemplateFile{Name: entry.Name, SHA: entry.Oid})
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
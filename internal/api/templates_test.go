@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIssueTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []TemplateFile
+	}{
+		{
+			name: "templates",
+			response: `{"data": {"repository": {"object": {"entries": [
+				{"name": "config.yml", "oid": "c0ffee", "type": "blob"},
+				{"name": "bug_report.yml", "oid": "badc0de", "type": "blob"},
+				{"name": "archive", "oid": "7ree", "type": "tree"}]}}}}`,
+			expected: []TemplateFile{{Name: "bug_report.yml", SHA: "badc0de"}, {Name: "config.yml", SHA: "c0ffee"}},
+		},
+		{
+			name:     "no templates directory",
+			response: `{"data": {"repository": {"object": null}}}`,
+			expected: []TemplateFile{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := createGraphQLTestAPI(func(_ string, variables map[string]interface{}) string {
+				return tt.response
+			})
+
+			templates, err := api.GetIssueTemplates(context.Background(), SourceClient, "owner", "repo")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, templates)
+		})
+	}
+}
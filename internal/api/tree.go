@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// TreeProgressFunc receives the number of tree entries walked so far while a repository tree is walked
+type TreeProgressFunc func(entries int)
+
+// pendingTree is a directory of a tree walk that has not been listed yet
+type pendingTree struct {
+	path string // Path of the directory in the repository, empty for the root
+	sha  string // Tree SHA of the directory, or the ref of the root
+}
+
+// walkTree calls visit with the path and entry of every file of the tree of ref. Each directory is listed
+// with the recursive trees API, so most trees take a single request. GitHub truncates recursive listings of
+// large trees, so a directory whose listing is truncated is listed one level at a time instead, and its
+// subdirectories are walked the same way: the walk never holds more than one listing in memory, and monorepos
+// are walked completely. It stops with an error wrapping ErrPartialData once more than maxEntries entries
+// were walked, unless maxEntries is 0, and reports its progress to progress when it is set.
+func walkTree(ctx context.Context, restClient *github.Client, owner, name, ref string, maxEntries int,
+	progress TreeProgressFunc, visit func(filePath string, entry *github.TreeEntry) error) error {
+	walked := 0
+	pending := []pendingTree{{sha: ref}}
+	for len(pending) > 0 {
+		dir := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		tree, _, err := restClient.Git.GetTree(ctx, owner, name, dir.sha, true)
+		if err != nil {
+			return classifyError(err)
+		}
+		truncated := tree.GetTruncated()
+		if truncated {
+			if tree, _, err = restClient.Git.GetTree(ctx, owner, name, dir.sha, false); err != nil {
+				return classifyError(err)
+			}
+		}
+
+		var subdirectories []pendingTree
+		for _, entry := range tree.Entries {
+			walked++
+			if maxEntries > 0 && walked > maxEntries {
+				return fmt.Errorf("the tree has more than %d entries (--max-tree-entries): %w", maxEntries, ErrPartialData)
+			}
+
+			filePath := path.Join(dir.path, entry.GetPath())
+			switch entry.GetType() {
+			case "blob":
+				if err := visit(filePath, entry); err != nil {
+					return err
+				}
+			case "tree":
+				// Recursive listings include the subdirectories with their contents
+				if truncated {
+					subdirectories = append(subdirectories, pendingTree{path: filePath, sha: entry.GetSHA()})
+				}
+			}
+		}
+		// Subdirectories are walked in listing order
+		for i := len(subdirectories) - 1; i >= 0; i-- {
+			pending = append(pending, subdirectories[i])
+		}
+
+		if progress != nil {
+			progress(walked)
+		}
+	}
+	return nil
+}
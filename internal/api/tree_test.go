@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v62/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeTestClient returns a REST client answering the tree requests of owner/repo with trees, keyed by tree SHA
+// and whether the listing is recursive
+func treeTestClient(t *testing.T, trees map[string]string, requests *[]string) *github.Client {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		key := strings.TrimPrefix(req.URL.Path, "/repos/owner/repo/git/trees/")
+		if req.URL.Query().Get("recursive") != "" {
+			key += "?recursive"
+		}
+		*requests = append(*requests, key)
+		response, ok := trees[key]
+		if !ok {
+			t.Errorf("Unexpected tree request: %s", key)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(response)), Header: make(http.Header), Request: req}, nil
+	})
+	return github.NewClient(&http.Client{Transport: transport})
+}
+
+func TestWalkTree_TruncatedListing(t *testing.T) {
+	var requests []string
+	client := treeTestClient(t, map[string]string{
+		// The recursive listing of the root is truncated, so it is listed one level at a time
+		"main?recursive": `{"sha": "root", "truncated": true, "tree": [{"path": "a.bin", "type": "blob", "sha": "a"}]}`,
+		"main": `{"sha": "root", "tree": [
+			{"path": "a.bin", "type": "blob", "sha": "a"},
+			{"path": "assets", "type": "tree", "sha": "assets"},
+			{"path": "docs", "type": "tree", "sha": "docs"},
+			{"path": "z.bin", "type": "blob", "sha": "z"}]}`,
+		"assets?recursive": `{"sha": "assets", "tree": [
+			{"path": "images", "type": "tree", "sha": "images"},
+			{"path": "images/logo.png", "type": "blob", "sha": "logo"}]}`,
+		"docs?recursive": `{"sha": "docs", "tree": [{"path": "guide.md", "type": "blob", "sha": "guide"}]}`,
+	}, &requests)
+
+	var files []string
+	var progress []int
+	err := walkTree(context.Background(), client, "owner", "repo", "main", 0, func(entries int) {
+		progress = append(progress, entries)
+	}, func(filePath string, entry *github.TreeEntry) error {
+		files = append(files, filePath)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.bin", "z.bin", "assets/images/logo.png", "docs/guide.md"}, files)
+	assert.Equal(t, []string{"main?recursive", "main", "assets?recursive", "docs?recursive"}, requests)
+	assert.Equal(t, []int{4, 6, 7}, progress)
+}
+
+func TestWalkTree_MaxEntries(t *testing.T) {
+	var requests []string
+	client := treeTestClient(t, map[string]string{
+		"main?recursive": `{"sha": "root", "tree": [
+			{"path": "a.bin", "type": "blob", "sha": "a"},
+			{"path": "b.bin", "type": "blob", "sha": "b"},
+			{"path": "c.bin", "type": "blob", "sha": "c"}]}`,
+	}, &requests)
+
+	visit := func(filePath string, entry *github.TreeEntry) error { return nil }
+	err := walkTree(context.Background(), client, "owner", "repo", "main", 2, nil, visit)
+	require.ErrorIs(t, err, ErrPartialData)
+	assert.EqualError(t, err, "the tree has more than 2 entries (--max-tree-entries): partial data")
+
+	assert.NoError(t, walkTree(context.Background(), client, "owner", "repo", "main", 3, nil, visit))
+}
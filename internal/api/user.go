@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetAuthenticatedLogin retrieves the login of the user the client's token belongs to using the REST API.
+// GitHub App installation tokens do not belong to a user and return an error.
+func (api *GitHubAPI) GetAuthenticatedLogin(clientType ClientType) (string, error) {
+	ctx := context.Background()
+
+	client, clientName, err := api.getRESTClient(clientType)
+	if err != nil {
+		return "", err
+	}
+	if client == nil {
+		return "", fmt.Errorf("no %s client configured", clientName)
+	}
+
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to query the %s authenticated user: %w", clientName, classifyError(err))
+	}
+
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RunIDHeader is the request header carrying the run ID set with --run-id, so server-side admins can
+// allowlist or trace the requests of one validation run
+const RunIDHeader = "X-GHMV-Run-Id"
+
+// userAgentProduct is the product name of the User-Agent header of the requests of the tool
+const userAgentProduct = "gh-migration-validator"
+
+// UserAgent returns the User-Agent header of the requests of a side, e.g.
+// "gh-migration-validator/v1.4.0 (source; run cutover-42)". The version and run ID are omitted when empty.
+func UserAgent(version, side, runID string) string {
+	product := userAgentProduct
+	if version != "" {
+		product += "/" + version
+	}
+	if runID != "" {
+		return fmt.Sprintf("%s (%s; run %s)", product, side, runID)
+	}
+	return fmt.Sprintf("%s (%s)", product, side)
+}
+
+// userAgentTransport sets the User-Agent and run ID headers of the requests sent through it
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	runID     string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	if t.runID != "" {
+		req.Header.Set(RunIDHeader, t.runID)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withUserAgent returns base tagging its requests with the User-Agent and run ID of config, when set
+func withUserAgent(base http.RoundTripper, config ClientConfig) http.RoundTripper {
+	if config.UserAgent == "" && config.RunID == "" {
+		return base
+	}
+	return &userAgentTransport{base: base, userAgent: config.UserAgent, runID: config.RunID}
+}
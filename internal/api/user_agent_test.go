@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "gh-migration-validator/v1.4.0 (source)", UserAgent("v1.4.0", "source", ""))
+	assert.Equal(t, "gh-migration-validator/v1.4.0 (target; run cutover-42)", UserAgent("v1.4.0", "target", "cutover-42"))
+	assert.Equal(t, "gh-migration-validator (source)", UserAgent("", "source", ""))
+}
+
+func TestCreateAuthenticatedClient_UserAgent(t *testing.T) {
+	var userAgent, runID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		runID = r.Header.Get(RunIDHeader)
+		w.Write([]byte(`{"name": "repo"}`))
+	}))
+	defer server.Close()
+
+	client, err := newGitHubClient(ClientConfig{
+		Token:     "token",
+		APIURL:    server.URL,
+		UserAgent: UserAgent("v1.4.0", "source", "cutover-42"),
+		RunID:     "cutover-42",
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.Repositories.Get(context.Background(), "owner", "repo")
+	require.NoError(t, err)
+	// The User-Agent of go-github is replaced
+	assert.Equal(t, "gh-migration-validator/v1.4.0 (source; run cutover-42)", userAgent)
+	assert.Equal(t, "cutover-42", runID)
+}
+
+func TestCreateLFSClient_UserAgent(t *testing.T) {
+	client, err := createLFSClient(ClientConfig{
+		LFSServer: LFSServerConfig{URL: "https://lfs.example.com"},
+		UserAgent: "gh-migration-validator (target)",
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gh-migration-validator (target)", r.Header.Get("User-Agent"))
+		assert.Empty(t, r.Header.Get(RunIDHeader))
+	}))
+	defer server.Close()
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuthenticatedLogin(t *testing.T) {
+	api := createTestAPI(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/user", req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"login": "octocat"}`)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	}))
+
+	login, err := api.GetAuthenticatedLogin(SourceClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+}
+
+func TestGetAuthenticatedLogin_NoClient(t *testing.T) {
+	_, err := (&GitHubAPI{}).GetAuthenticatedLogin(TargetClient)
+
+	assert.ErrorContains(t, err, "no target client configured")
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // isSafeSymlinkTarget checks if both the symlink and its target will remain within destPath after resolution.
@@ -51,8 +52,60 @@ func isSafeSymlinkTarget(symlinkPath, linkname, destPath string) (bool, error) {
 	return true, nil
 }
 
-// ExtractTarGz extracts a .tar.gz file to the specified destination directory
+// ExtractProgress is the progress of an extraction, as reported by ExtractTarGzWithProgress
+type ExtractProgress struct {
+	Files      int           // Entries extracted so far
+	Bytes      int64         // Bytes of the compressed archive read so far
+	TotalBytes int64         // Size of the compressed archive
+	Elapsed    time.Duration // Time since the extraction started
+}
+
+// ETA returns the time the extraction should still take at its average rate so far, or 0 when it is unknown
+func (p ExtractProgress) ETA() time.Duration {
+	if p.Bytes <= 0 || p.TotalBytes <= p.Bytes {
+		return 0
+	}
+	return time.Duration(float64(p.Elapsed) * float64(p.TotalBytes-p.Bytes) / float64(p.Bytes))
+}
+
+// String returns the progress for display, e.g. "1200 files, 512.0 MB of 2048.0 MB (25%), ETA 3m0s"
+func (p ExtractProgress) String() string {
+	text := fmt.Sprintf("%d files, %.1f MB", p.Files, float64(p.Bytes)/(1<<20))
+	if p.TotalBytes > 0 {
+		text += fmt.Sprintf(" of %.1f MB (%d%%)", float64(p.TotalBytes)/(1<<20), p.Bytes*100/p.TotalBytes)
+	}
+	if eta := p.ETA(); eta > 0 {
+		text += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+	return text
+}
+
+// progressInterval is the shortest time between two progress reports of an extraction
+var progressInterval = 500 * time.Millisecond
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	bytes  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// ExtractTarGz extracts a .tar.gz file to the specified destination directory. Entry names are read with
+// either separator, and names that cannot be created on the file system of the running system (reserved on
+// Windows, or differing only in case from another entry where case is ignored) are rejected rather than
+// extracted over each other.
 func ExtractTarGz(srcPath, destPath string) error {
+	return ExtractTarGzWithProgress(srcPath, destPath, nil)
+}
+
+// ExtractTarGzWithProgress extracts a .tar.gz file as ExtractTarGz does, calling progress, when set, at most
+// every progressInterval and once more when the extraction is complete
+func ExtractTarGzWithProgress(srcPath, destPath string, progress func(ExtractProgress)) error {
 	// Open the source file
 	file, err := os.Open(srcPath)
 	if err != nil {
@@ -60,8 +113,21 @@ func ExtractTarGz(srcPath, destPath string) error {
 	}
 	defer file.Close()
 
+	// The compressed bytes read measure the progress against the archive size
+	var totalBytes int64
+	if info, err := file.Stat(); err == nil {
+		totalBytes = info.Size()
+	}
+	compressed := &countingReader{reader: file}
+	started := time.Now()
+	lastReported := started
+	files := 0
+	report := func() {
+		progress(ExtractProgress{Files: files, Bytes: compressed.bytes, TotalBytes: totalBytes, Elapsed: time.Since(started)})
+	}
+
 	// Create gzip reader
-	gzipReader, err := gzip.NewReader(file)
+	gzipReader, err := gzip.NewReader(compressed)
 	if err != nil {
 		return fmt.Errorf("failed to create gzip reader: %v", err)
 	}
@@ -83,29 +149,47 @@ func ExtractTarGz(srcPath, destPath string) error {
 		resolvedDestPath = destPath
 	}
 	cleanDestPath := filepath.Clean(resolvedDestPath)
+	collisions := caseCollisions{}
 
 	// Extract files
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
+			if progress != nil {
+				report()
+			}
 			break // End of archive
 		}
 		if err != nil {
 			return fmt.Errorf("failed to read tar header: %v", err)
 		}
 
+		// Read Windows separators as forward slashes, so checks and extraction match on every system
+		name := normalizeEntryName(header.Name)
+
 		// Skip problematic paths
-		if header.Name == "" || header.Name == "." || header.Name == "./" {
+		if name == "" || name == "." || name == "./" {
 			continue
 		}
 
-		// Reject absolute paths in archives
-		if filepath.IsAbs(header.Name) {
+		// Reject absolute paths in archives, including Windows drive and UNC paths
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || hasDriveLetter(name) {
 			return fmt.Errorf("invalid file path: %s (absolute paths not allowed)", header.Name)
 		}
 
+		if windowsPaths {
+			if err := checkWindowsName(name); err != nil {
+				return err
+			}
+		}
+		if caseInsensitivePaths {
+			if err := collisions.check(name); err != nil {
+				return err
+			}
+		}
+
 		// Construct the full path for the file using the resolved destination path
-		fullPath := filepath.Join(resolvedDestPath, header.Name)
+		fullPath := filepath.Join(resolvedDestPath, filepath.FromSlash(name))
 
 		// Security check: ensure the file path is within the destination directory
 		cleanFullPath := filepath.Clean(fullPath)
@@ -132,23 +216,27 @@ func ExtractTarGz(srcPath, destPath string) error {
 			return fmt.Errorf("path escapes extraction directory after symlink resolution: %s", header.Name)
 		}
 
+		// Paths past MAX_PATH need the extended-length form on Windows
+		osPath := longPath(fullPath)
+
 		// Handle different file types
 		switch header.Typeflag {
 		case tar.TypeDir:
 			// Create directory
-			if err := os.MkdirAll(fullPath, os.FileMode(header.Mode)); err != nil {
+			if err := os.MkdirAll(osPath, os.FileMode(header.Mode)); err != nil {
 				return fmt.Errorf("failed to create directory %s: %v", fullPath, err)
 			}
 
 		case tar.TypeReg:
 			// Create file
-			if err := extractFile(tarReader, fullPath, header.Mode); err != nil {
+			if err := extractFile(tarReader, osPath, header.Mode); err != nil {
 				return fmt.Errorf("failed to extract file %s: %v", fullPath, err)
 			}
 
 		case tar.TypeSymlink:
 			// Securely create symbolic link after validating both symlink location and target
-			safe, err := isSafeSymlinkTarget(fullPath, header.Linkname, resolvedDestPath)
+			linkname := filepath.FromSlash(normalizeEntryName(header.Linkname))
+			safe, err := isSafeSymlinkTarget(fullPath, linkname, resolvedDestPath)
 			if err != nil {
 				return err
 			}
@@ -156,17 +244,24 @@ func ExtractTarGz(srcPath, destPath string) error {
 				return fmt.Errorf("symlink target escapes extraction directory: %s -> %s", fullPath, header.Linkname)
 			}
 			// Ensure parent directory exists before creating the symlink
-			symlinkDir := filepath.Dir(fullPath)
+			symlinkDir := filepath.Dir(osPath)
 			if err := os.MkdirAll(symlinkDir, 0755); err != nil {
 				return fmt.Errorf("failed to create directory for symlink %s: %v", fullPath, err)
 			}
-			if err := os.Symlink(header.Linkname, fullPath); err != nil {
+			if err := os.Symlink(linkname, osPath); err != nil {
 				return fmt.Errorf("failed to create symlink %s: %v", fullPath, err)
 			}
 
 		default:
 			// Skip other file types (block devices, character devices, etc.)
 			log.Printf("Skipping unsupported file type for %s (type: %d)\n", header.Name, header.Typeflag)
+			continue
+		}
+
+		files++
+		if progress != nil && time.Since(lastReported) >= progressInterval {
+			lastReported = time.Now()
+			report()
 		}
 	}
 
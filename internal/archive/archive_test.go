@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetArchiveDestination(t *testing.T) {
@@ -530,3 +531,51 @@ func createTestArchiveWithSymlink(path, linkname, target string) error {
 	}
 	return tw.WriteHeader(header)
 }
+
+func TestExtractTarGzWithProgress(t *testing.T) {
+	defer func(interval time.Duration) { progressInterval = interval }(progressInterval)
+	progressInterval = 0
+
+	tempDir := t.TempDir()
+	archivePath := filepath.Join(tempDir, "test.tar.gz")
+	err := createTestArchive(archivePath, []testFile{
+		{name: "dir1/", content: "", fileType: tar.TypeDir},
+		{name: "dir1/file1.txt", content: "First file", fileType: tar.TypeReg},
+		{name: "dir1/file2.txt", content: "Second file", fileType: tar.TypeReg},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test archive: %v", err)
+	}
+
+	var reports []ExtractProgress
+	if err := ExtractTarGzWithProgress(archivePath, filepath.Join(tempDir, "extract"), func(progress ExtractProgress) {
+		reports = append(reports, progress)
+	}); err != nil {
+		t.Fatalf("ExtractTarGzWithProgress failed: %v", err)
+	}
+
+	// One report per entry, and a final one
+	if len(reports) != 4 {
+		t.Fatalf("Expected 4 progress reports, got %d: %v", len(reports), reports)
+	}
+	final := reports[len(reports)-1]
+	info, _ := os.Stat(archivePath)
+	if final.Files != 3 || final.TotalBytes != info.Size() || final.Bytes <= 0 || final.Bytes > final.TotalBytes {
+		t.Errorf("Unexpected final progress: %+v (archive size %d)", final, info.Size())
+	}
+}
+
+func TestExtractProgress(t *testing.T) {
+	progress := ExtractProgress{Files: 1200, Bytes: 512 << 20, TotalBytes: 2048 << 20, Elapsed: time.Minute}
+	if eta := progress.ETA(); eta != 3*time.Minute {
+		t.Errorf("Expected an ETA of 3m, got %s", eta)
+	}
+	if expected := "1200 files, 512.0 MB of 2048.0 MB (25%), ETA 3m0s"; progress.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, progress.String())
+	}
+
+	done := ExtractProgress{Files: 10, Bytes: 1 << 20, TotalBytes: 1 << 20, Elapsed: time.Second}
+	if expected := "10 files, 1.0 MB of 1.0 MB (100%)"; done.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, done.String())
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package archive
+
+// longPath returns path unchanged: only Windows limits path lengths to MAX_PATH
+func longPath(path string) string {
+	return path
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package archive
+
+import "path/filepath"
+
+// longPath returns path in the extended-length form Windows needs for paths of MAX_PATH characters or more
+func longPath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return extendedLengthPath(absPath)
+}
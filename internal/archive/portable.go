@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// windowsPaths is set when extracting on Windows, whose file names have restrictions other systems lack
+var windowsPaths = runtime.GOOS == "windows"
+
+// caseInsensitivePaths is set when extracting on systems whose file systems ignore case by default, where two
+// entries differing only in case would overwrite each other
+var caseInsensitivePaths = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// windowsMaxPath is the length from which Windows paths need the extended-length \\?\ prefix. Directories are
+// limited to MAX_PATH (260) minus the 12 characters of an 8.3 file name.
+const windowsMaxPath = 248
+
+// windowsReservedNames are the device names Windows reserves, with or without an extension
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// normalizeEntryName returns the name of a tar entry with forward slashes only, so names written with Windows
+// separators extract to the same tree, and are checked the same way, on every system
+func normalizeEntryName(name string) string {
+	return strings.ReplaceAll(name, `\`, "/")
+}
+
+// hasDriveLetter reports whether the normalized entry name starts with a Windows drive, e.g. C:/ or C:
+func hasDriveLetter(name string) bool {
+	return len(name) >= 2 && name[1] == ':' && ('a' <= name[0] && name[0] <= 'z' || 'A' <= name[0] && name[0] <= 'Z')
+}
+
+// checkWindowsName returns an error when a component of the normalized entry name cannot be created on
+// Windows: reserved device names, reserved characters, and names ending with a dot or a space
+func checkWindowsName(name string) error {
+	for _, component := range strings.Split(strings.Trim(name, "/"), "/") {
+		if component == "" || component == "." || component == ".." {
+			continue
+		}
+		if strings.ContainsAny(component, `<>:"|?*`) || strings.IndexFunc(component, func(r rune) bool { return r < 32 }) >= 0 {
+			return fmt.Errorf("invalid file path: %s (%q has characters Windows does not allow)", name, component)
+		}
+		if strings.HasSuffix(component, ".") || strings.HasSuffix(component, " ") {
+			return fmt.Errorf("invalid file path: %s (%q ends with a dot or space, which Windows drops)", name, component)
+		}
+		base, _, _ := strings.Cut(component, ".")
+		if windowsReservedNames[strings.ToUpper(strings.TrimRight(base, " "))] {
+			return fmt.Errorf("invalid file path: %s (%q is a reserved device name on Windows)", name, component)
+		}
+	}
+	return nil
+}
+
+// caseCollisions detects entries whose names differ only in case, by case-folded name
+type caseCollisions map[string]string
+
+// check returns an error when the normalized entry name differs only in case from an earlier entry. The same
+// name appearing twice, as a directory listed before its files, is not a collision.
+func (c caseCollisions) check(name string) error {
+	cleanName := path.Clean(name)
+	folded := strings.ToLower(cleanName)
+	if earlier, found := c[folded]; found && earlier != cleanName {
+		return fmt.Errorf("invalid file path: %s (collides with %s on case-insensitive file systems)", name, earlier)
+	}
+	c[folded] = cleanName
+	return nil
+}
+
+// extendedLengthPath returns absPath, an absolute Windows path, with the \\?\ prefix lifting the MAX_PATH
+// limit when it is long enough to need it
+func extendedLengthPath(absPath string) string {
+	if len(absPath) < windowsMaxPath || strings.HasPrefix(absPath, `\\?\`) {
+		return absPath
+	}
+	if strings.HasPrefix(absPath, `\\`) {
+		return `\\?\UNC\` + absPath[2:] // \\server\share\path
+	}
+	return `\\?\` + absPath
+}
@@ -0,0 +1,143 @@
+package archive
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckWindowsName(t *testing.T) {
+	tests := []struct {
+		name          string
+		errorContains string
+	}{
+		{name: "issues_000001.json"},
+		{name: "attachments/abc123/screenshot.png"},
+		{name: "repositories/console/readme.md"},
+		{name: "attachments/CON", errorContains: "reserved device name"},
+		{name: "attachments/nul.txt", errorContains: "reserved device name"},
+		{name: "Lpt1 .log", errorContains: "reserved device name"},
+		{name: "attachments/a:b.png", errorContains: "characters Windows does not allow"},
+		{name: "what?.txt", errorContains: "characters Windows does not allow"},
+		{name: "notes.", errorContains: "ends with a dot or space"},
+		{name: "dir /file.txt", errorContains: "ends with a dot or space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWindowsName(tt.name)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestCaseCollisions(t *testing.T) {
+	collisions := caseCollisions{}
+	for _, name := range []string{"attachments/", "attachments/a.png", "attachments/a.png", "Issues_000001.json"} {
+		if err := collisions.check(name); err != nil {
+			t.Errorf("%s: expected no collision, got %v", name, err)
+		}
+	}
+
+	if err := collisions.check("attachments/A.png"); err == nil || !strings.Contains(err.Error(), "collides with attachments/a.png") {
+		t.Errorf("Expected a collision, got %v", err)
+	}
+	if err := collisions.check("issues_000001.json"); err == nil {
+		t.Error("Expected a collision with Issues_000001.json")
+	}
+}
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := strings.Repeat("a", windowsMaxPath)
+	tests := map[string]string{
+		`C:\archives\short`:       `C:\archives\short`,
+		`C:\archives\` + long:     `\\?\C:\archives\` + long,
+		`\\server\share\` + long:  `\\?\UNC\server\share\` + long,
+		`\\?\C:\archives\` + long: `\\?\C:\archives\` + long,
+	}
+	for input, expected := range tests {
+		if result := extendedLengthPath(input); result != expected {
+			t.Errorf("extendedLengthPath(%.30q...) = %.40q..., want %.40q...", input, result, expected)
+		}
+	}
+}
+
+func TestExtractTarGz_WindowsNames(t *testing.T) {
+	defer func(windows, caseInsensitive bool) {
+		windowsPaths, caseInsensitivePaths = windows, caseInsensitive
+	}(windowsPaths, caseInsensitivePaths)
+	windowsPaths, caseInsensitivePaths = true, true
+
+	tests := []struct {
+		name          string
+		files         []testFile
+		errorContains string
+	}{
+		{
+			name:          "reserved device name",
+			files:         []testFile{{name: "attachments/aux.png", content: "image", fileType: tar.TypeReg}},
+			errorContains: "reserved device name",
+		},
+		{
+			name: "case collision",
+			files: []testFile{
+				{name: "README.md", content: "upper", fileType: tar.TypeReg},
+				{name: "readme.md", content: "lower", fileType: tar.TypeReg},
+			},
+			errorContains: "collides with README.md",
+		},
+		{
+			name:          "drive letter",
+			files:         []testFile{{name: `C:\Windows\system.ini`, content: "content", fileType: tar.TypeReg}},
+			errorContains: "absolute paths not allowed",
+		},
+		{
+			name:          "backslash traversal",
+			files:         []testFile{{name: `dir\..\..\evil.txt`, content: "content", fileType: tar.TypeReg}},
+			errorContains: "outside",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archivePath := filepath.Join(tempDir, "test.tar.gz")
+			if err := createTestArchive(archivePath, tt.files); err != nil {
+				t.Fatalf("Failed to create test archive: %v", err)
+			}
+
+			err := ExtractTarGz(archivePath, filepath.Join(tempDir, "extract"))
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_BackslashSeparators(t *testing.T) {
+	tempDir := t.TempDir()
+	archivePath := filepath.Join(tempDir, "test.tar.gz")
+	extractPath := filepath.Join(tempDir, "extract")
+
+	if err := createTestArchive(archivePath, []testFile{
+		{name: `attachments\abc123\screenshot.png`, content: "image", fileType: tar.TypeReg},
+	}); err != nil {
+		t.Fatalf("Failed to create test archive: %v", err)
+	}
+	if err := ExtractTarGz(archivePath, extractPath); err != nil {
+		t.Fatalf("ExtractTarGz failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(extractPath, "attachments", "abc123", "screenshot.png"))
+	if err != nil || string(content) != "image" {
+		t.Errorf("Expected the entry to be extracted under attachments/abc123, got %q (error: %v)", content, err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package audit appends a record of every validation run to a JSON Lines file, as evidence of the
+// validations performed
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Entry is one line of the audit log
+type Entry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	User        string    `json:"user"`                   // Operating system user running the tool
+	Host        string    `json:"host,omitempty"`         // Machine the tool ran on
+	GitHubLogin string    `json:"github_login,omitempty"` // Login of the token used, when it could be read
+	Command     string    `json:"command"`
+	Source      string    `json:"source"`
+	Target      string    `json:"target"`
+	Verdict     string    `json:"verdict"`
+	Passed      int       `json:"passed"`
+	Failed      int       `json:"failed"`
+	Warnings    int       `json:"warnings"`
+	ToolVersion string    `json:"tool_version"`
+	// PreviousHash is the SHA-256 of the previous line, so removed or edited lines break the chain
+	PreviousHash string `json:"previous_hash"`
+}
+
+// Append writes entry as the last line of the audit log at path, creating the file when needed. The file is
+// only ever opened for appending and its lines are never rewritten. An exclusive lock on the file is held
+// while the last line is read and the entry written, so that runs sharing the log do not break its chain.
+func Append(path string, entry Entry) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock audit log %s: %w", path, err)
+	}
+	defer unlockFile(file)
+
+	previous, err := lastLine(file)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	entry.PreviousHash = hashLine(previous)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log entry: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", path, err)
+	}
+	return nil
+}
+
+// Verify checks the hash chain of the audit log at path and returns the number of entries. An error names
+// the first line that does not follow the line before it.
+func Verify(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var previous []byte
+	count := 0
+	for scanner.Scan() {
+		count++
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count - 1, fmt.Errorf("line %d is not a valid audit log entry: %v", count, err)
+		}
+		if entry.PreviousHash != hashLine(previous) {
+			return count - 1, fmt.Errorf("line %d does not follow line %d: the audit log was modified", count, count-1)
+		}
+		previous = append(previous[:0], scanner.Bytes()...)
+	}
+	return count, scanner.Err()
+}
+
+// hashLine returns the hex SHA-256 of a line without its newline, or an empty string for the first entry
+func hashLine(line []byte) string {
+	if len(line) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+// lastLineChunkSize is the size of the blocks lastLine reads backwards from the end of the file
+const lastLineChunkSize = 4096
+
+// lastLine returns the last non-empty line of file, without its newline. The file is read backwards from its
+// end, so that only the last line is read however long the log grows.
+func lastLine(file *os.File) ([]byte, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	for end > 0 {
+		start := max(end-lastLineChunkSize, 0)
+		chunk := make([]byte, end-start)
+		if _, err := file.ReadAt(chunk, start); err != nil {
+			return nil, err
+		}
+		data = append(chunk, data...)
+		end = start
+
+		line := bytes.TrimRight(data, "\n")
+		if i := bytes.LastIndexByte(line, '\n'); i >= 0 {
+			return line[i+1:], nil
+		}
+	}
+	return bytes.TrimRight(data, "\n"), nil
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEntry(target, verdict string) Entry {
+	return Entry{
+		Timestamp:   time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		User:        "auditor",
+		Command:     "validate",
+		Source:      "source-org/repo",
+		Target:      target,
+		Verdict:     verdict,
+		ToolVersion: "v1.4.0",
+	}
+}
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	require.NoError(t, Append(path, newEntry("target-org/repo", "passed")))
+	require.NoError(t, Append(path, newEntry("target-org/other", "failed")))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "target-org/repo", entries[0].Target)
+	assert.Empty(t, entries[0].PreviousHash, "The first entry has no previous line")
+	assert.Equal(t, "failed", entries[1].Verdict)
+	assert.Len(t, entries[1].PreviousHash, 64)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for _, target := range []string{"target-org/a", "target-org/b", "target-org/c"} {
+		require.NoError(t, Append(path, newEntry(target, "passed")))
+	}
+
+	count, err := Verify(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Editing the verdict of the second run breaks the chain at the third line
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(string(data), "\n")
+	lines[1] = strings.Replace(lines[1], `"verdict":"passed"`, `"verdict":"failed"`, 1)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+
+	count, err = Verify(path)
+	assert.ErrorContains(t, err, "line 3 does not follow line 2")
+	assert.Equal(t, 2, count)
+}
+
+func TestAppend_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, Append(path, newEntry(fmt.Sprintf("target-org/repo-%d", i), "passed")))
+		}()
+	}
+	wg.Wait()
+
+	count, err := Verify(path)
+	require.NoError(t, err, "concurrent appends keep the chain intact")
+	assert.Equal(t, 20, count)
+}
+
+func TestLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	long := strings.Repeat("x", 3*lastLineChunkSize)
+	require.NoError(t, os.WriteFile(path, []byte("first\n"+long+"\n\n"), 0o600))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	line, err := lastLine(file)
+	require.NoError(t, err)
+	assert.Equal(t, long, string(line), "lines longer than a chunk are read whole")
+
+	require.NoError(t, os.WriteFile(path, []byte("only"), 0o600))
+	line, err = lastLine(file)
+	require.NoError(t, err)
+	assert.Equal(t, "only", string(line))
+}
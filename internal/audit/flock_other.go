@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package audit
+
+import "os"
+
+// lockFile does nothing: file locks are only available on Unix and Windows
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing, as lockFile
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package audit
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockRange is the byte range locked by lockFile: one byte far beyond the end of any audit log, so that the
+// lock does not keep other processes from reading the log
+const lockRange = ^uint32(0)
+
+// lockFile takes an exclusive lock on file, waiting for other processes holding it to release it
+func lockFile(file *os.File) error {
+	overlapped := windows.Overlapped{Offset: lockRange, OffsetHigh: lockRange}
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &overlapped)
+}
+
+// unlockFile releases the lock taken by lockFile
+func unlockFile(file *os.File) error {
+	overlapped := windows.Overlapped{Offset: lockRange, OffsetHigh: lockRange}
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &overlapped)
+}
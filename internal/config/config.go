@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the configuration file inside the configuration directory
+const FileName = "config.yaml"
+
+// secretKeySuffixes identify profile keys holding credentials, e.g. target-token or webhook-secret. Keys are
+// matched by whole name or suffix so that keys such as download-path are not mistaken for a "pat".
+var secretKeySuffixes = []string{"token", "pat", "private-key", "secret"}
+
+// File is the parsed configuration file
+type File struct {
+	Path     string
+	Profiles map[string]Profile `yaml:"profiles"`
+	Labels   map[string]string  `yaml:"labels"` // Status labels keyed by lowercase status name, e.g. pass: "OK"
+}
+
+// Profile maps flag names (without leading dashes) to their values
+type Profile map[string]interface{}
+
+// DefaultPath returns ~/.config/gh-migration-validator/config.yaml, honouring XDG_CONFIG_HOME
+func DefaultPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, "gh-migration-validator", FileName), nil
+}
+
+// Load reads and parses the configuration file at path.
+// Files containing credentials must not be accessible by other users (0600).
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	file := &File{Path: path}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if file.hasSecrets() {
+		if err := checkPermissions(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+// Profile returns the named profile
+func (f *File) Profile(name string) (Profile, error) {
+	profile, ok := f.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(f.Profiles))
+		for profileName := range f.Profiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("profile %q not found in %s (available: %s)", name, f.Path, strings.Join(names, ", "))
+	}
+
+	return profile, nil
+}
+
+// Values returns the profile values as strings keyed by flag name
+func (p Profile) Values() map[string]string {
+	values := make(map[string]string, len(p))
+	for key, value := range p {
+		values[strings.TrimLeft(key, "-")] = fmt.Sprint(value)
+	}
+	return values
+}
+
+// hasSecrets reports whether any profile contains a credential
+func (f *File) hasSecrets() bool {
+	for _, profile := range f.Profiles {
+		for key := range profile {
+			if isSecretKey(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSecretKey reports whether a profile key holds a credential
+func isSecretKey(key string) bool {
+	key = strings.ToLower(strings.TrimLeft(key, "-"))
+	for _, suffix := range secretKeySuffixes {
+		if key == suffix || strings.HasSuffix(key, "-"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPermissions ensures the file is not readable or writable by group or other users
+func checkPermissions(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat config file %s: %w", path, err)
+	}
+
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("config file %s contains credentials but has permissions %04o; restrict it with: chmod 600 %s", path, perm, path)
+	}
+
+	return nil
+}
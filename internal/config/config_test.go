@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `profiles:
+  prod-ghes:
+    source-hostname: https://ghes.example.com
+    source-token: ghp_source
+    target-token: ghp_target
+    no-lfs: true
+    issue-offset: 2
+  cloud:
+    target-org: target-org
+`
+
+func writeConfig(t *testing.T, content string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), perm))
+	require.NoError(t, os.Chmod(path, perm))
+	return path
+}
+
+func TestLoad_Profile(t *testing.T) {
+	file, err := Load(writeConfig(t, testConfig, 0o600))
+	require.NoError(t, err)
+
+	profile, err := file.Profile("prod-ghes")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"source-hostname": "https://ghes.example.com",
+		"source-token":    "ghp_source",
+		"target-token":    "ghp_target",
+		"no-lfs":          "true",
+		"issue-offset":    "2",
+	}, profile.Values())
+
+	_, err = file.Profile("missing")
+	assert.EqualError(t, err, `profile "missing" not found in `+file.Path+` (available: cloud, prod-ghes)`)
+}
+
+func TestLoad_Permissions(t *testing.T) {
+	_, err := Load(writeConfig(t, testConfig, 0o644))
+	assert.ErrorContains(t, err, "contains credentials but has permissions 0644")
+
+	// Files without credentials may be shared
+	_, err = Load(writeConfig(t, "profiles:\n  cloud:\n    target-org: target-org\n", 0o644))
+	assert.NoError(t, err)
+
+	_, err = Load(writeConfig(t, "profiles:\n  cloud:\n    download-path: /tmp/archives\n    archive-path: a.tar.gz\n", 0o644))
+	assert.NoError(t, err, "paths are not credentials")
+}
+
+func TestIsSecretKey(t *testing.T) {
+	for _, key := range []string{"source-token", "target-lfs-token", "github-target-pat", "webhook-secret", "app-private-key", "--target-token", "token"} {
+		assert.True(t, isSecretKey(key), key)
+	}
+	for _, key := range []string{"download-path", "archive-path", "token-file", "target-org", "no-lfs"} {
+		assert.False(t, isSecretKey(key), key)
+	}
+}
+
+func TestLoad_Labels(t *testing.T) {
+	file, err := Load(writeConfig(t, "labels:\n  pass: OK\n  fail: MISSING\n", 0o644))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pass": "OK", "fail": "MISSING"}, file.Labels)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	_, err = Load(writeConfig(t, "profiles: [", 0o600))
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/xdg/gh-migration-validator/config.yaml", path)
+}
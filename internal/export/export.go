@@ -7,8 +7,10 @@ import (
 	"mona-actions/gh-migration-validator/internal/migrationarchive"
 	"mona-actions/gh-migration-validator/internal/output"
 	"mona-actions/gh-migration-validator/internal/validator"
+	"mona-actions/gh-migration-validator/internal/xlsx"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,12 +22,18 @@ type ExportData struct {
 	ExportTimestamp  time.Time                                 `json:"export_timestamp"`
 	Repository       validator.RepositoryData                  `json:"repository_data"`
 	MigrationArchive *migrationarchive.MigrationArchiveMetrics `json:"migration_archive,omitempty"`
+	Tool             *ToolMetadata                             `json:"tool,omitempty"` // nil in exports of earlier versions
+	// PreviousSnapshots are the earlier exports of the repository to the same file, oldest first
+	PreviousSnapshots []Snapshot `json:"previous_snapshots,omitempty"`
 }
 
 // ExportSourceData exports source repository data at a point in time
 // Takes a validator instance to leverage existing data retrieval functionality
-// If migrationArchiveDir is provided, it will analyze and include migration archive metrics
-func ExportSourceData(mv *validator.MigrationValidator, owner, repoName, format, outputFile string, timestamp time.Time, migrationArchiveDir string) error {
+// If migrationArchiveDir is provided, it will analyze and include migration archive metrics,
+// reusing the metrics cached in the archive directory by an earlier run when archiveCache is set
+// The tool metadata, when not nil, records how the export was taken
+// Returns the path of the written export file
+func ExportSourceData(mv *validator.MigrationValidator, owner, repoName, format, outputFile string, timestamp time.Time, migrationArchiveDir string, archiveCache bool, tool *ToolMetadata) (string, error) {
 	fmt.Println("Starting source repository data export...")
 	fmt.Printf("Repository: %s/%s\n", owner, repoName)
 
@@ -36,31 +44,51 @@ func ExportSourceData(mv *validator.MigrationValidator, owner, repoName, format,
 	errorMsgs, err := mv.RetrieveSourceData(owner, repoName, spinner)
 
 	// Log any API errors (safe to call after spinner finishes)
-	output.LogAPIErrors(errorMsgs, owner, repoName, err)
+	output.LogAPIErrors(output.NewLogger(os.Stdout), errorMsgs, owner, repoName, err)
 
 	if err != nil {
-		return fmt.Errorf("failed to retrieve source data for export: %w", err)
+		return "", fmt.Errorf("failed to retrieve source data for export: %w", err)
 	}
 
 	// Prepare export data
 	exportData := ExportData{
 		ExportTimestamp: timestamp,
 		Repository:      *mv.SourceData,
+		Tool:            tool,
 	}
 
 	// Analyze migration archive if provided
 	if migrationArchiveDir != "" {
 		archiveSpinner, _ := pterm.DefaultSpinner.Start("Analyzing migration archive metrics...")
 
-		archiveMetrics, err := migrationarchive.AnalyzeMigrationArchive(migrationArchiveDir)
+		// Organization archives hold several repositories, so only the entities of this one are counted
+		archiveRepository := owner + "/" + repoName
+		var archiveMetrics *migrationarchive.MigrationArchiveMetrics
+		cached := false
+		if archiveCache {
+			archiveMetrics, cached, err = migrationarchive.AnalyzeMigrationArchiveCached(migrationArchiveDir, archiveRepository)
+		} else {
+			archiveMetrics, err = migrationarchive.AnalyzeMigrationArchive(migrationArchiveDir, archiveRepository)
+		}
 		if err != nil {
 			archiveSpinner.Fail("Failed to analyze migration archive")
-			return fmt.Errorf("failed to analyze migration archive: %w", err)
+			return "", fmt.Errorf("failed to analyze migration archive: %w", err)
 		}
 
 		exportData.MigrationArchive = archiveMetrics
-		archiveSpinner.Success(fmt.Sprintf("Migration archive analyzed - Issues: %d, PRs: %d, Protected Branches: %d, Releases: %d",
-			archiveMetrics.Issues, archiveMetrics.PullRequests, archiveMetrics.ProtectedBranches, archiveMetrics.Releases))
+		analyzed := "analyzed"
+		if cached {
+			analyzed = "metrics read from " + migrationarchive.CacheFileName
+		}
+		archiveSpinner.Success(fmt.Sprintf("Migration archive %s - Issues: %d, PRs: %d, Protected Branches: %d, Releases: %d, Commit Comments: %d", analyzed,
+			archiveMetrics.Issues, archiveMetrics.PullRequests, archiveMetrics.ProtectedBranches, archiveMetrics.Releases, archiveMetrics.CommitComments))
+		if broken := archiveMetrics.BrokenReferences; len(broken) > 0 {
+			pterm.Warning.Printfln("The migration archive has %d broken references, which would fail its import:\n  %s",
+				len(broken), strings.Join(broken, "\n  "))
+		}
+		if archiveMetrics.SchemaWarning != "" {
+			pterm.Warning.Printfln("Migration archive: %s", archiveMetrics.SchemaWarning)
+		}
 	}
 
 	// Generate output filename if not provided
@@ -69,22 +97,32 @@ func ExportSourceData(mv *validator.MigrationValidator, owner, repoName, format,
 	}
 
 	// Export based on format
+	var previous *ExportData
 	switch strings.ToLower(format) {
 	case "json":
+		// Exporting again to the same file keeps the earlier exports, for pre-freeze verification
+		if previous = loadPreviousExport(outputFile, owner, repoName); previous != nil {
+			appendSnapshot(&exportData, previous)
+		}
 		err = exportToJSON(exportData, outputFile)
 	case "csv":
 		err = exportToCSV(exportData, outputFile)
+	case "xlsx":
+		err = exportToXLSX(exportData, outputFile)
 	default:
-		return fmt.Errorf("unsupported format: %s. Supported formats: json, csv", format)
+		return "", fmt.Errorf("unsupported format: %s. Supported formats: json, csv, xlsx", format)
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to export data: %w", err)
+		return "", fmt.Errorf("failed to export data: %w", err)
 	}
 
 	spinner.Success(fmt.Sprintf("Export completed successfully: %s", outputFile))
+	if previous != nil {
+		printExportDiff(previous, exportData.Repository)
+	}
 	fmt.Println()
-	return nil
+	return outputFile, nil
 }
 
 // generateExportFileName creates a default filename for the export in a .exports directory
@@ -119,6 +157,18 @@ func exportToJSON(data ExportData, filename string) error {
 	return nil
 }
 
+// archiveCSVHeader are the columns of the migration archive counts, added to CSV exports with a migration archive
+var archiveCSVHeader = []string{
+	"archive_issues_count",
+	"archive_pull_requests_count",
+	"archive_protected_branches_count",
+	"archive_releases_count",
+	"archive_commit_comments_count",
+	"archive_references_count",
+	"archive_broken_references",
+	"archive_schema_version",
+}
+
 // exportToCSV exports data to CSV format
 func exportToCSV(data ExportData, filename string) error {
 	// Create directory if it doesn't exist
@@ -150,10 +200,14 @@ func exportToCSV(data ExportData, filename string) error {
 		"tags_count",
 		"releases_count",
 		"commits_count",
+		"commit_comments_count",
 		"latest_commit_sha",
 		"branch_protection_rules_count",
 		"webhooks_count",
 	}
+	if data.MigrationArchive != nil {
+		header = append(header, archiveCSVHeader...)
+	}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write CSV header: %w", err)
 	}
@@ -179,10 +233,23 @@ func exportToCSV(data ExportData, filename string) error {
 		fmt.Sprintf("%d", data.Repository.Tags),
 		fmt.Sprintf("%d", data.Repository.Releases),
 		fmt.Sprintf("%d", data.Repository.CommitCount),
+		fmt.Sprintf("%d", data.Repository.CommitComments),
 		data.Repository.LatestCommitSHA,
 		fmt.Sprintf("%d", data.Repository.BranchProtectionRules),
 		fmt.Sprintf("%d", data.Repository.Webhooks),
 	}
+	if archive := data.MigrationArchive; archive != nil {
+		record = append(record,
+			fmt.Sprintf("%d", archive.Issues),
+			fmt.Sprintf("%d", archive.PullRequests),
+			fmt.Sprintf("%d", archive.ProtectedBranches),
+			fmt.Sprintf("%d", archive.Releases),
+			fmt.Sprintf("%d", archive.CommitComments),
+			fmt.Sprintf("%d", archive.References),
+			strings.Join(archive.BrokenReferences, ";"),
+			archive.SchemaVersion,
+		)
+	}
 	if err := writer.Write(record); err != nil {
 		return fmt.Errorf("failed to write CSV record: %w", err)
 	}
@@ -190,6 +257,122 @@ func exportToCSV(data ExportData, filename string) error {
 	return nil
 }
 
+// exportToXLSX exports data to an XLSX workbook with one sheet per data category
+func exportToXLSX(data ExportData, filename string) error {
+	return xlsx.WriteFile(filename, xlsxSheets(data))
+}
+
+// xlsxSheets returns the sheets of the XLSX export: the repository, its counts, and the branches, LFS patterns,
+// issue templates and migration archive counts when the export has them
+func xlsxSheets(data ExportData) []xlsx.Sheet {
+	repository := data.Repository
+	sheets := []xlsx.Sheet{
+		{Name: "Repository", Rows: [][]interface{}{
+			{"Field", "Value"},
+			{"Export Timestamp", data.ExportTimestamp.Format(time.RFC3339)},
+			{"Owner", repository.Owner},
+			{"Name", repository.Name},
+			{"Renamed From", repository.RenamedFrom},
+			{"Fork Parent", repository.ForkParent},
+			{"Latest Commit SHA", repository.LatestCommitSHA},
+			{"Default Branch Tree SHA", repository.TreeSHA},
+		}},
+	}
+
+	counts := [][]interface{}{{"Metric", "Count"}}
+	for _, count := range repositoryCounts(repository) {
+		counts = append(counts, []interface{}{count.name, count.value})
+	}
+	sheets = append(sheets, xlsx.Sheet{Name: "Counts", Rows: counts})
+
+	if len(repository.Branches) > 0 {
+		names := make([]string, 0, len(repository.Branches))
+		for name := range repository.Branches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		rows := [][]interface{}{{"Branch", "Head SHA", "Commits"}}
+		for _, name := range names {
+			head := repository.Branches[name]
+			rows = append(rows, []interface{}{name, head.SHA, head.Commits})
+		}
+		sheets = append(sheets, xlsx.Sheet{Name: "Branches", Rows: rows})
+	}
+
+	if len(repository.LFSPatterns) > 0 {
+		rows := [][]interface{}{{"Pattern"}}
+		for _, pattern := range repository.LFSPatterns {
+			rows = append(rows, []interface{}{pattern})
+		}
+		sheets = append(sheets, xlsx.Sheet{Name: "LFS Patterns", Rows: rows})
+	}
+
+	if len(repository.IssueTemplates) > 0 {
+		rows := [][]interface{}{{"File", "Blob SHA"}}
+		for _, template := range repository.IssueTemplates {
+			rows = append(rows, []interface{}{template.Name, template.SHA})
+		}
+		sheets = append(sheets, xlsx.Sheet{Name: "Issue Templates", Rows: rows})
+	}
+
+	if archive := data.MigrationArchive; archive != nil {
+		sheets = append(sheets, xlsx.Sheet{Name: "Migration Archive", Rows: [][]interface{}{
+			{"Metric", "Count"},
+			{"Issues", archive.Issues},
+			{"Pull Requests", archive.PullRequests},
+			{"Protected Branches", archive.ProtectedBranches},
+			{"Releases", archive.Releases},
+			{"Commit Comments", archive.CommitComments},
+			{"File References", archive.References},
+			{"Broken References", len(archive.BrokenReferences)},
+			{"Schema Version", archive.SchemaVersion},
+		}})
+	}
+
+	if tool := data.Tool; tool != nil {
+		rows := [][]interface{}{
+			{"Field", "Value"},
+			{"Version", tool.Version},
+			{"Auth Mode", tool.AuthMode},
+			{"Hostname", tool.Hostname},
+		}
+		sheets = append(sheets, xlsx.Sheet{Name: "Tool", Rows: append(rows, tool.flagRows()...)})
+	}
+
+	return sheets
+}
+
+// repositoryCount is a count of the exported repository data
+type repositoryCount struct {
+	name  string
+	value int
+}
+
+// repositoryCounts returns the counts of the repository data, in report order
+func repositoryCounts(repository validator.RepositoryData) []repositoryCount {
+	counts := []repositoryCount{{"Issues", repository.Issues}}
+	if repository.PRs != nil {
+		counts = append(counts,
+			repositoryCount{"Pull Requests (Open)", repository.PRs.Open},
+			repositoryCount{"Pull Requests (Closed)", repository.PRs.Closed},
+			repositoryCount{"Pull Requests (Merged)", repository.PRs.Merged},
+			repositoryCount{"Pull Requests (Total)", repository.PRs.Total},
+		)
+	}
+	return append(counts,
+		repositoryCount{"Tags", repository.Tags},
+		repositoryCount{"Releases", repository.Releases},
+		repositoryCount{"Branches", repository.BranchCount},
+		repositoryCount{"Commits", repository.CommitCount},
+		repositoryCount{"Commit Comments", repository.CommitComments},
+		repositoryCount{"Branch Protection Rules", repository.BranchProtectionRules},
+		repositoryCount{"Protected Tag Rules", repository.ProtectedTagRules},
+		repositoryCount{"Webhooks", repository.Webhooks},
+		repositoryCount{"LFS Objects", repository.LFSObjects},
+	)
+}
+
 // LoadExportData loads and validates export data from a JSON file
 func LoadExportData(filename string) (*ExportData, error) {
 	// Check if file exists
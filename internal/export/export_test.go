@@ -1,6 +1,7 @@
 package export
 
 import (
+	"archive/zip"
 	"encoding/csv"
 	"encoding/json"
 	"mona-actions/gh-migration-validator/internal/api"
@@ -8,6 +9,7 @@ import (
 	"mona-actions/gh-migration-validator/internal/validator"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -173,6 +175,45 @@ func TestExportToCSV(t *testing.T) {
 	}
 }
 
+func TestExportToCSV_MigrationArchive(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.csv")
+	exportData := createTestExportData()
+	exportData.MigrationArchive = &migrationarchive.MigrationArchiveMetrics{
+		Issues: 40, PullRequests: 20, ProtectedBranches: 1, Releases: 3, CommitComments: 2,
+		References: 5, BrokenReferences: []string{"tarball://root/attachments/1/a.png", "urls.json (missing)"},
+		SchemaVersion: "1.2.0",
+	}
+
+	if err := exportToCSV(exportData, filename); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+
+	header, row := records[0], records[1]
+	columns := make(map[string]string, len(header))
+	for i, name := range header {
+		columns[name] = row[i]
+	}
+	if columns["archive_issues_count"] != "40" || columns["archive_releases_count"] != "3" || columns["archive_references_count"] != "5" {
+		t.Errorf("Expected the archive counts in the CSV, got %v", columns)
+	}
+	if got := columns["archive_broken_references"]; got != "tarball://root/attachments/1/a.png;urls.json (missing)" {
+		t.Errorf("Expected the broken references in the CSV, got %q", got)
+	}
+	if got := columns["archive_schema_version"]; got != "1.2.0" {
+		t.Errorf("Expected the schema version in the CSV, got %q", got)
+	}
+}
+
 func TestExportToJSON_CreateDirectory(t *testing.T) {
 	// Create a temporary directory for test files
 	tmpDir := t.TempDir()
@@ -238,3 +279,47 @@ func TestExportToCSV_CreateDirectory(t *testing.T) {
 		t.Fatalf("Expected file to be created at %s", filename)
 	}
 }
+
+func TestXLSXSheets(t *testing.T) {
+	sheets := xlsxSheets(createTestExportData())
+
+	var names []string
+	for _, sheet := range sheets {
+		names = append(names, sheet.Name)
+	}
+	if strings.Join(names, ",") != "Repository,Counts" {
+		t.Errorf("Expected the Repository and Counts sheets only, got %v", names)
+	}
+	if sheets[1].Rows[1][0] != "Issues" || sheets[1].Rows[1][1] != 42 {
+		t.Errorf("Expected 42 issues in the first count row, got %v", sheets[1].Rows[1])
+	}
+
+	sheets = xlsxSheets(createTestExportDataWithMigrationArchive())
+	if last := sheets[len(sheets)-1]; last.Name != "Migration Archive" || last.Rows[2][1] != 29 {
+		t.Errorf("Expected a Migration Archive sheet with 29 pull requests, got %+v", last)
+	}
+}
+
+func TestExportToXLSX(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "nested", "test.xlsx")
+
+	if err := exportToXLSX(createTestExportData(), filename); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	archive, err := zip.OpenReader(filename)
+	if err != nil {
+		t.Fatalf("Expected a zip package, got: %v", err)
+	}
+	defer archive.Close()
+
+	sheets := 0
+	for _, file := range archive.File {
+		if strings.HasPrefix(file.Name, "xl/worksheets/") {
+			sheets++
+		}
+	}
+	if sheets != 2 {
+		t.Errorf("Expected 2 worksheets, got %d", sheets)
+	}
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"sort"
+)
+
+// ToolMetadata records the tool and options an export was taken with, so validations against the export can
+// warn about options it was not taken with
+type ToolMetadata struct {
+	Version  string            `json:"version"`
+	AuthMode string            `json:"auth_mode"`          // "token" or "app"
+	Hostname string            `json:"hostname,omitempty"` // Source hostname, empty for github.com
+	Flags    map[string]string `json:"flags,omitempty"`    // Flags set on the command line, with tokens masked
+	Options  ExportOptions     `json:"options"`
+}
+
+// ExportOptions are the validation options that select which source data an export contains
+type ExportOptions struct {
+	NoLFS            bool   `json:"no_lfs,omitempty"`
+	CheckSecurity    bool   `json:"check_security,omitempty"`
+	CheckLFSLocks    bool   `json:"check_lfs_locks,omitempty"`
+	CheckTruncation  int    `json:"check_truncation,omitempty"`
+	Branches         string `json:"branches,omitempty"`
+	CountPRsAsIssues bool   `json:"count_prs_as_issues,omitempty"`
+	RewrittenHistory bool   `json:"rewritten_history,omitempty"`
+}
+
+// NewExportOptions returns the options of an export taken with the validation options
+func NewExportOptions(options validator.ValidationOptions) ExportOptions {
+	return ExportOptions{
+		NoLFS:            options.NoLFS,
+		CheckSecurity:    options.CheckSecurity,
+		CheckLFSLocks:    options.CheckLFSLocks,
+		CheckTruncation:  options.CheckTruncation,
+		Branches:         options.Branches,
+		CountPRsAsIssues: options.CountPRsAsIssues,
+		RewrittenHistory: options.RewrittenHistory,
+	}
+}
+
+// CompatibilityWarnings returns why validating with options against the export would report differences that
+// come from how the export was taken rather than from the migration. Exports without tool metadata, taken by
+// earlier versions, have no warnings.
+func (d *ExportData) CompatibilityWarnings(options validator.ValidationOptions) []string {
+	if d.Tool == nil {
+		return nil
+	}
+	exported := d.Tool.Options
+
+	var warnings []string
+	if exported.NoLFS && !options.NoLFS {
+		warnings = append(warnings, "the export was taken with --no-lfs, so the source has no LFS objects to compare; pass --no-lfs")
+	}
+	if options.CheckSecurity && !exported.CheckSecurity {
+		warnings = append(warnings, "the export was taken without --check-security, so security features are not compared")
+	}
+	if options.CheckLFSLocks && !exported.CheckLFSLocks {
+		warnings = append(warnings, "the export was taken without --check-lfs-locks, so LFS locks are not compared")
+	}
+	if options.Branches != "" && options.Branches != exported.Branches {
+		warnings = append(warnings, fmt.Sprintf("the export was taken with --branches %q, so branches it does not list are reported missing from the source", exported.Branches))
+	}
+	if options.CountPRsAsIssues != exported.CountPRsAsIssues {
+		warnings = append(warnings, fmt.Sprintf("the export was taken with --count-prs-as-issues=%t, so the issue counts are not counted alike", exported.CountPRsAsIssues))
+	}
+	if options.RewrittenHistory && !exported.RewrittenHistory {
+		warnings = append(warnings, "the export was taken without --rewritten-history, so the commit authors and dates are not compared")
+	}
+	return warnings
+}
+
+// flagRows returns the flags of the metadata as sorted name and value rows
+func (m *ToolMetadata) flagRows() [][]interface{} {
+	names := make([]string, 0, len(m.Flags))
+	for name := range m.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]interface{}, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []interface{}{"--" + name, m.Flags[name]})
+	}
+	return rows
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/json"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"strings"
+	"testing"
+)
+
+func TestCompatibilityWarnings(t *testing.T) {
+	data := createTestExportData()
+	if warnings := data.CompatibilityWarnings(validator.ValidationOptions{CheckSecurity: true}); warnings != nil {
+		t.Errorf("Expected no warnings for an export without tool metadata, got %v", warnings)
+	}
+
+	data.Tool = &ToolMetadata{Version: "v1.2.3", AuthMode: "token", Options: ExportOptions{NoLFS: true, Branches: "main"}}
+
+	warnings := data.CompatibilityWarnings(validator.ValidationOptions{NoLFS: true, Branches: "main"})
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings with the options of the export, got %v", warnings)
+	}
+
+	warnings = data.CompatibilityWarnings(validator.ValidationOptions{CheckSecurity: true, Branches: "all"})
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "--no-lfs") {
+		t.Errorf("Expected the first warning to suggest --no-lfs, got %q", warnings[0])
+	}
+}
+
+func TestExportData_ToolJSON(t *testing.T) {
+	data := createTestExportData()
+	data.Tool = &ToolMetadata{
+		Version:  "v1.2.3",
+		AuthMode: "app",
+		Hostname: "https://github.example.com",
+		Flags:    map[string]string{"no-lfs": "true"},
+		Options:  ExportOptions{NoLFS: true},
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"tool":{"version":"v1.2.3","auth_mode":"app","hostname":"https://github.example.com","flags":{"no-lfs":"true"},"options":{"no_lfs":true}}`) {
+		t.Errorf("Unexpected tool metadata in %s", encoded)
+	}
+
+	var decoded ExportData
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if decoded.Tool == nil || !decoded.Tool.Options.NoLFS {
+		t.Errorf("Expected the tool metadata to round-trip, got %+v", decoded.Tool)
+	}
+}
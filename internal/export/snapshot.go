@@ -0,0 +1,101 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/migrationarchive"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot is an earlier export of the repository kept in the export file when it is exported again
+type Snapshot struct {
+	ExportTimestamp  time.Time                                 `json:"export_timestamp"`
+	Repository       validator.RepositoryData                  `json:"repository_data"`
+	MigrationArchive *migrationarchive.MigrationArchiveMetrics `json:"migration_archive,omitempty"`
+}
+
+// MetricChange is a metric whose exported value changed since the previous export
+type MetricChange struct {
+	Metric   string
+	Previous string
+	Current  string
+}
+
+// String returns the change for display, e.g. "Issues: 42 → 45 (+3)"
+func (c MetricChange) String() string {
+	change := fmt.Sprintf("%s: %s → %s", c.Metric, c.Previous, c.Current)
+	previous, previousErr := strconv.Atoi(c.Previous)
+	current, currentErr := strconv.Atoi(c.Current)
+	if previousErr == nil && currentErr == nil {
+		change += fmt.Sprintf(" (%+d)", current-previous)
+	}
+	return change
+}
+
+// loadPreviousExport returns the JSON export of owner/name already in filename, or nil when the file does not
+// exist or holds another repository or format, in which case it is overwritten
+func loadPreviousExport(filename, owner, name string) *ExportData {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+
+	var previous ExportData
+	if err := json.Unmarshal(content, &previous); err != nil || validateExportData(&previous) != nil {
+		return nil
+	}
+	if !strings.EqualFold(previous.Repository.Owner, owner) || !strings.EqualFold(previous.Repository.Name, name) {
+		return nil
+	}
+	return &previous
+}
+
+// appendSnapshot keeps the previous export, and the snapshots it kept, in the snapshots of data
+func appendSnapshot(data *ExportData, previous *ExportData) {
+	data.PreviousSnapshots = append(previous.PreviousSnapshots, Snapshot{
+		ExportTimestamp:  previous.ExportTimestamp,
+		Repository:       previous.Repository,
+		MigrationArchive: previous.MigrationArchive,
+	})
+}
+
+// DiffExports returns the counts and SHAs that changed between the previous and the current export
+func DiffExports(previous, current validator.RepositoryData) []MetricChange {
+	previousCounts := make(map[string]int)
+	for _, count := range repositoryCounts(previous) {
+		previousCounts[count.name] = count.value
+	}
+
+	var changes []MetricChange
+	for _, count := range repositoryCounts(current) {
+		if value, ok := previousCounts[count.name]; ok && value != count.value {
+			changes = append(changes, MetricChange{count.name, fmt.Sprint(value), fmt.Sprint(count.value)})
+		}
+	}
+	if previous.LatestCommitSHA != current.LatestCommitSHA {
+		changes = append(changes, MetricChange{"Latest Commit SHA", previous.LatestCommitSHA, current.LatestCommitSHA})
+	}
+	if previous.TreeSHA != current.TreeSHA {
+		changes = append(changes, MetricChange{"Default Branch Tree SHA", previous.TreeSHA, current.TreeSHA})
+	}
+	return changes
+}
+
+// printExportDiff prints the metrics that changed since the previous export
+func printExportDiff(previous *ExportData, current validator.RepositoryData) {
+	since := previous.ExportTimestamp.Format(time.RFC3339)
+	changes := DiffExports(previous.Repository, current)
+	if len(changes) == 0 {
+		fmt.Printf("No changes since the export of %s\n", since)
+		return
+	}
+
+	fmt.Printf("Changes since the export of %s:\n", since)
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadPreviousExport(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "export.json")
+	if previous := loadPreviousExport(filename, "test-owner", "test-repo"); previous != nil {
+		t.Errorf("Expected no previous export without a file, got %+v", previous)
+	}
+
+	if err := exportToJSON(createTestExportData(), filename); err != nil {
+		t.Fatalf("Failed to write the export: %v", err)
+	}
+	if previous := loadPreviousExport(filename, "Test-Owner", "test-repo"); previous == nil || previous.Repository.Issues != 42 {
+		t.Errorf("Expected the previous export of the repository, got %+v", previous)
+	}
+	if previous := loadPreviousExport(filename, "test-owner", "other-repo"); previous != nil {
+		t.Errorf("Expected the export of another repository to be ignored, got %+v", previous)
+	}
+}
+
+func TestAppendSnapshot(t *testing.T) {
+	first := createTestExportData()
+	second := createTestExportData()
+	second.ExportTimestamp = first.ExportTimestamp.Add(time.Hour)
+	appendSnapshot(&second, &first)
+
+	third := createTestExportData()
+	appendSnapshot(&third, &second)
+
+	if len(third.PreviousSnapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(third.PreviousSnapshots))
+	}
+	if !third.PreviousSnapshots[0].ExportTimestamp.Equal(first.ExportTimestamp) || !third.PreviousSnapshots[1].ExportTimestamp.Equal(second.ExportTimestamp) {
+		t.Errorf("Expected the snapshots oldest first, got %+v", third.PreviousSnapshots)
+	}
+}
+
+func TestDiffExports(t *testing.T) {
+	previous := createTestExportData().Repository
+	current := createTestExportData().Repository
+	if changes := DiffExports(previous, current); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+
+	current.Issues = 45
+	current.LatestCommitSHA = "fff999"
+	changes := DiffExports(previous, current)
+
+	expected := []string{"Issues: 42 → 45 (+3)", "Latest Commit SHA: abc123def456 → fff999"}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if change.String() != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], change.String())
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/validator"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// schema creates the validation results and mannequin snapshot tables and the indexes used for trend queries
+const schema = `
+CREATE TABLE IF NOT EXISTS validation_results (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id            TEXT    NOT NULL,
+	recorded_at       TEXT    NOT NULL,
+	source_repository TEXT    NOT NULL,
+	target_repository TEXT    NOT NULL,
+	metric            TEXT    NOT NULL,
+	source_value      TEXT    NOT NULL,
+	target_value      TEXT    NOT NULL,
+	status            TEXT    NOT NULL,
+	difference        INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_validation_results_target ON validation_results (target_repository, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_validation_results_run ON validation_results (run_id);
+CREATE TABLE IF NOT EXISTS mannequin_snapshots (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	recorded_at  TEXT    NOT NULL,
+	organization TEXT    NOT NULL,
+	mannequins   INTEGER NOT NULL,
+	reclaimed    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_mannequin_snapshots_organization ON mannequin_snapshots (organization, recorded_at);
+`
+
+// Store appends validation results to a SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+// Run identifies a single validation run of a source and target repository
+type Run struct {
+	ID               string
+	Timestamp        time.Time
+	SourceRepository string
+	TargetRepository string
+}
+
+// NewRun creates a Run for the given repositories, deriving the run ID from the timestamp
+func NewRun(sourceRepository, targetRepository string, timestamp time.Time) Run {
+	return Run{
+		ID:               timestamp.UTC().Format("20060102T150405.000000000Z"),
+		Timestamp:        timestamp,
+		SourceRepository: sourceRepository,
+		TargetRepository: targetRepository,
+	}
+}
+
+// Open opens (creating if needed) the SQLite history database at path and ensures the schema exists
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database %s: %w", path, err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history database schema: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Record appends every validation result of a run in a single transaction
+func (s *Store) Record(run Run, results []validator.ValidationResult) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start history transaction: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO validation_results
+		(run_id, recorded_at, source_repository, target_repository, metric, source_value, target_value, status, difference)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to prepare history insert: %w", err)
+	}
+	defer stmt.Close()
+
+	recordedAt := run.Timestamp.UTC().Format(time.RFC3339)
+	for _, result := range results {
+		_, err := stmt.Exec(
+			run.ID,
+			recordedAt,
+			run.SourceRepository,
+			run.TargetRepository,
+			result.Metric,
+			fmt.Sprintf("%v", result.SourceVal),
+			fmt.Sprintf("%v", result.TargetVal),
+			result.StatusType.String(),
+			result.Difference,
+		)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record %s result: %w", result.Metric, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit history transaction: %w", err)
+	}
+
+	return nil
+}
+
+// MannequinSnapshot records how many mannequins of an organization were reclaimed at a point in time
+type MannequinSnapshot struct {
+	Timestamp    time.Time
+	Organization string
+	Mannequins   int
+	Reclaimed    int
+}
+
+// RecordMannequinSnapshot appends a mannequin reclamation snapshot
+func (s *Store) RecordMannequinSnapshot(snapshot MannequinSnapshot) error {
+	_, err := s.db.Exec(`INSERT INTO mannequin_snapshots (recorded_at, organization, mannequins, reclaimed) VALUES (?, ?, ?, ?)`,
+		snapshot.Timestamp.UTC().Format(time.RFC3339), snapshot.Organization, snapshot.Mannequins, snapshot.Reclaimed)
+	if err != nil {
+		return fmt.Errorf("failed to record mannequin snapshot: %w", err)
+	}
+	return nil
+}
+
+// MannequinSnapshots returns the mannequin reclamation snapshots of an organization, oldest first
+func (s *Store) MannequinSnapshots(organization string) ([]MannequinSnapshot, error) {
+	rows, err := s.db.Query(`SELECT recorded_at, mannequins, reclaimed FROM mannequin_snapshots
+		WHERE organization = ? ORDER BY recorded_at, id`, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mannequin snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []MannequinSnapshot
+	for rows.Next() {
+		snapshot := MannequinSnapshot{Organization: organization}
+		var recordedAt string
+		if err := rows.Scan(&recordedAt, &snapshot.Mannequins, &snapshot.Reclaimed); err != nil {
+			return nil, fmt.Errorf("failed to read mannequin snapshot: %w", err)
+		}
+		if snapshot.Timestamp, err = time.Parse(time.RFC3339, recordedAt); err != nil {
+			return nil, fmt.Errorf("invalid mannequin snapshot time %q: %w", recordedAt, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
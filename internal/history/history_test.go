@@ -0,0 +1,78 @@
+package history
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_RecordAppendsResults(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "history.db")
+
+	results := []validator.ValidationResult{
+		{Metric: "Tags", SourceVal: 3, TargetVal: 3, StatusType: validator.ValidationStatusPass},
+		{Metric: "Releases", SourceVal: 5, TargetVal: 4, StatusType: validator.ValidationStatusFail, Difference: 1},
+	}
+
+	// Record two runs, reopening the database in between to make sure results are appended
+	for i := 0; i < 2; i++ {
+		store, err := Open(dbPath)
+		require.NoError(t, err)
+
+		run := NewRun("source-org/repo", "target-org/repo", time.Date(2025, 1, 1, 12, 0, i, 0, time.UTC))
+		require.NoError(t, store.Record(run, results))
+		require.NoError(t, store.Close())
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM validation_results`).Scan(&count))
+	assert.Equal(t, 4, count)
+
+	var runs int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(DISTINCT run_id) FROM validation_results`).Scan(&runs))
+	assert.Equal(t, 2, runs)
+
+	var sourceValue, targetValue, status string
+	var difference int
+	require.NoError(t, db.QueryRow(
+		`SELECT source_value, target_value, status, difference FROM validation_results WHERE metric = 'Releases' LIMIT 1`,
+	).Scan(&sourceValue, &targetValue, &status, &difference))
+	assert.Equal(t, "5", sourceValue)
+	assert.Equal(t, "4", targetValue)
+	assert.Equal(t, "FAIL", status)
+	assert.Equal(t, 1, difference)
+}
+
+func TestOpen_InvalidPath(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing-dir", "history.db"))
+	assert.Error(t, err)
+}
+
+func TestStore_MannequinSnapshots(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	first := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, store.RecordMannequinSnapshot(MannequinSnapshot{Timestamp: first.Add(24 * time.Hour), Organization: "target-org", Mannequins: 10, Reclaimed: 6}))
+	require.NoError(t, store.RecordMannequinSnapshot(MannequinSnapshot{Timestamp: first, Organization: "target-org", Mannequins: 10, Reclaimed: 2}))
+	require.NoError(t, store.RecordMannequinSnapshot(MannequinSnapshot{Timestamp: first, Organization: "other-org", Mannequins: 3, Reclaimed: 0}))
+
+	snapshots, err := store.MannequinSnapshots("target-org")
+	require.NoError(t, err)
+
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, first, snapshots[0].Timestamp)
+	assert.Equal(t, 2, snapshots[0].Reclaimed)
+	assert.Equal(t, 6, snapshots[1].Reclaimed)
+}
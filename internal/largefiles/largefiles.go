@@ -0,0 +1,165 @@
+package largefiles
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pterm/pterm"
+)
+
+// PushLimit is the size above which GitHub rejects a pushed file. Files tracked by Git LFS are stored as
+// small pointer files, so a file above the limit is never LFS-tracked.
+const PushLimit int64 = 100 << 20
+
+// WarningSize is the size above which GitHub warns about a pushed file
+const WarningSize int64 = 50 << 20
+
+// Report lists the files of a repository above WarningSize, largest first
+type Report struct {
+	Repository string
+	Scope      string // What was scanned, e.g. "default branch" or "full history"
+	Files      []api.LargeFile
+	Truncated  bool // The default branch has too many files to be listed completely
+}
+
+// runGit runs git against gitDir and returns its standard output; replaced in tests
+var runGit = func(gitDir string, args ...string) ([]byte, error) {
+	output, err := exec.Command("git", append([]string{"--git-dir", gitDir}, args...)...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git %s: %v", args[0], err)
+	}
+	return output, nil
+}
+
+// FromAPI scans the files of the default branch of a repository
+func FromAPI(githubAPI *api.GitHubAPI, clientType api.ClientType, owner, name string) (*Report, error) {
+	spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Scanning files of %s/%s...", owner, name))
+
+	files, truncated, err := githubAPI.GetLargeFiles(clientType, owner, name, WarningSize)
+	if err != nil {
+		spinner.Fail(fmt.Sprintf("Failed to scan files of %s/%s", owner, name))
+		return nil, err
+	}
+	spinner.Success(fmt.Sprintf("Scanned files of %s/%s", owner, name))
+
+	report := &Report{Repository: owner + "/" + name, Scope: "default branch", Files: files, Truncated: truncated}
+	report.sortFiles()
+	return report, nil
+}
+
+// FromGitDir scans every file in the history of the local repository at gitDir, such as a bare clone or the
+// repository of an extracted migration archive. Each large blob is reported once, under the first path it
+// is found at.
+func FromGitDir(gitDir string) (*Report, error) {
+	output, err := runGit(gitDir, "cat-file", "--batch-all-objects", "--batch-check=%(objectname) %(objecttype) %(objectsize)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %v", err)
+	}
+
+	sizes := make(map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of object %s: %v", fields[0], err)
+		}
+		if size > WarningSize {
+			sizes[fields[0]] = size
+		}
+	}
+
+	report := &Report{Repository: gitDir, Scope: "full history"}
+	if len(sizes) == 0 {
+		return report, nil
+	}
+
+	// Objects only reachable from dangling commits have no path and are not pushed
+	output, err = runGit(gitDir, "rev-list", "--objects", "--all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %v", err)
+	}
+
+	scanner = bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		sha, path, found := strings.Cut(scanner.Text(), " ")
+		size, large := sizes[sha]
+		if !found || !large {
+			continue
+		}
+		report.Files = append(report.Files, api.LargeFile{Path: path, Size: size})
+		delete(sizes, sha)
+	}
+
+	report.sortFiles()
+	return report, nil
+}
+
+// OverLimit returns the number of files GitHub would reject
+func (r *Report) OverLimit() int {
+	count := 0
+	for _, file := range r.Files {
+		if file.Size > PushLimit {
+			count++
+		}
+	}
+	return count
+}
+
+// sortFiles orders the files largest first
+func (r *Report) sortFiles() {
+	sort.SliceStable(r.Files, func(i, j int) bool {
+		return r.Files[i].Size > r.Files[j].Size
+	})
+}
+
+// Print displays the large files and a summary of the files that would block a migration
+func (r *Report) Print() {
+	pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgBlue)).WithTextStyle(pterm.NewStyle(pterm.FgWhite)).Println(output.Heading("📦 Large File Scan"))
+	pterm.Info.Printfln("Scanned the %s of %s", r.Scope, r.Repository)
+
+	if len(r.Files) > 0 {
+		tableData := [][]string{{"File", "Size", "Status"}}
+		for _, file := range r.Files {
+			status := output.Heading("⚠️ Over 50 MB")
+			if file.Size > PushLimit {
+				status = output.Heading("❌ Over 100 MB limit")
+			}
+			tableData = append(tableData, []string{file.Path, formatSize(file.Size), status})
+		}
+		output.RenderTable(tableData, false)
+		fmt.Println()
+	}
+
+	if r.Truncated {
+		pterm.Warning.Println("The repository has too many files to be listed completely; scan a local clone with --git-dir for a complete result")
+	}
+
+	overLimit := r.OverLimit()
+	switch {
+	case overLimit > 0:
+		pterm.Error.Printfln("%d files exceed GitHub's 100 MB file size limit and will make the migration fail; track them with Git LFS (git lfs migrate import) before migrating", overLimit)
+	case len(r.Files) > 0:
+		pterm.Warning.Printfln("%d files are over 50 MB; they can be migrated but GitHub recommends tracking them with Git LFS", len(r.Files))
+	default:
+		pterm.Success.Println("No files over 50 MB")
+	}
+}
+
+// formatSize returns a file size in megabytes
+func formatSize(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+}
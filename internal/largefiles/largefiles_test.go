@@ -0,0 +1,78 @@
+package largefiles
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stubGit(t *testing.T, run func(gitDir string, args ...string) ([]byte, error)) {
+	original := runGit
+	runGit = run
+	t.Cleanup(func() { runGit = original })
+}
+
+func TestFromGitDir(t *testing.T) {
+	stubGit(t, func(gitDir string, args ...string) ([]byte, error) {
+		assert.Equal(t, "repo.git", gitDir)
+		switch args[0] {
+		case "cat-file":
+			return []byte("c0ffee commit 250\n" +
+				"aaaa blob 157286400\n" +
+				"bbbb blob 1024\n" +
+				"cccc blob 62914560\n" +
+				"dddd blob 209715200\n" +
+				"eeee tree 96\n"), nil
+		case "rev-list":
+			return []byte("c0ffee\n" +
+				"eeee \n" +
+				"aaaa assets/video.mp4\n" +
+				"bbbb README.md\n" +
+				"cccc dist/bundle.zip\n" +
+				"aaaa old/video.mp4\n"), nil
+		}
+		return nil, fmt.Errorf("unexpected command %v", args)
+	})
+
+	report, err := FromGitDir("repo.git")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "full history", report.Scope)
+	assert.Equal(t, []api.LargeFile{
+		{Path: "assets/video.mp4", Size: 157286400},
+		{Path: "dist/bundle.zip", Size: 62914560},
+	}, report.Files, "unreachable blobs are skipped and each blob is listed once")
+	assert.Equal(t, 1, report.OverLimit())
+}
+
+func TestFromGitDir_NoLargeFiles(t *testing.T) {
+	stubGit(t, func(gitDir string, args ...string) ([]byte, error) {
+		if args[0] != "cat-file" {
+			t.Errorf("History should not be listed without large blobs, got %v", args)
+		}
+		return []byte("bbbb blob 1024\n"), nil
+	})
+
+	report, err := FromGitDir("repo.git")
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Files)
+	assert.Equal(t, 0, report.OverLimit())
+}
+
+func TestFromGitDir_Error(t *testing.T) {
+	stubGit(t, func(gitDir string, args ...string) ([]byte, error) {
+		return nil, fmt.Errorf("git cat-file: fatal: not a git repository")
+	})
+
+	_, err := FromGitDir("missing.git")
+
+	assert.ErrorContains(t, err, "not a git repository")
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "150.0 MB", formatSize(157286400))
+	assert.Equal(t, "0.5 MB", formatSize(512<<10))
+}
@@ -0,0 +1,187 @@
+// Package lock implements advisory lock files that keep two validation runs from working on the same target
+// repository at the same time, e.g. when two operators validate and remediate a repository during cutover.
+// The locks are only advisory: they are honored by the runs that use the same lock directory.
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrLocked is returned by Acquire when another run holds the lock of the repository
+var ErrLocked = errors.New("repository is locked by another validation run")
+
+// dirMode is the mode of the lock directories Acquire creates. The directory is shared by every user of the
+// host, as /tmp is: anyone can create lock files, and the sticky bit keeps them from removing those of others.
+const dirMode = os.ModeDir | os.ModeSticky | 0777
+
+// Holder describes the run holding a lock, as written to its lock file
+type Holder struct {
+	Repository string    `json:"repository"`
+	User       string    `json:"user"`
+	Hostname   string    `json:"hostname"`
+	PID        int       `json:"pid"`
+	RunID      string    `json:"run_id,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// String describes the holder for error messages, e.g. "alice on jumpbox (pid 4242) since 2025-06-14T09:30:00Z"
+func (h Holder) String() string {
+	description := fmt.Sprintf("%s on %s (pid %d", h.User, h.Hostname, h.PID)
+	if h.RunID != "" {
+		description += ", run " + h.RunID
+	}
+	return description + ") since " + h.AcquiredAt.UTC().Format(time.RFC3339)
+}
+
+// Lock is a lock held by this process, until it is released
+type Lock struct {
+	Path   string
+	Holder Holder
+}
+
+// Acquire creates the lock file of repository in dir. When another run holds the lock, it fails with an error
+// wrapping ErrLocked that describes the holder, unless force is set: the lock is then taken over, which also
+// clears stale locks left by interrupted runs.
+func Acquire(dir, repository, runID string, force bool) (*Lock, error) {
+	if err := createDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory %s: %w", dir, err)
+	}
+
+	lock := &Lock{Path: filepath.Join(dir, fileName(repository)), Holder: currentHolder(repository, runID)}
+	content, err := json.MarshalIndent(lock.Holder, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %v", err)
+	}
+
+	for {
+		file, err := os.OpenFile(lock.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = file.Write(content)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lock.Path)
+				return nil, fmt.Errorf("failed to write lock file %s: %w", lock.Path, err)
+			}
+			return lock, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file %s: %w", lock.Path, err)
+		}
+
+		if !force {
+			holder, err := read(lock.Path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue // Released in the meantime
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s, its lock file %s cannot be read: %v", ErrLocked, repository, lock.Path, err)
+			}
+			return nil, fmt.Errorf("%w: %s is being validated by %s", ErrLocked, repository, holder)
+		}
+
+		if err := os.Remove(lock.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove lock file %s: %w", lock.Path, err)
+		}
+		force = false
+	}
+}
+
+// Release removes the lock file, unless another run took the lock over in the meantime
+func (l *Lock) Release() error {
+	holder, err := read(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if holder.PID != l.Holder.PID || holder.Hostname != l.Holder.Hostname || !holder.AcquiredAt.Equal(l.Holder.AcquiredAt) {
+		return nil
+	}
+	if err := os.Remove(l.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove lock file %s: %w", l.Path, err)
+	}
+	return nil
+}
+
+// createDir creates dir and its missing parents with dirMode, so that other users can lock repositories in it.
+// Existing directories are left as they are.
+func createDir(dir string) error {
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return nil
+	}
+	if parent := filepath.Dir(dir); parent != dir {
+		if err := createDir(parent); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Mkdir(dir, dirMode); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil // Created by another run in the meantime
+		}
+		return err
+	}
+	// The umask clears the write permission of the group and others, and Mkdir ignores the sticky bit on some
+	// systems
+	return os.Chmod(dir, dirMode)
+}
+
+// read returns the holder recorded in the lock file at path
+func read(path string) (Holder, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Holder{}, err
+	}
+	var holder Holder
+	if err := json.Unmarshal(content, &holder); err != nil {
+		return Holder{}, fmt.Errorf("invalid lock file: %v", err)
+	}
+	return holder, nil
+}
+
+// currentHolder describes this process as the holder of the lock of repository
+func currentHolder(repository, runID string) Holder {
+	holder := Holder{
+		Repository: repository,
+		User:       "unknown",
+		Hostname:   "unknown",
+		PID:        os.Getpid(),
+		RunID:      runID,
+		AcquiredAt: time.Now().UTC(),
+	}
+	if current, err := user.Current(); err == nil {
+		holder.User = current.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		holder.Hostname = hostname
+	}
+	return holder
+}
+
+// fileName returns the name of the lock file of repository, e.g. github.com_target-org_repo.lock. Slashes
+// become underscores and every other character outside [a-z0-9.-] is percent-encoded, so that two repositories
+// such as org_a/b and org/a_b never share a lock file.
+func fileName(repository string) string {
+	var name strings.Builder
+	for _, b := range []byte(strings.ToLower(repository)) {
+		switch {
+		case b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '.' || b == '-':
+			name.WriteByte(b)
+		case b == '/':
+			name.WriteByte('_')
+		default:
+			fmt.Fprintf(&name, "%%%02x", b)
+		}
+	}
+	return name.String() + ".lock"
+}
@@ -0,0 +1,93 @@
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := Acquire(dir, "github.com/Target-Org/repo", "cutover-42", false)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "github.com_target-org_repo.lock"), lock.Path)
+	assert.Equal(t, os.Getpid(), lock.Holder.PID)
+
+	_, err = Acquire(dir, "github.com/target-org/repo", "", false)
+	require.ErrorIs(t, err, ErrLocked)
+	assert.Contains(t, err.Error(), "github.com/target-org/repo is being validated by ")
+	assert.Contains(t, err.Error(), "run cutover-42")
+
+	other, err := Acquire(dir, "github.com/target-org/other-repo", "", false)
+	require.NoError(t, err, "locks are per repository")
+	require.NoError(t, other.Release())
+
+	require.NoError(t, lock.Release())
+	assert.NoFileExists(t, lock.Path)
+
+	lock, err = Acquire(dir, "github.com/target-org/repo", "", false)
+	require.NoError(t, err, "the lock can be acquired again once released")
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquire_Force(t *testing.T) {
+	dir := t.TempDir()
+	stale, err := Acquire(dir, "github.com/target-org/repo", "", false)
+	require.NoError(t, err)
+
+	lock, err := Acquire(dir, "github.com/target-org/repo", "takeover", true)
+	require.NoError(t, err)
+
+	// The run whose lock was taken over does not remove the new lock
+	require.NoError(t, stale.Release())
+	assert.FileExists(t, lock.Path)
+
+	require.NoError(t, lock.Release())
+	assert.NoFileExists(t, lock.Path)
+}
+
+func TestAcquire_SharedDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory permissions are not enforced on Windows")
+	}
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "gh-migration-validator", "locks")
+
+	lock, err := Acquire(dir, "github.com/target-org/repo", "", false)
+	require.NoError(t, err)
+	defer lock.Release()
+
+	for _, created := range []string{dir, filepath.Dir(dir)} {
+		info, err := os.Stat(created)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0777), info.Mode().Perm(), "%s is writable by every user despite the umask", created)
+		assert.NotZero(t, info.Mode()&os.ModeSticky, "%s keeps users from removing the locks of others", created)
+	}
+
+	info, err := os.Stat(parent)
+	require.NoError(t, err)
+	assert.Zero(t, info.Mode()&os.ModeSticky, "existing directories are left as they are")
+}
+
+func TestAcquire_InvalidLockFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "github.com_target-org_repo.lock"), []byte("garbage"), 0644))
+
+	_, err := Acquire(dir, "github.com/target-org/repo", "", false)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	lock, err := Acquire(dir, "github.com/target-org/repo", "", true)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "github.com_target-org_repo.lock", fileName("github.com/Target-Org/repo"))
+	assert.Equal(t, "github.com_org%5fa_b.lock", fileName("github.com/org_a/b"))
+	assert.NotEqual(t, fileName("github.com/org_a/b"), fileName("github.com/org/a_b"))
+}
@@ -0,0 +1,179 @@
+package mannequins
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/history"
+	"mona-actions/gh-migration-validator/internal/output"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+)
+
+// Entry is a mannequin with the number of migrated items attributed to it
+type Entry struct {
+	api.Mannequin
+	AuthoredItems int // Issues and pull requests authored by the mannequin, -1 when they could not be counted
+}
+
+// Reclaimed reports whether the mannequin has been reclaimed by a user of the organization
+func (e Entry) Reclaimed() bool {
+	return e.Claimant != ""
+}
+
+// Report lists the mannequins of an organization and their reclamation status
+type Report struct {
+	Organization string
+	GeneratedAt  time.Time
+	Entries      []Entry
+}
+
+// Fetch builds the mannequin report of org. Authored items are counted with one search per mannequin;
+// a failed count is reported and recorded as -1 rather than failing the report.
+func Fetch(githubAPI *api.GitHubAPI, clientType api.ClientType, org string, countAuthoredItems bool) (*Report, error) {
+	spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Fetching mannequins from %s...", org))
+
+	list, err := githubAPI.ListMannequins(clientType, org)
+	if err != nil {
+		spinner.Fail(fmt.Sprintf("Failed to fetch mannequins from %s", org))
+		return nil, err
+	}
+
+	report := &Report{Organization: org, GeneratedAt: time.Now()}
+	var countErrors []string
+	for i, mannequin := range list {
+		entry := Entry{Mannequin: mannequin, AuthoredItems: -1}
+		if countAuthoredItems {
+			spinner.UpdateText(fmt.Sprintf("Counting items authored by mannequins (%d/%d)...", i+1, len(list)))
+			count, err := githubAPI.GetAuthoredItemCount(clientType, org, mannequin.Login)
+			if err != nil {
+				countErrors = append(countErrors, fmt.Sprintf("%s: %v", mannequin.Login, err))
+			} else {
+				entry.AuthoredItems = count
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].AuthoredItems > report.Entries[j].AuthoredItems
+	})
+
+	spinner.Success(fmt.Sprintf("Found %d mannequins in %s", len(report.Entries), org))
+	for _, message := range countErrors {
+		pterm.Warning.Printfln("Failed to count authored items of %s", message)
+	}
+
+	return report, nil
+}
+
+// Reclaimed returns the number of reclaimed mannequins
+func (r *Report) Reclaimed() int {
+	reclaimed := 0
+	for _, entry := range r.Entries {
+		if entry.Reclaimed() {
+			reclaimed++
+		}
+	}
+	return reclaimed
+}
+
+// Snapshot returns the reclamation progress of the report for the history database
+func (r *Report) Snapshot() history.MannequinSnapshot {
+	return history.MannequinSnapshot{
+		Timestamp:    r.GeneratedAt,
+		Organization: r.Organization,
+		Mannequins:   len(r.Entries),
+		Reclaimed:    r.Reclaimed(),
+	}
+}
+
+// WriteCSV writes one row per mannequin with its reclamation status and authored items
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"login", "email", "created_at", "reclaimed", "claimant", "authored_items"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, entry := range r.Entries {
+		record := []string{
+			entry.Login,
+			entry.Email,
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatBool(entry.Reclaimed()),
+			entry.Claimant,
+			formatAuthoredItems(entry.AuthoredItems),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record for %s: %w", entry.Login, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Print displays the mannequins, the reclamation summary and, when snapshots are given, the progress over time
+func (r *Report) Print(snapshots []history.MannequinSnapshot) {
+	pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgBlue)).WithTextStyle(pterm.NewStyle(pterm.FgWhite)).Println(output.Heading("👤 Mannequin Reclamation Report"))
+
+	if len(r.Entries) > 0 {
+		tableData := [][]string{{"Mannequin", "Email", "Authored Items", "Status", "Claimant"}}
+		for _, entry := range r.Entries {
+			status := "⏳ Unclaimed"
+			if entry.Reclaimed() {
+				status = output.Heading("✅ Reclaimed")
+			}
+			tableData = append(tableData, []string{entry.Login, entry.Email, formatAuthoredItems(entry.AuthoredItems), status, entry.Claimant})
+		}
+		output.RenderTable(tableData, false)
+		fmt.Println()
+	}
+
+	if len(snapshots) > 1 {
+		pterm.DefaultSection.Println(output.Heading("📈 Reclamation Progress"))
+		tableData := [][]string{{"Recorded", "Mannequins", "Reclaimed", "Progress"}}
+		for _, snapshot := range snapshots {
+			tableData = append(tableData, []string{
+				snapshot.Timestamp.Local().Format("2006-01-02 15:04"),
+				strconv.Itoa(snapshot.Mannequins),
+				strconv.Itoa(snapshot.Reclaimed),
+				formatProgress(snapshot.Reclaimed, snapshot.Mannequins),
+			})
+		}
+		output.RenderTable(tableData, false)
+		fmt.Println()
+	}
+
+	reclaimed := r.Reclaimed()
+	switch {
+	case len(r.Entries) == 0:
+		pterm.Success.Printfln("No mannequins in %s - all migrated activity is attributed", r.Organization)
+	case reclaimed == len(r.Entries):
+		pterm.Success.Printfln("All %d mannequins in %s have been reclaimed", reclaimed, r.Organization)
+	default:
+		pterm.Warning.Printfln("%d of %d mannequins in %s reclaimed (%s)", reclaimed, len(r.Entries), r.Organization,
+			formatProgress(reclaimed, len(r.Entries)))
+	}
+}
+
+// formatAuthoredItems returns the authored items count for display, or "unknown" when it could not be counted
+func formatAuthoredItems(count int) string {
+	if count < 0 {
+		return "unknown"
+	}
+	return strconv.Itoa(count)
+}
+
+// formatProgress returns the reclaimed share of mannequins as a percentage
+func formatProgress(reclaimed, total int) string {
+	if total == 0 {
+		return "100%"
+	}
+	return fmt.Sprintf("%.0f%%", float64(reclaimed)*100/float64(total))
+}
@@ -0,0 +1,54 @@
+package mannequins
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReport() *Report {
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &Report{
+		Organization: "target-org",
+		GeneratedAt:  createdAt.Add(48 * time.Hour),
+		Entries: []Entry{
+			{Mannequin: api.Mannequin{Login: "octocat-1234", Email: "octocat@example.com", CreatedAt: createdAt, Claimant: "octocat"}, AuthoredItems: 12},
+			{Mannequin: api.Mannequin{Login: "hubot-5678", CreatedAt: createdAt}, AuthoredItems: -1},
+		},
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestReport().WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"login", "email", "created_at", "reclaimed", "claimant", "authored_items"},
+		{"octocat-1234", "octocat@example.com", "2025-03-01T10:00:00Z", "true", "octocat", "12"},
+		{"hubot-5678", "", "2025-03-01T10:00:00Z", "false", "", "unknown"},
+	}, records)
+}
+
+func TestReport_Snapshot(t *testing.T) {
+	report := newTestReport()
+	snapshot := report.Snapshot()
+
+	assert.Equal(t, "target-org", snapshot.Organization)
+	assert.Equal(t, report.GeneratedAt, snapshot.Timestamp)
+	assert.Equal(t, 2, snapshot.Mannequins)
+	assert.Equal(t, 1, snapshot.Reclaimed)
+}
+
+func TestFormatProgress(t *testing.T) {
+	assert.Equal(t, "33%", formatProgress(1, 3))
+	assert.Equal(t, "100%", formatProgress(0, 0))
+}
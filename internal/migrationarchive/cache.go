@@ -0,0 +1,112 @@
+package migrationarchive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CacheFileName is the file, in the extracted archive directory, caching the metrics of the archive
+const CacheFileName = "metrics-cache.json"
+
+// cacheVersion changes whenever the cached metrics are counted differently, invalidating older caches
+const cacheVersion = 4
+
+// metricsCache is the content of the cache file: the metrics of the archive, the repository they were counted
+// for and the checksums of the files they were counted from
+type metricsCache struct {
+	Version    int                     `json:"version"`
+	Repository string                  `json:"repository,omitempty"`
+	Checksums  map[string]string       `json:"checksums"` // SHA-256 of each JSON file of the archive, by file name
+	Metrics    MigrationArchiveMetrics `json:"metrics"`
+}
+
+// AnalyzeMigrationArchiveCached returns the metrics of repository in the archive in archiveDir from its
+// metrics-cache.json, when they were cached for the same repository and the JSON files of the archive are
+// unchanged, and analyzes the archive otherwise, caching its metrics for the next run. It reports whether the metrics were read from the cache. Hashing the files is
+// much faster than parsing them, so reusing the cache saves most of the time of large archives.
+func AnalyzeMigrationArchiveCached(archiveDir, repository string) (*MigrationArchiveMetrics, bool, error) {
+	checksums, err := archiveChecksums(archiveDir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cachePath := filepath.Join(archiveDir, CacheFileName)
+	if cache, err := readMetricsCache(cachePath); err == nil && cache.Version == cacheVersion &&
+		strings.EqualFold(cache.Repository, repository) && maps.Equal(cache.Checksums, checksums) {
+		metrics := cache.Metrics
+		return &metrics, true, nil
+	}
+
+	metrics, err := AnalyzeMigrationArchive(archiveDir, repository)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The cache only saves time, so an archive directory that cannot be written to is analyzed on every run
+	if content, err := json.MarshalIndent(metricsCache{Version: cacheVersion, Repository: repository, Checksums: checksums, Metrics: *metrics}, "", "  "); err == nil {
+		os.WriteFile(cachePath, content, 0o644)
+	}
+	return metrics, false, nil
+}
+
+// readMetricsCache reads the cache file at path
+func readMetricsCache(path string) (*metricsCache, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cache metricsCache
+	if err := json.Unmarshal(content, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// archiveChecksums returns the SHA-256 of every JSON file of archiveDir
+func archiveChecksums(archiveDir string) (map[string]string, error) {
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive directory: %v", err)
+	}
+
+	checksums := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !isArchiveManifest(entry.Name()) {
+			continue
+		}
+		checksum, err := fileChecksum(filepath.Join(archiveDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %v", entry.Name(), err)
+		}
+		checksums[entry.Name()] = checksum
+	}
+	return checksums, nil
+}
+
+// isArchiveManifest reports whether the archive file with this name is one of its JSON files, which the
+// metrics are read from, rather than the metrics cache
+func isArchiveManifest(fileName string) bool {
+	return strings.HasSuffix(fileName, ".json") && fileName != CacheFileName
+}
+
+// fileChecksum returns the hex-encoded SHA-256 of the file at path, read without loading it in memory
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
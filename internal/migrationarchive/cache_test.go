@@ -0,0 +1,61 @@
+package migrationarchive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzeMigrationArchiveCached(t *testing.T) {
+	tempDir := t.TempDir()
+	createTestJSONFile(t, tempDir, "issues_000001.json", []map[string]interface{}{{"id": 1}, {"id": 2}})
+	createTestJSONFile(t, tempDir, "releases_000001.json", []map[string]interface{}{{"id": 1}})
+
+	metrics, cached, err := AnalyzeMigrationArchiveCached(tempDir, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached || metrics.Issues != 2 || metrics.Releases != 1 {
+		t.Errorf("Expected the archive to be analyzed on the first run, got %+v (cached: %v)", metrics, cached)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, CacheFileName)); err != nil {
+		t.Fatalf("Expected the metrics to be cached: %v", err)
+	}
+
+	metrics, cached, err = AnalyzeMigrationArchiveCached(tempDir, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cached || metrics.Issues != 2 {
+		t.Errorf("Expected the cached metrics to be reused, got %+v (cached: %v)", metrics, cached)
+	}
+
+	// A changed file invalidates the cache
+	createTestJSONFile(t, tempDir, "issues_000001.json", []map[string]interface{}{{"id": 1}, {"id": 2}, {"id": 3}})
+	metrics, cached, err = AnalyzeMigrationArchiveCached(tempDir, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached || metrics.Issues != 3 {
+		t.Errorf("Expected a changed archive to be analyzed again, got %+v (cached: %v)", metrics, cached)
+	}
+
+	// So does a new file
+	createTestJSONFile(t, tempDir, "pull_requests_000001.json", []map[string]interface{}{{"id": 1}})
+	metrics, cached, err = AnalyzeMigrationArchiveCached(tempDir, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached || metrics.PullRequests != 1 {
+		t.Errorf("Expected an archive with a new file to be analyzed again, got %+v (cached: %v)", metrics, cached)
+	}
+
+	// The metrics of another repository of the same archive are counted again
+	_, cached, err = AnalyzeMigrationArchiveCached(tempDir, "source-org/other-repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached {
+		t.Errorf("Expected the metrics cached for another repository not to be reused")
+	}
+}
@@ -2,17 +2,26 @@ package migrationarchive
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"mona-actions/gh-migration-validator/internal/api"
 	"mona-actions/gh-migration-validator/internal/archive"
-
-	"github.com/pterm/pterm"
+	"mona-actions/gh-migration-validator/internal/output"
 )
 
 // MigrationArchiveMetrics holds the counts of different entities in a migration archive
@@ -21,6 +30,16 @@ type MigrationArchiveMetrics struct {
 	PullRequests      int `json:"pull_requests"`
 	ProtectedBranches int `json:"protected_branches"`
 	Releases          int `json:"releases"`
+	CommitComments    int `json:"commit_comments"`
+	// References are the distinct files referenced inside the archive, e.g. attachments and release assets
+	References int `json:"references"`
+	// BrokenReferences are the references to files missing from the archive and the missing or invalid urls.json
+	BrokenReferences []string `json:"broken_references,omitempty"`
+	// SchemaVersion is the format version recorded in the schema.json of the archive
+	SchemaVersion string `json:"schema_version,omitempty"`
+	// SchemaWarning explains why the counts may be wrong: a missing schema.json, or a version the counting was
+	// not written for
+	SchemaWarning string `json:"schema_warning,omitempty"`
 }
 
 // SelectMigrationForRepository finds and selects a migration containing the specified repository
@@ -83,7 +102,12 @@ func DownloadAndExtractArchive(githubAPI *api.GitHubAPI, org, repoName, download
 	if err != nil {
 		return "", err
 	}
+	return DownloadAndExtractMigration(githubAPI, org, repoName, migrationID, downloadPath)
+}
 
+// DownloadAndExtractMigration downloads and extracts the archive of the exported migration with the given ID,
+// naming it after the repository. Returns the path to the extracted archive directory
+func DownloadAndExtractMigration(githubAPI *api.GitHubAPI, org, repoName string, migrationID int64, downloadPath string) (string, error) {
 	// Use provided download path or default
 	outputDir := "migration-archives"
 	if downloadPath != "" {
@@ -97,7 +121,7 @@ func DownloadAndExtractArchive(githubAPI *api.GitHubAPI, org, repoName, download
 	archivePath := filepath.Join(outputDir, fmt.Sprintf("migration-%s-%d.tar.gz", repoName, migrationID))
 
 	// Download the archive with spinner
-	downloadSpinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Downloading migration archive %d...", migrationID))
+	downloadSpinner := output.StartSpinner(os.Stdout, fmt.Sprintf("Downloading migration archive %d...", migrationID))
 	downloadedPath, err := githubAPI.DownloadMigrationArchive(api.SourceClient, org, migrationID, archivePath)
 	if err != nil {
 		downloadSpinner.Fail("Failed to download migration archive")
@@ -105,59 +129,169 @@ func DownloadAndExtractArchive(githubAPI *api.GitHubAPI, org, repoName, download
 	}
 	downloadSpinner.Success(fmt.Sprintf("Archive downloaded successfully: %s", downloadedPath))
 
-	// Extract the archive with spinner
+	return extractArchive(downloadedPath)
+}
+
+// DownloadAndExtractURL downloads and extracts the migration archive at a pre-signed URL, such as the Azure or
+// AWS blob storage URL a migration was run with. When checksum is set, the downloaded archive must have that
+// SHA-256 checksum. Returns the path to the extracted archive directory
+func DownloadAndExtractURL(githubAPI *api.GitHubAPI, archiveURL, checksum, repoName, downloadPath string) (string, error) {
+	outputDir := "migration-archives"
+	if downloadPath != "" {
+		outputDir = downloadPath
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	parsedURL, err := url.Parse(archiveURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid archive URL: %v", err)
+	}
+	archivePath := filepath.Join(outputDir, archiveURLFileName(parsedURL, repoName))
+
+	// Only the host is shown, as the query of a pre-signed URL grants access to the archive
+	downloadSpinner := output.StartSpinner(os.Stdout, fmt.Sprintf("Downloading migration archive from %s...", parsedURL.Host))
+	downloadedPath, err := githubAPI.DownloadArchiveURL(api.SourceClient, archiveURL, archivePath)
+	if err != nil {
+		downloadSpinner.Fail("Failed to download migration archive")
+		return "", fmt.Errorf("failed to download migration archive: %v", err)
+	}
+	if checksum != "" {
+		if err := VerifyChecksum(downloadedPath, checksum); err != nil {
+			downloadSpinner.Fail("Migration archive checksum mismatch")
+			os.Remove(downloadedPath) // A corrupted archive must not be reused
+			return "", err
+		}
+	}
+	downloadSpinner.Success(fmt.Sprintf("Archive downloaded successfully: %s", downloadedPath))
+
+	return extractArchive(downloadedPath)
+}
+
+// archiveURLFileName names the archive downloaded from archiveURL after the file of its path, e.g.
+// migration_archive.tar.gz, or after the repository when the path does not end with a .tar.gz file
+func archiveURLFileName(archiveURL *url.URL, repoName string) string {
+	if name := path.Base(archiveURL.Path); strings.HasSuffix(name, ".tar.gz") {
+		return name
+	}
+	return fmt.Sprintf("migration-%s.tar.gz", repoName)
+}
+
+// VerifyChecksum checks that the file at filePath has the given SHA-256 checksum, in hexadecimal
+func VerifyChecksum(filePath, expected string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %v", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("failed to read archive: %v", err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("archive checksum mismatch: expected SHA-256 %s, got %s", strings.TrimSpace(expected), actual)
+	}
+	return nil
+}
+
+// extractArchive extracts the downloaded archive next to it, reporting the files extracted, the bytes read
+// and the time left as it goes. Returns the extracted archive directory
+func extractArchive(downloadedPath string) (string, error) {
 	extractPath := archive.GetArchiveDestination(downloadedPath)
-	extractSpinner, _ := pterm.DefaultSpinner.Start("Extracting migration archive...")
+	progress := output.StartProgress("Extracting migration archive")
 
-	err = archive.ExtractTarGz(downloadedPath, extractPath)
+	var extracted archive.ExtractProgress
+	err := archive.ExtractTarGzWithProgress(downloadedPath, extractPath, func(current archive.ExtractProgress) {
+		extracted = current
+		progress.Update(current.String())
+	})
 	if err != nil {
-		extractSpinner.Fail("Failed to extract archive")
+		progress.Fail("Failed to extract archive")
 		return "", fmt.Errorf("failed to extract archive: %v", err)
 	}
-	extractSpinner.Success(fmt.Sprintf("Archive extracted successfully: %s", extractPath))
+	progress.Success(fmt.Sprintf("Archive extracted successfully: %s (%d files in %s)", extractPath, extracted.Files,
+		extracted.Elapsed.Round(time.Second)))
 
 	return extractPath, nil
 }
 
-// AnalyzeMigrationArchive analyzes a migration archive directory and returns metrics
-func AnalyzeMigrationArchive(archiveDir string) (*MigrationArchiveMetrics, error) {
+// AnalyzeMigrationArchive analyzes a migration archive directory and returns metrics. Organization archives
+// hold several repositories, so when repository (OWNER/REPO) is set only the entities whose repository URL
+// points to it are counted; entities without a repository URL are always counted.
+func AnalyzeMigrationArchive(archiveDir, repository string) (*MigrationArchiveMetrics, error) {
 	metrics := &MigrationArchiveMetrics{}
 
+	// The counting relies on the archive format, so archives of other schema versions are flagged
+	switch version, err := ReadSchemaVersion(archiveDir); {
+	case errors.Is(err, fs.ErrNotExist):
+		metrics.SchemaWarning = fmt.Sprintf("%s is missing, so the schema version is unknown: counts may be wrong", SchemaFileName)
+	case err != nil:
+		metrics.SchemaWarning = fmt.Sprintf("%v: counts may be wrong", err)
+	default:
+		metrics.SchemaVersion = version
+		metrics.SchemaWarning = SchemaWarning(version)
+	}
+
 	// Count issues from issues_*.json files
-	issuesCount, err := countJSONArrayEntries(archiveDir, "issues_")
+	issuesCount, err := countJSONArrayEntries(archiveDir, "issues_", repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count issues: %v", err)
 	}
 	metrics.Issues = issuesCount
 
 	// Count pull requests from pull_requests_*.json files
-	pullRequestsCount, err := countJSONArrayEntries(archiveDir, "pull_requests_")
+	pullRequestsCount, err := countJSONArrayEntries(archiveDir, "pull_requests_", repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count pull requests: %v", err)
 	}
 	metrics.PullRequests = pullRequestsCount
 
 	// Count protected branches from protected_branches_*.json files
-	protectedBranchesCount, err := countJSONArrayEntries(archiveDir, "protected_branches_")
+	protectedBranchesCount, err := countJSONArrayEntries(archiveDir, "protected_branches_", repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count protected branches: %v", err)
 	}
 	metrics.ProtectedBranches = protectedBranchesCount
 
 	// Count releases from releases_*.json files
-	releasesCount, err := countJSONArrayEntries(archiveDir, "releases_")
+	releasesCount, err := countJSONArrayEntries(archiveDir, "releases_", repository)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count releases: %v", err)
 	}
 	metrics.Releases = releasesCount
 
+	// Count commit comments from commit_comments_*.json files
+	commitCommentsCount, err := countJSONArrayEntries(archiveDir, "commit_comments_", repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count commit comments: %v", err)
+	}
+	metrics.CommitComments = commitCommentsCount
+
+	// Check the files referenced inside the archive, whose absence fails the import
+	references, err := CheckArchiveReferences(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check archive references: %v", err)
+	}
+	metrics.References = references.Checked
+	metrics.BrokenReferences = references.Broken
+
 	return metrics, nil
 }
 
-// countJSONArrayEntries counts all entries in JSON files matching the given prefix
-func countJSONArrayEntries(archiveDir, filePrefix string) (int, error) {
-	totalCount := 0
+// archiveEntity is the part of an archive entity needed to tell which repository it belongs to
+type archiveEntity struct {
+	Repository string `json:"repository"` // URL of the repository, e.g. https://github.com/org/repo
+}
+
+// countWorkers is the number of JSON files counted at once: archives hold hundreds of issues_*.json shards
+var countWorkers = runtime.NumCPU()
 
+// countJSONArrayEntries counts all entries in JSON files matching the given prefix, only counting the entries
+// of repository (OWNER/REPO) when it is set. Files are counted concurrently by up to countWorkers workers.
+func countJSONArrayEntries(archiveDir, filePrefix, repository string) (int, error) {
 	// Read directory contents
 	entries, err := os.ReadDir(archiveDir)
 	if err != nil {
@@ -165,30 +299,82 @@ func countJSONArrayEntries(archiveDir, filePrefix string) (int, error) {
 	}
 
 	// Find all files matching the prefix pattern (e.g., "issues_000001.json", "issues_000002.json")
+	var fileNames []string
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
-
 		fileName := entry.Name()
 		if strings.HasPrefix(fileName, filePrefix) && strings.HasSuffix(fileName, ".json") {
-			filePath := filepath.Join(archiveDir, fileName)
-
-			// Read and parse the JSON file
-			fileContent, err := os.ReadFile(filePath)
-			if err != nil {
-				return 0, fmt.Errorf("failed to read file %s: %v", fileName, err)
-			}
+			fileNames = append(fileNames, fileName)
+		}
+	}
 
-			// Parse as JSON array to count entries
-			var jsonArray []interface{}
-			if err := json.Unmarshal(fileContent, &jsonArray); err != nil {
-				return 0, fmt.Errorf("failed to parse JSON in file %s: %v", fileName, err)
+	counts := make([]int, len(fileNames))
+	errs := make([]error, len(fileNames))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(max(countWorkers, 1), len(fileNames)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				counts[i], errs[i] = countJSONFileEntries(archiveDir, fileNames[i], repository)
 			}
+		}()
+	}
+	for i := range fileNames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-			totalCount += len(jsonArray)
+	// Errors are returned in file order, so the same archive always fails the same way
+	totalCount := 0
+	for i, count := range counts {
+		if errs[i] != nil {
+			return 0, errs[i]
 		}
+		totalCount += count
 	}
 
 	return totalCount, nil
 }
+
+// countJSONFileEntries counts the entries of the JSON array in fileName, only counting the entries of
+// repository (OWNER/REPO) when it is set
+func countJSONFileEntries(archiveDir, fileName, repository string) (int, error) {
+	// Read and parse the JSON file
+	fileContent, err := os.ReadFile(filepath.Join(archiveDir, fileName))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file %s: %v", fileName, err)
+	}
+
+	// Parse as JSON array to count entries
+	var jsonArray []json.RawMessage
+	if err := json.Unmarshal(fileContent, &jsonArray); err != nil {
+		return 0, fmt.Errorf("failed to parse JSON in file %s: %v", fileName, err)
+	}
+
+	if repository == "" {
+		return len(jsonArray), nil
+	}
+	count := 0
+	for _, raw := range jsonArray {
+		var entity archiveEntity
+		// Entries that are not objects, or have no repository URL, cannot be told apart and are counted
+		if err := json.Unmarshal(raw, &entity); err != nil || entity.Repository == "" ||
+			repositoryURLMatches(entity.Repository, repository) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// repositoryURLMatches reports whether repositoryURL, e.g. https://github.com/org/repo, is the URL of
+// repository (OWNER/REPO), ignoring case
+func repositoryURLMatches(repositoryURL, repository string) bool {
+	path := strings.TrimSuffix(strings.TrimSuffix(repositoryURL, "/"), ".git")
+	return strings.HasSuffix(strings.ToLower(path), "/"+strings.ToLower(strings.Trim(repository, "/")))
+}
@@ -1,10 +1,22 @@
 package migrationarchive
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
 )
 
 func TestAnalyzeMigrationArchive(t *testing.T) {
@@ -39,8 +51,13 @@ func TestAnalyzeMigrationArchive(t *testing.T) {
 		{"type": "release", "id": 5},
 	})
 
+	createTestJSONFile(t, tempDir, "commit_comments_000001.json", []map[string]interface{}{
+		{"type": "commit_comment", "id": 1},
+		{"type": "commit_comment", "id": 2},
+	})
+
 	// Test AnalyzeMigrationArchive
-	metrics, err := AnalyzeMigrationArchive(tempDir)
+	metrics, err := AnalyzeMigrationArchive(tempDir, "")
 	if err != nil {
 		t.Fatalf("AnalyzeMigrationArchive failed: %v", err)
 	}
@@ -50,6 +67,7 @@ func TestAnalyzeMigrationArchive(t *testing.T) {
 	expectedPRs := 2      // 2 from pull_requests_000001.json
 	expectedBranches := 1 // 1 from protected_branches_000001.json
 	expectedReleases := 5 // 5 from releases_000001.json
+	expectedCommitComments := 2
 
 	if metrics.Issues != expectedIssues {
 		t.Errorf("Expected %d issues, got %d", expectedIssues, metrics.Issues)
@@ -66,12 +84,16 @@ func TestAnalyzeMigrationArchive(t *testing.T) {
 	if metrics.Releases != expectedReleases {
 		t.Errorf("Expected %d releases, got %d", expectedReleases, metrics.Releases)
 	}
+
+	if metrics.CommitComments != expectedCommitComments {
+		t.Errorf("Expected %d commit comments, got %d", expectedCommitComments, metrics.CommitComments)
+	}
 }
 
 func TestAnalyzeMigrationArchive_EmptyDirectory(t *testing.T) {
 	tempDir := t.TempDir()
 
-	metrics, err := AnalyzeMigrationArchive(tempDir)
+	metrics, err := AnalyzeMigrationArchive(tempDir, "")
 	if err != nil {
 		t.Fatalf("AnalyzeMigrationArchive failed: %v", err)
 	}
@@ -84,7 +106,7 @@ func TestAnalyzeMigrationArchive_EmptyDirectory(t *testing.T) {
 }
 
 func TestAnalyzeMigrationArchive_NonExistentDirectory(t *testing.T) {
-	_, err := AnalyzeMigrationArchive("/nonexistent/directory")
+	_, err := AnalyzeMigrationArchive("/nonexistent/directory", "")
 	if err == nil {
 		t.Error("Expected error for non-existent directory, got nil")
 	}
@@ -100,7 +122,7 @@ func TestAnalyzeMigrationArchive_InvalidJSON(t *testing.T) {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	_, err = AnalyzeMigrationArchive(tempDir)
+	_, err = AnalyzeMigrationArchive(tempDir, "")
 	if err == nil {
 		t.Error("Expected error for invalid JSON, got nil")
 	}
@@ -124,7 +146,7 @@ func TestCountJSONArrayEntries(t *testing.T) {
 	})
 
 	// Test counting issues
-	count, err := countJSONArrayEntries(tempDir, "issues_")
+	count, err := countJSONArrayEntries(tempDir, "issues_", "")
 	if err != nil {
 		t.Fatalf("countJSONArrayEntries failed: %v", err)
 	}
@@ -135,7 +157,7 @@ func TestCountJSONArrayEntries(t *testing.T) {
 	}
 
 	// Test counting non-existent prefix
-	count, err = countJSONArrayEntries(tempDir, "nonexistent_")
+	count, err = countJSONArrayEntries(tempDir, "nonexistent_", "")
 	if err != nil {
 		t.Fatalf("countJSONArrayEntries failed: %v", err)
 	}
@@ -145,6 +167,76 @@ func TestCountJSONArrayEntries(t *testing.T) {
 	}
 }
 
+func TestCountJSONArrayEntries_Shards(t *testing.T) {
+	defer func(workers int) { countWorkers = workers }(countWorkers)
+	countWorkers = 4
+	tempDir := t.TempDir()
+
+	// 100 shards of 1 to 100 issues
+	for shard := 1; shard <= 100; shard++ {
+		issues := make([]map[string]interface{}, shard)
+		for i := range issues {
+			issues[i] = map[string]interface{}{"id": i}
+		}
+		createTestJSONFile(t, tempDir, fmt.Sprintf("issues_%06d.json", shard), issues)
+	}
+
+	count, err := countJSONArrayEntries(tempDir, "issues_", "")
+	if err != nil {
+		t.Fatalf("countJSONArrayEntries failed: %v", err)
+	}
+	if expected := 100 * 101 / 2; count != expected {
+		t.Errorf("Expected count %d, got %d", expected, count)
+	}
+
+	// The first invalid shard is reported, whichever worker reads it
+	for _, shard := range []string{"issues_000050.json", "issues_000090.json"} {
+		if err := os.WriteFile(filepath.Join(tempDir, shard), []byte("{invalid"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", shard, err)
+		}
+	}
+	_, err = countJSONArrayEntries(tempDir, "issues_", "")
+	if err == nil || !strings.Contains(err.Error(), "issues_000050.json") {
+		t.Errorf("Expected the error of issues_000050.json, got %v", err)
+	}
+}
+
+func TestCountJSONArrayEntries_Repository(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// An organization archive holding the issues of two repositories
+	createTestJSONFile(t, tempDir, "issues_000001.json", []map[string]interface{}{
+		{"id": 1, "repository": "https://github.com/source-org/repo"},
+		{"id": 2, "repository": "https://github.com/source-org/other-repo"},
+		{"id": 3, "repository": "https://github.com/Source-Org/Repo/"},
+		{"id": 4},
+	})
+
+	count, err := countJSONArrayEntries(tempDir, "issues_", "source-org/repo")
+	if err != nil {
+		t.Fatalf("countJSONArrayEntries failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected the 2 issues of source-org/repo and the one without repository, got %d", count)
+	}
+
+	count, err = countJSONArrayEntries(tempDir, "issues_", "source-org/other-repo")
+	if err != nil {
+		t.Fatalf("countJSONArrayEntries failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected the issue of source-org/other-repo and the one without repository, got %d", count)
+	}
+
+	count, err = countJSONArrayEntries(tempDir, "issues_", "")
+	if err != nil {
+		t.Fatalf("countJSONArrayEntries failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected every issue to be counted without a repository, got %d", count)
+	}
+}
+
 func TestSelectMigrationForRepository(t *testing.T) {
 	// Create a mock GitHubAPI - this would require setting up the API mock
 	// For now, this is a placeholder test structure
@@ -211,6 +303,94 @@ func TestDownloadPathHandling(t *testing.T) {
 	}
 }
 
+func TestArchiveURLFileName(t *testing.T) {
+	tests := map[string]string{
+		"https://storage.blob.core.windows.net/migrations/archive-123.tar.gz?sv=2023&sig=secret": "archive-123.tar.gz",
+		"https://bucket.s3.amazonaws.com/exports/migration.tar.gz?X-Amz-Signature=secret":        "migration.tar.gz",
+		"https://storage.example.com/download?id=123":                                            "migration-my-repo.tar.gz",
+	}
+	for rawURL, expected := range tests {
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", rawURL, err)
+		}
+		if name := archiveURLFileName(parsedURL, "my-repo"); name != expected {
+			t.Errorf("%s: expected %s, got %s", rawURL, expected, name)
+		}
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := os.WriteFile(filePath, []byte("archive"), 0644); err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	sum := sha256.Sum256([]byte("archive"))
+	checksum := hex.EncodeToString(sum[:])
+
+	if err := VerifyChecksum(filePath, strings.ToUpper(checksum)); err != nil {
+		t.Errorf("Expected the checksum to match, got %v", err)
+	}
+	if err := VerifyChecksum(filePath, strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected a checksum mismatch, got %v", err)
+	}
+}
+
+func TestDownloadAndExtractURL(t *testing.T) {
+	content := testTarGz(t, "issues_000001.json", `[{"type": "issue"}]`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sig") != "secret" || r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	githubAPI, err := api.NewSourceOnlyAPI(api.ClientConfig{Token: "token", APIURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create API: %v", err)
+	}
+	sum := sha256.Sum256(content)
+	downloadPath := t.TempDir()
+
+	archiveDir, err := DownloadAndExtractURL(githubAPI, server.URL+"/archive.tar.gz?sig=secret", hex.EncodeToString(sum[:]), "my-repo", downloadPath)
+	if err != nil {
+		t.Fatalf("Expected the archive to be downloaded, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(archiveDir, "issues_000001.json")); err != nil {
+		t.Errorf("Expected the archive to be extracted: %v", err)
+	}
+
+	_, err = DownloadAndExtractURL(githubAPI, server.URL+"/other.tar.gz?sig=secret", strings.Repeat("0", 64), "my-repo", downloadPath)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected a checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(downloadPath, "other.tar.gz")); !os.IsNotExist(err) {
+		t.Errorf("Expected the corrupted archive to be removed, got %v", err)
+	}
+}
+
+// testTarGz returns a gzipped tarball holding a single file
+func testTarGz(t *testing.T, name, content string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("Failed to write tar header: %v", err)
+	}
+	if _, err := tarWriter.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write tar content: %v", err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("Failed to close tar: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("Failed to close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
 // Helper function to create test JSON files
 func createTestJSONFile(t *testing.T, dir, filename string, data []map[string]interface{}) {
 	filePath := filepath.Join(dir, filename)
@@ -0,0 +1,131 @@
+package migrationarchive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// urlsFileName is the manifest of the URL templates of an archive, which the importer rewrites URLs with
+const urlsFileName = "urls.json"
+
+// archiveURLPrefix starts the URLs of files stored inside the archive, e.g. the attachments and release assets
+// referenced as tarball://root/attachments/1/screenshot.png
+const archiveURLPrefix = "tarball://root/"
+
+// ArchiveReferences are the files the JSON files of an archive reference inside the archive
+type ArchiveReferences struct {
+	Checked int      // Distinct files referenced
+	Broken  []string // References to files missing from the archive, and missing or invalid manifests, sorted
+}
+
+// CheckArchiveReferences checks that urls.json is a valid manifest and that every tarball://root/ URL of the
+// JSON files of archiveDir, such as the attachments of issues and the assets of releases, points to a file of
+// the archive. Broken references predict import failures in the target. The JSON files are read as streams,
+// so archives with multi-GB files are checked without loading them in memory.
+func CheckArchiveReferences(archiveDir string) (*ArchiveReferences, error) {
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive directory: %v", err)
+	}
+
+	references := make(map[string]bool) // Whether each referenced file exists
+	var broken []string
+	for _, entry := range entries {
+		// urls.json has URL templates, not references
+		if entry.IsDir() || !isArchiveManifest(entry.Name()) || entry.Name() == urlsFileName {
+			continue
+		}
+		if err := collectArchiveURLs(filepath.Join(archiveDir, entry.Name()), archiveDir, references); err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %v", entry.Name(), err)
+		}
+	}
+
+	if err := checkURLsManifest(archiveDir); err != nil {
+		broken = append(broken, fmt.Sprintf("%s (%v)", urlsFileName, err))
+	}
+	for reference, exists := range references {
+		if !exists {
+			broken = append(broken, reference)
+		}
+	}
+	sort.Strings(broken)
+
+	return &ArchiveReferences{Checked: len(references), Broken: broken}, nil
+}
+
+// checkURLsManifest checks that the archive has a urls.json mapping each model to its URL templates
+func checkURLsManifest(archiveDir string) error {
+	content, err := os.ReadFile(filepath.Join(archiveDir, urlsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("missing")
+	}
+	if err != nil {
+		return err
+	}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("invalid: %v", err)
+	}
+	if len(manifest) == 0 {
+		return fmt.Errorf("no URL templates")
+	}
+	return nil
+}
+
+// collectArchiveURLs records in references every tarball://root/ URL of the JSON file at path, with whether
+// the file it points to exists in archiveDir
+func collectArchiveURLs(path, archiveDir string, references map[string]bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		value, ok := token.(string)
+		if !ok || !strings.HasPrefix(value, archiveURLPrefix) {
+			continue
+		}
+		if _, seen := references[value]; seen {
+			continue
+		}
+		references[value] = archiveFileExists(archiveDir, strings.TrimPrefix(value, archiveURLPrefix))
+	}
+}
+
+// archiveFileExists reports whether the file at the slash-separated path inside the archive exists, as is or
+// URL-decoded. Paths leaving the archive directory never do.
+func archiveFileExists(archiveDir, path string) bool {
+	candidates := []string{path}
+	if unescaped, err := url.PathUnescape(path); err == nil && unescaped != path {
+		candidates = append(candidates, unescaped)
+	}
+
+	for _, candidate := range candidates {
+		candidate = filepath.FromSlash(candidate)
+		if !filepath.IsLocal(candidate) {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(archiveDir, candidate)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package migrationarchive
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckArchiveReferences(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "urls.json"), []byte(`{"issue": {"url": "{scheme}://{host}/{owner}/{repository}/issues/{number}"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	createTestJSONFile(t, tempDir, "attachments_000001.json", []map[string]interface{}{
+		{"type": "attachment", "asset_url": "tarball://root/attachments/1/screenshot.png"},
+		{"type": "attachment", "asset_url": "tarball://root/attachments/2/missing.png"},
+		{"type": "attachment", "asset_url": "tarball://root/attachments/1/screenshot.png"},
+	})
+	createTestJSONFile(t, tempDir, "releases_000001.json", []map[string]interface{}{
+		{"type": "release", "release_assets": []map[string]interface{}{
+			{"asset_url": "tarball://root/release_assets/3/build%20output.zip"},
+			{"asset_url": "tarball://root/../outside.zip"},
+		}},
+	})
+	for _, file := range []string{"attachments/1/screenshot.png", "release_assets/3/build output.zip"} {
+		path := filepath.Join(tempDir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	references, err := CheckArchiveReferences(tempDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if references.Checked != 4 {
+		t.Errorf("Expected 4 distinct references, got %d", references.Checked)
+	}
+	expected := []string{"tarball://root/../outside.zip", "tarball://root/attachments/2/missing.png"}
+	if !reflect.DeepEqual(references.Broken, expected) {
+		t.Errorf("Expected broken references %v, got %v", expected, references.Broken)
+	}
+}
+
+func TestCheckArchiveReferences_URLsManifest(t *testing.T) {
+	tempDir := t.TempDir()
+	references, err := CheckArchiveReferences(tempDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(references.Broken, []string{"urls.json (missing)"}) {
+		t.Errorf("Expected a missing urls.json to be reported, got %v", references.Broken)
+	}
+
+	if err := os.WriteFile(filepath.Join(tempDir, "urls.json"), []byte(`{"issue": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	references, err = CheckArchiveReferences(tempDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(references.Broken) != 1 || references.Broken[0] != "urls.json (invalid: unexpected end of JSON input)" {
+		t.Errorf("Expected an invalid urls.json to be reported, got %v", references.Broken)
+	}
+}
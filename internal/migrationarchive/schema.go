@@ -0,0 +1,57 @@
+package migrationarchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"mona-actions/gh-migration-validator/internal/update"
+)
+
+// SchemaFileName is the file of the archive recording the version of its format
+const SchemaFileName = "schema.json"
+
+// The archive schema versions the counting of archive entities was written for
+const (
+	MinSupportedSchemaVersion = "1.0.0"
+	MaxSupportedSchemaVersion = "1.2.0"
+)
+
+// archiveSchema is the content of schema.json, e.g. {"version": "1.2.0"}
+type archiveSchema struct {
+	Version string `json:"version"`
+}
+
+// ReadSchemaVersion returns the version recorded in the schema.json of the archive in archiveDir
+func ReadSchemaVersion(archiveDir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(archiveDir, SchemaFileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", SchemaFileName, err)
+	}
+	var schema archiveSchema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return "", fmt.Errorf("invalid %s: %v", SchemaFileName, err)
+	}
+	if schema.Version == "" {
+		return "", fmt.Errorf("%s has no version", SchemaFileName)
+	}
+	return schema.Version, nil
+}
+
+// SchemaWarning returns why the counts of an archive of the given schema version may be wrong, or "" when
+// the version is one the counting was written for
+func SchemaWarning(version string) string {
+	older, err := update.CompareVersions(version, MinSupportedSchemaVersion)
+	if err != nil {
+		return fmt.Sprintf("unrecognized schema version %q: counts may be wrong", version)
+	}
+	newer, _ := update.CompareVersions(version, MaxSupportedSchemaVersion)
+	switch {
+	case older < 0:
+		return fmt.Sprintf("schema version %s is older than %s: counts may be wrong", version, MinSupportedSchemaVersion)
+	case newer > 0:
+		return fmt.Sprintf("schema version %s is newer than %s: counts may be wrong", version, MaxSupportedSchemaVersion)
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package migrationarchive
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSchemaWarning(t *testing.T) {
+	tests := []struct {
+		version       string
+		warnsContains string
+	}{
+		{version: "1.0.0"},
+		{version: "1.0.1"},
+		{version: "1.2"},
+		{version: MaxSupportedSchemaVersion},
+		{version: "0.9.3", warnsContains: "older than 1.0.0"},
+		{version: "1.3.0", warnsContains: "newer than 1.2.0"},
+		{version: "2.0.0", warnsContains: "newer than 1.2.0"},
+		{version: "beta", warnsContains: "unrecognized schema version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			warning := SchemaWarning(tt.version)
+			if tt.warnsContains == "" {
+				if warning != "" {
+					t.Errorf("Expected no warning, got %q", warning)
+				}
+			} else if !strings.Contains(warning, tt.warnsContains) {
+				t.Errorf("Expected a warning containing %q, got %q", tt.warnsContains, warning)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMigrationArchive_Schema(t *testing.T) {
+	tests := []struct {
+		name            string
+		schema          string
+		expectedVersion string
+		warnsContains   string
+	}{
+		{name: "supported version", schema: `{"version": "1.2.0"}`, expectedVersion: "1.2.0"},
+		{name: "newer version", schema: `{"version": "2.0.0"}`, expectedVersion: "2.0.0", warnsContains: "newer than"},
+		{name: "missing schema", warnsContains: "schema.json is missing"},
+		{name: "invalid schema", schema: `{"version":`, warnsContains: "invalid schema.json"},
+		{name: "no version", schema: `{}`, warnsContains: "schema.json has no version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if tt.schema != "" {
+				if err := os.WriteFile(filepath.Join(tempDir, SchemaFileName), []byte(tt.schema), 0644); err != nil {
+					t.Fatalf("Failed to write schema.json: %v", err)
+				}
+			}
+
+			metrics, err := AnalyzeMigrationArchive(tempDir, "")
+			if err != nil {
+				t.Fatalf("AnalyzeMigrationArchive failed: %v", err)
+			}
+			if metrics.SchemaVersion != tt.expectedVersion {
+				t.Errorf("Expected schema version %q, got %q", tt.expectedVersion, metrics.SchemaVersion)
+			}
+			if tt.warnsContains == "" {
+				if metrics.SchemaWarning != "" {
+					t.Errorf("Expected no schema warning, got %q", metrics.SchemaWarning)
+				}
+			} else if !strings.Contains(metrics.SchemaWarning, tt.warnsContains) {
+				t.Errorf("Expected a schema warning containing %q, got %q", tt.warnsContains, metrics.SchemaWarning)
+			}
+		})
+	}
+}
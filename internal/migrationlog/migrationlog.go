@@ -0,0 +1,110 @@
+package migrationlog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// MigrationLogMetrics holds the details of the migration log issue found in a target repository
+// and the summary counts parsed from its body. Counts are nil when the log does not mention them.
+type MigrationLogMetrics struct {
+	Found             bool   `json:"found"`
+	IssueNumber       int    `json:"issue_number,omitempty"`
+	IssueURL          string `json:"issue_url,omitempty"`
+	Issues            *int   `json:"issues,omitempty"`
+	PullRequests      *int   `json:"pull_requests,omitempty"`
+	ProtectedBranches *int   `json:"protected_branches,omitempty"`
+	Releases          *int   `json:"releases,omitempty"`
+}
+
+// summaryKeys maps the entity names used in migration log summaries to the metric they describe
+var summaryKeys = map[string]string{
+	"issue":              "issues",
+	"issues":             "issues",
+	"pull request":       "pull_requests",
+	"pull requests":      "pull_requests",
+	"protected branch":   "protected_branches",
+	"protected branches": "protected_branches",
+	"release":            "releases",
+	"releases":           "releases",
+}
+
+var (
+	// Matches "Issues: 42" and "| Issues | 42 |" style lines
+	keyThenCountPattern = regexp.MustCompile(`^([a-z ]+?)\s*[:=|]\s*(\d+)\b`)
+	// Matches "42 issues" and "Migrated 42 pull requests" style lines
+	countThenKeyPattern = regexp.MustCompile(`\b(\d+)\s+([a-z ]+)$`)
+)
+
+// New builds migration log metrics from the migration log issue returned by the API.
+// A nil issue produces metrics with Found set to false.
+func New(issue *api.MigrationLogIssue) *MigrationLogMetrics {
+	if issue == nil {
+		return &MigrationLogMetrics{Found: false}
+	}
+
+	metrics := ParseSummary(issue.Body)
+	metrics.Found = true
+	metrics.IssueNumber = issue.Number
+	metrics.IssueURL = issue.URL
+	return metrics
+}
+
+// ParseSummary extracts summary counts from the body of a migration log issue.
+// Lines that do not describe a known entity count are ignored.
+func ParseSummary(body string) *MigrationLogMetrics {
+	metrics := &MigrationLogMetrics{}
+
+	for _, line := range strings.Split(body, "\n") {
+		key, count, ok := parseSummaryLine(line)
+		if !ok {
+			continue
+		}
+
+		value := count
+		switch key {
+		case "issues":
+			metrics.Issues = &value
+		case "pull_requests":
+			metrics.PullRequests = &value
+		case "protected_branches":
+			metrics.ProtectedBranches = &value
+		case "releases":
+			metrics.Releases = &value
+		}
+	}
+
+	return metrics
+}
+
+// parseSummaryLine normalizes a single line of the log and returns the metric key and count it describes
+func parseSummaryLine(line string) (string, int, bool) {
+	// Strip markdown decoration so tables, bullets and bold text parse the same as plain text
+	normalized := strings.ToLower(line)
+	normalized = strings.NewReplacer("*", "", "`", "", "#", "", "_", " ").Replace(normalized)
+	normalized = strings.TrimSpace(strings.Trim(strings.TrimSpace(normalized), "-|"))
+	if normalized == "" {
+		return "", 0, false
+	}
+
+	if match := keyThenCountPattern.FindStringSubmatch(normalized); match != nil {
+		if key, ok := summaryKeys[strings.TrimSpace(match[1])]; ok {
+			if count, err := strconv.Atoi(match[2]); err == nil {
+				return key, count, true
+			}
+		}
+	}
+
+	if match := countThenKeyPattern.FindStringSubmatch(normalized); match != nil {
+		if key, ok := summaryKeys[strings.TrimSpace(strings.TrimSuffix(match[2], "migrated"))]; ok {
+			if count, err := strconv.Atoi(match[1]); err == nil {
+				return key, count, true
+			}
+		}
+	}
+
+	return "", 0, false
+}
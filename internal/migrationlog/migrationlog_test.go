@@ -0,0 +1,92 @@
+package migrationlog
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSummary(t *testing.T) {
+	tests := []struct {
+		name                      string
+		body                      string
+		expectedIssues            *int
+		expectedPullRequests      *int
+		expectedProtectedBranches *int
+		expectedReleases          *int
+	}{
+		{
+			name:                      "key and count lines",
+			body:                      "# Migration Log\n\nIssues: 42\nPull requests: 30\nProtected branches: 2\nReleases: 5\n",
+			expectedIssues:            intPtr(42),
+			expectedPullRequests:      intPtr(30),
+			expectedProtectedBranches: intPtr(2),
+			expectedReleases:          intPtr(5),
+		},
+		{
+			name:                 "markdown table",
+			body:                 "| Entity | Count |\n|--------|-------|\n| Issues | 7 |\n| Pull Requests | 3 |\n",
+			expectedIssues:       intPtr(7),
+			expectedPullRequests: intPtr(3),
+		},
+		{
+			name:                      "count before entity",
+			body:                      "- Migrated 12 issues\n- **4 releases** migrated\n- 1 protected branch",
+			expectedIssues:            intPtr(12),
+			expectedProtectedBranches: intPtr(1),
+			expectedReleases:          intPtr(4),
+		},
+		{
+			name: "no summary counts",
+			body: "Migration completed successfully.\nSee the logs for more details.",
+		},
+		{
+			name: "empty body",
+			body: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := ParseSummary(tt.body)
+
+			assert.Equal(t, tt.expectedIssues, metrics.Issues)
+			assert.Equal(t, tt.expectedPullRequests, metrics.PullRequests)
+			assert.Equal(t, tt.expectedProtectedBranches, metrics.ProtectedBranches)
+			assert.Equal(t, tt.expectedReleases, metrics.Releases)
+			assert.False(t, metrics.Found, "ParseSummary should not mark the log as found")
+		})
+	}
+}
+
+func TestNew_WithIssue(t *testing.T) {
+	issue := &api.MigrationLogIssue{
+		Number: 43,
+		Title:  "Migration Log",
+		Body:   "Issues: 42\nReleases: 5",
+		URL:    "https://github.com/target-org/target-repo/issues/43",
+	}
+
+	metrics := New(issue)
+
+	assert.True(t, metrics.Found)
+	assert.Equal(t, 43, metrics.IssueNumber)
+	assert.Equal(t, issue.URL, metrics.IssueURL)
+	assert.Equal(t, intPtr(42), metrics.Issues)
+	assert.Equal(t, intPtr(5), metrics.Releases)
+	assert.Nil(t, metrics.PullRequests)
+}
+
+func TestNew_WithoutIssue(t *testing.T) {
+	metrics := New(nil)
+
+	assert.NotNil(t, metrics)
+	assert.False(t, metrics.Found)
+	assert.Nil(t, metrics.Issues)
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -5,19 +5,15 @@ import (
 	"errors"
 	"testing"
 	"time"
-
-	"github.com/pterm/pterm"
 )
 
 func TestLogRateLimitWarning_AboveThreshold(t *testing.T) {
 	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	// Should be a no-op when remaining >= threshold
-	LogRateLimitWarning("Source", 100, time.Now().Add(5*time.Minute), 50)
+	LogRateLimitWarning(logger, "Source", 100, time.Now().Add(5*time.Minute), 50)
 
 	if buf.Len() > 0 {
 		t.Errorf("Expected no output when remaining >= threshold, got: %s", buf.String())
@@ -27,12 +23,10 @@ func TestLogRateLimitWarning_AboveThreshold(t *testing.T) {
 func TestLogRateLimitWarning_AtThreshold(t *testing.T) {
 	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	// Should be a no-op when remaining == threshold
-	LogRateLimitWarning("Source", 50, time.Now().Add(5*time.Minute), 50)
+	LogRateLimitWarning(logger, "Source", 50, time.Now().Add(5*time.Minute), 50)
 
 	if buf.Len() > 0 {
 		t.Errorf("Expected no output when remaining == threshold, got: %s", buf.String())
@@ -42,12 +36,10 @@ func TestLogRateLimitWarning_AtThreshold(t *testing.T) {
 func TestLogRateLimitWarning_BelowThreshold(t *testing.T) {
 	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	resetTime := time.Now().Add(5 * time.Minute)
-	LogRateLimitWarning("Source", 25, resetTime, 50)
+	LogRateLimitWarning(logger, "Source", 25, resetTime, 50)
 
 	output := buf.String()
 
@@ -85,12 +77,10 @@ func TestLogRateLimitWarning_BelowThreshold(t *testing.T) {
 func TestLogRateLimitWarning_ZeroRemaining(t *testing.T) {
 	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	resetTime := time.Now().Add(10 * time.Minute)
-	LogRateLimitWarning("Target", 0, resetTime, 50)
+	LogRateLimitWarning(logger, "Target", 0, resetTime, 50)
 
 	output := buf.String()
 
@@ -106,21 +96,26 @@ func TestLogRateLimitWarning_ZeroRemaining(t *testing.T) {
 }
 
 func TestLogAPIErrors_EmptyMessages(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextPrinter(&buf).Logger(&buf)
+
 	// Should be a no-op with empty slice - no panic, no output
-	LogAPIErrors([]string{}, "owner", "repo", nil)
-	LogAPIErrors(nil, "owner", "repo", nil)
-	LogAPIErrors([]string{}, "owner", "repo", errors.New("some error"))
+	LogAPIErrors(logger, []string{}, "owner", "repo", nil)
+	LogAPIErrors(logger, nil, "owner", "repo", nil)
+	LogAPIErrors(logger, []string{}, "owner", "repo", errors.New("some error"))
+
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output with empty messages, got: %s", buf.String())
+	}
 }
 
 func TestLogAPIErrors_WithMessages_NoFatalError(t *testing.T) {
-	// Capture output by redirecting pterm's logger writer
+	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	messages := []string{"issues: connection timeout", "pull requests: rate limited"}
-	LogAPIErrors(messages, "testowner", "testrepo", nil)
+	LogAPIErrors(logger, messages, "testowner", "testrepo", nil)
 
 	output := buf.String()
 
@@ -149,15 +144,13 @@ func TestLogAPIErrors_WithMessages_NoFatalError(t *testing.T) {
 }
 
 func TestLogAPIErrors_WithMessages_WithFatalError(t *testing.T) {
-	// Capture output by redirecting pterm's logger writer
+	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	messages := []string{"issues: not found", "commits: forbidden"}
 	fatalErr := errors.New("all API requests failed")
-	LogAPIErrors(messages, "myorg", "myrepo", fatalErr)
+	LogAPIErrors(logger, messages, "myorg", "myrepo", fatalErr)
 
 	output := buf.String()
 
@@ -177,13 +170,12 @@ func TestLogAPIErrors_WithMessages_WithFatalError(t *testing.T) {
 }
 
 func TestLogAPIErrors_SingleMessage(t *testing.T) {
+	// Capture output
 	var buf bytes.Buffer
-	originalLogger := pterm.DefaultLogger
-	pterm.DefaultLogger = *pterm.DefaultLogger.WithWriter(&buf)
-	defer func() { pterm.DefaultLogger = originalLogger }()
+	logger := NewTextPrinter(&buf).Logger(&buf)
 
 	messages := []string{"webhooks: permission denied"}
-	LogAPIErrors(messages, "acme", "widget", nil)
+	LogAPIErrors(logger, messages, "acme", "widget", nil)
 
 	output := buf.String()
 
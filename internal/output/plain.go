@@ -0,0 +1,49 @@
+package output
+
+import (
+	"strings"
+	"unicode"
+)
+
+// plain is set by --plain to print reports without emoji
+var plain bool
+
+// SetPlain selects whether reports are printed without emoji, for terminals and ticket systems that mangle unicode
+func SetPlain(enabled bool) {
+	plain = enabled
+}
+
+// Plain reports whether reports are printed without emoji
+func Plain() bool {
+	return plain
+}
+
+// Heading returns text for display, without its leading emoji in plain mode, e.g. "📊 Summary" becomes "Summary"
+func Heading(text string) string {
+	if !plain {
+		return text
+	}
+
+	if prefix, rest, ok := strings.Cut(text, " "); ok && !isASCII(prefix) {
+		return rest
+	}
+	return text
+}
+
+// Bullet returns the bullet for a list, replaced by "-" in plain mode
+func Bullet(emoji string) string {
+	if plain {
+		return "-"
+	}
+	return emoji
+}
+
+// isASCII reports whether text only has ASCII characters
+func isASCII(text string) bool {
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
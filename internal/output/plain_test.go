@@ -0,0 +1,28 @@
+package output
+
+import "testing"
+
+func TestHeading(t *testing.T) {
+	defer SetPlain(false)
+
+	if got := Heading("📊 Migration Validation Report"); got != "📊 Migration Validation Report" {
+		t.Errorf("Expected the emoji to be kept by default, got %q", got)
+	}
+
+	SetPlain(true)
+	tests := map[string]string{
+		"📊 Migration Validation Report": "Migration Validation Report",
+		"⚠️ Failed Requests":            "Failed Requests",
+		"Markdown report saved to %s\n": "Markdown report saved to %s\n",
+		"Archive vs Source Validation":  "Archive vs Source Validation",
+	}
+	for text, expected := range tests {
+		if got := Heading(text); got != expected {
+			t.Errorf("Heading(%q) = %q, expected %q", text, got, expected)
+		}
+	}
+
+	if got := Bullet("📊"); got != "-" {
+		t.Errorf("Expected a plain bullet, got %q", got)
+	}
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"io"
+	"os"
+)
+
+// Level is the severity of a message
+type Level string
+
+const (
+	LevelInfo    Level = "INFO"
+	LevelSuccess Level = "SUCCESS"
+	LevelWarning Level = "WARNING"
+	LevelError   Level = "ERROR"
+)
+
+// Color highlights the text of a bullet list item
+type Color int
+
+const (
+	ColorDefault Color = iota
+	ColorGreen
+	ColorRed
+	ColorYellow
+	ColorCyan
+	ColorMagenta
+	ColorGray
+)
+
+// Box is a titled box of text, e.g. the repository of one side of a report
+type Box struct {
+	Title string
+	Text  string
+}
+
+// BulletItem is an item of a bullet list
+type BulletItem struct {
+	Text  string
+	Color Color
+}
+
+// Spinner shows the progress of an operation until it ends with a success, warning or failure message
+type Spinner interface {
+	UpdateText(text string)
+	Success(message ...any)
+	Warning(message ...any)
+	Fail(message ...any)
+}
+
+// Logger writes structured log messages; args alternate keys and values, e.g. "repo", "owner/name"
+type Logger interface {
+	Warn(message string, args ...any)
+	Error(message string, args ...any)
+}
+
+// Printer draws reports, progress and log messages. Reports are written to the output of the printer, while
+// spinners, loggers and messages given a writer write to it, e.g. the progress writer of a validation.
+// The CLI draws them on the terminal with pterm; by default they are written as plain text to stdout.
+type Printer interface {
+	Header(text string)
+	Section(text string)
+	Boxes(boxes ...Box)
+	Table(data [][]string, boxed bool)
+	BulletList(items []BulletItem, bullet string)
+	// Message writes text with its level to w, or to the output of the printer when w is nil
+	Message(w io.Writer, level Level, text string)
+	StartSpinner(w io.Writer, text string) Spinner
+	// StartSpinners starts spinners that update concurrently on w, one per text, until stop is called
+	StartSpinners(w io.Writer, texts ...string) (spinners []Spinner, stop func())
+	Logger(w io.Writer) Logger
+}
+
+// printer draws the output of the validator, set with SetPrinter
+var printer Printer = NewTextPrinter(os.Stdout)
+
+// SetPrinter selects how reports, progress and log messages are drawn
+func SetPrinter(p Printer) {
+	printer = p
+}
+
+// CurrentPrinter returns the printer selected with SetPrinter
+func CurrentPrinter() Printer {
+	return printer
+}
+
+// Header prints the title of a report
+func Header(text string) {
+	printer.Header(text)
+}
+
+// Section prints the heading of a report section
+func Section(text string) {
+	printer.Section(text)
+}
+
+// Boxes prints boxes side by side
+func Boxes(boxes ...Box) {
+	printer.Boxes(boxes...)
+}
+
+// BulletList prints items as a list with bullet
+func BulletList(items []BulletItem, bullet string) {
+	printer.BulletList(items, bullet)
+}
+
+// Message writes text with its level to w, or to the report output when w is nil
+func Message(w io.Writer, level Level, text string) {
+	printer.Message(w, level, text)
+}
+
+// StartSpinner starts a spinner on w showing text
+func StartSpinner(w io.Writer, text string) Spinner {
+	return printer.StartSpinner(w, text)
+}
+
+// StartSpinners starts spinners that update concurrently on w, one per text, until stop is called
+func StartSpinners(w io.Writer, texts ...string) ([]Spinner, func()) {
+	return printer.StartSpinners(w, texts...)
+}
+
+// NewLogger returns a logger writing to w
+func NewLogger(w io.Writer) Logger {
+	return printer.Logger(w)
+}
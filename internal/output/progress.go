@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// quiet is set by --quiet to hide the progress of long operations
+var quiet bool
+
+// SetQuiet selects whether the progress of long operations, such as archive extraction, is hidden
+func SetQuiet(enabled bool) {
+	quiet = enabled
+}
+
+// Quiet reports whether the progress of long operations is hidden
+func Quiet() bool {
+	return quiet
+}
+
+// IsTerminal reports whether stdout is a terminal, where spinners can update their line in place
+func IsTerminal() bool {
+	_, _, err := term.GetSize(int(os.Stdout.Fd()))
+	return err == nil
+}
+
+// PlainProgressInterval is the shortest time between two progress lines when stdout is not a terminal, so CI
+// logs get a line now and then rather than one per update
+var PlainProgressInterval = 30 * time.Second
+
+// Progress reports the progress of a long operation: through a spinner on terminals, and in plain lines at most
+// every PlainProgressInterval otherwise. With --quiet, only its start and outcome are shown.
+type Progress struct {
+	text        string
+	spinner     Spinner
+	writer      io.Writer // Plain lines, when stdout is not a terminal
+	lastPrinted time.Time
+}
+
+// StartProgress starts reporting the progress of the operation described by text, e.g. "Extracting migration archive"
+func StartProgress(text string) *Progress {
+	if IsTerminal() {
+		return &Progress{text: text, spinner: StartSpinner(os.Stdout, text+"...")}
+	}
+	return newPlainProgress(text, os.Stdout)
+}
+
+// newPlainProgress returns a progress reported in plain lines to writer
+func newPlainProgress(text string, writer io.Writer) *Progress {
+	fmt.Fprintf(writer, "%s...\n", text)
+	return &Progress{text: text, writer: writer, lastPrinted: time.Now()}
+}
+
+// Update reports detail, e.g. "120 files, ETA 2m0s", as the current progress of the operation
+func (p *Progress) Update(detail string) {
+	if quiet {
+		return
+	}
+	if p.spinner != nil {
+		p.spinner.UpdateText(fmt.Sprintf("%s: %s", p.text, detail))
+		return
+	}
+	if time.Since(p.lastPrinted) >= PlainProgressInterval {
+		p.lastPrinted = time.Now()
+		fmt.Fprintf(p.writer, "%s: %s\n", p.text, detail)
+	}
+}
+
+// Success ends the progress with a success message
+func (p *Progress) Success(message string) {
+	if p.spinner != nil {
+		p.spinner.Success(message)
+		return
+	}
+	Message(nil, LevelSuccess, message)
+}
+
+// Fail ends the progress with a failure message
+func (p *Progress) Fail(message string) {
+	if p.spinner != nil {
+		p.spinner.Fail(message)
+		return
+	}
+	Message(nil, LevelError, message)
+}
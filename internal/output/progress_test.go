@@ -0,0 +1,33 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestPlainProgress(t *testing.T) {
+	defer func(interval time.Duration) { PlainProgressInterval = interval }(PlainProgressInterval)
+	defer SetQuiet(false)
+
+	var buf bytes.Buffer
+	PlainProgressInterval = time.Hour
+	progress := newPlainProgress("Extracting migration archive", &buf)
+	progress.Update("10 files")
+	if expected := "Extracting migration archive...\n"; buf.String() != expected {
+		t.Errorf("Expected updates within the interval to be skipped, got %q", buf.String())
+	}
+
+	PlainProgressInterval = 0
+	progress.Update("20 files")
+	if expected := "Extracting migration archive...\nExtracting migration archive: 20 files\n"; buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	SetQuiet(true)
+	progress.Update("30 files")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no progress with --quiet, got %q", buf.String())
+	}
+}
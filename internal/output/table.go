@@ -0,0 +1,118 @@
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// minColumnWidth is the narrowest a column is made to fit a table in the terminal
+const minColumnWidth = 10
+
+const (
+	tableSeparatorWidth = 3 // " | " between columns
+	tableBoxWidth       = 4 // "| " and " |" around the rows of boxed tables
+)
+
+// RenderTable prints a table whose first row is the header, fitted to the terminal width
+func RenderTable(data [][]string, boxed bool) {
+	printer.Table(FitTable(data, TerminalWidth(), boxed), boxed)
+}
+
+// TerminalWidth returns the width tables are fitted to: $COLUMNS when set, the width of the terminal when
+// stdout is one, and 0 (unlimited) otherwise
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// FitTable narrows the widest columns of a table until its rows fit in width, wrapping their cells at spaces
+// and truncating words that are still too long, e.g. commit SHAs. Columns are not narrowed below
+// minColumnWidth, and a width of 0 leaves the table unchanged.
+func FitTable(data [][]string, width int, boxed bool) [][]string {
+	if width <= 0 || len(data) == 0 {
+		return data
+	}
+
+	widths := columnWidths(data)
+	available := width - tableSeparatorWidth*(len(widths)-1)
+	if boxed {
+		available -= tableBoxWidth
+	}
+
+	total := 0
+	for _, columnWidth := range widths {
+		total += columnWidth
+	}
+	for total > available {
+		widest := 0
+		for i, columnWidth := range widths {
+			if columnWidth > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	fitted := make([][]string, len(data))
+	for i, row := range data {
+		fitted[i] = make([]string, len(row))
+		for j, cell := range row {
+			fitted[i][j] = wrapCell(cell, widths[j])
+		}
+	}
+	return fitted
+}
+
+// columnWidths returns the display width of the widest cell of every column
+func columnWidths(data [][]string) []int {
+	var widths []int
+	for _, row := range data {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if cellWidth := runewidth.StringWidth(cell); cellWidth > widths[i] {
+				widths[i] = cellWidth
+			}
+		}
+	}
+	return widths
+}
+
+// wrapCell wraps a cell onto lines of at most width, truncating words longer than a line
+func wrapCell(cell string, width int) string {
+	if runewidth.StringWidth(cell) <= width {
+		return cell
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(cell) {
+		word = runewidth.Truncate(word, width, "…")
+		switch {
+		case line == "":
+			line = word
+		case runewidth.StringWidth(line)+1+runewidth.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFitTable(t *testing.T) {
+	data := [][]string{
+		{"Metric", "Source Value", "Target Value"},
+		{"Latest Commit SHA", "0123456789abcdef0123456789abcdef01234567", "0123456789abcdef0123456789abcdef01234567"},
+		{"Branch Protection Rules", "3", "3"},
+	}
+
+	if got := FitTable(data, 0, false); !reflect.DeepEqual(got, data) {
+		t.Errorf("Expected an unlimited width to leave the table unchanged, got %v", got)
+	}
+	if got := FitTable(data, 200, false); !reflect.DeepEqual(got, data) {
+		t.Errorf("Expected a table that fits to be unchanged, got %v", got)
+	}
+
+	// 60 columns leave 54 for the cells once the two separators are taken, so the widest columns are
+	// narrowed until all three are 18 wide
+	got := FitTable(data, 60, false)
+	expected := [][]string{
+		{"Metric", "Source Value", "Target Value"},
+		{"Latest Commit SHA", "0123456789abcdef0…", "0123456789abcdef0…"},
+		{"Branch Protection\nRules", "3", "3"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("FitTable() = %q, expected %q", got, expected)
+	}
+
+	// Text is wrapped at spaces, and no column is narrowed below minColumnWidth
+	got = FitTable(data, 20, true)
+	if got[2][0] != "Branch\nProtection\nRules" {
+		t.Errorf("Expected the metric to be wrapped, got %q", got[2][0])
+	}
+	if got[1][1] != "012345678…" {
+		t.Errorf("Expected the SHA to be truncated to %d columns, got %q", minColumnWidth, got[1][1])
+	}
+}
+
+func TestTerminalWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "72")
+	if got := TerminalWidth(); got != 72 {
+		t.Errorf("Expected the width from COLUMNS, got %d", got)
+	}
+}
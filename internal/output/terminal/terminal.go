@@ -0,0 +1,118 @@
+// Package terminal draws the output of the CLI on the terminal with pterm. It is kept out of the output
+// package so that programs using the validator as a library do not depend on pterm.
+package terminal
+
+import (
+	"io"
+
+	"mona-actions/gh-migration-validator/internal/output"
+
+	"github.com/pterm/pterm"
+)
+
+// colors maps the colors of bullet list items to their pterm styles
+var colors = map[output.Color]*pterm.Style{
+	output.ColorGreen:   pterm.NewStyle(pterm.FgGreen),
+	output.ColorRed:     pterm.NewStyle(pterm.FgRed),
+	output.ColorYellow:  pterm.NewStyle(pterm.FgYellow),
+	output.ColorCyan:    pterm.NewStyle(pterm.FgCyan),
+	output.ColorMagenta: pterm.NewStyle(pterm.FgMagenta),
+	output.ColorGray:    pterm.NewStyle(pterm.FgGray),
+}
+
+// Printer draws reports, spinners and log messages with pterm. Reports are written to stdout.
+type Printer struct{}
+
+// Header prints text in a full-width blue header
+func (Printer) Header(text string) {
+	pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgBlue)).WithTextStyle(pterm.NewStyle(pterm.FgWhite)).Println(text)
+}
+
+// Section prints text as a section heading
+func (Printer) Section(text string) {
+	pterm.DefaultSection.Println(text)
+}
+
+// Boxes prints boxes side by side in a panel
+func (Printer) Boxes(boxes ...output.Box) {
+	panels := make([]pterm.Panel, 0, len(boxes))
+	for _, box := range boxes {
+		panels = append(panels, pterm.Panel{Data: pterm.DefaultBox.WithTitle(box.Title).WithTitleTopLeft().Sprint(box.Text)})
+	}
+	pterm.DefaultPanel.WithPanels([][]pterm.Panel{panels}).Render()
+}
+
+// Table prints a table whose first row is the header
+func (Printer) Table(data [][]string, boxed bool) {
+	pterm.DefaultTable.WithHasHeader().WithBoxed(boxed).WithData(data).Render()
+}
+
+// BulletList prints items as a bullet list, colored by their color
+func (Printer) BulletList(items []output.BulletItem, bullet string) {
+	list := make([]pterm.BulletListItem, 0, len(items))
+	for _, item := range items {
+		list = append(list, pterm.BulletListItem{Level: 0, Text: item.Text, TextStyle: colors[item.Color]})
+	}
+	pterm.DefaultBulletList.WithItems(list).WithBullet(bullet).Render()
+}
+
+// Message prints text with the prefix of its level to w, or to stdout when w is nil
+func (Printer) Message(w io.Writer, level output.Level, text string) {
+	prefix := prefixPrinter(level)
+	if w != nil {
+		prefix = prefix.WithWriter(w)
+	}
+	prefix.Println(text)
+}
+
+// prefixPrinter returns the pterm printer of a message level
+func prefixPrinter(level output.Level) *pterm.PrefixPrinter {
+	switch level {
+	case output.LevelSuccess:
+		return &pterm.Success
+	case output.LevelWarning:
+		return &pterm.Warning
+	case output.LevelError:
+		return &pterm.Error
+	default:
+		return &pterm.Info
+	}
+}
+
+// StartSpinner starts a spinner on w
+func (Printer) StartSpinner(w io.Writer, text string) output.Spinner {
+	spinner, _ := pterm.DefaultSpinner.WithWriter(w).Start(text)
+	return spinner
+}
+
+// StartSpinners starts spinners on the lines of a multi printer writing to w
+func (Printer) StartSpinners(w io.Writer, texts ...string) ([]output.Spinner, func()) {
+	multi := pterm.DefaultMultiPrinter.WithWriter(w)
+	spinners := make([]output.Spinner, 0, len(texts))
+	for _, text := range texts {
+		spinner, _ := pterm.DefaultSpinner.WithWriter(multi.NewWriter()).Start(text)
+		spinners = append(spinners, spinner)
+	}
+	multi.Start()
+	return spinners, func() { multi.Stop() }
+}
+
+// Logger returns the pterm logger writing to w
+func (Printer) Logger(w io.Writer) output.Logger {
+	return logger{pterm.DefaultLogger.WithWriter(w)}
+}
+
+// logger passes the key-value arguments of log messages to a pterm logger
+type logger struct {
+	logger *pterm.Logger
+}
+
+func (l logger) Warn(message string, args ...any) {
+	l.logger.Warn(message, l.logger.Args(args...))
+}
+
+func (l logger) Error(message string, args ...any) {
+	l.logger.Error(message, l.logger.Args(args...))
+}
+
+var _ output.Printer = Printer{}
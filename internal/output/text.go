@@ -0,0 +1,144 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TextPrinter writes reports, progress and log messages as plain text, without colors or cursor movements
+type TextPrinter struct {
+	w io.Writer
+}
+
+// NewTextPrinter returns a printer writing reports to w
+func NewTextPrinter(w io.Writer) *TextPrinter {
+	return &TextPrinter{w: w}
+}
+
+// Header prints text underlined with "="
+func (p *TextPrinter) Header(text string) {
+	fmt.Fprintf(p.w, "\n%s\n%s\n", text, strings.Repeat("=", runewidth.StringWidth(text)))
+}
+
+// Section prints text underlined with "-"
+func (p *TextPrinter) Section(text string) {
+	fmt.Fprintf(p.w, "\n%s\n%s\n", text, strings.Repeat("-", runewidth.StringWidth(text)))
+}
+
+// Boxes prints the title of every box followed by its indented text
+func (p *TextPrinter) Boxes(boxes ...Box) {
+	for _, box := range boxes {
+		fmt.Fprintln(p.w, box.Title)
+		for _, line := range strings.Split(box.Text, "\n") {
+			fmt.Fprintf(p.w, "  %s\n", line)
+		}
+	}
+}
+
+// Table prints the rows of data in aligned columns, the lines of wrapped cells joined again
+func (p *TextPrinter) Table(data [][]string, boxed bool) {
+	writer := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, row := range data {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(cell, "\n", " ")
+		}
+		fmt.Fprintln(writer, strings.Join(cells, "\t"))
+	}
+	writer.Flush()
+}
+
+// BulletList prints every item on its own line after bullet
+func (p *TextPrinter) BulletList(items []BulletItem, bullet string) {
+	for _, item := range items {
+		fmt.Fprintf(p.w, "%s %s\n", bullet, item.Text)
+	}
+}
+
+// Message prints text after its level, e.g. "WARNING: rate limit low"
+func (p *TextPrinter) Message(w io.Writer, level Level, text string) {
+	if w == nil {
+		w = p.w
+	}
+	fmt.Fprintf(w, "%s: %s\n", level, strings.TrimRight(text, "\n"))
+}
+
+// StartSpinner prints text, and the message the spinner ends with; text updates are not printed
+func (p *TextPrinter) StartSpinner(w io.Writer, text string) Spinner {
+	return newTextSpinner(w, &sync.Mutex{}, text)
+}
+
+// StartSpinners starts text spinners writing to w one line at a time
+func (p *TextPrinter) StartSpinners(w io.Writer, texts ...string) ([]Spinner, func()) {
+	mu := &sync.Mutex{}
+	spinners := make([]Spinner, 0, len(texts))
+	for _, text := range texts {
+		spinners = append(spinners, newTextSpinner(w, mu, text))
+	}
+	return spinners, func() {}
+}
+
+// Logger returns a logger writing "LEVEL message key=value" lines to w
+func (p *TextPrinter) Logger(w io.Writer) Logger {
+	return textLogger{w: w}
+}
+
+// textSpinner prints the start and outcome of an operation as lines, mu serializing the lines of spinners
+// sharing w
+type textSpinner struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+func newTextSpinner(w io.Writer, mu *sync.Mutex, text string) *textSpinner {
+	spinner := &textSpinner{w: w, mu: mu}
+	spinner.println(text)
+	return spinner
+}
+
+func (s *textSpinner) UpdateText(text string) {}
+
+func (s *textSpinner) Success(message ...any) {
+	s.println(string(LevelSuccess) + ": " + fmt.Sprint(message...))
+}
+
+func (s *textSpinner) Warning(message ...any) {
+	s.println(string(LevelWarning) + ": " + fmt.Sprint(message...))
+}
+
+func (s *textSpinner) Fail(message ...any) {
+	s.println(string(LevelError) + ": " + fmt.Sprint(message...))
+}
+
+func (s *textSpinner) println(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(s.w, line)
+}
+
+// textLogger writes log messages as plain lines
+type textLogger struct {
+	w io.Writer
+}
+
+func (l textLogger) Warn(message string, args ...any) {
+	l.log("WARN", message, args)
+}
+
+func (l textLogger) Error(message string, args ...any) {
+	l.log("ERROR", message, args)
+}
+
+func (l textLogger) log(level, message string, args []any) {
+	var line strings.Builder
+	fmt.Fprintf(&line, "%-5s %s", level, message)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&line, " %v=%v", args[i], args[i+1])
+	}
+	fmt.Fprintln(l.w, line.String())
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTextPrinter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewTextPrinter(&buf)
+
+	printer.Header("Report")
+	printer.Boxes(Box{Title: "Source Repository", Text: "Repository: org/repo"})
+	printer.Table([][]string{{"Metric", "Status"}, {"Issues", "wrapped\nstatus"}}, true)
+	printer.BulletList([]BulletItem{{Text: "Passed: 1", Color: ColorGreen}}, "-")
+	printer.Message(nil, LevelSuccess, "done\n")
+
+	expected := "\nReport\n======\n" +
+		"Source Repository\n  Repository: org/repo\n" +
+		"Metric  Status\nIssues  wrapped status\n" +
+		"- Passed: 1\n" +
+		"SUCCESS: done\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestTextPrinter_Progress(t *testing.T) {
+	var report, progress bytes.Buffer
+	printer := NewTextPrinter(&report)
+
+	spinners, stop := printer.StartSpinners(&progress, "Retrieving source...", "Retrieving target...")
+	spinners[0].UpdateText("Fetching issues...")
+	spinners[0].Success("source retrieved")
+	spinners[1].Fail("target unavailable")
+	stop()
+	printer.Message(&progress, LevelWarning, "rate limit low")
+	printer.Logger(&progress).Error("issues: forbidden", "repo", "org/repo")
+
+	expected := "Retrieving source...\nRetrieving target...\n" +
+		"SUCCESS: source retrieved\nERROR: target unavailable\n" +
+		"WARNING: rate limit low\n" +
+		"ERROR issues: forbidden repo=org/repo\n"
+	if progress.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, progress.String())
+	}
+	if report.Len() != 0 {
+		t.Errorf("Expected the progress to stay out of the report, got %q", report.String())
+	}
+}
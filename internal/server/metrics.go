@@ -0,0 +1,93 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// Metric types of the Prometheus text format
+const (
+	MetricCounter = "counter"
+	MetricGauge   = "gauge"
+)
+
+// Metric is a metric exposed at GET /metrics in the Prometheus text format
+type Metric struct {
+	Name    string
+	Help    string
+	Type    string // MetricCounter or MetricGauge
+	Samples []Sample
+}
+
+// Sample is a value of a metric, with the labels distinguishing it from the other samples of the metric
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricsFunc returns metrics observed outside the server, e.g. the API requests made by the validations
+type MetricsFunc func() []Metric
+
+// validationCounters counts the validations run by the server since it started
+type validationCounters struct {
+	run    atomic.Int64 // Validations that finished, with or without an error
+	passed atomic.Int64
+	failed atomic.Int64 // Validations whose results have failures
+	errors atomic.Int64 // Validations that could not be completed
+}
+
+// AddMetrics exposes the metrics returned by metrics at GET /metrics, along with the validation counters.
+// It must be called before Handler.
+func (s *Server) AddMetrics(metrics MetricsFunc) {
+	s.metrics = append(s.metrics, metrics)
+}
+
+// handleMetrics responds with the validation counters and the added metrics in the Prometheus text format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics := []Metric{
+		{Name: "ghmv_validations_total", Help: "Validations run", Type: MetricCounter,
+			Samples: []Sample{{Value: float64(s.counters.run.Load())}}},
+		{Name: "ghmv_validations_passed_total", Help: "Validations that passed", Type: MetricCounter,
+			Samples: []Sample{{Value: float64(s.counters.passed.Load())}}},
+		{Name: "ghmv_validations_failed_total", Help: "Validations with failed checks", Type: MetricCounter,
+			Samples: []Sample{{Value: float64(s.counters.failed.Load())}}},
+		{Name: "ghmv_validation_errors_total", Help: "Validations that could not be completed", Type: MetricCounter,
+			Samples: []Sample{{Value: float64(s.counters.errors.Load())}}},
+	}
+	for _, added := range s.metrics {
+		metrics = append(metrics, added()...)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n", metric.Name, metric.Help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", metric.Name, metric.Type)
+		for _, sample := range metric.Samples {
+			fmt.Fprintf(w, "%s%s %g\n", metric.Name, formatLabels(sample.Labels), sample.Value)
+		}
+	}
+}
+
+// formatLabels returns the labels in the Prometheus text format, sorted by name, e.g. {client="source"}
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escaper.Replace(labels[name])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Metrics(t *testing.T) {
+	outcomes := []error{nil, fmt.Errorf("target repository not found"), nil}
+	srv := New(func(req ValidationRequest) ([]validator.ValidationResult, error) {
+		err := outcomes[0]
+		outcomes = outcomes[1:]
+		if err != nil {
+			return nil, err
+		}
+		status := validator.ValidationStatusPass
+		if req.TargetRepo == "missing-tags" {
+			status = validator.ValidationStatusFail
+		}
+		return []validator.ValidationResult{{Metric: "Tags", StatusType: status}}, nil
+	})
+	srv.AddMetrics(func() []Metric {
+		return []Metric{{Name: "ghmv_rate_limit_remaining", Help: "Requests remaining", Type: MetricGauge, Samples: []Sample{
+			{Labels: map[string]string{"client": "source"}, Value: 4999},
+			{Labels: map[string]string{"client": `say "target"`}, Value: 12},
+		}}}
+	})
+	srv.Start()
+	defer srv.Stop()
+	handler := srv.Handler()
+
+	for _, body := range []string{validBody, validBody, strings.Replace(validBody, `"target_repo":"repo"`, `"target_repo":"missing-tags"`, 1)} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body)))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		var queued Job
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+		waitForJob(t, handler, queued.ID)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "# HELP ghmv_validations_total Validations run\n# TYPE ghmv_validations_total counter\nghmv_validations_total 3\n")
+	assert.Contains(t, rec.Body.String(), "\nghmv_validations_passed_total 1\n")
+	assert.Contains(t, rec.Body.String(), "\nghmv_validations_failed_total 1\n")
+	assert.Contains(t, rec.Body.String(), "\nghmv_validation_errors_total 1\n")
+	assert.Contains(t, rec.Body.String(), "# TYPE ghmv_rate_limit_remaining gauge\n"+
+		"ghmv_rate_limit_remaining{client=\"source\"} 4999\n"+
+		"ghmv_rate_limit_remaining{client=\"say \\\"target\\\"\"} 12\n")
+}
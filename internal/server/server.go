@@ -0,0 +1,341 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+)
+
+// JobStatus is the lifecycle state of an asynchronous validation job
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Triggers identify what queued a validation
+const (
+	TriggerAPI     = "api"
+	TriggerWebhook = "webhook"
+)
+
+// jobQueueSize bounds the number of validations waiting to run
+const jobQueueSize = 100
+
+// Finished jobs are kept for jobTTL after they complete, and at most maxFinishedJobs of them, so the memory
+// of a long-running server does not grow without limit
+const (
+	jobTTL          = 24 * time.Hour
+	maxFinishedJobs = 1000
+)
+
+// ValidationRequest holds the source and target coordinates of a validation
+type ValidationRequest struct {
+	SourceOrganization string `json:"source_organization"`
+	SourceRepo         string `json:"source_repo"`
+	TargetOrganization string `json:"target_organization"`
+	TargetRepo         string `json:"target_repo"`
+	Trigger            string `json:"trigger,omitempty"`
+}
+
+// validate checks that all coordinates are provided
+func (r ValidationRequest) validate() error {
+	var missing []string
+	if r.SourceOrganization == "" {
+		missing = append(missing, "source_organization")
+	}
+	if r.SourceRepo == "" {
+		missing = append(missing, "source_repo")
+	}
+	if r.TargetOrganization == "" {
+		missing = append(missing, "target_organization")
+	}
+	if r.TargetRepo == "" {
+		missing = append(missing, "target_repo")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// ValidateFunc runs a single validation and returns its results
+type ValidateFunc func(req ValidationRequest) ([]validator.ValidationResult, error)
+
+// Result is the JSON representation of a validation result
+type Result struct {
+	Metric      string      `json:"metric"`
+	SourceValue interface{} `json:"source_value"`
+	TargetValue interface{} `json:"target_value"`
+	Status      string      `json:"status"`
+	Difference  int         `json:"difference"`
+	Note        string      `json:"note,omitempty"` // e.g. the reason of a known difference
+}
+
+// Job tracks an asynchronous validation and its outcome
+type Job struct {
+	ID          string             `json:"id"`
+	Status      JobStatus          `json:"status"`
+	Request     ValidationRequest  `json:"request"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	Passed      *bool              `json:"passed,omitempty"`
+	Summary     *validator.Summary `json:"summary,omitempty"`
+	Results     []Result           `json:"results,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	ErrorCode   string             `json:"error_code,omitempty"` // Kind of the error: auth, not_found, rate_limited, partial_data or error
+}
+
+// Server exposes validation over HTTP, running jobs one at a time in the background
+type Server struct {
+	validate ValidateFunc
+	webhook  *WebhookConfig
+	metrics  []MetricsFunc
+	counters validationCounters
+	apiToken []byte // Bearer token required by every endpoint but the webhook, when set
+
+	mu              sync.RWMutex
+	jobs            map[string]*Job
+	nextID          int
+	queue           chan string
+	jobTTL          time.Duration
+	maxFinishedJobs int
+}
+
+// New creates a Server that runs validations with the given function
+func New(validate ValidateFunc) *Server {
+	return &Server{
+		validate:        validate,
+		jobs:            make(map[string]*Job),
+		queue:           make(chan string, jobQueueSize),
+		jobTTL:          jobTTL,
+		maxFinishedJobs: maxFinishedJobs,
+	}
+}
+
+// RequireToken requires requests to every endpoint but POST /webhook, whose deliveries are verified with
+// the webhook secret, to send token in an "Authorization: Bearer" header
+func (s *Server) RequireToken(token string) {
+	s.apiToken = []byte(token)
+}
+
+// Start runs queued jobs until Stop is called
+func (s *Server) Start() {
+	go func() {
+		for id := range s.queue {
+			s.run(id)
+		}
+	}()
+}
+
+// Stop ends the job runner once the current job finishes.
+// It must only be called after the HTTP server has stopped accepting requests.
+func (s *Server) Stop() {
+	close(s.queue)
+}
+
+// Handler returns the HTTP handler serving the validation API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate", s.authenticate(s.handleValidate))
+	mux.HandleFunc("GET /status/{id}", s.authenticate(s.handleStatus))
+	mux.HandleFunc("GET /metrics", s.authenticate(s.handleMetrics))
+	if s.webhook != nil {
+		mux.HandleFunc("POST /webhook", s.handleWebhook)
+	}
+	return mux
+}
+
+// authenticate rejects requests without the bearer token set with RequireToken
+func (s *Server) authenticate(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(s.apiToken) > 0 {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), s.apiToken) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gh-migration-validator"`)
+				writeError(w, http.StatusUnauthorized, "missing or invalid API token")
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// handleValidate queues a validation and responds with the job ID
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req ValidationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.Trigger = TriggerAPI
+
+	job, err := s.enqueue(req)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/status/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// handleStatus responds with the current state of a job
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("validation %s not found", r.PathValue("id")))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// enqueue registers a new job and adds it to the queue
+func (s *Server) enqueue(req ValidationRequest) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneJobs(time.Now().UTC())
+	s.nextID++
+	job := &Job{
+		ID:        fmt.Sprintf("%d", s.nextID),
+		Status:    JobStatusQueued,
+		Request:   req,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	select {
+	case s.queue <- job.ID:
+	default:
+		return Job{}, fmt.Errorf("validation queue is full, try again later")
+	}
+
+	s.jobs[job.ID] = job
+	return *job, nil
+}
+
+// pruneJobs removes the jobs that finished more than jobTTL before now, then the oldest finished jobs beyond
+// maxFinishedJobs. Queued and running jobs are kept. The caller must hold s.mu.
+func (s *Server) pruneJobs(now time.Time) {
+	var finished []*Job
+	for id, job := range s.jobs {
+		if job.CompletedAt == nil {
+			continue
+		}
+		if now.Sub(*job.CompletedAt) > s.jobTTL {
+			delete(s.jobs, id)
+			continue
+		}
+		finished = append(finished, job)
+	}
+
+	if len(finished) <= s.maxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].CompletedAt.Before(*finished[j].CompletedAt)
+	})
+	for _, job := range finished[:len(finished)-s.maxFinishedJobs] {
+		delete(s.jobs, job.ID)
+	}
+}
+
+// job returns a copy of the job with the given ID
+func (s *Server) job(id string) (Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// run executes a queued job and stores its outcome
+func (s *Server) run(id string) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	job.Status = JobStatusRunning
+	req := job.Request
+	s.mu.Unlock()
+
+	results, err := s.validate(req)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	s.counters.run.Add(1)
+
+	if err != nil {
+		s.counters.errors.Add(1)
+		job.Status = JobStatusFailed
+		job.Error = err.Error()
+		job.ErrorCode = api.ErrorCode(err)
+		return
+	}
+
+	// A target that is not there yet has not passed, but its results are still reported
+	summary := validator.Summarize(results)
+	passed := summary.Succeeded()
+	if passed {
+		s.counters.passed.Add(1)
+	} else {
+		s.counters.failed.Add(1)
+	}
+	job.Status = JobStatusCompleted
+	job.Passed = &passed
+	job.Summary = &summary
+	job.Results = toResults(results)
+}
+
+// toResults converts validation results to their JSON representation
+func toResults(results []validator.ValidationResult) []Result {
+	converted := make([]Result, 0, len(results))
+	for _, result := range results {
+		converted = append(converted, Result{
+			Metric:      result.Metric,
+			SourceValue: result.SourceVal,
+			TargetValue: result.TargetVal,
+			Status:      result.StatusType.String(),
+			Difference:  result.Difference,
+			Note:        result.Note,
+		})
+	}
+	return converted
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
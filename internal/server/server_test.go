@@ -0,0 +1,203 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validBody = `{"source_organization":"source-org","source_repo":"repo","target_organization":"target-org","target_repo":"repo"}`
+
+// waitForJob polls the status endpoint until the job is no longer queued or running
+func waitForJob(t *testing.T, handler http.Handler, id string) Job {
+	t.Helper()
+
+	var job Job
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/"+id, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+		return job.Status == JobStatusCompleted || job.Status == JobStatusFailed
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestServer_ValidateAndStatus(t *testing.T) {
+	var received ValidationRequest
+	srv := New(func(req ValidationRequest) ([]validator.ValidationResult, error) {
+		received = req
+		return []validator.ValidationResult{
+			{Metric: "Tags", SourceVal: 3, TargetVal: 2, StatusType: validator.ValidationStatusFail, Difference: 1},
+		}, nil
+	})
+	srv.Start()
+	defer srv.Stop()
+	handler := srv.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(validBody)))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var queued Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+	assert.NotEmpty(t, queued.ID)
+	assert.Equal(t, "/status/"+queued.ID, rec.Header().Get("Location"))
+
+	job := waitForJob(t, handler, queued.ID)
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, "target-org", received.TargetOrganization)
+	require.NotNil(t, job.Passed)
+	assert.False(t, *job.Passed)
+	require.NotNil(t, job.Summary)
+	assert.Equal(t, validator.Summary{Failed: 1, Verdict: validator.VerdictFailed}, *job.Summary)
+	require.Len(t, job.Results, 1)
+	assert.Equal(t, "Tags", job.Results[0].Metric)
+	assert.Equal(t, "FAIL", job.Results[0].Status)
+	assert.Equal(t, 1, job.Results[0].Difference)
+	assert.NotNil(t, job.CompletedAt)
+}
+
+func TestServer_ValidationError(t *testing.T) {
+	srv := New(func(req ValidationRequest) ([]validator.ValidationResult, error) {
+		return nil, fmt.Errorf("target repository not found: %w", api.ErrNotFound)
+	})
+	srv.Start()
+	defer srv.Stop()
+	handler := srv.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(validBody)))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var queued Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+
+	job := waitForJob(t, handler, queued.ID)
+	assert.Equal(t, JobStatusFailed, job.Status)
+	assert.Equal(t, "target repository not found: not found", job.Error)
+	assert.Equal(t, api.ErrorCodeNotFound, job.ErrorCode)
+	assert.Nil(t, job.Passed)
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	handler := New(nil).Handler()
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "invalid JSON",
+			method:       http.MethodPost,
+			path:         "/validate",
+			body:         "{",
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid request body",
+		},
+		{
+			name:         "missing coordinates",
+			method:       http.MethodPost,
+			path:         "/validate",
+			body:         `{"source_organization":"source-org"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing required fields: source_repo, target_organization, target_repo",
+		},
+		{
+			name:         "unknown job",
+			method:       http.MethodGet,
+			path:         "/status/42",
+			expectedCode: http.StatusNotFound,
+			expectedErr:  "validation 42 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Contains(t, body["error"], tt.expectedErr)
+		})
+	}
+}
+
+func TestServer_RequireToken(t *testing.T) {
+	srv := New(func(req ValidationRequest) ([]validator.ValidationResult, error) { return nil, nil })
+	srv.RequireToken("api-token")
+	srv.EnableWebhooks(WebhookConfig{Secret: []byte(testWebhookSecret), SourceOrganization: "source-org"})
+	handler := srv.Handler()
+
+	for _, path := range []string{"/status/1", "/metrics"} {
+		for _, authorization := range []string{"", "Bearer wrong-token", "api-token"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, "%s with %q", path, authorization)
+			assert.Equal(t, `Bearer realm="gh-migration-validator"`, rec.Header().Get("WWW-Authenticate"))
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(validBody)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(validBody))
+	req.Header.Set("Authorization", "Bearer api-token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	// Webhook deliveries are verified with their signature instead
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newWebhookRequest("ping", `{}`, testWebhookSecret))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_PruneJobs(t *testing.T) {
+	srv := New(nil)
+	srv.maxFinishedJobs = 2
+	now := time.Now().UTC()
+
+	finishedAt := func(age time.Duration) *time.Time {
+		completedAt := now.Add(-age)
+		return &completedAt
+	}
+	srv.jobs = map[string]*Job{
+		"1": {ID: "1", Status: JobStatusCompleted, CompletedAt: finishedAt(jobTTL + time.Minute)},
+		"2": {ID: "2", Status: JobStatusFailed, CompletedAt: finishedAt(3 * time.Hour)},
+		"3": {ID: "3", Status: JobStatusCompleted, CompletedAt: finishedAt(2 * time.Hour)},
+		"4": {ID: "4", Status: JobStatusCompleted, CompletedAt: finishedAt(time.Hour)},
+		"5": {ID: "5", Status: JobStatusRunning},
+		"6": {ID: "6", Status: JobStatusQueued},
+	}
+
+	srv.pruneJobs(now)
+
+	var ids []string
+	for id := range srv.jobs {
+		ids = append(ids, id)
+	}
+	assert.ElementsMatch(t, []string{"3", "4", "5", "6"}, ids, "expired and the oldest finished jobs beyond the limit are removed")
+}
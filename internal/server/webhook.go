@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// WebhookConfig configures validations triggered by GitHub webhook events from the target organization
+type WebhookConfig struct {
+	// Secret is the webhook secret used to verify the X-Hub-Signature-256 header
+	Secret []byte
+	// SourceOrganization is the organization the migrated repositories came from.
+	// Source repositories are assumed to have the same name as the target repository.
+	SourceOrganization string
+}
+
+// migrationEvent is the payload of a "migration" webhook event
+type migrationEvent struct {
+	Action string             `json:"action"`
+	Repo   *github.Repository `json:"repository"`
+}
+
+// EnableWebhooks serves POST /webhook, queueing a validation whenever a repository import completes
+func (s *Server) EnableWebhooks(config WebhookConfig) {
+	s.webhook = &config
+}
+
+// handleWebhook verifies and parses a webhook delivery and queues a validation for completed imports
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := github.ValidatePayload(r, s.webhook.Secret)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, fmt.Sprintf("invalid webhook signature: %v", err))
+		return
+	}
+
+	eventType := github.WebHookType(r)
+	if eventType == "ping" {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "pong"})
+		return
+	}
+
+	repo, err := completedImportRepository(eventType, payload)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if repo == nil {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ignored"})
+		return
+	}
+
+	job, err := s.enqueue(ValidationRequest{
+		SourceOrganization: s.webhook.SourceOrganization,
+		SourceRepo:         repo.GetName(),
+		TargetOrganization: repo.GetOwner().GetLogin(),
+		TargetRepo:         repo.GetName(),
+		Trigger:            TriggerWebhook,
+	})
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/status/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// completedImportRepository returns the repository of an event that signals a finished import,
+// or nil if the event does not require a validation. Handled events:
+//   - migration with action "completed"
+//   - repository_import with status "success"
+//   - repository with action "transferred" (repository moved into the target organization)
+func completedImportRepository(eventType string, payload []byte) (*github.Repository, error) {
+	var repo *github.Repository
+
+	switch eventType {
+	case "migration":
+		var event migrationEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse %s event: %v", eventType, err)
+		}
+		if event.Action != "completed" {
+			return nil, nil
+		}
+		repo = event.Repo
+	case "repository_import":
+		var event github.RepositoryImportEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse %s event: %v", eventType, err)
+		}
+		if event.GetStatus() != "success" {
+			return nil, nil
+		}
+		repo = event.Repo
+	case "repository":
+		var event github.RepositoryEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse %s event: %v", eventType, err)
+		}
+		if event.GetAction() != "transferred" {
+			return nil, nil
+		}
+		repo = event.Repo
+	default:
+		return nil, nil
+	}
+
+	if repo.GetName() == "" || repo.GetOwner().GetLogin() == "" {
+		return nil, fmt.Errorf("%s event is missing the repository name or owner", eventType)
+	}
+
+	return repo, nil
+}
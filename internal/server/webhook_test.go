@@ -0,0 +1,115 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+// newWebhookRequest builds a signed webhook delivery
+func newWebhookRequest(eventType, payload, secret string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", eventType)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestServer_WebhookQueuesValidation(t *testing.T) {
+	received := make(chan ValidationRequest, 1)
+	srv := New(func(req ValidationRequest) ([]validator.ValidationResult, error) {
+		received <- req
+		return nil, nil
+	})
+	srv.EnableWebhooks(WebhookConfig{Secret: []byte(testWebhookSecret), SourceOrganization: "source-org"})
+	srv.Start()
+	defer srv.Stop()
+
+	payload := `{"status": "success", "repository": {"name": "my-repo", "owner": {"login": "target-org"}}}`
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newWebhookRequest("repository_import", payload, testWebhookSecret))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, TriggerWebhook, job.Request.Trigger)
+
+	req := <-received
+	assert.Equal(t, ValidationRequest{
+		SourceOrganization: "source-org",
+		SourceRepo:         "my-repo",
+		TargetOrganization: "target-org",
+		TargetRepo:         "my-repo",
+		Trigger:            TriggerWebhook,
+	}, req)
+}
+
+func TestServer_WebhookRejectsInvalidSignature(t *testing.T) {
+	srv := New(nil)
+	srv.EnableWebhooks(WebhookConfig{Secret: []byte(testWebhookSecret), SourceOrganization: "source-org"})
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newWebhookRequest("repository_import", `{"status": "success"}`, "wrong-secret"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_WebhookDisabledByDefault(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil).Handler().ServeHTTP(rec, newWebhookRequest("ping", `{}`, testWebhookSecret))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCompletedImportRepository(t *testing.T) {
+	repository := `"repository": {"name": "my-repo", "owner": {"login": "target-org"}}`
+
+	tests := []struct {
+		name          string
+		eventType     string
+		payload       string
+		expectRepo    bool
+		expectedError bool
+	}{
+		{name: "completed migration", eventType: "migration", payload: `{"action": "completed", ` + repository + `}`, expectRepo: true},
+		{name: "started migration", eventType: "migration", payload: `{"action": "started", ` + repository + `}`},
+		{name: "successful import", eventType: "repository_import", payload: `{"status": "success", ` + repository + `}`, expectRepo: true},
+		{name: "failed import", eventType: "repository_import", payload: `{"status": "failure", ` + repository + `}`},
+		{name: "transferred repository", eventType: "repository", payload: `{"action": "transferred", ` + repository + `}`, expectRepo: true},
+		{name: "created repository", eventType: "repository", payload: `{"action": "created", ` + repository + `}`},
+		{name: "unrelated event", eventType: "push", payload: `{}`},
+		{name: "missing repository", eventType: "repository_import", payload: `{"status": "success"}`, expectedError: true},
+		{name: "invalid payload", eventType: "migration", payload: `{`, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := completedImportRepository(tt.eventType, []byte(tt.payload))
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectRepo {
+				require.NotNil(t, repo)
+				assert.Equal(t, "target-org/my-repo", repo.GetOwner().GetLogin()+"/"+repo.GetName())
+			} else {
+				assert.Nil(t, repo)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package signing creates and verifies detached signatures of report files, so reports cannot be edited
+// unnoticed after sign-off. Signatures are raw signature bytes, verifiable with openssl: SHA-256 with
+// PKCS #1 v1.5 for RSA keys, SHA-256 with an ASN.1 signature for ECDSA keys, and pure Ed25519.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SignatureExtension is appended to the path of a report to name its signature file
+const SignatureExtension = ".sig"
+
+// LoadPrivateKey reads an RSA, ECDSA or Ed25519 private key from a PEM file (PKCS #8, PKCS #1 or SEC 1)
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T in %s", key, path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%s does not contain an RSA, ECDSA or Ed25519 private key", path)
+}
+
+// LoadPublicKey reads a public key from a PEM file, or the public key of a private key file
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	signer, err := LoadPrivateKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s does not contain a public key", path)
+	}
+	return signer.Public(), nil
+}
+
+// SignFile writes the detached signature of the file at path to path + SignatureExtension and returns its path
+func SignFile(path string, signer crypto.Signer) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+
+	signature, err := Sign(data, signer)
+	if err != nil {
+		return "", err
+	}
+
+	signaturePath := path + SignatureExtension
+	if err := os.WriteFile(signaturePath, signature, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write signature %s: %w", signaturePath, err)
+	}
+	return signaturePath, nil
+}
+
+// Sign returns the signature of data
+func Sign(data []byte, signer crypto.Signer) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		// Ed25519 signs the message itself
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(data)
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign report: %w", err)
+	}
+	return signature, nil
+}
+
+// VerifyFile checks the detached signature at signaturePath of the file at path
+func VerifyFile(path, signaturePath string, publicKey crypto.PublicKey) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return fmt.Errorf("failed to read signature %s: %w", signaturePath, err)
+	}
+	return Verify(data, signature, publicKey)
+}
+
+// ErrInvalidSignature is returned when a signature does not match the report and the key
+var ErrInvalidSignature = errors.New("the signature does not match the report: it was modified or signed with another key")
+
+// Verify checks the signature of data
+func Verify(data, signature []byte, publicKey crypto.PublicKey) error {
+	digest := sha256.Sum256(data)
+
+	var valid bool
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, data, signature)
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// readPEM returns the first PEM block of the file at path
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM file", path)
+	}
+	return block, nil
+}
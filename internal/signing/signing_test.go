@@ -0,0 +1,112 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes der as a PEM block of blockType and returns its path
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestSignAndVerifyFile(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		keyFile   func(t *testing.T) string
+		publicKey crypto.PublicKey
+	}{
+		{"RSA PKCS1", func(t *testing.T) string {
+			return writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+		}, &rsaKey.PublicKey},
+		{"ECDSA SEC1", func(t *testing.T) string { return writePEM(t, "ec.pem", "EC PRIVATE KEY", ecDER) }, &ecKey.PublicKey},
+		{"Ed25519 PKCS8", func(t *testing.T) string { return writePEM(t, "ed.pem", "PRIVATE KEY", edDER) }, edKey.Public()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := LoadPrivateKey(tt.keyFile(t))
+			require.NoError(t, err)
+
+			report := filepath.Join(t.TempDir(), "report.md")
+			require.NoError(t, os.WriteFile(report, []byte("# Migration Validation Report\n"), 0o644))
+
+			signatureFile, err := SignFile(report, signer)
+			require.NoError(t, err)
+			assert.Equal(t, report+".sig", signatureFile)
+			assert.NoError(t, VerifyFile(report, signatureFile, tt.publicKey))
+
+			require.NoError(t, os.WriteFile(report, []byte("# Migration Validation Report\nedited\n"), 0o644))
+			assert.ErrorIs(t, VerifyFile(report, signatureFile, tt.publicKey), ErrInvalidSignature)
+		})
+	}
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	publicKey, err := LoadPublicKey(writePEM(t, "public.pem", "PUBLIC KEY", publicDER))
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(publicKey))
+
+	// The public key of a private key file is used too
+	privateDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	publicKey, err = LoadPublicKey(writePEM(t, "private.pem", "EC PRIVATE KEY", privateDER))
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(publicKey))
+}
+
+func TestVerify_WrongKey(t *testing.T) {
+	_, signer, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signature, err := Sign([]byte("report"), signer)
+	require.NoError(t, err)
+	assert.ErrorIs(t, Verify([]byte("report"), signature, other), ErrInvalidSignature)
+}
+
+func TestLoadPrivateKey_Errors(t *testing.T) {
+	_, err := LoadPrivateKey(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a key"), 0o600))
+	_, err = LoadPrivateKey(notPEM)
+	assert.ErrorContains(t, err, "is not a PEM file")
+
+	_, err = LoadPrivateKey(writePEM(t, "garbage.pem", "PRIVATE KEY", []byte("garbage")))
+	assert.ErrorContains(t, err, "does not contain")
+}
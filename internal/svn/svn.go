@@ -0,0 +1,130 @@
+package svn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Metrics holds the counts read from a Subversion repository for comparison with its git-svn conversion
+type Metrics struct {
+	URL       string `json:"url"`
+	Revisions int    `json:"revisions"` // Revisions that changed the trunk, each converted to one commit by git-svn
+	Tags      int    `json:"tags"`
+}
+
+// Layout locates the trunk and tags directories within the repository, relative to its root
+type Layout struct {
+	Trunk string
+	Tags  string
+}
+
+// StandardLayout is the trunk/branches/tags layout assumed by git svn clone --stdlayout
+var StandardLayout = Layout{Trunk: "trunk", Tags: "tags"}
+
+// runSVN runs the svn command line client and returns its standard output; replaced in tests
+var runSVN = func(args ...string) ([]byte, error) {
+	output, err := exec.Command("svn", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("svn %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("svn %s: %v", args[0], err)
+	}
+	return output, nil
+}
+
+// FetchMetrics reads the revision and tag counts of the Subversion repository at location,
+// which is either a repository URL or the path of a local svnsync mirror
+func FetchMetrics(location string, layout Layout) (*Metrics, error) {
+	repoURL, err := RepositoryURL(location)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := countRevisions(joinURL(repoURL, layout.Trunk))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count revisions: %v", err)
+	}
+
+	tags, err := countTags(joinURL(repoURL, layout.Tags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %v", err)
+	}
+
+	return &Metrics{URL: repoURL, Revisions: revisions, Tags: tags}, nil
+}
+
+// RepositoryURL returns the URL of location, converting the path of a local mirror to a file:// URL
+func RepositoryURL(location string) (string, error) {
+	if location == "" {
+		return "", fmt.Errorf("SVN repository URL is required")
+	}
+	if strings.Contains(location, "://") {
+		return strings.TrimSuffix(location, "/"), nil
+	}
+
+	path, err := filepath.Abs(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid SVN mirror path %q: %v", location, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("SVN mirror not found: %v", err)
+	}
+	return "file://" + filepath.ToSlash(path), nil
+}
+
+// joinURL appends a layout directory to the repository URL, an empty directory meaning the root
+func joinURL(repoURL, dir string) string {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return repoURL
+	}
+	return repoURL + "/" + dir
+}
+
+// countRevisions counts the revisions in the history of url
+func countRevisions(url string) (int, error) {
+	output, err := runSVN("log", "--quiet", "--xml", "--non-interactive", url)
+	if err != nil {
+		return 0, err
+	}
+
+	var log struct {
+		Entries []struct {
+			Revision int `xml:"revision,attr"`
+		} `xml:"logentry"`
+	}
+	if err := xml.Unmarshal(output, &log); err != nil {
+		return 0, fmt.Errorf("failed to parse svn log output: %v", err)
+	}
+	return len(log.Entries), nil
+}
+
+// countTags counts the directories directly under the tags url, each converted to a tag by git-svn
+func countTags(url string) (int, error) {
+	output, err := runSVN("list", "--xml", "--non-interactive", url)
+	if err != nil {
+		return 0, err
+	}
+
+	var list struct {
+		Entries []struct {
+			Kind string `xml:"kind,attr"`
+		} `xml:"list>entry"`
+	}
+	if err := xml.Unmarshal(output, &list); err != nil {
+		return 0, fmt.Errorf("failed to parse svn list output: %v", err)
+	}
+
+	count := 0
+	for _, entry := range list.Entries {
+		if entry.Kind == "dir" {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,86 @@
+package svn
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testLog = `<?xml version="1.0" encoding="UTF-8"?>
+<log>
+<logentry revision="42"><author>alice</author><date>2024-01-02T00:00:00.000000Z</date></logentry>
+<logentry revision="17"><author>bob</author><date>2023-06-01T00:00:00.000000Z</date></logentry>
+<logentry revision="1"><author>alice</author><date>2023-01-01T00:00:00.000000Z</date></logentry>
+</log>`
+
+const testList = `<?xml version="1.0" encoding="UTF-8"?>
+<lists>
+<list path="https://svn.example.com/repo/tags">
+<entry kind="dir"><name>v1.0</name></entry>
+<entry kind="dir"><name>v1.1</name></entry>
+<entry kind="file"><name>README</name></entry>
+</list>
+</lists>`
+
+func stubSVN(t *testing.T, run func(args ...string) ([]byte, error)) {
+	original := runSVN
+	runSVN = run
+	t.Cleanup(func() { runSVN = original })
+}
+
+func TestFetchMetrics(t *testing.T) {
+	var urls []string
+	stubSVN(t, func(args ...string) ([]byte, error) {
+		urls = append(urls, args[len(args)-1])
+		switch args[0] {
+		case "log":
+			return []byte(testLog), nil
+		case "list":
+			return []byte(testList), nil
+		}
+		return nil, fmt.Errorf("unexpected command %v", args)
+	})
+
+	metrics, err := FetchMetrics("https://svn.example.com/repo/", StandardLayout)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if metrics.URL != "https://svn.example.com/repo" || metrics.Revisions != 3 || metrics.Tags != 2 {
+		t.Errorf("Unexpected metrics: %+v", metrics)
+	}
+	expectedURLs := "https://svn.example.com/repo/trunk https://svn.example.com/repo/tags"
+	if got := strings.Join(urls, " "); got != expectedURLs {
+		t.Errorf("Expected svn to read %q, got %q", expectedURLs, got)
+	}
+}
+
+func TestFetchMetrics_Error(t *testing.T) {
+	stubSVN(t, func(args ...string) ([]byte, error) {
+		return nil, fmt.Errorf("svn log: E170013: Unable to connect to a repository")
+	})
+
+	_, err := FetchMetrics("https://svn.example.com/repo", StandardLayout)
+	if err == nil || !strings.Contains(err.Error(), "failed to count revisions") {
+		t.Errorf("Expected a revision count error, got %v", err)
+	}
+}
+
+func TestRepositoryURL(t *testing.T) {
+	mirror := t.TempDir()
+
+	url, err := RepositoryURL(mirror)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(url, "file://") || !strings.HasSuffix(url, strings.TrimPrefix(mirror, "/")) {
+		t.Errorf("Expected a file URL for the mirror, got %q", url)
+	}
+
+	if _, err := RepositoryURL(mirror + "/missing"); err == nil {
+		t.Error("Expected an error for a missing mirror")
+	}
+	if _, err := RepositoryURL(""); err == nil {
+		t.Error("Expected an error for an empty location")
+	}
+}
@@ -0,0 +1,147 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of the validator
+const instrumentationName = "mona-actions/gh-migration-validator"
+
+// serviceName is reported as the OpenTelemetry service name of every span and metric
+const serviceName = "gh-migration-validator"
+
+// Setup exports spans and metrics over OTLP/HTTP to the collector at endpoint, e.g. http://localhost:4318.
+// Until Setup is called, spans and metrics are recorded with no-op providers at no cost.
+// The returned function flushes pending telemetry and must be called before the process exits.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	tracesURL, err := signalURL(endpoint, "v1/traces")
+	if err != nil {
+		return nil, err
+	}
+	metricsURL, err := signalURL(endpoint, "v1/metrics")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry resource: %v", err)
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(tracesURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %v", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(metricsURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric exporter: %v", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}
+	return shutdown, nil
+}
+
+// signalURL returns the OTLP/HTTP URL of a signal (traces or metrics) below the collector endpoint
+func signalURL(endpoint, signalPath string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid OpenTelemetry endpoint %q: %v", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid OpenTelemetry endpoint %q: expected an http:// or https:// URL", endpoint)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + signalPath
+	return u.String(), nil
+}
+
+// Tracer returns the tracer used for validation spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartRepositorySpan starts the span covering the validation of a source and target repository
+func StartRepositorySpan(ctx context.Context, source, target string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "validate repository", trace.WithAttributes(
+		attribute.String("ghmv.source.repository", source),
+		attribute.String("ghmv.target.repository", target),
+	))
+}
+
+// StartMetricSpan starts the span covering the fetch of a metric from one side
+func StartMetricSpan(ctx context.Context, side, metricName string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "fetch "+metricName, trace.WithAttributes(
+		attribute.String("ghmv.side", side),
+		attribute.String("ghmv.metric", metricName),
+	))
+}
+
+// EndSpan ends span, marking it as failed when err is not nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndMetricSpan records the outcome of a metric fetch on its span and in the fetch metrics, then ends the span
+func EndMetricSpan(ctx context.Context, span trace.Span, side, metricName string, duration time.Duration, apiCalls int, err error) {
+	span.SetAttributes(attribute.Int("ghmv.api_calls", apiCalls))
+	EndSpan(span, err)
+
+	attrs := metric.WithAttributes(
+		attribute.String("ghmv.side", side),
+		attribute.String("ghmv.metric", metricName),
+		attribute.Bool("ghmv.failed", err != nil),
+	)
+	fetch := getFetchInstruments()
+	fetch.duration.Record(ctx, duration.Seconds(), attrs)
+	fetch.apiCalls.Add(ctx, int64(apiCalls), attrs)
+}
+
+// fetchInstruments are the metric instruments recorded for every metric fetch
+type fetchInstruments struct {
+	duration metric.Float64Histogram
+	apiCalls metric.Int64Counter
+}
+
+// getFetchInstruments creates the instruments from the global meter on first use.
+// Instruments created before Setup forward to the exporting meter provider once it is set.
+var getFetchInstruments = sync.OnceValue(func() fetchInstruments {
+	meter := otel.Meter(instrumentationName)
+
+	// Instrument creation only fails for invalid names or units, and returns a usable no-op instrument then
+	duration, _ := meter.Float64Histogram("ghmv.metric.fetch.duration",
+		metric.WithDescription("Time taken to fetch a metric from a repository"), metric.WithUnit("s"))
+	apiCalls, _ := meter.Int64Counter("ghmv.api.requests",
+		metric.WithDescription("HTTP requests made to the GitHub API to fetch a metric"), metric.WithUnit("{request}"))
+
+	return fetchInstruments{duration: duration, apiCalls: apiCalls}
+})
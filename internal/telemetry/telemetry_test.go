@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSignalURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"http://localhost:4318", "http://localhost:4318/v1/traces"},
+		{"https://collector.example.com/otlp/", "https://collector.example.com/otlp/v1/traces"},
+	}
+
+	for _, tt := range tests {
+		got, err := signalURL(tt.endpoint, "v1/traces")
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, got)
+	}
+
+	_, err := signalURL("localhost:4318", "v1/traces")
+	assert.Error(t, err, "expected an endpoint without scheme to be rejected")
+}
+
+func TestSetup(t *testing.T) {
+	previousTracerProvider := otel.GetTracerProvider()
+	previousMeterProvider := otel.GetMeterProvider()
+	defer otel.SetTracerProvider(previousTracerProvider)
+	defer otel.SetMeterProvider(previousMeterProvider)
+
+	var paths []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+	}))
+	defer collector.Close()
+
+	shutdown, err := Setup(context.Background(), collector.URL)
+	require.NoError(t, err)
+
+	ctx, span := StartRepositorySpan(context.Background(), "source-org/repo", "target-org/repo")
+	metricCtx, metricSpan := StartMetricSpan(ctx, "source", "tags")
+	EndMetricSpan(metricCtx, metricSpan, "source", "tags", time.Millisecond, 1, nil)
+	EndSpan(span, nil)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, shutdown(shutdownCtx))
+	assert.ElementsMatch(t, []string{"/v1/traces", "/v1/metrics"}, paths)
+
+	_, err = Setup(context.Background(), "not a url")
+	assert.Error(t, err)
+}
+
+func TestMetricSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	ctx, repoSpan := StartRepositorySpan(context.Background(), "source-org/repo", "target-org/repo")
+	metricCtx, metricSpan := StartMetricSpan(ctx, "target", "issues")
+	EndMetricSpan(metricCtx, metricSpan, "target", "issues", time.Second, 2, errors.New("not found"))
+	EndSpan(repoSpan, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	fetch := spans[0]
+	assert.Equal(t, "fetch issues", fetch.Name())
+	assert.Equal(t, codes.Error, fetch.Status().Code)
+	assert.Equal(t, spans[1].SpanContext().SpanID(), fetch.Parent().SpanID())
+	assert.Contains(t, fetch.Attributes(), attribute.Int("ghmv.api_calls", 2))
+	assert.Contains(t, fetch.Attributes(), attribute.String("ghmv.side", "target"))
+
+	assert.Equal(t, "validate repository", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -0,0 +1,213 @@
+// Package recorder replays recorded GitHub API interactions ("cassettes") in tests, so API methods can be
+// tested against real responses without network access.
+//
+// Cassettes are JSON files in testdata/cassettes of the package under test. Set GHMV_RECORD_CASSETTES=1 and
+// GHMV_RECORD_TOKEN to a token to send the requests of a test to GitHub and overwrite its cassette with the
+// responses. Recorded cassettes never contain request headers, so the token is not written.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Method      string      `json:"method"`
+	Path        string      `json:"path"` // Path and query, e.g. /repos/owner/name/tags?per_page=1
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header,omitempty"` // Response headers
+	Body        string      `json:"body"`
+}
+
+// Cassette is the interactions of one test, in the order they happened
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper answering requests from a cassette, or recording them in record mode
+type Recorder struct {
+	t         testing.TB
+	path      string
+	recording bool
+	token     string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a recorder for the cassette testdata/cassettes/<name>.json. In replay mode the test fails when
+// the cassette does not exist; in record mode the cassette is written when the test completes.
+func New(t testing.TB, name string) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		t:         t,
+		path:      filepath.Join("testdata", "cassettes", name+".json"),
+		recording: os.Getenv("GHMV_RECORD_CASSETTES") == "1",
+		token:     os.Getenv("GHMV_RECORD_TOKEN"),
+	}
+
+	if r.recording {
+		if r.token == "" {
+			t.Fatal("GHMV_RECORD_TOKEN is required to record cassettes")
+		}
+		t.Cleanup(r.save)
+		return r
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		t.Fatalf("invalid cassette %s: %v", r.path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r
+}
+
+// Client returns an HTTP client sending its requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.recording {
+		return r.record(req, body)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Interactions are matched in order, so a repeated request is answered with its next response
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction, req, body) {
+			continue
+		}
+		r.used[i] = true
+		return response(req, interaction), nil
+	}
+
+	r.t.Errorf("cassette %s has no interaction for %s %s", r.path, req.Method, req.URL.RequestURI())
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// record sends req to GitHub and appends the interaction to the cassette
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+r.token)
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Method:      req.Method,
+		Path:        req.URL.RequestURI(),
+		RequestBody: string(body),
+		StatusCode:  resp.StatusCode,
+		Header:      recordedHeader(resp.Header),
+		Body:        string(responseBody),
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return response(req, interaction), nil
+}
+
+// save writes the recorded cassette
+func (r *Recorder) save() {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		r.t.Errorf("failed to encode cassette: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		r.t.Errorf("failed to create cassette directory: %v", err)
+		return
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		r.t.Errorf("failed to write cassette: %v", err)
+	}
+}
+
+// recordedHeader keeps the response headers API clients read: pagination and the content type
+func recordedHeader(header http.Header) http.Header {
+	recorded := make(http.Header)
+	for _, name := range []string{"Content-Type", "Link", "X-GitHub-SSO"} {
+		if value := header.Get(name); value != "" {
+			recorded.Set(name, value)
+		}
+	}
+	return recorded
+}
+
+// matches reports whether an interaction was recorded for req. JSON bodies, such as GraphQL queries, are
+// compared by value so formatting differences do not matter.
+func matches(interaction Interaction, req *http.Request, body []byte) bool {
+	if interaction.Method != req.Method || interaction.Path != req.URL.RequestURI() {
+		return false
+	}
+	return equalBodies(interaction.RequestBody, string(body))
+}
+
+func equalBodies(recorded, sent string) bool {
+	recorded, sent = strings.TrimSpace(recorded), strings.TrimSpace(sent)
+	if recorded == sent {
+		return true
+	}
+
+	var recordedValue, sentValue any
+	if json.Unmarshal([]byte(recorded), &recordedValue) != nil || json.Unmarshal([]byte(sent), &sentValue) != nil {
+		return false
+	}
+	recordedJSON, _ := json.Marshal(recordedValue)
+	sentJSON, _ := json.Marshal(sentValue)
+	return bytes.Equal(recordedJSON, sentJSON)
+}
+
+// response builds the response to req from an interaction
+func response(req *http.Request, interaction Interaction) *http.Response {
+	header := interaction.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode: interaction.StatusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(interaction.Body)),
+		Request:    req,
+	}
+}
@@ -0,0 +1,51 @@
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failureRecorder records the errors reported through it instead of failing the test
+type failureRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (f *failureRecorder) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, format)
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	client := New(t, "replay").Client()
+
+	// JSON bodies are matched by value
+	resp, err := client.Post("https://api.github.com/graphql", "application/json", strings.NewReader(`{ "query": "{viewer{login}}" }`))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"data":{"viewer":{"login":"octocat"}}}`, string(body))
+
+	// Repeated requests are answered in recording order
+	resp, err = client.Get("https://api.github.com/repos/octocat/Hello-World/tags?per_page=1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Link"), `rel="last"`)
+
+	resp, err = client.Get("https://api.github.com/repos/octocat/Hello-World/tags?per_page=1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestRecorder_UnrecordedRequest(t *testing.T) {
+	failures := &failureRecorder{TB: t}
+	client := New(failures, "replay").Client()
+
+	_, err := client.Get("https://api.github.com/repos/octocat/Hello-World/branches")
+
+	assert.Error(t, err)
+	assert.Len(t, failures.errors, 1, "An unrecorded request should fail the test")
+}
@@ -0,0 +1,139 @@
+// Package update checks whether a newer release of the extension has been published
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository is the repository the extension is released from
+const Repository = "mona-actions/gh-migration-validator"
+
+// DefaultAPIURL is the GitHub API the latest release is read from
+const DefaultAPIURL = "https://api.github.com"
+
+// CheckInterval is how long the result of a check is reused before checking again
+const CheckInterval = 24 * time.Hour
+
+// Release is the latest published release
+type Release struct {
+	Version string    `json:"tag_name"`
+	URL     string    `json:"html_url"`
+	Date    time.Time `json:"published_at"`
+}
+
+// LatestRelease reads the latest release of the extension from the GitHub API at apiURL
+func LatestRelease(ctx context.Context, client *http.Client, apiURL string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimSuffix(apiURL, "/"), Repository), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for a newer version: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for a newer version: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse the latest release: %w", err)
+	}
+	return &release, nil
+}
+
+// Newer reports whether latest is a higher version than current. Versions are compared with CompareVersions;
+// a current version that is not a release, such as dev, is never outdated.
+func Newer(current, latest string) bool {
+	comparison, err := CompareVersions(latest, current)
+	return err == nil && comparison > 0
+}
+
+// CompareVersions compares two versions like v1.2.3, 1.2 or 1.2.3-rc.1 as MAJOR.MINOR.PATCH, returning -1, 0
+// or 1 as a is older than, the same as or newer than b. Missing components count as 0 and pre-release or
+// build suffixes are ignored.
+func CompareVersions(a, b string) (int, error) {
+	aParts, ok := parseVersion(a)
+	if !ok {
+		return 0, fmt.Errorf("invalid version %q", a)
+	}
+	bParts, ok := parseVersion(b)
+	if !ok {
+		return 0, fmt.Errorf("invalid version %q", b)
+	}
+
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			if aParts[i] < bParts[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// parseVersion returns the major, minor and patch numbers of a version like v1.2.3 or 1.2.3-rc.1
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 0 {
+			return parts, false
+		}
+		parts[i] = number
+	}
+	return parts, true
+}
+
+// cache is the result of the last check, stored next to the config file
+type cache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release   Release   `json:"release"`
+}
+
+// CachedLatestRelease returns the latest release, reading it from the API at most once per CheckInterval.
+// The result is cached in cachePath; failing to write the cache does not fail the check.
+func CachedLatestRelease(ctx context.Context, client *http.Client, apiURL, cachePath string, now time.Time) (*Release, error) {
+	if data, err := os.ReadFile(cachePath); err == nil {
+		var cached cache
+		if json.Unmarshal(data, &cached) == nil && now.Sub(cached.CheckedAt) < CheckInterval && cached.Release.Version != "" {
+			return &cached.Release, nil
+		}
+	}
+
+	release, err := LatestRelease(ctx, client, apiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(cache{CheckedAt: now, Release: *release}); err == nil {
+		if os.MkdirAll(filepath.Dir(cachePath), 0o755) == nil {
+			os.WriteFile(cachePath, data, 0o644)
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,106 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		expected        bool
+	}{
+		{"v1.2.3", "v1.2.4", true},
+		{"v1.2.3", "v1.10.0", true},
+		{"v1.2.3", "v2.0.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.3.0", "v1.2.9", false},
+		{"1.2.3", "v1.2.4", true},
+		{"v1.2.3-rc.1", "v1.2.3", false},
+		{"dev", "v9.9.9", false},
+		{"v1.2.3", "nightly", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Newer(tt.current, tt.latest), "Newer(%q, %q)", tt.current, tt.latest)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"v1.10.0", "1.9.9", 1},
+		{"0.9.3", "1.0.0", -1},
+		{"1.2.3-rc.1", "1.2.3", 0},
+	}
+	for _, tt := range tests {
+		comparison, err := CompareVersions(tt.a, tt.b)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, comparison, "CompareVersions(%q, %q)", tt.a, tt.b)
+	}
+
+	_, err := CompareVersions("beta", "1.0.0")
+	assert.EqualError(t, err, `invalid version "beta"`)
+}
+
+// newReleaseServer serves tag as the latest release and counts the requests
+func newReleaseServer(t *testing.T, tag string, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		assert.Equal(t, "/repos/"+Repository+"/releases/latest", r.URL.Path)
+		w.Write([]byte(`{"tag_name": "` + tag + `", "html_url": "https://github.com/` + Repository + `/releases/tag/` + tag + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLatestRelease(t *testing.T) {
+	requests := 0
+	server := newReleaseServer(t, "v1.4.0", &requests)
+
+	release, err := LatestRelease(context.Background(), server.Client(), server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", release.Version)
+	assert.Equal(t, "https://github.com/"+Repository+"/releases/tag/v1.4.0", release.URL)
+}
+
+func TestLatestRelease_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := LatestRelease(context.Background(), server.Client(), server.URL)
+
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestCachedLatestRelease(t *testing.T) {
+	requests := 0
+	server := newReleaseServer(t, "v1.4.0", &requests)
+	cachePath := filepath.Join(t.TempDir(), "update-check.json")
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{now, now.Add(time.Hour)} {
+		release, err := CachedLatestRelease(context.Background(), server.Client(), server.URL, cachePath, at)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.4.0", release.Version)
+	}
+	assert.Equal(t, 1, requests, "A check within the interval should use the cache")
+
+	_, err := CachedLatestRelease(context.Background(), server.Client(), server.URL, cachePath, now.Add(CheckInterval))
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "An expired cache should be refreshed")
+}
@@ -0,0 +1,394 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"mona-actions/gh-migration-validator/internal/xlsx"
+)
+
+// RepositoryReport is the validation of one repository of a batch, as shown in the multi-repository
+// markdown report
+type RepositoryReport struct {
+	Source  string
+	Target  string
+	Summary Summary
+	Results []ValidationResult
+	// Markdown is the repository's report as returned by MarkdownReport, empty when the validation failed to run
+	Markdown string
+	// Err is the error that stopped the validation, nil when the repository was validated
+	Err error
+}
+
+// RepositoryReport returns the validation of the current repository pair for a multi-repository report
+func (mv *MigrationValidator) RepositoryReport(results []ValidationResult) RepositoryReport {
+	return RepositoryReport{
+		Source:   repositoryName(mv.SourceData),
+		Target:   repositoryName(mv.TargetData),
+		Summary:  Summarize(results),
+		Results:  results,
+		Markdown: mv.MarkdownReport(results),
+	}
+}
+
+// TimedOut reports whether the validation of the repository did not finish within its time limit
+func (r RepositoryReport) TimedOut() bool {
+	return errors.Is(r.Err, api.ErrTimeout)
+}
+
+// Interrupted reports whether the validation of the repository was stopped by an interrupt signal
+func (r RepositoryReport) Interrupted() bool {
+	return errors.Is(r.Err, api.ErrInterrupted)
+}
+
+// verdictEmoji are the emoji of the verdict names in the summary table
+var verdictEmoji = map[string]string{
+	"timeout":     "⏱️",
+	"interrupted": "🛑",
+	"error":       "💥",
+	"incomplete":  "🚫",
+	"failed":      "❌",
+	"warnings":    "⚠️",
+	"passed":      "✅",
+}
+
+// verdictName returns the verdict of a repository, or error when it was not validated
+func (r RepositoryReport) verdictName() string {
+	if r.TimedOut() {
+		return "timeout"
+	}
+	if r.Interrupted() {
+		return "interrupted"
+	}
+	if r.Err != nil {
+		return "error"
+	}
+	switch r.Summary.Verdict {
+	case VerdictIncomplete:
+		return "incomplete"
+	case VerdictFailed:
+		return "failed"
+	case VerdictWarnings:
+		return "warnings"
+	default:
+		return "passed"
+	}
+}
+
+// verdictLabel returns the verdict of a repository for the summary table, or error when it was not validated
+func (r RepositoryReport) verdictLabel() string {
+	name := r.verdictName()
+	return output.Heading(verdictEmoji[name] + " " + name)
+}
+
+// maxWorstRepositories is the number of repositories listed in the worst repositories of a wave summary
+const maxWorstRepositories = 10
+
+// WaveSummary rolls up the validation of the repositories of a batch, e.g. a migration wave: the pass rate,
+// the items migrated and missing per metric across every repository, and the repositories missing the most
+type WaveSummary struct {
+	Repositories int     `json:"repositories"`
+	Passed       int     `json:"passed"`    // Repositories whose validation passed, with or without warnings
+	PassRate     float64 `json:"pass_rate"` // Percentage of the repositories that passed
+	// Verdicts counts the repositories by verdict: passed, warnings, failed, incomplete, timeout, interrupted or error
+	Verdicts          map[string]int     `json:"verdicts"`
+	Metrics           []MetricTotal      `json:"metrics"`
+	WorstRepositories []RepositoryRating `json:"worst_repositories"`
+}
+
+// MetricTotal is a source vs target metric summed over the repositories of a batch
+type MetricTotal struct {
+	Metric              string `json:"metric"`
+	Source              int    `json:"source"`               // Items in the source repositories
+	Migrated            int    `json:"migrated"`             // Items of the source repositories found in their target
+	Missing             int    `json:"missing"`              // Items of the source repositories missing in their target
+	RepositoriesMissing int    `json:"repositories_missing"` // Repositories missing some of the items
+}
+
+// RepositoryRating is a repository of a batch that did not pass, with the checks it failed and items it misses
+type RepositoryRating struct {
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Verdict string `json:"verdict"`
+	Failed  int    `json:"failed"`
+	Missing int    `json:"missing"`
+}
+
+// SummarizeWave rolls the reports of a batch up into a wave summary. Metrics are summed from the source vs
+// target results with numeric values; the worst repositories are the ones that did not pass, ordered by the
+// items they miss and then by their failed checks.
+func SummarizeWave(reports []RepositoryReport) WaveSummary {
+	summary := WaveSummary{
+		Repositories:      len(reports),
+		Verdicts:          make(map[string]int),
+		Metrics:           []MetricTotal{},
+		WorstRepositories: []RepositoryRating{},
+	}
+
+	totals := make(map[string]*MetricTotal)
+	var metrics []string // In the order of the reports
+	for _, report := range reports {
+		verdict := report.verdictName()
+		summary.Verdicts[verdict]++
+		if verdict == "passed" || verdict == "warnings" {
+			summary.Passed++
+		}
+
+		missing := 0
+		for _, result := range report.Results {
+			source, sourceOK := countValue(result.SourceVal)
+			_, targetOK := countValue(result.TargetVal)
+			if !sourceOK || !targetOK || result.StatusType == ValidationStatusUnavailable || resultSection(result) != sectionSourceVsTarget {
+				continue
+			}
+
+			// Issue metrics are named after their expected offset, which may differ between repositories
+			metric, _, _ := strings.Cut(result.Metric, " (expected")
+			total, ok := totals[metric]
+			if !ok {
+				total = &MetricTotal{Metric: metric}
+				totals[metric] = total
+				metrics = append(metrics, metric)
+			}
+			total.Source += source
+			total.Migrated += source
+			if result.Difference > 0 {
+				total.Missing += result.Difference
+				total.Migrated -= min(result.Difference, source)
+				total.RepositoriesMissing++
+				missing += result.Difference
+			}
+		}
+
+		if verdict != "passed" && verdict != "warnings" {
+			summary.WorstRepositories = append(summary.WorstRepositories, RepositoryRating{
+				Source: report.Source, Target: report.Target, Verdict: verdict, Failed: report.Summary.Failed, Missing: missing,
+			})
+		}
+	}
+
+	for _, metric := range metrics {
+		summary.Metrics = append(summary.Metrics, *totals[metric])
+	}
+	if summary.Repositories > 0 {
+		summary.PassRate = float64(summary.Passed) * 100 / float64(summary.Repositories)
+	}
+
+	sort.SliceStable(summary.WorstRepositories, func(i, j int) bool {
+		a, b := summary.WorstRepositories[i], summary.WorstRepositories[j]
+		if a.Missing != b.Missing {
+			return a.Missing > b.Missing
+		}
+		return a.Failed > b.Failed
+	})
+	if len(summary.WorstRepositories) > maxWorstRepositories {
+		summary.WorstRepositories = summary.WorstRepositories[:maxWorstRepositories]
+	}
+	return summary
+}
+
+// countValue returns the count of a numeric result value
+func countValue(value interface{}) (int, bool) {
+	switch count := value.(type) {
+	case int:
+		return count, true
+	case int64:
+		return int(count), true
+	default:
+		return 0, false
+	}
+}
+
+// JSONLine returns the summary as a single line of JSON under a wave_summary key, without a trailing newline,
+// so it can follow the NDJSON reports of the repositories
+func (s WaveSummary) JSONLine() ([]byte, error) {
+	return json.Marshal(map[string]WaveSummary{"wave_summary": s})
+}
+
+// WriteText writes the summary for the terminal: the pass rate, the metric totals and the worst repositories
+func (s WaveSummary) WriteText(writer io.Writer) error {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Wave summary: %d of %d repositories passed (%.1f%%)\n", s.Passed, s.Repositories, s.PassRate)
+
+	table := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	if len(s.Metrics) > 0 {
+		fmt.Fprintln(&buffer, "\nTotals per metric:")
+		fmt.Fprintln(table, "Metric\tSource\tMigrated\tMissing\tRepositories Missing")
+		for _, metric := range s.Metrics {
+			fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%d\n", metric.Metric, metric.Source, metric.Migrated, metric.Missing, metric.RepositoriesMissing)
+		}
+		table.Flush()
+	}
+	if len(s.WorstRepositories) > 0 {
+		fmt.Fprintln(&buffer, "\nWorst repositories:")
+		fmt.Fprintln(table, "Source\tTarget\tResult\tFailed\tMissing")
+		for _, repository := range s.WorstRepositories {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%d\n", repository.Source, repository.Target, repository.Verdict, repository.Failed, repository.Missing)
+		}
+		table.Flush()
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+// writeMarkdown writes the metric totals and worst repositories sections of the multi-repository report
+func (s WaveSummary) writeMarkdown(writer io.Writer) {
+	if len(s.Metrics) > 0 {
+		fmt.Fprintln(writer, "## Wave Totals")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Metric | Source | Migrated | Missing | Repositories Missing |")
+		fmt.Fprintln(writer, "|--------|--------|----------|---------|----------------------|")
+		for _, metric := range s.Metrics {
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %d |\n", metric.Metric, metric.Source, metric.Migrated, metric.Missing, metric.RepositoriesMissing)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(s.WorstRepositories) > 0 {
+		fmt.Fprintln(writer, "## Worst Repositories")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Source | Target | Result | Failed | Missing |")
+		fmt.Fprintln(writer, "|--------|--------|--------|--------|---------|")
+		for _, repository := range s.WorstRepositories {
+			fmt.Fprintf(writer, "| `%s` | `%s` | %s | %d | %d |\n", repository.Source, repository.Target,
+				output.Heading(verdictEmoji[repository.Verdict]+" "+repository.Verdict), repository.Failed, repository.Missing)
+		}
+		fmt.Fprintln(writer)
+	}
+}
+
+// BatchMarkdownReport returns a single markdown document for the validation of several repositories: a summary
+// table of every repository followed by a collapsible section with each repository's report
+func BatchMarkdownReport(reports []RepositoryReport) string {
+	var buffer bytes.Buffer
+	writeBatchMarkdownReport(&buffer, reports)
+	return buffer.String()
+}
+
+// WriteBatchMarkdownFile writes the multi-repository markdown report to path
+func WriteBatchMarkdownFile(reports []RepositoryReport, path string) error {
+	return os.WriteFile(path, []byte(BatchMarkdownReport(reports)), 0o644)
+}
+
+// BatchXLSXSheets returns the sheets of the multi-repository XLSX report: a summary row per repository, and
+// the results of every repository
+func BatchXLSXSheets(reports []RepositoryReport) []xlsx.Sheet {
+	summary := [][]interface{}{{"Source", "Target", "Result", "Passed", "Failed", "Warnings", "Error"}}
+	results := [][]interface{}{{"Source", "Target", "Metric", "Source Value", "Target Value", "Status", "Difference"}}
+	for _, report := range reports {
+		errorMessage := ""
+		if report.Err != nil {
+			errorMessage = report.Err.Error()
+		}
+		summary = append(summary, []interface{}{report.Source, report.Target, report.verdictName(),
+			report.Summary.Passed, report.Summary.Failed, report.Summary.Warnings, errorMessage})
+
+		for _, result := range report.Results {
+			results = append(results, []interface{}{report.Source, report.Target, result.Metric,
+				fmt.Sprint(result.SourceVal), fmt.Sprint(result.TargetVal), result.StatusType.String(), formatDifference(result)})
+		}
+	}
+
+	return []xlsx.Sheet{
+		{Name: "Summary", Rows: summary},
+		{Name: "Results", Rows: results},
+	}
+}
+
+// WriteBatchXLSXFile writes the multi-repository XLSX report to path
+func WriteBatchXLSXFile(reports []RepositoryReport, path string) error {
+	return xlsx.WriteFile(path, BatchXLSXSheets(reports))
+}
+
+func writeBatchMarkdownReport(writer io.Writer, reports []RepositoryReport) {
+	verdicts := make(map[string]int)
+	for _, report := range reports {
+		verdicts[report.verdictLabel()]++
+	}
+
+	wave := SummarizeWave(reports)
+
+	fmt.Fprintln(writer, "# Migration Validation Report")
+	fmt.Fprintln(writer)
+	fmt.Fprintf(writer, "**Repositories:** %d  \n", len(reports))
+	fmt.Fprintf(writer, "**Pass rate:** %.1f%% (%d of %d)  \n", wave.PassRate, wave.Passed, wave.Repositories)
+	for _, label := range []string{
+		output.Heading("✅ passed"), output.Heading("⚠️ warnings"), output.Heading("❌ failed"),
+		output.Heading("🚫 incomplete"), output.Heading("⏱️ timeout"), output.Heading("🛑 interrupted"), output.Heading("💥 error"),
+	} {
+		if verdicts[label] > 0 {
+			fmt.Fprintf(writer, "- %s: %d  \n", label, verdicts[label])
+		}
+	}
+	fmt.Fprintln(writer)
+	if verdicts[output.Heading("🛑 interrupted")] > 0 {
+		fmt.Fprintln(writer, "> **Interrupted:** the batch was stopped before every repository was validated; this report only covers the repositories listed below.")
+		fmt.Fprintln(writer)
+	}
+
+	wave.writeMarkdown(writer)
+
+	fmt.Fprintln(writer, "## Summary")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| Source | Target | Result | Passed | Failed | Warnings |")
+	fmt.Fprintln(writer, "|--------|--------|--------|--------|--------|----------|")
+	for _, report := range reports {
+		fmt.Fprintf(writer, "| `%s` | `%s` | %s | %d | %d | %d |\n",
+			report.Source, report.Target, report.verdictLabel(),
+			report.Summary.Passed, report.Summary.Failed, report.Summary.Warnings)
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "## Repositories")
+	fmt.Fprintln(writer)
+	for _, report := range reports {
+		// Repositories that need attention are expanded
+		open := ""
+		if report.Err != nil || report.Summary.Verdict == VerdictFailed || report.Summary.Verdict == VerdictIncomplete {
+			open = " open"
+		}
+
+		fmt.Fprintf(writer, "<details%s>\n", open)
+		fmt.Fprintf(writer, "<summary>%s → %s: %s</summary>\n\n", report.Source, report.Target, report.verdictLabel())
+		if report.Err != nil {
+			fmt.Fprintf(writer, "Validation failed: %v\n", report.Err)
+		} else {
+			fmt.Fprint(writer, nestedReport(report.Markdown))
+		}
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "</details>")
+		fmt.Fprintln(writer)
+	}
+}
+
+// nestedReport adapts a single repository report to a section of the multi-repository report: its title is
+// dropped, as the section summary names the repositories, and its other headings are moved down two levels
+func nestedReport(markdown string) string {
+	lines := strings.Split(strings.TrimRight(markdown, "\n"), "\n")
+
+	var builder strings.Builder
+	for i, line := range lines {
+		if i == 0 && strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if i == 1 && line == "" && strings.HasPrefix(lines[0], "# ") {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			line = "##" + line
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchMarkdownReport(t *testing.T) {
+	passing := setupTestValidator(
+		&RepositoryData{Owner: "source-org", Name: "repo-a"},
+		&RepositoryData{Owner: "target-org", Name: "repo-a"},
+	)
+	failing := setupTestValidator(
+		&RepositoryData{Owner: "source-org", Name: "repo-b"},
+		&RepositoryData{Owner: "target-org", Name: "repo-b"},
+	)
+
+	reports := []RepositoryReport{
+		passing.RepositoryReport([]ValidationResult{
+			{Metric: "Issues", SourceVal: 10, TargetVal: 10, StatusType: ValidationStatusPass},
+		}),
+		failing.RepositoryReport([]ValidationResult{
+			{Metric: "Issues", SourceVal: 10, TargetVal: 8, StatusType: ValidationStatusFail, Difference: 2},
+		}),
+		{Source: "source-org/repo-c", Target: "target-org/repo-c", Err: errors.New("repository not found")},
+	}
+
+	report := BatchMarkdownReport(reports)
+
+	assert.True(t, strings.HasPrefix(report, "# Migration Validation Report\n"))
+	assert.Equal(t, 1, strings.Count(report, "# Migration Validation Report"), "repository reports lose their title")
+	assert.Contains(t, report, "**Repositories:** 3")
+	assert.Contains(t, report, "- ✅ passed: 1")
+	assert.Contains(t, report, "- ❌ failed: 1")
+	assert.Contains(t, report, "- 💥 error: 1")
+
+	assert.Contains(t, report, "| `source-org/repo-a` | `target-org/repo-a` | ✅ passed | 1 | 0 | 0 |")
+	assert.Contains(t, report, "| `source-org/repo-b` | `target-org/repo-b` | ❌ failed | 0 | 1 | 0 |")
+	assert.Contains(t, report, "| `source-org/repo-c` | `target-org/repo-c` | 💥 error | 0 | 0 | 0 |")
+
+	// Passing repositories are collapsed, the others expanded
+	assert.Contains(t, report, "<details>\n<summary>source-org/repo-a → target-org/repo-a: ✅ passed</summary>")
+	assert.Contains(t, report, "<details open>\n<summary>source-org/repo-b → target-org/repo-b: ❌ failed</summary>")
+	assert.Contains(t, report, "<details open>\n<summary>source-org/repo-c → target-org/repo-c: 💥 error</summary>\n\nValidation failed: repository not found")
+	assert.Equal(t, 3, strings.Count(report, "</details>"))
+
+	// Headings of repository reports are nested under the Repositories section
+	assert.Contains(t, report, "#### Summary")
+	assert.Contains(t, report, "| Issues | ❌ FAIL | 10 | 8 |")
+}
+
+func TestBatchMarkdownReport_Timeout(t *testing.T) {
+	report := BatchMarkdownReport([]RepositoryReport{{
+		Source: "source-org/huge",
+		Target: "target-org/huge",
+		Err:    fmt.Errorf("validation did not finish within 15m0s: %w", api.ErrTimeout),
+	}})
+
+	assert.Contains(t, report, "- ⏱️ timeout: 1")
+	assert.Contains(t, report, "| `source-org/huge` | `target-org/huge` | ⏱️ timeout | 0 | 0 | 0 |")
+	assert.Contains(t, report, "Validation failed: validation did not finish within 15m0s: timed out")
+}
+
+func TestBatchMarkdownReport_Interrupted(t *testing.T) {
+	report := BatchMarkdownReport([]RepositoryReport{{
+		Source: "source-org/repo",
+		Target: "target-org/repo",
+		Err:    fmt.Errorf("validation was stopped before it finished: %w", api.ErrInterrupted),
+	}})
+
+	assert.Contains(t, report, "- 🛑 interrupted: 1")
+	assert.Contains(t, report, "> **Interrupted:** the batch was stopped")
+	assert.Contains(t, report, "| `source-org/repo` | `target-org/repo` | 🛑 interrupted | 0 | 0 | 0 |")
+}
+
+func TestNestedReport(t *testing.T) {
+	nested := nestedReport("# Migration Validation Report\n\n**Source:** `a/b`\n\n## Summary\n\n- **Passed:** 1\n")
+	assert.Equal(t, "**Source:** `a/b`\n\n#### Summary\n\n- **Passed:** 1\n", nested)
+}
+
+func TestBatchXLSXSheets(t *testing.T) {
+	failing := setupTestValidator(
+		&RepositoryData{Owner: "source-org", Name: "repo-a"},
+		&RepositoryData{Owner: "target-org", Name: "repo-a"},
+	)
+	reports := []RepositoryReport{
+		failing.RepositoryReport([]ValidationResult{
+			{Metric: "Issues", SourceVal: 10, TargetVal: 8, StatusType: ValidationStatusFail, Difference: 2},
+		}),
+		{Source: "source-org/repo-b", Target: "target-org/repo-b", Err: errors.New("repository not found")},
+	}
+
+	sheets := BatchXLSXSheets(reports)
+
+	assert.Equal(t, "Summary", sheets[0].Name)
+	assert.Equal(t, []interface{}{"source-org/repo-a", "target-org/repo-a", "failed", 0, 1, 0, ""}, sheets[0].Rows[1])
+	assert.Equal(t, []interface{}{"source-org/repo-b", "target-org/repo-b", "error", 0, 0, 0, "repository not found"}, sheets[0].Rows[2])
+	assert.Equal(t, "Results", sheets[1].Name)
+	assert.Len(t, sheets[1].Rows, 2, "a header and the result of the validated repository")
+	assert.Equal(t, []interface{}{"source-org/repo-a", "target-org/repo-a", "Issues", "10", "8", "FAIL", "Missing: 2"}, sheets[1].Rows[1])
+}
+
+func TestSummarizeWave(t *testing.T) {
+	reports := []RepositoryReport{
+		{Source: "source-org/repo-a", Target: "target-org/repo-a", Results: []ValidationResult{
+			{Metric: "Issues (expected +1 for migration log)", SourceVal: 10, TargetVal: 11, StatusType: ValidationStatusPass},
+			{Metric: "Tags", SourceVal: 5, TargetVal: 5, StatusType: ValidationStatusPass},
+			{Metric: "Latest Commit SHA", SourceVal: "abc", TargetVal: "abc", StatusType: ValidationStatusPass},
+		}},
+		{Source: "source-org/repo-b", Target: "target-org/repo-b", Results: []ValidationResult{
+			{Metric: "Issues", SourceVal: 20, TargetVal: 15, StatusType: ValidationStatusFail, Difference: 5},
+			{Metric: "Tags", SourceVal: 3, TargetVal: 2, StatusType: ValidationStatusFail, Difference: 1},
+			{Metric: "Archive vs Target Issues", SourceVal: 20, TargetVal: 15, StatusType: ValidationStatusFail, Difference: 5},
+		}},
+		{Source: "source-org/repo-c", Target: "target-org/repo-c", Results: []ValidationResult{
+			{Metric: "Tags", SourceVal: 4, TargetVal: 1, StatusType: ValidationStatusFail, Difference: 3},
+		}},
+		{Source: "source-org/repo-d", Target: "target-org/repo-d", Err: errors.New("repository not found")},
+	}
+	for i := range reports {
+		reports[i].Summary = Summarize(reports[i].Results)
+	}
+
+	summary := SummarizeWave(reports)
+
+	assert.Equal(t, 4, summary.Repositories)
+	assert.Equal(t, 1, summary.Passed)
+	assert.Equal(t, 25.0, summary.PassRate)
+	assert.Equal(t, map[string]int{"passed": 1, "failed": 2, "error": 1}, summary.Verdicts)
+	assert.Equal(t, []MetricTotal{
+		{Metric: "Issues", Source: 30, Migrated: 25, Missing: 5, RepositoriesMissing: 1},
+		{Metric: "Tags", Source: 12, Migrated: 8, Missing: 4, RepositoriesMissing: 2},
+	}, summary.Metrics, "archive rows and non-numeric metrics are not summed")
+	assert.Equal(t, []RepositoryRating{
+		{Source: "source-org/repo-b", Target: "target-org/repo-b", Verdict: "failed", Failed: 3, Missing: 6},
+		{Source: "source-org/repo-c", Target: "target-org/repo-c", Verdict: "failed", Failed: 1, Missing: 3},
+		{Source: "source-org/repo-d", Target: "target-org/repo-d", Verdict: "error"},
+	}, summary.WorstRepositories)
+
+	var text strings.Builder
+	assert.NoError(t, summary.WriteText(&text))
+	assert.Contains(t, text.String(), "Wave summary: 1 of 4 repositories passed (25.0%)")
+	assert.Contains(t, text.String(), "Tags    12      8         4        2")
+	assert.Contains(t, text.String(), "source-org/repo-b  target-org/repo-b  failed  3       6")
+
+	markdown := BatchMarkdownReport(reports)
+	assert.Contains(t, markdown, "**Pass rate:** 25.0% (1 of 4)")
+	assert.Contains(t, markdown, "| Issues | 30 | 25 | 5 | 1 |")
+	assert.Contains(t, markdown, "| `source-org/repo-b` | `target-org/repo-b` | ❌ failed | 3 | 6 |")
+}
+
+func TestSummarizeWave_WorstRepositoriesLimit(t *testing.T) {
+	var reports []RepositoryReport
+	for i := range 12 {
+		reports = append(reports, RepositoryReport{Source: fmt.Sprintf("source-org/repo-%d", i), Err: errors.New("repository not found")})
+	}
+
+	summary := SummarizeWave(reports)
+
+	assert.Len(t, summary.WorstRepositories, maxWorstRepositories)
+	assert.Zero(t, summary.PassRate)
+	assert.Empty(t, summary.Metrics)
+}
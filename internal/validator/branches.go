@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"sort"
+	"strings"
+)
+
+// selectedBranchesMetric is the metric name of the count of branches listed with --branches
+const selectedBranchesMetric = "Selected Branches"
+
+// branchMetricPrefix starts the metric name of the comparison of a single branch
+const branchMetricPrefix = "Branch: "
+
+// missingBranchValue is displayed for a branch that does not exist in a repository
+const missingBranchValue = "Branch missing"
+
+// selectedBranches parses the Branches option: all branches of the source, or a comma-separated list of branch
+// names. Returns false when branches are not compared.
+func (mv *MigrationValidator) selectedBranches() (all bool, names []string, ok bool) {
+	value := strings.TrimSpace(mv.options.Branches)
+	if value == "" {
+		return false, nil, false
+	}
+	if value == "all" {
+		return true, nil, true
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return false, names, len(names) > 0
+}
+
+// branchResults compares the head SHA and commit count of the branches selected with --branches, catching
+// branches the migration did not push. Returns one result per branch that is missing or differs, after a
+// count of the listed branches on each side when branches are listed by name.
+func (mv *MigrationValidator) branchResults() []ValidationResult {
+	source, target := mv.SourceData.Branches, mv.TargetData.Branches
+	all, names, ok := mv.selectedBranches()
+	if !ok || source == nil || target == nil {
+		return nil
+	}
+
+	var results []ValidationResult
+	if all {
+		names = make([]string, 0, len(source))
+		for name := range source {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	} else {
+		results = append(results, countResult(selectedBranchesMetric, countPresent(source, names), countPresent(target, names)))
+	}
+
+	for _, name := range names {
+		sourceHead, inSource := source[name]
+		targetHead, inTarget := target[name]
+
+		result := ValidationResult{
+			Metric:     branchMetricPrefix + name,
+			SourceVal:  formatBranchHead(sourceHead, inSource),
+			TargetVal:  formatBranchHead(targetHead, inTarget),
+			Status:     ValidationStatusMessageFail,
+			StatusType: ValidationStatusFail,
+			Difference: sourceHead.Commits - targetHead.Commits,
+		}
+		switch {
+		case !inSource:
+			// A selected branch that does not exist in the source is most likely a typo in --branches
+			result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+		case inTarget && sourceHead == targetHead:
+			continue
+		case inTarget && mv.options.RewrittenHistory && sourceHead.Commits == targetHead.Commits:
+			// Rewritten history changes the head SHA, so only the commit count is compared
+			continue
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// countPresent returns how many of the named branches exist
+func countPresent(branches map[string]api.BranchHead, names []string) int {
+	count := 0
+	for _, name := range names {
+		if _, ok := branches[name]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// formatBranchHead returns the commit count and abbreviated head SHA of a branch, e.g. "120 commits @ 1a2b3c4"
+func formatBranchHead(head api.BranchHead, exists bool) string {
+	if !exists {
+		return missingBranchValue
+	}
+	sha := head.SHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return fmt.Sprintf("%d commits @ %s", head.Commits, sha)
+}
+
+// isBranchResult reports whether result belongs to the branch comparison
+func isBranchResult(result ValidationResult) bool {
+	return result.Metric == selectedBranchesMetric || strings.HasPrefix(result.Metric, branchMetricPrefix)
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"strings"
+)
+
+// RequestFailure describes data that could not be retrieved from one side, and why
+type RequestFailure struct {
+	Side  string // "source" or "target"
+	Data  string // The data the request was for, e.g. "issues"
+	Cause api.FailureCause
+	Err   error
+}
+
+// requestFailures pairs the data that failed to be retrieved from one side with the errors of its requests
+func requestFailures(side string, failedRequests []string, requestErrors []error) []RequestFailure {
+	failures := make([]RequestFailure, 0, len(failedRequests))
+	for i, data := range failedRequests {
+		failure := RequestFailure{Side: side, Data: data, Cause: api.CauseUnknown}
+		if i < len(requestErrors) {
+			failure.Err = requestErrors[i]
+			failure.Cause = api.ClassifyFailure(requestErrors[i])
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+// describeFailures lists failed data with its cause, e.g. "issues (forbidden), tags (not found)"
+func describeFailures(failures []RequestFailure) string {
+	descriptions := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", failure.Data, failure.Cause))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// FailedRequests returns the data that could not be retrieved during the last validation, source first
+func (mv *MigrationValidator) FailedRequests() []RequestFailure {
+	failures := make([]RequestFailure, 0, len(mv.sourceFailures)+len(mv.targetFailures))
+	failures = append(failures, mv.sourceFailures...)
+	return append(failures, mv.targetFailures...)
+}
+
+// displayFailedRequests prints the data that could not be retrieved with the cause and a hint for each
+func (mv *MigrationValidator) displayFailedRequests() {
+	failures := mv.FailedRequests()
+	if len(failures) == 0 {
+		return
+	}
+
+	output.Section(output.Heading("⚠️ Failed Requests"))
+
+	tableData := [][]string{{"Side", "Data", "Cause", "Hint"}}
+	for _, failure := range failures {
+		tableData = append(tableData, []string{failure.Side, failure.Data, string(failure.Cause), failure.Cause.Hint()})
+	}
+	output.RenderTable(tableData, true)
+
+	fmt.Println("Metrics using this data were compared with a value of 0.")
+}
+
+// writeMarkdownFailedRequests writes the data that could not be retrieved as a markdown section
+func (mv *MigrationValidator) writeMarkdownFailedRequests(writer io.Writer) {
+	failures := mv.FailedRequests()
+	if len(failures) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "## Failed Requests")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| Side | Data | Cause | Hint |")
+	fmt.Fprintln(writer, "|------|------|-------|------|")
+	for _, failure := range failures {
+		fmt.Fprintf(writer, "| %s | %s | %s | %s |\n", failure.Side, failure.Data, failure.Cause, failure.Cause.Hint())
+	}
+}
+
+// ssoError returns the first request error caused by SAML single sign-on enforcement in organization as an
+// *api.SSOError, or nil when there is none
+func (mv *MigrationValidator) ssoError(clientType api.ClientType, organization string, requestErrors []error) error {
+	for _, err := range requestErrors {
+		if ssoErr := mv.api.SSOError(clientType, organization, err); ssoErr != nil {
+			return ssoErr
+		}
+	}
+	return nil
+}
+
+// explainSSO returns err as an *api.SSOError when it was caused by SAML single sign-on enforcement, or unchanged
+func (mv *MigrationValidator) explainSSO(clientType api.ClientType, organization string, err error) error {
+	if ssoErr := mv.api.SSOError(clientType, organization, err); ssoErr != nil {
+		return ssoErr
+	}
+	return err
+}
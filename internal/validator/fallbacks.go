@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/output"
+)
+
+// restFallbackNote describes the data of one side counted with the REST API because its GraphQL query failed,
+// or returns an empty string when every count used GraphQL
+func restFallbackNote(side string, data *RepositoryData) string {
+	if data == nil || len(data.RESTFallbacks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %s: %s", side, repositoryName(data), strings.Join(data.RESTFallbacks, ", "))
+}
+
+// restFallbackNotes returns the notes of the source and the target, source first
+func (mv *MigrationValidator) restFallbackNotes() []string {
+	var notes []string
+	for _, note := range []string{restFallbackNote("Source", mv.SourceData), restFallbackNote("Target", mv.TargetData)} {
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// displayRESTFallbacks prints the data counted with the REST API instead of GraphQL
+func (mv *MigrationValidator) displayRESTFallbacks() {
+	notes := mv.restFallbackNotes()
+	if len(notes) == 0 {
+		return
+	}
+
+	output.Section(output.Heading("ℹ️ Counted with the REST API"))
+	for _, note := range notes {
+		fmt.Println(note)
+	}
+	fmt.Println("GraphQL queries for this data failed. Search API counts can miss items created in the last minutes.")
+}
+
+// writeMarkdownRESTFallbacks writes the data counted with the REST API instead of GraphQL as a markdown section
+func (mv *MigrationValidator) writeMarkdownRESTFallbacks(writer io.Writer) {
+	notes := mv.restFallbackNotes()
+	if len(notes) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "## Counted with the REST API")
+	fmt.Fprintln(writer)
+	for _, note := range notes {
+		fmt.Fprintf(writer, "- %s\n", note)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "GraphQL queries for this data failed. Search API counts can miss items created in the last minutes.")
+}
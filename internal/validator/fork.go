@@ -0,0 +1,30 @@
+package validator
+
+// forkMetric is the metric name of the fork relationship report
+const forkMetric = "Fork Relationship"
+
+// forkResult reports the fork parent of both repositories as an INFO row when either is a fork. Fork
+// relationships are not migrated: the target is a standalone repository, so teams migrating a fork network
+// have to plan re-forking on the target.
+func (mv *MigrationValidator) forkResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.ForkParent, mv.TargetData.ForkParent
+	if source == "" && target == "" {
+		return ValidationResult{}, false
+	}
+
+	return ValidationResult{
+		Metric:     forkMetric,
+		SourceVal:  formatForkParent(source),
+		TargetVal:  formatForkParent(target),
+		Status:     ValidationStatusMessageInfo,
+		StatusType: ValidationStatusInfo,
+	}, true
+}
+
+// formatForkParent returns the display value of a fork parent
+func formatForkParent(parent string) string {
+	if parent == "" {
+		return "Not a fork"
+	}
+	return "Fork of " + parent
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"time"
+)
+
+// commitIdentitiesMetric is the metric name of the commit author and date comparison of --rewritten-history
+var commitIdentitiesMetric = fmt.Sprintf("Commit Authors and Dates (last %d commits)", api.HistorySampleSize)
+
+// rewrittenHistoryNote explains why a SHA mismatch is reported as INFO with --rewritten-history
+const rewrittenHistoryNote = "history rewritten, SHAs are expected to differ"
+
+// commitIdentitiesResult compares the authors and authored dates of the most recent default branch commits in
+// order, which a history rewrite keeps while changing every SHA. Returns false when either side was not
+// retrieved, i.e. without --rewritten-history or for empty repositories.
+func (mv *MigrationValidator) commitIdentitiesResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.CommitIdentities, mv.TargetData.CommitIdentities
+	if source == nil || target == nil {
+		return ValidationResult{}, false
+	}
+
+	result := ValidationResult{
+		Metric:     commitIdentitiesMetric,
+		SourceVal:  fmt.Sprintf("%d commits", len(source)),
+		TargetVal:  fmt.Sprintf("%d commits", len(target)),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+	}
+
+	// Commits missing from the shorter sequence count as differences
+	first := -1
+	for i := 0; i < max(len(source), len(target)); i++ {
+		if i < len(source) && i < len(target) && source[i] == target[i] {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		result.Difference++
+	}
+	if first >= 0 {
+		result.Status, result.StatusType = ValidationStatusMessageFail, ValidationStatusFail
+		result.Note = fmt.Sprintf("first difference at commit %d: %s vs %s", first+1,
+			formatCommitIdentity(source, first), formatCommitIdentity(target, first))
+	}
+	return result, true
+}
+
+// formatCommitIdentity returns the author and date of the commit at index i, e.g. "Mona <mona@example.com> 2025-03-01T09:00:00Z"
+func formatCommitIdentity(commits []api.CommitIdentity, i int) string {
+	if i >= len(commits) {
+		return "no commit"
+	}
+	commit := commits[i]
+	return fmt.Sprintf("%s <%s> %s", commit.AuthorName, commit.AuthorEmail, commit.AuthoredDate.Format(time.RFC3339))
+}
+
+// squashedImportMetric is the metric name of the finding of a squashed or shallow import
+const squashedImportMetric = "Squashed or Shallow Import"
+
+// squashedImportMaxShare is the share of the source commits, in percent, below which a target with the same
+// tree is reported as a squashed or shallow import
+const squashedImportMaxShare = 50
+
+// squashedImportResult reports a target whose default branch has the contents of the source with far fewer
+// commits, the pattern of an import that squashed the history or cloned it shallow rather than of commits
+// missing from an incomplete push. Returns false when the pattern does not apply.
+func (mv *MigrationValidator) squashedImportResult() (ValidationResult, bool) {
+	source, target := mv.SourceData, mv.TargetData
+	if source.TreeSHA == "" || source.TreeSHA != target.TreeSHA || target.CommitCount*100 >= source.CommitCount*squashedImportMaxShare {
+		return ValidationResult{}, false
+	}
+
+	return ValidationResult{
+		Metric:     squashedImportMetric,
+		SourceVal:  fmt.Sprintf("%d commits", source.CommitCount),
+		TargetVal:  fmt.Sprintf("%d commits, same tree", target.CommitCount),
+		Status:     ValidationStatusMessageFail,
+		StatusType: ValidationStatusFail,
+		Difference: source.CommitCount - target.CommitCount,
+		Note:       "the target has the contents of the source without its history",
+	}, true
+}
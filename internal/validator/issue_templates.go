@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// issueTemplatesMetric is the metric name of the issue templates comparison
+const issueTemplatesMetric = "Issue Templates"
+
+// issueTemplatesResult compares the files of .github/ISSUE_TEMPLATE on both default branches by name and git
+// blob SHA, a quick check that repository contents were migrated beyond counts. Missing or changed templates
+// fail, templates only in the target are a warning. Returns false when either side was not retrieved or
+// neither has issue templates.
+func (mv *MigrationValidator) issueTemplatesResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.IssueTemplates, mv.TargetData.IssueTemplates
+	if source == nil || target == nil || (len(source) == 0 && len(target) == 0) {
+		return ValidationResult{}, false
+	}
+
+	targetSHAs := make(map[string]string, len(target))
+	for _, file := range target {
+		targetSHAs[file.Name] = file.SHA
+	}
+	sourceNames := make(map[string]bool, len(source))
+	var missing, changed, extra []string
+	for _, file := range source {
+		sourceNames[file.Name] = true
+		sha, ok := targetSHAs[file.Name]
+		switch {
+		case !ok:
+			missing = append(missing, file.Name)
+		case sha != file.SHA:
+			changed = append(changed, file.Name)
+		}
+	}
+	for _, file := range target {
+		if !sourceNames[file.Name] {
+			extra = append(extra, file.Name)
+		}
+	}
+
+	result := ValidationResult{
+		Metric:     issueTemplatesMetric,
+		SourceVal:  formatTemplateCount(source),
+		TargetVal:  formatTemplateCount(target),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: len(missing),
+	}
+	if len(missing) == 0 {
+		result.Difference = -len(extra)
+	}
+
+	var notes []string
+	if len(missing) > 0 {
+		notes = append(notes, "missing: "+strings.Join(missing, ", "))
+	}
+	if len(changed) > 0 {
+		notes = append(notes, "changed: "+strings.Join(changed, ", "))
+	}
+	if len(extra) > 0 {
+		notes = append(notes, "extra: "+strings.Join(extra, ", "))
+	}
+	result.Note = strings.Join(notes, "; ")
+
+	switch {
+	case len(missing) > 0 || len(changed) > 0:
+		result.Status, result.StatusType = ValidationStatusMessageFail, ValidationStatusFail
+	case len(extra) > 0:
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result, true
+}
+
+// formatTemplateCount returns the display value of the issue templates of a repository
+func formatTemplateCount(templates []api.TemplateFile) string {
+	if len(templates) == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", len(templates))
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// jsonReport is the JSON representation of the validation report of a repository
+type jsonReport struct {
+	Source      string       `json:"source"`
+	Target      string       `json:"target"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Summary     *Summary     `json:"summary,omitempty"` // Omitted when the validation failed to run
+	Results     []jsonResult `json:"results"`
+	Error       string       `json:"error,omitempty"`
+	ErrorCode   string       `json:"error_code,omitempty"` // Kind of the error: auth, not_found, rate_limited, partial_data or error
+}
+
+// jsonResult is the JSON representation of a validation result
+type jsonResult struct {
+	// Section of the report, matching the tables of the terminal output: source_vs_target, archive_vs_source,
+	// archive_vs_target, migration_log_vs_target, branches or security
+	Section    string      `json:"section"`
+	Metric     string      `json:"metric"`
+	Source     interface{} `json:"source"`
+	Target     interface{} `json:"target"`
+	Status     string      `json:"status"` // pass, fail, warn, info or unavailable
+	Difference int         `json:"difference"`
+	Note       string      `json:"note,omitempty"`
+}
+
+// newJSONResult returns the JSON representation of result
+func newJSONResult(result ValidationResult) jsonResult {
+	return jsonResult{
+		Section:    resultSection(result),
+		Metric:     result.Metric,
+		Source:     result.SourceVal,
+		Target:     result.TargetVal,
+		Status:     strings.ToLower(result.StatusType.String()),
+		Difference: result.Difference,
+		Note:       result.Note,
+	}
+}
+
+// JSONReport returns the source, target, summary and results of the validation as indented JSON
+func (mv *MigrationValidator) JSONReport(results []ValidationResult) ([]byte, error) {
+	return json.MarshalIndent(newJSONReport(mv.RepositoryReport(results)), "", "  ")
+}
+
+// JSONLine returns the report as a single line of JSON, without a trailing newline, for NDJSON output
+func (r RepositoryReport) JSONLine() ([]byte, error) {
+	return json.Marshal(newJSONReport(r))
+}
+
+// newJSONReport returns the JSON representation of the validation of a repository
+func newJSONReport(r RepositoryReport) jsonReport {
+	report := jsonReport{
+		Source:      r.Source,
+		Target:      r.Target,
+		GeneratedAt: time.Now().UTC(),
+		Results:     make([]jsonResult, 0, len(r.Results)),
+	}
+	if r.Err != nil {
+		report.Error = r.Err.Error()
+		report.ErrorCode = api.ErrorCode(r.Err)
+		return report
+	}
+
+	summary := r.Summary
+	report.Summary = &summary
+	for _, result := range r.Results {
+		report.Results = append(report.Results, newJSONResult(result))
+	}
+	return report
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONReport(t *testing.T) {
+	mv := setupTestValidator(
+		&RepositoryData{Owner: "source-org", Name: "repo"},
+		&RepositoryData{Owner: "target-org", Name: "repo"},
+	)
+	results := []ValidationResult{
+		{Metric: "Issues", SourceVal: 10, TargetVal: 8, StatusType: ValidationStatusFail, Difference: 2},
+		{Metric: "Releases", SourceVal: 3, TargetVal: 0, StatusType: ValidationStatusInfo, Difference: 3, Note: "known difference: releases are copied separately"},
+		{Metric: "Archive vs Source Issues", SourceVal: 10, TargetVal: 10, StatusType: ValidationStatusPass},
+		{Metric: "Archive vs Target Issues", SourceVal: 10, TargetVal: 8, StatusType: ValidationStatusFail, Difference: 2},
+	}
+
+	data, err := mv.JSONReport(results)
+	require.NoError(t, err)
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "source-org/repo", report["source"])
+	assert.Equal(t, "target-org/repo", report["target"])
+	assert.NotEmpty(t, report["generated_at"])
+	assert.Equal(t, map[string]interface{}{
+		"passed": 1.0, "failed": 2.0, "warnings": 0.0, "info": 1.0, "unavailable": 0.0, "verdict": "failed",
+	}, report["summary"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"section": "source_vs_target", "metric": "Issues", "source": 10.0, "target": 8.0, "status": "fail", "difference": 2.0},
+		map[string]interface{}{"section": "source_vs_target", "metric": "Releases", "source": 3.0, "target": 0.0, "status": "info", "difference": 3.0,
+			"note": "known difference: releases are copied separately"},
+		map[string]interface{}{"section": "archive_vs_source", "metric": "Archive vs Source Issues", "source": 10.0, "target": 10.0, "status": "pass", "difference": 0.0},
+		map[string]interface{}{"section": "archive_vs_target", "metric": "Archive vs Target Issues", "source": 10.0, "target": 8.0, "status": "fail", "difference": 2.0},
+	}, report["results"])
+}
+
+func TestRepositoryReport_JSONLine(t *testing.T) {
+	mv := setupTestValidator(
+		&RepositoryData{Owner: "source-org", Name: "repo"},
+		&RepositoryData{Owner: "target-org", Name: "repo"},
+	)
+	validated := mv.RepositoryReport([]ValidationResult{
+		{Metric: "Issues", SourceVal: 10, TargetVal: 10, StatusType: ValidationStatusPass},
+	})
+	failed := RepositoryReport{Source: "source-org/other", Target: "target-org/other",
+		Err: fmt.Errorf("target repository not found: %w", api.ErrNotFound)}
+
+	line, err := validated.JSONLine()
+	require.NoError(t, err)
+	assert.NotContains(t, string(line), "\n")
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(line, &report))
+	assert.Equal(t, "source-org/repo", report["source"])
+	assert.Equal(t, "passed", report["summary"].(map[string]interface{})["verdict"])
+	assert.Len(t, report["results"], 1)
+	assert.NotContains(t, report, "error")
+
+	line, err = failed.JSONLine()
+	require.NoError(t, err)
+
+	report = nil
+	require.NoError(t, json.Unmarshal(line, &report))
+	assert.Equal(t, "target-org/other", report["target"])
+	assert.Equal(t, "target repository not found: not found", report["error"])
+	assert.Equal(t, "not_found", report["error_code"])
+	assert.NotContains(t, report, "summary")
+	assert.Equal(t, []interface{}{}, report["results"])
+}
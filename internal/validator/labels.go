@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultStatusLabels are the labels reports display for each status
+var defaultStatusLabels = map[ValidationStatus]string{
+	ValidationStatusPass:        ValidationStatusMessagePass,
+	ValidationStatusFail:        ValidationStatusMessageFail,
+	ValidationStatusWarn:        ValidationStatusMessageWarn,
+	ValidationStatusInfo:        ValidationStatusMessageInfo,
+	ValidationStatusUnavailable: ValidationStatusMessageUnavailable,
+}
+
+// plainStatusLabels are the ASCII labels displayed with --plain
+var plainStatusLabels = map[ValidationStatus]string{
+	ValidationStatusPass:        "PASS",
+	ValidationStatusFail:        "FAIL",
+	ValidationStatusWarn:        "WARN",
+	ValidationStatusInfo:        "INFO",
+	ValidationStatusUnavailable: "TARGET UNAVAILABLE",
+}
+
+// statusLabels are the labels selected with SetStatusLabels
+var statusLabels = defaultStatusLabels
+
+// SetStatusLabels selects the labels reports display for each status: the ASCII labels when plain is set,
+// with overrides keyed by lowercase status name (pass, fail, warn, info or unavailable) replacing either
+func SetStatusLabels(plain bool, overrides map[string]string) error {
+	base := defaultStatusLabels
+	if plain {
+		base = plainStatusLabels
+	}
+
+	labels := make(map[ValidationStatus]string, len(base))
+	for status, label := range base {
+		labels[status] = label
+	}
+
+	for key, label := range overrides {
+		status, ok := parseStatus(key)
+		if !ok {
+			return fmt.Errorf("unknown status %q in labels (valid: %s)", key, strings.Join(statusKeys(), ", "))
+		}
+		labels[status] = label
+	}
+
+	statusLabels = labels
+	return nil
+}
+
+// Label returns the label reports display for the status
+func (s ValidationStatus) Label() string {
+	if label, ok := statusLabels[s]; ok {
+		return label
+	}
+	return s.String()
+}
+
+// parseStatus returns the status named by a lowercase label key
+func parseStatus(key string) (ValidationStatus, bool) {
+	for status := range defaultStatusLabels {
+		if strings.EqualFold(status.String(), key) {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// statusKeys returns the label keys of every status, sorted
+func statusKeys() []string {
+	keys := make([]string, 0, len(defaultStatusLabels))
+	for status := range defaultStatusLabels {
+		keys = append(keys, strings.ToLower(status.String()))
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetStatusLabels(t *testing.T) {
+	t.Cleanup(func() { SetStatusLabels(false, nil) })
+
+	assert.Equal(t, "✅ PASS", ValidationStatusPass.Label())
+
+	require.NoError(t, SetStatusLabels(true, nil))
+	assert.Equal(t, "PASS", ValidationStatusPass.Label())
+	assert.Equal(t, "TARGET UNAVAILABLE", ValidationStatusUnavailable.Label())
+
+	require.NoError(t, SetStatusLabels(true, map[string]string{"fail": "MISSING", "Warn": "CHECK"}))
+	assert.Equal(t, "MISSING", ValidationStatusFail.Label())
+	assert.Equal(t, "CHECK", ValidationStatusWarn.Label())
+	assert.Equal(t, "INFO", ValidationStatusInfo.Label(), "labels that are not overridden keep their plain value")
+
+	err := SetStatusLabels(false, map[string]string{"ok": "OK"})
+	assert.EqualError(t, err, `unknown status "ok" in labels (valid: fail, info, pass, unavailable, warn)`)
+	assert.Equal(t, "MISSING", ValidationStatusFail.Label(), "invalid labels are not applied")
+}
+
+func TestMarkdownReport_StatusLabels(t *testing.T) {
+	t.Cleanup(func() { SetStatusLabels(false, nil) })
+	require.NoError(t, SetStatusLabels(true, map[string]string{"pass": "OK"}))
+
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{Owner: "source-org", Name: "repo"}
+	mv.TargetData = &RepositoryData{Owner: "target-org", Name: "repo"}
+
+	var buffer bytes.Buffer
+	mv.printMarkdownTable([]ValidationResult{countResult("Tags", 2, 2), countResult("Releases", 3, 1)},
+		markdownOutputOptions{writer: &buffer})
+
+	assert.Contains(t, buffer.String(), "| Tags | OK | 2 | 2 | Perfect match |")
+	assert.Contains(t, buffer.String(), "| Releases | FAIL | 3 | 1 | Missing: 2 |")
+}
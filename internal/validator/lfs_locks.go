@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// lfsLocksMetric is the metric name of the LFS locks comparison
+const lfsLocksMetric = "LFS Locks"
+
+// maxListedLockPaths is the number of missing lock paths listed in the target value
+const maxListedLockPaths = 5
+
+// lfsLocksResult compares the paths of the LFS file locks with --check-lfs-locks. Locks are not migrated, so
+// teams using locking workflows have to recreate them; locks missing in the target are a warning listing their
+// paths. Returns false when either side was not retrieved or neither has locks.
+func (mv *MigrationValidator) lfsLocksResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.LFSLocks, mv.TargetData.LFSLocks
+	if source == nil || target == nil || (len(source) == 0 && len(target) == 0) {
+		return ValidationResult{}, false
+	}
+
+	missing := patternsNotIn(source, target)
+	result := ValidationResult{
+		Metric:     lfsLocksMetric,
+		SourceVal:  len(source),
+		TargetVal:  len(target),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: len(missing),
+	}
+	if len(missing) > 0 {
+		result.TargetVal = fmt.Sprintf("%d (missing: %s)", len(target), formatLockPaths(missing))
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	} else if extra := len(patternsNotIn(target, source)); extra > 0 {
+		result.Difference = -extra
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result, true
+}
+
+// formatLockPaths returns the first sorted paths as a comma-separated list, with the number of the others
+func formatLockPaths(paths []string) string {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	if len(sorted) <= maxListedLockPaths {
+		return strings.Join(sorted, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(sorted[:maxListedLockPaths], ", "), len(sorted)-maxListedLockPaths)
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+)
+
+// lfsPatternsMetric is the metric name of the LFS-tracked patterns comparison
+const lfsPatternsMetric = "LFS Tracked Patterns"
+
+// lfsPatternsResult compares the LFS-tracked patterns declared in .gitattributes on both default branches.
+// Divergent patterns usually mean the repository took the wrong LFS migration path, for example a history
+// rewrite by git lfs migrate, so a difference is a warning. Returns false when either side was not retrieved
+// or neither tracks anything with LFS.
+func (mv *MigrationValidator) lfsPatternsResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.LFSPatterns, mv.TargetData.LFSPatterns
+	if source == nil || target == nil || (len(source) == 0 && len(target) == 0) {
+		return ValidationResult{}, false
+	}
+
+	missing := len(patternsNotIn(source, target))
+	extra := len(patternsNotIn(target, source))
+
+	result := ValidationResult{
+		Metric:     lfsPatternsMetric,
+		SourceVal:  formatPatterns(source),
+		TargetVal:  formatPatterns(target),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: missing,
+	}
+	if missing == 0 {
+		result.Difference = -extra
+	}
+	if missing > 0 || extra > 0 {
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result, true
+}
+
+// patternsNotIn returns the patterns of a that are not in b
+func patternsNotIn(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, pattern := range b {
+		present[pattern] = true
+	}
+
+	var absent []string
+	for _, pattern := range a {
+		if !present[pattern] {
+			absent = append(absent, pattern)
+		}
+	}
+	return absent
+}
+
+// formatPatterns returns the sorted patterns as a comma-separated list, or "None"
+func formatPatterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return "None"
+	}
+
+	sorted := append([]string(nil), patterns...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
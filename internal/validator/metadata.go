@@ -0,0 +1,57 @@
+package validator
+
+// Metric names of the repository metadata comparison
+const (
+	descriptionMetric = "Description"
+	homepageMetric    = "Homepage URL"
+	topicsMetric      = "Topics"
+)
+
+// metadataResults compares the description, homepage URL and topics of both repositories. GitHub Enterprise
+// Importer does not migrate them, and teams rely on them to find repositories, so differences are warnings.
+// Returns nothing when the metadata of either side was not retrieved.
+func (mv *MigrationValidator) metadataResults() []ValidationResult {
+	source, target := mv.SourceData.Metadata, mv.TargetData.Metadata
+	if source == nil || target == nil {
+		return nil
+	}
+
+	missing := len(patternsNotIn(source.Topics, target.Topics))
+	extra := len(patternsNotIn(target.Topics, source.Topics))
+	topics := advisoryTextResult(topicsMetric, formatPatterns(source.Topics), formatPatterns(target.Topics), missing > 0 || extra > 0)
+	topics.Difference = missing
+	if missing == 0 {
+		topics.Difference = -extra
+	}
+
+	return []ValidationResult{
+		advisoryTextResult(descriptionMetric, formatMetadataValue(source.Description), formatMetadataValue(target.Description),
+			source.Description != target.Description),
+		advisoryTextResult(homepageMetric, formatMetadataValue(source.HomepageURL), formatMetadataValue(target.HomepageURL),
+			source.HomepageURL != target.HomepageURL),
+		topics,
+	}
+}
+
+// advisoryTextResult returns a passing result, or a warning when the values differ
+func advisoryTextResult(metric, sourceVal, targetVal string, differs bool) ValidationResult {
+	result := ValidationResult{
+		Metric:     metric,
+		SourceVal:  sourceVal,
+		TargetVal:  targetVal,
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+	}
+	if differs {
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result
+}
+
+// formatMetadataValue returns the display value of a description or homepage URL, "None" when it is not set
+func formatMetadataValue(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
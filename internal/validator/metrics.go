@@ -0,0 +1,564 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/migrationlog"
+	"mona-actions/gh-migration-validator/internal/output"
+	"time"
+)
+
+// repositoryFetch describes a request retrieving data of a repository. Both sides make every request that
+// applies to them, in registry order, and a failed request leaves its data at the zero value.
+type repositoryFetch struct {
+	data     string                                          // What is fetched, as named in timings and failed requests
+	progress string                                          // Progress message, formatted with the owner and name
+	applies  func(mv *MigrationValidator, r *retrieval) bool // Nil when the request is always made
+	fetch    func(mv *MigrationValidator, r *retrieval) error
+}
+
+// retrieval is the state of the retrieval of the data of one repository
+type retrieval struct {
+	clientType              api.ClientType
+	owner, name             string
+	sourceOwner, sourceName string // Source repository compared with, as SourceData is written concurrently
+	data                    *RepositoryData
+	failedRequests          []string
+	requestErrors           []error
+	errorMessages           []string
+	successfulRequests      int
+	spinner                 output.Spinner // Shows the progress of the retrieval, when set
+}
+
+// treeProgress shows the tree entries of owner/name walked so far in the spinner of the retrieval
+func (r *retrieval) treeProgress(owner, name string) api.TreeProgressFunc {
+	if r.spinner == nil {
+		return nil
+	}
+	return func(entries int) {
+		r.spinner.UpdateText(fmt.Sprintf("Fetching LFS objects from %s/%s... (%d tree entries walked)", owner, name, entries))
+	}
+}
+
+// repositoryFetches are the requests retrieving repository data. Adding a compared count is one entry here,
+// one in repositoryMetrics and the API method fetching it.
+var repositoryFetches = []repositoryFetch{
+	{
+		data:     "repository contents",
+		progress: "Checking repository contents of %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.IsEmpty, err = mv.api.IsRepositoryEmpty(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "issues",
+		progress: "Fetching issues from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Issues, err = mv.api.GetIssueCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "pull requests",
+		progress: "Fetching pull requests from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) error {
+			prCounts, err := mv.api.GetPRCounts(mv.traceContext(), r.clientType, r.owner, r.name)
+			if err != nil {
+				prCounts = &api.PRCounts{}
+			}
+			r.data.PRs = prCounts
+			if err == nil && mv.options.CountPRsAsIssues {
+				r.data.Issues += prCounts.Total
+				r.data.IssuesIncludePRs = true
+			}
+			return err
+		},
+	},
+	{
+		data:     "tags",
+		progress: "Fetching tags from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Tags, err = mv.api.GetTagCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "branch count",
+		progress: "Fetching branches from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.BranchCount, err = mv.api.GetBranchCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "releases",
+		progress: "Fetching releases from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Releases, err = mv.api.GetReleaseCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "commit comments",
+		progress: "Fetching commit comments from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.CommitComments, err = mv.api.GetCommitCommentCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "commits",
+		progress: "Fetching commit count from %s/%s...",
+		applies:  hasCommits,
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.CommitCount, err = mv.api.GetCommitCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "latest commit hash",
+		progress: "Fetching latest commit hash from %s/%s...",
+		applies:  hasCommits,
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.LatestCommitSHA, err = mv.api.GetLatestCommitHash(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "tree hash",
+		progress: "Fetching default branch tree hash from %s/%s...",
+		applies:  hasCommits,
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.TreeSHA, err = mv.api.GetTreeHash(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "commit history",
+		progress: "Fetching commit authors and dates from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return hasCommits(mv, r) && mv.options.RewrittenHistory
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.CommitIdentities, err = mv.api.GetCommitIdentities(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "commit signatures",
+		progress: "Fetching commit signatures from %s/%s...",
+		applies:  hasCommits,
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.CommitSignatures, err = mv.api.GetSignatureStats(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "branch protection rules",
+		progress: "Fetching branch protection rules from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.BranchProtectionRules, err = mv.api.GetBranchProtectionRulesCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "protected tag rules",
+		progress: "Fetching protected tag rules from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.ProtectedTagRules, err = mv.api.GetProtectedTagRuleCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "webhooks",
+		progress: "Fetching webhooks from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Webhooks, err = mv.api.GetWebhookCount(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "Pages configuration",
+		progress: "Fetching Pages configuration from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Pages, err = mv.api.GetPagesConfig(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "issue templates",
+		progress: "Fetching issue templates from %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.IssueTemplates, err = mv.api.GetIssueTemplates(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "repository metadata",
+		progress: "Fetching description and topics of %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Metadata, err = mv.api.GetRepositoryMetadata(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		data:     "fork parent",
+		progress: "Fetching fork parent of %s/%s...",
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.ForkParent, err = mv.api.GetForkParent(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		// Only the target has the migration log issue created by GitHub Enterprise Importer
+		data:     "migration log issue",
+		progress: "Fetching migration log issue from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return isTarget(mv, r) && !mv.options.TransferMode
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) error {
+			issue, err := mv.api.GetMigrationLogIssue(mv.traceContext(), r.clientType, r.owner, r.name)
+			if err == nil {
+				r.data.MigrationLog = migrationlog.New(issue)
+			}
+			return err
+		},
+	},
+	{
+		// A transferred repository stays reachable under its old name through a redirect
+		data:     "transfer redirect",
+		progress: "Checking the redirect to %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return isTarget(mv, r) && mv.options.TransferMode
+		},
+		fetch: (*MigrationValidator).fetchTransferRedirect,
+	},
+	{
+		data:     "LFS objects",
+		progress: "Fetching LFS objects from %s/%s...",
+		applies:  lfsEnabled,
+		fetch: func(mv *MigrationValidator, r *retrieval) error {
+			if r.clientType == api.TargetClient {
+				return mv.fetchTargetLFSObjects(r)
+			}
+			if mv.api.LFSServerConfigured(r.clientType) {
+				return mv.fetchStoredSourceLFSObjects(r)
+			}
+			objects, err := mv.api.GetLFSObjects(mv.traceContext(), r.clientType, r.owner, r.name, r.treeProgress(r.owner, r.name))
+			r.data.LFSObjects = len(objects)
+			return err
+		},
+	},
+	{
+		data:     "LFS locks",
+		progress: "Fetching LFS locks from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return mv.options.CheckLFSLocks && lfsEnabled(mv, r)
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) error {
+			locks, err := mv.api.GetLFSLocks(mv.traceContext(), r.clientType, r.owner, r.name)
+			if err != nil {
+				return err
+			}
+			r.data.LFSLocks = make([]string, 0, len(locks))
+			for _, lock := range locks {
+				r.data.LFSLocks = append(r.data.LFSLocks, lock.Path)
+			}
+			return nil
+		},
+	},
+	{
+		data:     "LFS patterns",
+		progress: "Fetching LFS patterns from %s/%s...",
+		applies:  lfsEnabled,
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.LFSPatterns, err = mv.api.GetLFSPatterns(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		// Every source body is read with --check-truncation; the target only reads those of the longest source
+		// bodies, once the source is retrieved, in retrieveTargetBodyLengths
+		data:     bodiesData,
+		progress: "Fetching issue and pull request bodies from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return r.clientType == api.SourceClient && mv.options.CheckTruncation > 0
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.LargestBodies, err = mv.api.GetLargestBodies(mv.traceContext(), r.clientType, r.owner, r.name, mv.options.CheckTruncation)
+			return err
+		},
+	},
+	{
+		data:     "branches",
+		progress: "Fetching branches from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			_, _, ok := mv.selectedBranches()
+			return ok
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Branches, err = mv.api.GetBranches(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+	{
+		// Alerts need the security_events scope, so security features are only fetched with --check-security
+		data:     "security features",
+		progress: "Fetching security features from %s/%s...",
+		applies: func(mv *MigrationValidator, r *retrieval) bool {
+			return mv.options.CheckSecurity
+		},
+		fetch: func(mv *MigrationValidator, r *retrieval) (err error) {
+			r.data.Security, err = mv.api.GetSecurityStatus(mv.traceContext(), r.clientType, r.owner, r.name)
+			return err
+		},
+	},
+}
+
+// hasCommits reports whether the repository has commits, as empty repositories have no default branch to query
+func hasCommits(mv *MigrationValidator, r *retrieval) bool {
+	return !r.data.IsEmpty
+}
+
+// isTarget reports whether the target repository is retrieved
+func isTarget(mv *MigrationValidator, r *retrieval) bool {
+	return r.clientType == api.TargetClient
+}
+
+// lfsEnabled reports whether LFS data is fetched, which the NoLFS option skips
+func lfsEnabled(mv *MigrationValidator, r *retrieval) bool {
+	return !mv.options.NoLFS
+}
+
+// fetchTargetLFSObjects counts the source LFS objects present in the target LFS storage, or every target LFS
+// object when the source objects cannot be listed
+func (mv *MigrationValidator) fetchTargetLFSObjects(r *retrieval) error {
+	sourceLFSObjects, sourceErr := mv.api.GetLFSObjects(mv.traceContext(), api.SourceClient, r.sourceOwner, r.sourceName,
+		r.treeProgress(r.sourceOwner, r.sourceName))
+	if sourceErr != nil {
+		count, err := mv.api.GetLFSObjectCount(mv.traceContext(), r.clientType, r.owner, r.name, r.treeProgress(r.owner, r.name))
+		r.data.LFSObjects = count
+		return err
+	}
+	if len(sourceLFSObjects) == 0 {
+		return nil
+	}
+
+	existingCount, missingCount, err := mv.api.ValidateLFSObjects(mv.traceContext(), r.clientType, r.owner, r.name, sourceLFSObjects)
+	var batchErr *api.LFSBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return err
+	}
+
+	// Only count the objects that actually exist in target LFS storage, including those of the chunks checked
+	// when other chunks failed
+	r.data.LFSObjects = existingCount
+	if missingCount > 0 {
+		r.errorMessages = append(r.errorMessages, fmt.Sprintf("LFS objects: %d found, %d missing from LFS storage", existingCount, missingCount))
+	}
+	return err
+}
+
+// fetchStoredSourceLFSObjects counts the source LFS objects stored on the standalone source LFS server, so
+// objects that were never uploaded there are not expected in the target
+func (mv *MigrationValidator) fetchStoredSourceLFSObjects(r *retrieval) error {
+	objects, err := mv.api.GetLFSObjects(mv.traceContext(), r.clientType, r.owner, r.name, r.treeProgress(r.owner, r.name))
+	if err != nil || len(objects) == 0 {
+		return err
+	}
+
+	existingCount, missingCount, err := mv.api.ValidateLFSObjects(mv.traceContext(), r.clientType, r.owner, r.name, objects)
+	var batchErr *api.LFSBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return err
+	}
+
+	r.data.LFSObjects = existingCount
+	if missingCount > 0 {
+		r.errorMessages = append(r.errorMessages, fmt.Sprintf("LFS objects: %d referenced, %d missing from the source LFS server", existingCount+missingCount, missingCount))
+	}
+	return err
+}
+
+// retrieve fetches the data of a repository into data, making every request of repositoryFetches that
+// applies. sourceOwner and sourceName name the source repository the target is compared with. Failed requests are logged and leave their data at the zero value; an error is only returned
+// when every request failed or the token is not authorized for SAML single sign-on.
+func (mv *MigrationValidator) retrieve(clientType api.ClientType, owner, name, sourceOwner, sourceName string, data *RepositoryData, spinner output.Spinner) ([]RequestFailure, []string, error) {
+	startTime := time.Now()
+	timer := mv.newMetricTimer(clientType)
+
+	// Data of an earlier retrieval is cleared so data that is not fetched is not compared
+	*data = RepositoryData{Owner: owner, Name: name}
+	r := &retrieval{clientType: clientType, owner: owner, name: name, sourceOwner: sourceOwner, sourceName: sourceName, data: data, spinner: spinner}
+
+	for _, fetch := range repositoryFetches {
+		if fetch.applies != nil && !fetch.applies(mv, r) {
+			continue
+		}
+		// A validation that timed out stops once its current request returns
+		if err := mv.traceContext().Err(); err != nil {
+			spinner.Fail(fmt.Sprintf("%s/%s: validation cancelled", owner, name))
+			return nil, r.errorMessages, err
+		}
+
+		spinner.UpdateText(fmt.Sprintf(fetch.progress, owner, name))
+		fallbacks := mv.api.RESTFallbackCount(clientType)
+		timer.Start(fetch.data)
+		err := fetch.fetch(mv, r)
+		timer.Stop(err)
+		if mv.api.RESTFallbackCount(clientType) > fallbacks {
+			data.RESTFallbacks = append(data.RESTFallbacks, fetch.data)
+		}
+		if err != nil {
+			r.failedRequests = append(r.failedRequests, fetch.data)
+			r.requestErrors = append(r.requestErrors, err)
+			r.errorMessages = append(r.errorMessages, fmt.Sprintf("%s: %v", fetch.data, err))
+		} else {
+			r.successfulRequests++
+		}
+	}
+
+	duration := time.Since(startTime)
+
+	// Stop instead of reporting zero counts when the token is not authorized for SAML single sign-on
+	if err := mv.ssoError(clientType, owner, r.requestErrors); err != nil {
+		spinner.Fail(fmt.Sprintf("%s/%s: the token is not authorized for SAML single sign-on", owner, name))
+		return nil, r.errorMessages, err
+	}
+
+	// Determine success/failure status
+	if r.successfulRequests == 0 {
+		spinner.Fail(fmt.Sprintf("Failed to retrieve any data from %s/%s", owner, name))
+		return nil, r.errorMessages, allRequestsFailedError(owner, name, r.requestErrors)
+	}
+	failures := requestFailures(timer.side, r.failedRequests, r.requestErrors)
+	if len(failures) > 0 {
+		spinner.Warning(fmt.Sprintf("%s/%s: %d OK, %d failed (%v) - missing: %s",
+			owner, name, r.successfulRequests, len(failures), duration, describeFailures(failures)))
+	} else {
+		spinner.Success(fmt.Sprintf("%s/%s retrieved successfully (%v)", owner, name, duration))
+	}
+
+	return failures, r.errorMessages, nil
+}
+
+// repositoryMetric describes a row of the comparison of the source and target repositories: a count read
+// from the data of both sides, or rows computed by the validator
+type repositoryMetric struct {
+	name    string                                          // Metric name in the report
+	value   func(data *RepositoryData) int                  // Count compared between the source and the target
+	offset  func(mv *MigrationValidator) int                // Additional items expected in the target, noted in the name
+	applies func(mv *MigrationValidator) bool               // Nil when the metric is always compared
+	results func(mv *MigrationValidator) []ValidationResult // Rows computed instead of a count
+
+	// compare decides the status of the count from its source and target values, countResult when nil.
+	// advisoryResult reports missing items as a warning instead. Comparison rules take precedence.
+	compare compareFunc
+}
+
+// repositoryMetrics are the rows of the comparison, in report order
+var repositoryMetrics = []repositoryMetric{
+	{name: "Issues", value: func(d *RepositoryData) int { return d.Issues }, offset: (*MigrationValidator).issueOffset},
+	{name: "Pull Requests (Total)", value: func(d *RepositoryData) int { return d.PRs.Total }},
+	{name: "Pull Requests (Open)", value: func(d *RepositoryData) int { return d.PRs.Open }},
+	{name: "Pull Requests (Merged)", value: func(d *RepositoryData) int { return d.PRs.Merged }},
+	{name: "Tags", value: func(d *RepositoryData) int { return d.Tags }},
+	{name: "Releases", value: func(d *RepositoryData) int { return d.Releases }},
+	// Empty repositories are reported instead of comparing commit data that does not exist
+	{results: single((*MigrationValidator).repositoryContentResult)},
+	{name: "Commits", value: func(d *RepositoryData) int { return d.CommitCount }, applies: eitherHasCommits},
+	{name: "Commit Comments", value: func(d *RepositoryData) int { return d.CommitComments }},
+	{name: "Branches", value: func(d *RepositoryData) int { return d.BranchCount }},
+	{name: "Branch Protection Rules", value: func(d *RepositoryData) int { return d.BranchProtectionRules }},
+	{name: "Protected Tag Rules", value: func(d *RepositoryData) int { return d.ProtectedTagRules }},
+	{name: "Webhooks", value: func(d *RepositoryData) int { return d.Webhooks }},
+	{name: "LFS Objects", value: func(d *RepositoryData) int { return d.LFSObjects }, applies: comparesLFS},
+	{results: single((*MigrationValidator).lfsPatternsResult), applies: comparesLFS},
+	{results: single((*MigrationValidator).lfsLocksResult), applies: comparesLFS},
+	{results: single((*MigrationValidator).transferRedirectResult)},
+	{results: single((*MigrationValidator).latestCommitResult)},
+	// Trees are equal when contents are, even after a history rewrite changed every commit SHA
+	{results: single((*MigrationValidator).treeHashResult)},
+	// Authors and dates of the latest commits, compared instead of SHAs with --rewritten-history
+	{results: single((*MigrationValidator).commitIdentitiesResult)},
+	// Far fewer commits with the same contents, as after a squashed or shallow import
+	{results: single((*MigrationValidator).squashedImportResult)},
+	{results: single((*MigrationValidator).issueTemplatesResult)},
+	// The share of verified commit signatures drops when signing keys are missing on the target
+	{results: single((*MigrationValidator).signaturesResult)},
+	// Bodies too long to be migrated are truncated
+	{results: single((*MigrationValidator).truncationResult)},
+	// Fork relationships and GitHub Pages are never migrated
+	{results: single((*MigrationValidator).forkResult)},
+	{results: single((*MigrationValidator).pagesResult)},
+	// Description, homepage and topics are not migrated either
+	{results: (*MigrationValidator).metadataResults},
+	// Branches selected with --branches
+	{results: (*MigrationValidator).branchResults},
+	// Security features, an advisory section only present with --check-security
+	{results: (*MigrationValidator).securityResults},
+	{results: (*MigrationValidator).archiveResults},
+	{results: (*MigrationValidator).validateMigrationLog, applies: func(mv *MigrationValidator) bool {
+		return mv.TargetData.MigrationLog != nil
+	}},
+}
+
+// archiveOnlyMetrics are the rows of the comparison when the migration archive is the only source data
+var archiveOnlyMetrics = []repositoryMetric{
+	{results: (*MigrationValidator).archiveResults},
+	{results: (*MigrationValidator).validateMigrationLog, applies: func(mv *MigrationValidator) bool {
+		return mv.TargetData.MigrationLog != nil
+	}},
+}
+
+// single adapts a result that is only reported in some cases to the results of a repositoryMetric
+func single(result func(mv *MigrationValidator) (ValidationResult, bool)) func(mv *MigrationValidator) []ValidationResult {
+	return func(mv *MigrationValidator) []ValidationResult {
+		if result, ok := result(mv); ok {
+			return []ValidationResult{result}
+		}
+		return nil
+	}
+}
+
+// eitherHasCommits reports whether commits can be compared, which they cannot when both repositories are empty
+func eitherHasCommits(mv *MigrationValidator) bool {
+	return !mv.SourceData.IsEmpty || !mv.TargetData.IsEmpty
+}
+
+// comparesLFS reports whether LFS data is compared, which the NoLFS option skips
+func comparesLFS(mv *MigrationValidator) bool {
+	return !mv.options.NoLFS
+}
+
+// compareMetric returns the rows of the metric for the source and target data of mv
+func (mv *MigrationValidator) compareMetric(metric repositoryMetric) []ValidationResult {
+	if metric.applies != nil && !metric.applies(mv) {
+		return nil
+	}
+	if metric.results != nil {
+		return metric.results(mv)
+	}
+
+	compare := mv.comparison(metric.name)
+	if compare == nil {
+		compare = metric.compare
+	}
+	if compare == nil {
+		compare = countResult
+	}
+
+	name, offset := metric.name, 0
+	if metric.offset != nil {
+		offset = metric.offset(mv)
+		name = issueMetricName(name, offset)
+	}
+
+	// The target is expected to have the offset more items than the source, which is displayed without it
+	sourceVal := metric.value(mv.SourceData)
+	result := compare(name, sourceVal+offset, metric.value(mv.TargetData))
+	result.SourceVal = sourceVal
+	return []ValidationResult{result}
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryFetches_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, fetch := range repositoryFetches {
+		assert.NotEmpty(t, fetch.data)
+		assert.Contains(t, fetch.progress, "%s/%s", "%s progress names the repository", fetch.data)
+		assert.NotNil(t, fetch.fetch, fetch.data)
+		assert.False(t, seen[fetch.data], "%s is fetched once", fetch.data)
+		seen[fetch.data] = true
+	}
+}
+
+func TestRepositoryMetrics_Defined(t *testing.T) {
+	for i, metric := range repositoryMetrics {
+		if metric.results == nil {
+			assert.NotEmpty(t, metric.name, "metric %d", i)
+			assert.NotNil(t, metric.value, "%s has a value", metric.name)
+		}
+	}
+}
+
+func TestCompareMetric_Offset(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{Issues: 10, PRs: &api.PRCounts{}}
+	mv.TargetData = &RepositoryData{Issues: 10, PRs: &api.PRCounts{}}
+
+	metric := repositoryMetric{
+		name:   "Issues",
+		value:  func(d *RepositoryData) int { return d.Issues },
+		offset: func(mv *MigrationValidator) int { return 1 },
+	}
+	results := mv.compareMetric(metric)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, issueMetricName("Issues", 1), results[0].Metric)
+	assert.Equal(t, 10, results[0].SourceVal, "the source is displayed without the offset")
+	assert.Equal(t, 1, results[0].Difference, "the target lacks the expected additional item")
+	assert.Equal(t, ValidationStatusFail, results[0].StatusType)
+
+	metric.compare = advisoryResult
+	assert.Equal(t, ValidationStatusWarn, mv.compareMetric(metric)[0].StatusType, "compare sets the severity policy")
+}
+
+func TestCompareMetric_Applies(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{}
+	mv.TargetData = &RepositoryData{}
+
+	metric := repositoryMetric{
+		name:    "Webhooks",
+		value:   func(d *RepositoryData) int { return d.Webhooks },
+		applies: func(mv *MigrationValidator) bool { return false },
+	}
+	assert.Empty(t, mv.compareMetric(metric))
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"sort"
+	"sync"
+)
+
+// missingTeamValue is shown as the target value of a source team that does not exist in the target organization
+const missingTeamValue = "Team missing"
+
+// teamMembersMetricPrefix starts the metric name of the per-team membership comparisons
+const teamMembersMetricPrefix = "Team Members: "
+
+// OrganizationData holds the organization-level metrics compared by an organization migration validation
+type OrganizationData struct {
+	Login        string
+	Repositories int
+	Teams        map[string]int // Direct members of each team, keyed by team slug
+	Webhooks     int
+	Projects     int
+}
+
+// TeamMemberships returns the number of direct team memberships across all teams
+func (data *OrganizationData) TeamMemberships() int {
+	memberships := 0
+	for _, members := range data.Teams {
+		memberships += members
+	}
+	return memberships
+}
+
+// ValidateOrganizationMigration compares the organization-level entities of a GitHub Enterprise Importer
+// organization migration: repositories, teams and their memberships, organization webhooks and projects.
+// Repository contents are validated separately, repository by repository.
+func (mv *MigrationValidator) ValidateOrganizationMigration(sourceOrg, targetOrg string) ([]ValidationResult, error) {
+	fmt.Fprintln(mv.progress(), "Starting organization migration validation...")
+	fmt.Fprintf(mv.progress(), "Source: %s | Target: %s\n", sourceOrg, targetOrg)
+
+	mv.SourceData = &RepositoryData{Name: sourceOrg, PRs: &api.PRCounts{}}
+	mv.TargetData = &RepositoryData{Name: targetOrg, PRs: &api.PRCounts{}}
+
+	spinners, stopSpinners := output.StartSpinners(mv.progress(),
+		fmt.Sprintf("Preparing to retrieve data from %s...", sourceOrg),
+		fmt.Sprintf("Preparing to retrieve data from %s...", targetOrg))
+	sourceSpinner, targetSpinner := spinners[0], spinners[1]
+
+	var wg sync.WaitGroup
+	var sourceData, targetData *OrganizationData
+	var sourceErr, targetErr error
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sourceData, sourceErr = mv.retrieveOrganization(api.SourceClient, sourceOrg, sourceSpinner)
+	}()
+	go func() {
+		defer wg.Done()
+		targetData, targetErr = mv.retrieveOrganization(api.TargetClient, targetOrg, targetSpinner)
+	}()
+	wg.Wait()
+
+	stopSpinners()
+
+	if sourceErr != nil {
+		return nil, fmt.Errorf("failed to retrieve source organization data: %w", sourceErr)
+	}
+	if targetErr != nil {
+		return nil, fmt.Errorf("failed to retrieve target organization data: %w", targetErr)
+	}
+
+	fmt.Fprintln(mv.progress(), "\nValidating organization data...")
+	results := validateOrganizationData(sourceData, targetData)
+
+	fmt.Fprintln(mv.progress(), "Organization migration validation completed!")
+	return results, nil
+}
+
+// retrieveOrganization retrieves the organization-level metrics of org.
+// Unlike repository data, any failed request fails the retrieval: the counts are only meaningful together.
+func (mv *MigrationValidator) retrieveOrganization(clientType api.ClientType, org string, spinner output.Spinner) (*OrganizationData, error) {
+	data := &OrganizationData{Login: org}
+	fail := func(err error) (*OrganizationData, error) {
+		spinner.Fail(fmt.Sprintf("Failed to retrieve data from %s", org))
+		return nil, mv.explainSSO(clientType, org, err)
+	}
+
+	var err error
+
+	spinner.UpdateText(fmt.Sprintf("Fetching repository count from %s...", org))
+	if data.Repositories, err = mv.api.GetOrganizationRepositoryCount(mv.traceContext(), clientType, org); err != nil {
+		return fail(err)
+	}
+
+	spinner.UpdateText(fmt.Sprintf("Fetching teams from %s...", org))
+	if data.Teams, err = mv.api.GetOrganizationTeams(mv.traceContext(), clientType, org); err != nil {
+		return fail(err)
+	}
+
+	spinner.UpdateText(fmt.Sprintf("Fetching webhooks from %s...", org))
+	if data.Webhooks, err = mv.api.GetOrganizationWebhookCount(mv.traceContext(), clientType, org); err != nil {
+		return fail(err)
+	}
+
+	spinner.UpdateText(fmt.Sprintf("Fetching projects from %s...", org))
+	if data.Projects, err = mv.api.GetOrganizationProjectCount(mv.traceContext(), clientType, org); err != nil {
+		return fail(err)
+	}
+
+	spinner.Success(fmt.Sprintf("Organization data retrieved from %s", org))
+	return data, nil
+}
+
+// validateOrganizationData compares the organization metrics, followed by the membership of every
+// source team that is missing in the target or has a different number of members
+func validateOrganizationData(source, target *OrganizationData) []ValidationResult {
+	results := []ValidationResult{
+		countResult("Repositories", source.Repositories, target.Repositories),
+		countResult("Teams", len(source.Teams), len(target.Teams)),
+		countResult("Team Memberships", source.TeamMemberships(), target.TeamMemberships()),
+		countResult("Organization Webhooks", source.Webhooks, target.Webhooks),
+		countResult("Organization Projects", source.Projects, target.Projects),
+	}
+
+	slugs := make([]string, 0, len(source.Teams))
+	for slug := range source.Teams {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		sourceMembers := source.Teams[slug]
+		targetMembers, ok := target.Teams[slug]
+		if !ok {
+			results = append(results, ValidationResult{
+				Metric:     teamMembersMetricPrefix + slug,
+				SourceVal:  sourceMembers,
+				TargetVal:  missingTeamValue,
+				Status:     ValidationStatusMessageFail,
+				StatusType: ValidationStatusFail,
+				Difference: sourceMembers,
+			})
+		} else if sourceMembers != targetMembers {
+			results = append(results, countResult(teamMembersMetricPrefix+slug, sourceMembers, targetMembers))
+		}
+	}
+
+	return results
+}
+
+// PrintOrganizationResults prints a formatted report of an organization migration validation and returns its summary
+func (mv *MigrationValidator) PrintOrganizationResults(results []ValidationResult) Summary {
+	output.Header(output.Heading("🏢 Organization Migration Validation Report"))
+
+	output.Boxes(
+		output.Box{Title: "Source Organization", Text: fmt.Sprintf("Organization: %s", mv.SourceData.Name)},
+		output.Box{Title: "Target Organization", Text: fmt.Sprintf("Organization: %s", mv.TargetData.Name)},
+	)
+
+	fmt.Println()
+	mv.displayValidationTable("🔄 Source vs Target Validation", results)
+	fmt.Println()
+	return mv.displayValidationSummary(results)
+}
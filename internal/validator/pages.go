@@ -0,0 +1,22 @@
+package validator
+
+// pagesMetric is the metric name of the GitHub Pages configuration comparison
+const pagesMetric = "GitHub Pages"
+
+// pagesResult reports the GitHub Pages configuration of both repositories as an INFO row when either has
+// Pages enabled. Pages is never migrated: it has to be configured again on the target, and custom domains
+// need their DNS records moved to the target site.
+func (mv *MigrationValidator) pagesResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.Pages, mv.TargetData.Pages
+	if source == nil && target == nil {
+		return ValidationResult{}, false
+	}
+
+	return ValidationResult{
+		Metric:     pagesMetric,
+		SourceVal:  source.String(),
+		TargetVal:  target.String(),
+		Status:     ValidationStatusMessageInfo,
+		StatusType: ValidationStatusInfo,
+	}, true
+}
@@ -0,0 +1,310 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/output"
+	"strings"
+)
+
+// graphQLCalls is the number of HTTP requests per rate-limit-aware GraphQL query:
+// every query is preceded by a rate limit check
+const graphQLCalls = 2
+
+// PlannedCall describes an API request a validation would make
+type PlannedCall struct {
+	Side     string // "source" or "target"
+	Purpose  string
+	Endpoint string
+	Calls    int    // Estimated number of HTTP requests
+	Note     string // Conditions under which the estimate changes
+}
+
+// ValidationPlan lists the API requests a validation would make and the metrics it would compare
+type ValidationPlan struct {
+	Source  string
+	Target  string
+	Calls   []PlannedCall
+	Metrics []string
+}
+
+// EstimatedCalls returns the estimated number of HTTP requests made with the given side's client
+func (p ValidationPlan) EstimatedCalls(side string) int {
+	total := 0
+	for _, call := range p.Calls {
+		if call.Side == side {
+			total += call.Calls
+		}
+	}
+	return total
+}
+
+// PlanValidation returns the API requests and metrics a validation would make with the current options,
+// without calling any API. When sourceFromExport is true, source data is expected to have been set with
+// SetSourceDataFromExport and only the target is queried.
+func (mv *MigrationValidator) PlanValidation(sourceOwner, sourceRepo, targetOwner, targetRepo string, sourceFromExport bool) ValidationPlan {
+	plan := ValidationPlan{
+		Source: fmt.Sprintf("%s/%s", sourceOwner, sourceRepo),
+		Target: fmt.Sprintf("%s/%s", targetOwner, targetRepo),
+	}
+	noLFS := mv.options.NoLFS
+
+	sides := []string{"source", "target"}
+	if sourceFromExport {
+		sides = []string{"target"}
+	}
+
+	for _, side := range sides {
+		plan.Calls = append(plan.Calls,
+			PlannedCall{Side: side, Purpose: "rename detection", Endpoint: "REST GET /repos/{owner}/{repo}", Calls: 1},
+			PlannedCall{Side: side, Purpose: "repository access", Endpoint: "GraphQL repository { id }", Calls: 1},
+			PlannedCall{Side: side, Purpose: "rate limit check", Endpoint: "GraphQL rateLimit", Calls: 1},
+			PlannedCall{Side: side, Purpose: "repository contents", Endpoint: "GraphQL repository { isEmpty }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "issues", Endpoint: "GraphQL repository { issues { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "pull requests", Endpoint: "GraphQL repository { pullRequests(states) { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "tags", Endpoint: "GraphQL repository { refs(refPrefix: \"refs/tags/\") { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "releases", Endpoint: "GraphQL repository { releases { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "branch count", Endpoint: "GraphQL repository { refs(refPrefix: \"refs/heads/\") { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "commit comments", Endpoint: "GraphQL repository { commitComments { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "commits", Endpoint: "GraphQL repository { defaultBranchRef { history { totalCount } } }", Calls: graphQLCalls, Note: "skipped for empty repositories"},
+			PlannedCall{Side: side, Purpose: "latest commit hash", Endpoint: "GraphQL repository { defaultBranchRef { target { oid } } }", Calls: graphQLCalls, Note: "skipped for empty repositories"},
+			PlannedCall{Side: side, Purpose: "tree hash", Endpoint: "GraphQL repository { defaultBranchRef { target { tree { oid } } } }", Calls: graphQLCalls, Note: "skipped for empty repositories"},
+			PlannedCall{Side: side, Purpose: "commit signatures", Endpoint: "GraphQL repository { defaultBranchRef { history(first: 100) { signature } } }", Calls: graphQLCalls, Note: "skipped for empty repositories"},
+			PlannedCall{Side: side, Purpose: "branch protection rules", Endpoint: "GraphQL repository { branchProtectionRules { totalCount } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "protected tag rules", Endpoint: "REST GET /repos/{owner}/{repo}/tags/protection and /rulesets", Calls: 2},
+			PlannedCall{Side: side, Purpose: "webhooks", Endpoint: "REST GET /repos/{owner}/{repo}/hooks", Calls: 1, Note: "+1 per 100 webhooks"},
+			PlannedCall{Side: side, Purpose: "Pages configuration", Endpoint: "REST GET /repos/{owner}/{repo}/pages", Calls: 1},
+			PlannedCall{Side: side, Purpose: "issue templates", Endpoint: "GraphQL repository { object(expression: \"HEAD:.github/ISSUE_TEMPLATE\") { entries } }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "repository metadata", Endpoint: "GraphQL repository { description, homepageUrl, repositoryTopics }", Calls: graphQLCalls},
+			PlannedCall{Side: side, Purpose: "fork parent", Endpoint: "GraphQL repository { isFork, parent { nameWithOwner } }", Calls: graphQLCalls},
+		)
+	}
+
+	if mv.options.RewrittenHistory {
+		for _, side := range sides {
+			plan.Calls = append(plan.Calls, PlannedCall{Side: side, Purpose: "commit history",
+				Endpoint: "GraphQL repository { defaultBranchRef { history(first: 100) { author, authoredDate } } }", Calls: graphQLCalls, Note: "skipped for empty repositories"})
+		}
+	}
+
+	if mv.options.TransferMode {
+		plan.Calls = append(plan.Calls,
+			PlannedCall{Side: "target", Purpose: "transfer redirect", Endpoint: "REST GET /repos/{source owner}/{source repo}", Calls: 1},
+		)
+	} else {
+		plan.Calls = append(plan.Calls,
+			PlannedCall{Side: "target", Purpose: "migration log issue", Endpoint: "GraphQL repository { issues(first: 10, orderBy: CREATED_AT) }", Calls: graphQLCalls},
+		)
+	}
+
+	if !noLFS {
+		lfsCalls := func(side string) PlannedCall {
+			return PlannedCall{
+				Side:     side,
+				Purpose:  "LFS objects",
+				Endpoint: "GraphQL repository { defaultBranchRef } + REST GET /repos/{owner}/{repo}/git/trees, /git/blobs",
+				Calls:    graphQLCalls + 3,
+				Note:     "+1 per LFS-tracked file",
+			}
+		}
+
+		if sourceFromExport {
+			plan.Calls = append(plan.Calls, lfsCalls("target"))
+		} else {
+			// Source LFS objects are listed once for the source metrics and again to check them against the target
+			plan.Calls = append(plan.Calls, lfsCalls("source"), lfsCalls("source"))
+			plan.Calls = append(plan.Calls, PlannedCall{
+				Side:     "target",
+				Purpose:  "LFS objects",
+				Endpoint: "POST {host}/{owner}/{repo}.git/info/lfs/objects/batch",
+				Calls:    1,
+				Note:     "only when the source has LFS objects; +1 per chunk of --lfs-batch-size objects",
+			})
+		}
+
+		if mv.options.CheckLFSLocks {
+			for _, side := range sides {
+				plan.Calls = append(plan.Calls, PlannedCall{
+					Side:     side,
+					Purpose:  "LFS locks",
+					Endpoint: "GET {host}/{owner}/{repo}.git/info/lfs/locks",
+					Calls:    1,
+					Note:     "+1 per 100 locks",
+				})
+			}
+		}
+
+		for _, side := range sides {
+			plan.Calls = append(plan.Calls, PlannedCall{
+				Side:     side,
+				Purpose:  "LFS patterns",
+				Endpoint: "GraphQL repository { defaultBranchRef } + REST GET /repos/{owner}/{repo}/git/trees, /git/blobs",
+				Calls:    graphQLCalls + 2,
+			})
+		}
+	}
+
+	if _, _, ok := mv.selectedBranches(); ok {
+		for _, side := range sides {
+			plan.Calls = append(plan.Calls, PlannedCall{
+				Side:     side,
+				Purpose:  "branches",
+				Endpoint: "GraphQL repository { refs(refPrefix: \"refs/heads/\") { target { oid, history { totalCount } } } }",
+				Calls:    graphQLCalls,
+				Note:     "+1 per 50 branches",
+			})
+		}
+	}
+
+	if bodies := mv.plannedTruncationBodies(sourceFromExport); bodies > 0 {
+		if !sourceFromExport {
+			plan.Calls = append(plan.Calls, PlannedCall{
+				Side:     "source",
+				Purpose:  "issue and pull request bodies",
+				Endpoint: "GraphQL repository { issues, pullRequests { body } }",
+				Calls:    2 * graphQLCalls,
+				Note:     "+1 per 100 issues and pull requests",
+			})
+		}
+		plan.Calls = append(plan.Calls, PlannedCall{
+			Side:     "target",
+			Purpose:  "issue and pull request bodies",
+			Endpoint: "GraphQL repository { issueOrPullRequest(number) { body } }",
+			Calls:    bodies * graphQLCalls,
+		})
+	}
+
+	if mv.options.CheckSecurity {
+		for _, side := range sides {
+			plan.Calls = append(plan.Calls, PlannedCall{
+				Side:     side,
+				Purpose:  "security features",
+				Endpoint: "REST GET /repos/{owner}/{repo}, /vulnerability-alerts, /dependabot/alerts, /secret-scanning/alerts, /code-scanning/alerts",
+				Calls:    5,
+				Note:     "+1 per 100 open alerts; alerts only listed for enabled features",
+			})
+		}
+	}
+
+	plan.Metrics = mv.plannedMetrics(noLFS, sourceFromExport)
+	return plan
+}
+
+// plannedMetrics returns the names of the metrics a validation would compare
+func (mv *MigrationValidator) plannedMetrics(noLFS, sourceFromExport bool) []string {
+	issues := "Issues (offset auto-detected from the migration log issue)"
+	if mv.options.IssueOffset != nil || mv.options.TransferMode {
+		issues = issueMetricName("Issues", mv.issueOffset())
+	}
+
+	latestCommit := "Latest Commit SHA"
+	if mv.options.RewrittenHistory {
+		latestCommit = "Latest Commit SHA (INFO, history rewritten)"
+	}
+
+	metrics := []string{
+		issues,
+		"Pull Requests (Total)",
+		"Pull Requests (Open)",
+		"Pull Requests (Merged)",
+		"Tags",
+		"Releases",
+		"Commits",
+		"Commit Comments",
+		"Branches",
+		"Branch Protection Rules",
+		"Protected Tag Rules",
+		"Webhooks",
+	}
+	if !noLFS {
+		metrics = append(metrics, "LFS Objects", "LFS Tracked Patterns (when either repository tracks files with LFS)")
+		if mv.options.CheckLFSLocks {
+			metrics = append(metrics, "LFS Locks (advisory, paths of the locked files)")
+		}
+	}
+	metrics = append(metrics,
+		latestCommit,
+		"Default Branch Tree SHA",
+		"Squashed or Shallow Import (when the target has the same tree with less than half of the commits)",
+		"Issue Templates (files and blob SHAs of .github/ISSUE_TEMPLATE, when either repository has templates)",
+		"Verified Commit Signatures (advisory, last 100 commits)",
+		"GitHub Pages (INFO, when either repository has Pages enabled)",
+		"Description, Homepage URL and Topics (advisory)",
+		"Fork Relationship (INFO, when either repository is a fork)",
+	)
+	if mv.options.RewrittenHistory {
+		metrics = append(metrics, "Commit Authors and Dates (last 100 commits, in order)")
+	}
+	if mv.options.TransferMode {
+		metrics = append(metrics, "Transfer Redirect (the source name redirects to the target)")
+	} else {
+		metrics = append(metrics,
+			"Migration Log Issue",
+			"Migration Log vs Target (counts reported in the migration log issue)",
+		)
+	}
+	if all, names, ok := mv.selectedBranches(); ok {
+		branches := "every branch"
+		if !all {
+			branches = strings.Join(names, ", ")
+		}
+		metrics = append(metrics, fmt.Sprintf("Branches: head SHA and commit count of %s", branches))
+	}
+	if bodies := mv.plannedTruncationBodies(sourceFromExport); bodies > 0 {
+		metrics = append(metrics, fmt.Sprintf("Truncated Bodies (advisory, %d longest issues and pull requests)", bodies))
+	}
+	if mv.options.CheckSecurity {
+		metrics = append(metrics, "Security: Dependabot alerts, secret scanning and code scanning enabled, and their open alerts (advisory)")
+	}
+
+	if sourceFromExport && mv.SourceData != nil && mv.SourceData.MigrationArchive != nil {
+		metrics = append(metrics,
+			"Archive vs Source (Issues, Pull Requests, Protected Branches, Releases, Commit Comments)",
+			"Archive vs Source File References (files referenced inside the archive and urls.json)",
+			"Archive vs Target (Issues, Pull Requests, Protected Branches, Releases, Commit Comments)",
+		)
+	}
+
+	return metrics
+}
+
+// plannedTruncationBodies returns the number of issue and pull request bodies compared to detect truncation:
+// the --check-truncation count, or the bodies recorded in the export when the source comes from one
+func (mv *MigrationValidator) plannedTruncationBodies(sourceFromExport bool) int {
+	if sourceFromExport {
+		if mv.SourceData == nil {
+			return 0
+		}
+		return len(mv.SourceData.LargestBodies)
+	}
+	return mv.options.CheckTruncation
+}
+
+// Print renders the plan as tables followed by the estimated number of API calls per side
+func (p ValidationPlan) Print() {
+	output.Header(output.Heading("🧪 Migration Validation Dry Run"))
+	fmt.Printf("Source: %s | Target: %s\n\n", p.Source, p.Target)
+
+	output.Section(output.Heading("🌐 Planned API Requests"))
+	tableData := [][]string{{"Side", "Purpose", "Endpoint", "Calls", "Note"}}
+	for _, call := range p.Calls {
+		tableData = append(tableData, []string{call.Side, call.Purpose, call.Endpoint, fmt.Sprintf("%d", call.Calls), call.Note})
+	}
+	output.RenderTable(tableData, false)
+
+	fmt.Println()
+	output.Section(output.Heading("📏 Metrics To Compare"))
+	items := make([]output.BulletItem, 0, len(p.Metrics))
+	for _, metric := range p.Metrics {
+		items = append(items, output.BulletItem{Text: metric})
+	}
+	output.BulletList(items, "•")
+
+	fmt.Println()
+	summary := []output.BulletItem{}
+	for _, side := range []string{"source", "target"} {
+		if calls := p.EstimatedCalls(side); calls > 0 {
+			summary = append(summary, output.BulletItem{Text: fmt.Sprintf("Estimated %s API calls: %d (minimum)", side, calls)})
+		}
+	}
+	output.BulletList(summary, output.Bullet("📊"))
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/migrationarchive"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanValidation(t *testing.T) {
+	mv := New(nil)
+	plan := mv.PlanValidation("source-org", "repo", "target-org", "repo", false)
+
+	assert.Equal(t, "source-org/repo", plan.Source)
+	assert.Equal(t, "target-org/repo", plan.Target)
+	assert.Greater(t, plan.EstimatedCalls("source"), 0)
+	assert.Greater(t, plan.EstimatedCalls("target"), 0)
+	assert.Contains(t, plan.Metrics, "LFS Objects")
+	assert.Contains(t, plan.Metrics, "Issues (offset auto-detected from the migration log issue)")
+
+	// Skipping LFS removes the LFS requests and metric
+	mv.options.NoLFS = true
+
+	noLFSPlan := mv.PlanValidation("source-org", "repo", "target-org", "repo", false)
+	assert.Less(t, noLFSPlan.EstimatedCalls("source"), plan.EstimatedCalls("source"))
+	assert.NotContains(t, noLFSPlan.Metrics, "LFS Objects")
+	for _, call := range noLFSPlan.Calls {
+		assert.NotEqual(t, "LFS objects", call.Purpose)
+	}
+}
+
+func TestPlanValidation_FromExport(t *testing.T) {
+	offset := 0
+	mv := NewWithOptions(nil, ValidationOptions{IssueOffset: &offset})
+	mv.SetSourceDataFromExport(&RepositoryData{
+		Owner:            "source-org",
+		Name:             "repo",
+		MigrationArchive: &migrationarchive.MigrationArchiveMetrics{},
+	})
+
+	plan := mv.PlanValidation("source-org", "repo", "target-org", "repo", true)
+
+	assert.Equal(t, 0, plan.EstimatedCalls("source"), "Source data comes from the export")
+	assert.Greater(t, plan.EstimatedCalls("target"), 0)
+	assert.Contains(t, plan.Metrics, "Issues")
+	assert.Contains(t, plan.Metrics, "Archive vs Target (Issues, Pull Requests, Protected Branches, Releases, Commit Comments)")
+}
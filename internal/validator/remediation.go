@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"mona-actions/gh-migration-validator/internal/output"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RemediationRule maps failed metrics to their likely cause and the next step to fix them
+type RemediationRule struct {
+	ID       string `yaml:"id" json:"id"`               // Stable identifier for scripts, e.g. "missing-releases"
+	Metric   string `yaml:"metric" json:"metric"`       // Metric name, or metric name prefix when it ends with "*"
+	Cause    string `yaml:"cause" json:"cause"`         // Likely cause of the failure
+	NextStep string `yaml:"next_step" json:"next_step"` // What to do about it
+}
+
+// Remediation is the rule explaining a failed validation result
+type Remediation struct {
+	Metric string
+	Rule   RemediationRule
+}
+
+// remediationRulesFile is the format of the file given with --explain-rules
+type remediationRulesFile struct {
+	Rules []RemediationRule `yaml:"rules"`
+}
+
+// defaultRemediationRules explain the failures of the standard metrics. Rules loaded from a file are
+// matched first, so they can override these.
+var defaultRemediationRules = []RemediationRule{
+	{ID: "missing-issues", Metric: "Issues*",
+		Cause:    "Issues failed to import or were deleted from the target",
+		NextStep: "Check the migration log for issues that failed to import, then re-run `gh gei migrate-repo`"},
+	{ID: "missing-pull-requests", Metric: "Pull Requests (*",
+		Cause:    "Pull requests failed to import, often because their head or base branch no longer exists",
+		NextStep: "Check the migration log for skipped pull requests, then re-run `gh gei migrate-repo`"},
+	{ID: "missing-tags", Metric: "Tags",
+		Cause:    "Tags were not pushed to the target",
+		NextStep: "Re-push tags from a mirror clone of the source: git push --tags <target remote>"},
+	{ID: "missing-releases", Metric: "Releases",
+		Cause:    "Releases were skipped by the migration",
+		NextStep: "Re-run `gh gei migrate-repo` without --skip-releases"},
+	{ID: "missing-commits", Metric: "Commits",
+		Cause:    "The default branch of the target is behind the source, e.g. commits were pushed after the migration",
+		NextStep: "Push the default branch from a clone of the source: git push <target remote> <default branch>"},
+	{ID: "squashed-import", Metric: squashedImportMetric,
+		Cause:    "The repository was imported from a squashed or shallow clone, e.g. git clone --depth, so it has the contents of the source without its history",
+		NextStep: "Push the default branch from a full clone of the source (git fetch --unshallow first): git push --force <target remote> <default branch>"},
+	{ID: "missing-commit-comments", Metric: "Commit Comments",
+		Cause:    "Commit comments on commits missing from the target were dropped",
+		NextStep: "Re-push the missing branches, then re-run `gh gei migrate-repo`"},
+	{ID: "missing-branches", Metric: "Branches",
+		Cause:    "Branches were not pushed to the target",
+		NextStep: "Re-push branches from a mirror clone of the source: git push --all <target remote>"},
+	{ID: "diverged-branch", Metric: branchMetricPrefix + "*",
+		Cause:    "The branch is missing on the target or points to a different commit",
+		NextStep: "Push the branch from a clone of the source: git push <target remote> <branch>"},
+	{ID: "missing-branch-protection", Metric: "Branch Protection Rules",
+		Cause:    "Branch protection rules are not migrated from every source, nor for branches missing on the target",
+		NextStep: "Recreate the rules in the target repository settings"},
+	{ID: "missing-tag-protection", Metric: "Protected Tag Rules",
+		Cause:    "Tag protection rules and tag rulesets are not migrated",
+		NextStep: "Recreate the tag rulesets in the target repository settings"},
+	{ID: "missing-webhooks", Metric: "Webhooks",
+		Cause:    "Webhooks are not migrated from every source",
+		NextStep: "Recreate the webhooks and their secrets in the target repository settings"},
+	{ID: "missing-lfs-objects", Metric: "LFS Objects",
+		Cause:    "Git LFS objects are not migrated by GitHub Enterprise Importer",
+		NextStep: "Run git lfs fetch --all in a clone of the source, then git lfs push --all <target remote>"},
+	{ID: "broken-archive-references", Metric: archiveReferencesMetric,
+		Cause:    "Files referenced by the migration archive, such as attachments or release assets, or its urls.json are missing, e.g. the archive was not extracted completely",
+		NextStep: "Extract the archive again, or export a new migration archive, before importing it"},
+	{ID: "incomplete-archive", Metric: "Archive vs Source*",
+		Cause:    "The migration archive is missing data of the source, e.g. it was exported before the data was created",
+		NextStep: "Export a new migration archive and migrate the repository again"},
+	{ID: "import-dropped-data", Metric: "Archive vs Target*",
+		Cause:    "The import dropped data that the migration archive contains",
+		NextStep: "Check the migration log for import errors, then re-run the migration"},
+	{ID: "deleted-after-migration", Metric: "Migration Log vs Target*",
+		Cause:    "Data the migration log reports as migrated is missing from the target, e.g. it was deleted since",
+		NextStep: "Check the audit log of the target repository for deletions"},
+}
+
+// LoadRemediationRules reads the remediation rules of a YAML file with a top-level "rules" list
+func LoadRemediationRules(path string) ([]RemediationRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remediation rules %s: %w", path, err)
+	}
+
+	var file remediationRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse remediation rules %s: %w", path, err)
+	}
+
+	for i, rule := range file.Rules {
+		if rule.Metric == "" || rule.NextStep == "" {
+			return nil, fmt.Errorf("remediation rule %d in %s needs a metric and a next_step", i+1, path)
+		}
+	}
+
+	return file.Rules, nil
+}
+
+// matches reports whether the rule applies to metric
+func (r RemediationRule) matches(metric string) bool {
+	return metricMatches(r.Metric, metric)
+}
+
+// Remediations returns the rule explaining each failed result that one matches, trying the rules of the
+// options before the default rules
+func (mv *MigrationValidator) Remediations(results []ValidationResult) []Remediation {
+	rules := append(append([]RemediationRule{}, mv.options.RemediationRules...), defaultRemediationRules...)
+
+	var remediations []Remediation
+	for _, result := range results {
+		if result.StatusType != ValidationStatusFail {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.matches(result.Metric) {
+				remediations = append(remediations, Remediation{Metric: result.Metric, Rule: rule})
+				break
+			}
+		}
+	}
+	return remediations
+}
+
+// displayRemediations prints the likely cause and next step of each explained failure
+func (mv *MigrationValidator) displayRemediations(results []ValidationResult) {
+	remediations := mv.Remediations(results)
+	if len(remediations) == 0 {
+		return
+	}
+
+	output.Section(output.Heading("💡 Suggested Fixes"))
+
+	tableData := [][]string{{"Metric", "Likely Cause", "Next Step"}}
+	for _, remediation := range remediations {
+		tableData = append(tableData, []string{remediation.Metric, remediation.Rule.Cause, remediation.Rule.NextStep})
+	}
+	output.RenderTable(tableData, true)
+}
+
+// writeMarkdownRemediations writes the likely cause and next step of each explained failure as a markdown section
+func (mv *MigrationValidator) writeMarkdownRemediations(writer io.Writer, results []ValidationResult) {
+	remediations := mv.Remediations(results)
+	if len(remediations) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "## Suggested Fixes")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| Metric | Rule | Likely Cause | Next Step |")
+	fmt.Fprintln(writer, "|--------|------|--------------|-----------|")
+	for _, remediation := range remediations {
+		fmt.Fprintf(writer, "| %s | %s | %s | %s |\n", remediation.Metric, remediation.Rule.ID, remediation.Rule.Cause, remediation.Rule.NextStep)
+	}
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// compareFunc decides the status of a count from its source and target values
+type compareFunc func(metric string, sourceVal, targetVal int) ValidationResult
+
+// Comparison names of the rules file
+const (
+	CompareExact      = "exact"      // The target has exactly the source count
+	CompareAtLeast    = "at-least"   // The target has at least the source count, e.g. commits of active repositories
+	CompareAdvisory   = "advisory"   // Missing items are a warning
+	ComparePercentage = "percentage" // The counts differ by at most the tolerance, in percent of the source count
+)
+
+// ComparisonRule selects how the source and target counts of a metric are compared
+type ComparisonRule struct {
+	Metric    string  `yaml:"metric" json:"metric"`                           // Count metric name, e.g. "Commits"
+	Compare   string  `yaml:"compare" json:"compare"`                         // One of the Compare* names
+	Tolerance float64 `yaml:"tolerance,omitempty" json:"tolerance,omitempty"` // Accepted difference in percent, for percentage
+}
+
+// IgnoreRule reports the failures and warnings of a metric as INFO, for differences that are known and accepted
+type IgnoreRule struct {
+	Metric       string   `yaml:"metric" json:"metric"`                                 // Metric name, or metric name prefix when it ends with "*"
+	Reason       string   `yaml:"reason" json:"reason"`                                 // Why the difference is accepted, shown in reports
+	Repositories []string `yaml:"repositories,omitempty" json:"repositories,omitempty"` // owner/name of the source or target, every repository when empty
+}
+
+// ValidationRules is the format of the file given with --rules
+type ValidationRules struct {
+	Comparisons []ComparisonRule `yaml:"comparisons"`
+	Ignore      []IgnoreRule     `yaml:"ignore"`
+}
+
+// comparisonStrategies build the compare function of each comparison name from its rule
+var comparisonStrategies = map[string]func(rule ComparisonRule) compareFunc{
+	CompareExact:      func(ComparisonRule) compareFunc { return countResult },
+	CompareAtLeast:    func(ComparisonRule) compareFunc { return atLeastResult },
+	CompareAdvisory:   func(ComparisonRule) compareFunc { return advisoryResult },
+	ComparePercentage: percentageComparison,
+}
+
+// LoadValidationRules reads the rules of a YAML file, e.g. a "comparisons" list selecting how the counts of
+// metrics are compared
+func LoadValidationRules(path string) (ValidationRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationRules{}, fmt.Errorf("failed to read validation rules %s: %w", path, err)
+	}
+
+	var rules ValidationRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return ValidationRules{}, fmt.Errorf("failed to parse validation rules %s: %w", path, err)
+	}
+
+	for i, rule := range rules.Comparisons {
+		if err := rule.validate(); err != nil {
+			return ValidationRules{}, fmt.Errorf("comparison rule %d in %s: %v", i+1, path, err)
+		}
+	}
+	for i, rule := range rules.Ignore {
+		if rule.Metric == "" || rule.Reason == "" {
+			return ValidationRules{}, fmt.Errorf("ignore rule %d in %s needs a metric and a reason", i+1, path)
+		}
+	}
+	return rules, nil
+}
+
+// validate checks that the rule names a count metric and a known comparison
+func (r ComparisonRule) validate() error {
+	if !isCountMetric(r.Metric) {
+		return fmt.Errorf("metric %q is not a count metric, expected one of: %s", r.Metric, strings.Join(countMetricNames(), ", "))
+	}
+	if _, ok := comparisonStrategies[r.Compare]; !ok {
+		return fmt.Errorf("unknown comparison %q, expected one of: %s", r.Compare, strings.Join(comparisonNames(), ", "))
+	}
+	if r.Tolerance < 0 || (r.Tolerance != 0 && r.Compare != ComparePercentage) {
+		return fmt.Errorf("tolerance must be a positive percentage, and is only used by the %s comparison", ComparePercentage)
+	}
+	return nil
+}
+
+// comparison returns the compare function the rules select for metric, or nil when none does
+func (mv *MigrationValidator) comparison(metric string) compareFunc {
+	for _, rule := range mv.options.ComparisonRules {
+		if rule.Metric == metric {
+			return comparisonStrategies[rule.Compare](rule)
+		}
+	}
+	return nil
+}
+
+// applyIgnoreRules reports the failures and warnings matching an ignore rule as INFO, noting the reason
+func (mv *MigrationValidator) applyIgnoreRules(results []ValidationResult) []ValidationResult {
+	for i, result := range results {
+		if result.StatusType != ValidationStatusFail && result.StatusType != ValidationStatusWarn {
+			continue
+		}
+		for _, rule := range mv.options.IgnoreRules {
+			if rule.matches(result.Metric) && mv.ignoresRepository(rule) {
+				results[i].Status, results[i].StatusType = ValidationStatusMessageInfo, ValidationStatusInfo
+				results[i].Note = "known difference: " + rule.Reason
+				break
+			}
+		}
+	}
+	return results
+}
+
+// matches reports whether the rule applies to metric
+func (r IgnoreRule) matches(metric string) bool {
+	return metricMatches(r.Metric, metric)
+}
+
+// ignoresRepository reports whether the rule applies to the source or target repository of mv
+func (mv *MigrationValidator) ignoresRepository(rule IgnoreRule) bool {
+	if len(rule.Repositories) == 0 {
+		return true
+	}
+	for _, repository := range rule.Repositories {
+		for _, data := range []*RepositoryData{mv.SourceData, mv.TargetData} {
+			if data != nil && strings.EqualFold(repository, repositoryName(data)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// metricMatches reports whether metric is named pattern, or starts with it when it ends with "*"
+func metricMatches(pattern, metric string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(metric, prefix)
+	}
+	return pattern == metric
+}
+
+// atLeastResult passes when the target has at least the source count
+func atLeastResult(metric string, sourceVal, targetVal int) ValidationResult {
+	result := countResult(metric, sourceVal, targetVal)
+	if result.StatusType == ValidationStatusWarn {
+		result.Status, result.StatusType = ValidationStatusMessagePass, ValidationStatusPass
+	}
+	return result
+}
+
+// percentageComparison passes counts differing by at most the tolerance of the rule, in percent of the
+// source count
+func percentageComparison(rule ComparisonRule) compareFunc {
+	return func(metric string, sourceVal, targetVal int) ValidationResult {
+		result := countResult(metric, sourceVal, targetVal)
+		difference := float64(result.Difference)
+		if difference < 0 {
+			difference = -difference
+		}
+		if difference <= float64(sourceVal)*rule.Tolerance/100 {
+			result.Status, result.StatusType = ValidationStatusMessagePass, ValidationStatusPass
+		}
+		return result
+	}
+}
+
+// isCountMetric reports whether name is a count metric that comparison rules apply to
+func isCountMetric(name string) bool {
+	for _, metric := range repositoryMetrics {
+		if metric.value != nil && metric.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// countMetricNames returns the names of the count metrics, in report order
+func countMetricNames() []string {
+	var names []string
+	for _, metric := range repositoryMetrics {
+		if metric.value != nil {
+			names = append(names, metric.name)
+		}
+	}
+	return names
+}
+
+// comparisonNames returns the sorted comparison names
+func comparisonNames() []string {
+	names := make([]string, 0, len(comparisonStrategies))
+	for name := range comparisonStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
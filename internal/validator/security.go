@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"mona-actions/gh-migration-validator/internal/api"
+	"strings"
+)
+
+// securityMetricPrefix starts the metric name of the security feature parity comparisons
+const securityMetricPrefix = "Security: "
+
+// securityEnabledSuffix ends the metric name of the comparisons of whether a security feature is enabled
+const securityEnabledSuffix = " Enabled"
+
+// securityResults compares the security features of the source and target repositories when both were
+// retrieved with --check-security. Security features and their alerts are never migrated, so the comparisons
+// are advisory: a feature missing on the target is a warning, and open alerts are only compared once the
+// feature is enabled on both sides since the target raises its own alerts.
+func (mv *MigrationValidator) securityResults() []ValidationResult {
+	source, target := mv.SourceData.Security, mv.TargetData.Security
+	if source == nil || target == nil {
+		return nil
+	}
+
+	var results []ValidationResult
+	features := []struct {
+		name           string
+		source, target api.SecurityFeature
+	}{
+		{"Dependabot", source.DependabotAlerts, target.DependabotAlerts},
+		{"Secret Scanning", source.SecretScanning, target.SecretScanning},
+		{"Code Scanning", source.CodeScanning, target.CodeScanning},
+	}
+
+	for _, feature := range features {
+		status, statusType := ValidationStatusMessagePass, ValidationStatusPass
+		if feature.source.Enabled && !feature.target.Enabled {
+			status, statusType = ValidationStatusMessageWarn, ValidationStatusWarn
+		}
+		results = append(results, ValidationResult{
+			Metric:     securityMetricPrefix + feature.name + securityEnabledSuffix,
+			SourceVal:  formatEnabled(feature.source.Enabled),
+			TargetVal:  formatEnabled(feature.target.Enabled),
+			Status:     status,
+			StatusType: statusType,
+		})
+
+		if feature.source.Enabled && feature.target.Enabled {
+			results = append(results, advisoryResult(securityMetricPrefix+feature.name+" Open Alerts",
+				feature.source.OpenAlerts, feature.target.OpenAlerts))
+		}
+	}
+
+	return results
+}
+
+// isSecurityEnabledResult reports whether result compares whether a security feature is enabled
+func isSecurityEnabledResult(result ValidationResult) bool {
+	return strings.HasPrefix(result.Metric, securityMetricPrefix) && strings.HasSuffix(result.Metric, securityEnabledSuffix)
+}
+
+// formatEnabled returns the display value of a security feature setting
+func formatEnabled(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// signaturesMetric is the metric name of the commit signature verification comparison
+var signaturesMetric = fmt.Sprintf("Verified Commit Signatures (last %d commits)", api.SignatureSampleSize)
+
+// signaturesResult compares the verified signatures among the most recent default branch commits. Migrated
+// commits keep their signatures, but GitHub only verifies them against keys uploaded to the instance, so
+// signatures verified on the source can show as unverified on the target. The comparison is advisory: fewer
+// verified signatures on the target is a warning.
+func (mv *MigrationValidator) signaturesResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.CommitSignatures, mv.TargetData.CommitSignatures
+	if source == nil || target == nil {
+		return ValidationResult{}, false
+	}
+
+	diff := source.Verified - target.Verified
+	status, statusType := ValidationStatusMessagePass, ValidationStatusPass
+	if diff > 0 {
+		status, statusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+
+	return ValidationResult{
+		Metric:     signaturesMetric,
+		SourceVal:  formatSignatures(source),
+		TargetVal:  formatSignatures(target),
+		Status:     status,
+		StatusType: statusType,
+		Difference: diff,
+	}, true
+}
+
+// formatSignatures returns the verified share of the checked commits, e.g. "85% (85/100)"
+func formatSignatures(stats *api.SignatureStats) string {
+	return fmt.Sprintf("%.0f%% (%d/%d)", stats.VerifiedPercent(), stats.Verified, stats.Commits)
+}
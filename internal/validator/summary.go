@@ -0,0 +1,107 @@
+package validator
+
+import "fmt"
+
+// Verdict is the overall outcome of a validation
+type Verdict int
+
+const (
+	VerdictPassed     Verdict = iota
+	VerdictWarnings           // Nothing is missing, but the target has more data than the source
+	VerdictFailed             // Some data is missing in the target
+	VerdictIncomplete         // The target repository is unavailable, so nothing was compared
+)
+
+// String returns the plain verdict name (passed, warnings, failed or incomplete)
+func (v Verdict) String() string {
+	switch v {
+	case VerdictPassed:
+		return "passed"
+	case VerdictWarnings:
+		return "warnings"
+	case VerdictFailed:
+		return "failed"
+	case VerdictIncomplete:
+		return "incomplete"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the verdict by name in JSON
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a verdict encoded by name
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for verdict := VerdictPassed; verdict <= VerdictIncomplete; verdict++ {
+		if verdict.String() == string(text) {
+			*v = verdict
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// Message returns the sentence reports display for the verdict
+func (v Verdict) Message() string {
+	switch v {
+	case VerdictIncomplete:
+		return "🚫 Migration validation INCOMPLETE - Target repository is unavailable"
+	case VerdictFailed:
+		return "❌ Migration validation FAILED - Some data is missing in target"
+	case VerdictWarnings:
+		return "⚠️ Migration validation completed with WARNINGS - Target has more data than source"
+	default:
+		return "✅ Migration validation PASSED - All data matches!"
+	}
+}
+
+// Summary counts validation results by status, with the overall verdict they lead to
+type Summary struct {
+	Passed      int     `json:"passed"`
+	Failed      int     `json:"failed"`
+	Warnings    int     `json:"warnings"`
+	Info        int     `json:"info"`
+	Unavailable int     `json:"unavailable"`
+	Verdict     Verdict `json:"verdict"`
+}
+
+// Summarize counts the validation results by status. The verdict is incomplete when the target was
+// unavailable, failed when any result failed, and otherwise passed, with warnings when any result warned.
+func Summarize(results []ValidationResult) Summary {
+	var summary Summary
+	for _, result := range results {
+		switch result.StatusType {
+		case ValidationStatusPass:
+			summary.Passed++
+		case ValidationStatusFail:
+			summary.Failed++
+		case ValidationStatusWarn:
+			summary.Warnings++
+		case ValidationStatusInfo:
+			summary.Info++
+		case ValidationStatusUnavailable:
+			summary.Unavailable++
+		}
+	}
+
+	switch {
+	case summary.Unavailable > 0:
+		summary.Verdict = VerdictIncomplete
+	case summary.Failed > 0:
+		summary.Verdict = VerdictFailed
+	case summary.Warnings > 0:
+		summary.Verdict = VerdictWarnings
+	default:
+		summary.Verdict = VerdictPassed
+	}
+
+	return summary
+}
+
+// Succeeded reports whether the validation found nothing missing in an available target
+func (s Summary) Succeeded() bool {
+	return s.Verdict == VerdictPassed || s.Verdict == VerdictWarnings
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []ValidationResult
+		expected Summary
+	}{
+		{
+			name:     "no results",
+			expected: Summary{Verdict: VerdictPassed},
+		},
+		{
+			name:     "passed with info",
+			results:  []ValidationResult{countResult("Tags", 2, 2), {Metric: forkMetric, StatusType: ValidationStatusInfo}},
+			expected: Summary{Passed: 1, Info: 1, Verdict: VerdictPassed},
+		},
+		{
+			name:     "warnings",
+			results:  []ValidationResult{countResult("Tags", 2, 2), countResult("Releases", 1, 2)},
+			expected: Summary{Passed: 1, Warnings: 1, Verdict: VerdictWarnings},
+		},
+		{
+			name:     "failures outweigh warnings",
+			results:  []ValidationResult{countResult("Tags", 3, 2), countResult("Releases", 1, 2)},
+			expected: Summary{Failed: 1, Warnings: 1, Verdict: VerdictFailed},
+		},
+		{
+			name:     "unavailable target",
+			results:  []ValidationResult{{Metric: "Tags", StatusType: ValidationStatusUnavailable}},
+			expected: Summary{Unavailable: 1, Verdict: VerdictIncomplete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := Summarize(tt.results)
+			assert.Equal(t, tt.expected, summary)
+			assert.Equal(t, tt.expected.Verdict == VerdictPassed || tt.expected.Verdict == VerdictWarnings, summary.Succeeded())
+		})
+	}
+}
+
+func TestSummary_JSON(t *testing.T) {
+	data, err := json.Marshal(Summary{Passed: 4, Failed: 1, Verdict: VerdictFailed})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"passed":4,"failed":1,"warnings":0,"info":0,"unavailable":0,"verdict":"failed"}`, string(data))
+
+	var decoded Summary
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, VerdictFailed, decoded.Verdict)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"verdict":"maybe"}`), &decoded))
+}
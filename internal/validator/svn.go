@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"mona-actions/gh-migration-validator/internal/svn"
+	"mona-actions/gh-migration-validator/internal/telemetry"
+)
+
+// svnMetricSuffix marks the Subversion comparisons as advisory: git-svn does not map revisions and tags
+// one to one (empty revisions are skipped, tags copied from a modified tree gain an extra commit)
+const svnMetricSuffix = " (SVN, advisory)"
+
+// ValidateFromSVN validates a repository converted from Subversion with git-svn against the revision
+// and tag counts of the Subversion repository. Differences are reported as warnings, never failures.
+func (mv *MigrationValidator) ValidateFromSVN(metrics *svn.Metrics, targetOwner, targetRepo string) ([]ValidationResult, error) {
+	ctx, span := telemetry.StartRepositorySpan(context.Background(), metrics.URL, targetOwner+"/"+targetRepo)
+	mv.spanContext = ctx
+
+	results, err := mv.validateFromSVN(metrics, targetOwner, targetRepo)
+	telemetry.EndSpan(span, err)
+	return results, err
+}
+
+func (mv *MigrationValidator) validateFromSVN(metrics *svn.Metrics, targetOwner, targetRepo string) ([]ValidationResult, error) {
+	mv.SourceData = &RepositoryData{
+		Name:        metrics.URL,
+		PRs:         &api.PRCounts{},
+		Tags:        metrics.Tags,
+		CommitCount: metrics.Revisions,
+	}
+
+	fmt.Fprintln(mv.progress(), "Validating repository access...")
+	if err := mv.api.ValidateRepoAccess(mv.traceContext(), api.TargetClient, targetOwner, targetRepo); err != nil {
+		return nil, fmt.Errorf("cannot access target repository %s/%s: %w", targetOwner, targetRepo, mv.explainSSO(api.TargetClient, targetOwner, err))
+	}
+
+	mv.checkAndWarnRateLimits()
+
+	fmt.Fprintln(mv.progress(), "Starting SVN migration validation...")
+	fmt.Fprintf(mv.progress(), "Source: %s (SVN) | Target: %s/%s\n", metrics.URL, targetOwner, targetRepo)
+
+	spinner := output.StartSpinner(mv.progress(), fmt.Sprintf("Fetching target data from %s/%s...", targetOwner, targetRepo))
+	errorMsgs, err := mv.retrieveTarget(targetOwner, targetRepo, mv.SourceData.Owner, mv.SourceData.Name, spinner)
+	output.LogAPIErrors(mv.logger(), errorMsgs, targetOwner, targetRepo, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve target data: %w", err)
+	}
+
+	fmt.Fprintln(mv.progress(), "\nValidating migration data...")
+	results := mv.validateSVNData()
+
+	fmt.Fprintln(mv.progress(), "Migration validation completed!")
+	return results, nil
+}
+
+// validateSVNData compares the Subversion revision and tag counts with the target commits and tags
+func (mv *MigrationValidator) validateSVNData() []ValidationResult {
+	return mv.applyIgnoreRules([]ValidationResult{
+		advisoryResult("Commits"+svnMetricSuffix, mv.SourceData.CommitCount, mv.TargetData.CommitCount),
+		advisoryResult("Tags"+svnMetricSuffix, mv.SourceData.Tags, mv.TargetData.Tags),
+	})
+}
+
+// advisoryResult compares counts whose mapping to the target is approximate, downgrading missing items to a warning
+func advisoryResult(metric string, sourceVal, targetVal int) ValidationResult {
+	result := countResult(metric, sourceVal, targetVal)
+	if result.StatusType == ValidationStatusFail {
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"mona-actions/gh-migration-validator/internal/telemetry"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MetricTiming records how long fetching a metric from one side took and how many API requests it made
+type MetricTiming struct {
+	Side     string // "source" or "target"
+	Metric   string
+	Duration time.Duration
+	APICalls int // HTTP requests made with the side's client, including rate limit checks and retries
+	Cost     int // GraphQL rate limit cost of the queries made
+}
+
+// metricTimer measures the metric fetches made with one client during data retrieval
+type metricTimer struct {
+	mv         *MigrationValidator
+	clientType api.ClientType
+	side       string
+	metric     string
+	start      time.Time
+	startCalls int
+	startCost  int
+	ctx        context.Context
+	span       trace.Span
+}
+
+// newMetricTimer returns a timer recording fetches made with the given client into mv
+func (mv *MigrationValidator) newMetricTimer(clientType api.ClientType) *metricTimer {
+	side := "target"
+	if clientType == api.SourceClient {
+		side = "source"
+	}
+	return &metricTimer{mv: mv, clientType: clientType, side: side}
+}
+
+// Start starts timing the fetch of metric, and its span when tracing is enabled
+func (t *metricTimer) Start(metric string) {
+	t.metric = metric
+	t.start = time.Now()
+	t.startCalls = t.mv.api.RequestCount(t.clientType)
+	t.startCost = t.mv.api.GraphQLCost(t.clientType)
+	t.ctx, t.span = telemetry.StartMetricSpan(t.mv.traceContext(), t.side, metric)
+}
+
+// Stop records the time and API calls taken since Start, and ends the span with the fetch error.
+// Source and target data are retrieved concurrently, so timings are kept per side.
+func (t *metricTimer) Stop(err error) {
+	timing := MetricTiming{
+		Side:     t.side,
+		Metric:   t.metric,
+		Duration: time.Since(t.start),
+		APICalls: t.mv.api.RequestCount(t.clientType) - t.startCalls,
+		Cost:     t.mv.api.GraphQLCost(t.clientType) - t.startCost,
+	}
+	telemetry.EndMetricSpan(t.ctx, t.span, timing.Side, timing.Metric, timing.Duration, timing.APICalls, err)
+
+	if t.clientType == api.SourceClient {
+		t.mv.sourceTimings = append(t.mv.sourceTimings, timing)
+	} else {
+		t.mv.targetTimings = append(t.mv.targetTimings, timing)
+	}
+}
+
+// traceContext returns the span context of the validation in progress, for fetches made outside of one
+func (mv *MigrationValidator) traceContext() context.Context {
+	if mv.spanContext == nil {
+		return context.Background()
+	}
+	return mv.spanContext
+}
+
+// Timings returns the metric fetch timings of the last validation, source first
+func (mv *MigrationValidator) Timings() []MetricTiming {
+	timings := make([]MetricTiming, 0, len(mv.sourceTimings)+len(mv.targetTimings))
+	timings = append(timings, mv.sourceTimings...)
+	return append(timings, mv.targetTimings...)
+}
+
+// displayTimings prints the metric fetch timings as a table
+func (mv *MigrationValidator) displayTimings() {
+	timings := mv.Timings()
+	if len(timings) == 0 {
+		return
+	}
+
+	output.Section("⏱️ Performance")
+
+	tableData := [][]string{{"Side", "Metric", "Duration", "API Calls", "GraphQL Cost"}}
+	totalCalls, totalCost := 0, 0
+	for _, timing := range timings {
+		tableData = append(tableData, []string{timing.Side, timing.Metric, formatDuration(timing.Duration), fmt.Sprintf("%d", timing.APICalls), fmt.Sprintf("%d", timing.Cost)})
+		totalCalls += timing.APICalls
+		totalCost += timing.Cost
+	}
+	output.RenderTable(tableData, true)
+
+	fmt.Printf("Total API calls for metric fetches: %d\n", totalCalls)
+	fmt.Printf("Total GraphQL cost for metric fetches: %d\n", totalCost)
+}
+
+// writeMarkdownTimings writes the metric fetch timings as a markdown section
+func (mv *MigrationValidator) writeMarkdownTimings(writer io.Writer) {
+	timings := mv.Timings()
+	if len(timings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "## Performance")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "| Side | Metric | Duration | API Calls | GraphQL Cost |")
+	fmt.Fprintln(writer, "|------|--------|----------|-----------|--------------|")
+	for _, timing := range timings {
+		fmt.Fprintf(writer, "| %s | %s | %s | %d | %d |\n", timing.Side, timing.Metric, formatDuration(timing.Duration), timing.APICalls, timing.Cost)
+	}
+}
+
+// formatDuration rounds a duration for display
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
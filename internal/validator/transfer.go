@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// transferRedirectMetric is the metric name of the transfer redirect check
+const transferRedirectMetric = "Transfer Redirect"
+
+// fetchTransferRedirect resolves the source name on the target instance, which redirects to the transferred
+// repository until a repository is created under the old name
+func (mv *MigrationValidator) fetchTransferRedirect(r *retrieval) error {
+	owner, name, err := mv.api.ResolveRepository(mv.traceContext(), r.clientType, r.sourceOwner, r.sourceName)
+	if errors.Is(err, api.ErrNotFound) {
+		resolved := ""
+		r.data.TransferRedirect = &resolved
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	resolved := fmt.Sprintf("%s/%s", owner, name)
+	r.data.TransferRedirect = &resolved
+	return nil
+}
+
+// transferRedirectResult checks in transfer mode that the source name redirects to the target. Returns false
+// when the redirect was not checked.
+func (mv *MigrationValidator) transferRedirectResult() (ValidationResult, bool) {
+	redirect := mv.TargetData.TransferRedirect
+	if redirect == nil {
+		return ValidationResult{}, false
+	}
+
+	target := repositoryName(mv.TargetData)
+	result := ValidationResult{
+		Metric:     transferRedirectMetric,
+		SourceVal:  repositoryName(mv.SourceData),
+		TargetVal:  *redirect,
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: 0, // Not applicable for a redirect
+	}
+	if *redirect == "" {
+		result.TargetVal = "Not found"
+	}
+	// The old name no longer resolves, was reused by another repository, or the repository was transferred again
+	if !strings.EqualFold(*redirect, target) {
+		result.Status, result.StatusType = ValidationStatusMessageFail, ValidationStatusFail
+	}
+	return result, true
+}
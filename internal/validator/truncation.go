@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"mona-actions/gh-migration-validator/internal/api"
+)
+
+// truncationMetric is the metric name of the issue and pull request body truncation check
+const truncationMetric = "Truncated Bodies"
+
+// bodiesData names the issue and pull request bodies in progress messages and failed requests
+const bodiesData = "issue and pull request bodies"
+
+// truncationRatio is the share of the source body length below which a target body is considered truncated.
+// Bodies are rewritten during migration, for example links to the source host, so small differences are expected.
+const truncationRatio = 0.9
+
+// retrieveTargetBodyLengths fetches the target bodies of the longest source issues and pull requests. It must run
+// once the source is retrieved, never concurrently with it. A failure is recorded as a failed target request and
+// its message returned for logging.
+func (mv *MigrationValidator) retrieveTargetBodyLengths(owner, name string) []string {
+	if len(mv.SourceData.LargestBodies) == 0 {
+		return nil
+	}
+
+	numbers := make([]int, 0, len(mv.SourceData.LargestBodies))
+	for _, body := range mv.SourceData.LargestBodies {
+		numbers = append(numbers, body.Number)
+	}
+
+	timer := mv.newMetricTimer(api.TargetClient)
+	timer.Start(bodiesData)
+	lengths, err := mv.api.GetBodyLengths(mv.traceContext(), api.TargetClient, owner, name, numbers)
+	timer.Stop(err)
+	if err != nil {
+		mv.targetFailures = append(mv.targetFailures, requestFailures(timer.side, []string{bodiesData}, []error{err})...)
+		return []string{fmt.Sprintf("%s: %v", bodiesData, err)}
+	}
+
+	mv.TargetData.LargestBodies = lengths
+	return nil
+}
+
+// truncationResult compares the lengths of the longest issue and pull request bodies of the source, retrieved
+// with --check-truncation, with the same issues and pull requests on the target. GitHub Enterprise Importer
+// truncates extremely long bodies, so a target body much shorter than its source is reported as a warning.
+func (mv *MigrationValidator) truncationResult() (ValidationResult, bool) {
+	source, target := mv.SourceData.LargestBodies, mv.TargetData.LargestBodies
+	if len(source) == 0 || target == nil {
+		return ValidationResult{}, false
+	}
+
+	targetLengths := make(map[int]int, len(target))
+	for _, body := range target {
+		targetLengths[body.Number] = body.Length
+	}
+
+	// Issues and pull requests missing from the target are reported by the count comparisons
+	var truncated []string
+	for _, body := range source {
+		length, found := targetLengths[body.Number]
+		if found && float64(length) < float64(body.Length)*truncationRatio {
+			truncated = append(truncated, fmt.Sprintf("#%d", body.Number))
+		}
+	}
+
+	result := ValidationResult{
+		Metric:     truncationMetric,
+		SourceVal:  fmt.Sprintf("%d longest checked", len(source)),
+		TargetVal:  "None truncated",
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+	}
+	if len(truncated) > 0 {
+		result.TargetVal = fmt.Sprintf("%d truncated: %s", len(truncated), strings.Join(truncated, ", "))
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+	}
+	return result, true
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"fmt"
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/output"
+	"strings"
+)
+
+// targetUnavailableValue is shown as the target value of every metric when the target could not be retrieved
+const targetUnavailableValue = "Unavailable"
+
+// targetUnavailableResults returns the results of a validation whose target repository could not be retrieved:
+// every metric comparing against the target is marked as unavailable instead of aborting the validation, so
+// batch summaries can tell repositories not migrated yet apart from data mismatches. Comparisons not involving
+// the target, such as the migration archive against the source, are still reported.
+func (mv *MigrationValidator) targetUnavailableResults(owner, name string, err error) []ValidationResult {
+	mv.targetUnavailable = err
+	mv.TargetData = &RepositoryData{Owner: owner, Name: name, PRs: &api.PRCounts{}}
+	mv.targetFailures = nil
+
+	output.Message(mv.progress(), output.LevelWarning, fmt.Sprintf("Target repository %s/%s is unavailable, every metric is reported as %s: %v",
+		owner, name, ValidationStatusMessageUnavailable, err))
+
+	results := mv.validateRepositoryData()
+	for i := range results {
+		if strings.HasPrefix(results[i].Metric, "Archive vs Source") {
+			continue
+		}
+		results[i].TargetVal = targetUnavailableValue
+		results[i].Status = ValidationStatusMessageUnavailable
+		results[i].StatusType = ValidationStatusUnavailable
+		results[i].Difference = 0
+	}
+
+	fmt.Fprintln(mv.progress(), "Migration validation completed without target data!")
+	return results
+}
+
+// TargetUnavailableError returns why the target repository could not be retrieved,
+// or nil when the results compare against target data
+func (mv *MigrationValidator) TargetUnavailableError() error {
+	return mv.targetUnavailable
+}
+
+// HasUnavailableTarget reports whether any validation result could not be compared because the target was unavailable
+func HasUnavailableTarget(results []ValidationResult) bool {
+	return Summarize(results).Unavailable > 0
+}
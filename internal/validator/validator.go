@@ -2,19 +2,19 @@ package validator
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mona-actions/gh-migration-validator/internal/api"
 	"mona-actions/gh-migration-validator/internal/migrationarchive"
+	"mona-actions/gh-migration-validator/internal/migrationlog"
 	"mona-actions/gh-migration-validator/internal/output"
+	"mona-actions/gh-migration-validator/internal/telemetry"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
-	"time"
-
-	"github.com/pterm/pterm"
-	"github.com/spf13/viper"
 )
 
 // ValidationStatus represents the logical status of a validation result
@@ -24,17 +24,88 @@ const (
 	ValidationStatusMessagePass = "✅ PASS"
 	ValidationStatusMessageFail = "❌ FAIL"
 	ValidationStatusMessageWarn = "⚠️ WARN"
+	ValidationStatusMessageInfo = "ℹ️ INFO"
+
+	ValidationStatusMessageUnavailable = "🚫 TARGET UNAVAILABLE"
 )
 
 const (
 	ValidationStatusPass ValidationStatus = iota
 	ValidationStatusFail
 	ValidationStatusWarn
+	ValidationStatusInfo
+	ValidationStatusUnavailable // The target repository could not be retrieved, so nothing was compared
 )
 
+// String returns the plain status name (PASS, FAIL, WARN, INFO or UNAVAILABLE)
+func (s ValidationStatus) String() string {
+	switch s {
+	case ValidationStatusPass:
+		return "PASS"
+	case ValidationStatusFail:
+		return "FAIL"
+	case ValidationStatusWarn:
+		return "WARN"
+	case ValidationStatusInfo:
+		return "INFO"
+	case ValidationStatusUnavailable:
+		return "UNAVAILABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // MigrationLogIssueOffset represents the additional issue created during migration
 const MigrationLogIssueOffset = 1
 
+// ValidationOptions controls how source and target data are compared
+type ValidationOptions struct {
+	// IssueOffset is the number of additional issues expected in the target.
+	// When nil, the offset is auto-detected from the presence of the migration log issue.
+	IssueOffset *int
+	// FollowRenames validates against the new name when a repository has been renamed
+	// instead of stopping with an error.
+	FollowRenames bool
+	// Explain adds the likely cause and next step of each failure to the report.
+	Explain bool
+	// RemediationRules explain failures in addition to the default rules, and take precedence over them.
+	RemediationRules []RemediationRule
+	// ComparisonRules select how the counts of metrics are compared, an exact match when no rule names a metric.
+	ComparisonRules []ComparisonRule
+	// IgnoreRules report the failures and warnings of known differences as INFO, with their reason.
+	IgnoreRules []IgnoreRule
+	// Progress receives the progress messages and spinners of a validation, stdout when nil.
+	Progress io.Writer
+
+	// NoLFS skips the LFS object and pattern comparisons.
+	NoLFS bool
+	// CheckSecurity compares security features, which needs the security_events scope.
+	CheckSecurity bool
+	// CheckLFSLocks compares the paths of the LFS file locks, which are not migrated.
+	CheckLFSLocks bool
+	// CheckTruncation compares the bodies of that many of the longest source issues and pull requests.
+	CheckTruncation int
+	// Branches compares every branch ("all") or a comma-separated list of branches.
+	Branches string
+	// CountPRsAsIssues adds pull requests to the issue counts, for sources whose issue counts include them.
+	CountPRsAsIssues bool
+	// TransferMode validates a repository transferred to another organization on the same instance instead of
+	// migrated: no migration log issue is expected, and the old name must redirect to the target.
+	TransferMode bool
+	// RewrittenHistory validates a migration that intentionally rewrote history, e.g. with BFG or git lfs migrate:
+	// SHA mismatches are reported as INFO, and the authors and dates of the latest commits are compared instead.
+	RewrittenHistory bool
+	// RateLimitThreshold is the remaining requests below which a low rate limit is reported, 0 to never report it.
+	RateLimitThreshold int
+
+	// ShowTimings adds the time and API calls of every metric fetch to the results.
+	ShowTimings bool
+	// MarkdownTable prints the results as a markdown table instead of a summary table.
+	MarkdownTable bool
+	// MarkdownFile is the file the markdown report is written to, none when empty.
+	MarkdownFile string
+}
+
 // getValidationStatus returns both display string and enum value based on difference
 // diff > 0: target has fewer items than source (FAIL)
 // diff < 0: target has more items than source (WARN)
@@ -50,20 +121,66 @@ func getValidationStatus(diff int) (string, ValidationStatus) {
 	}
 }
 
+// countResult compares a source count with the target count
+func countResult(metric string, sourceVal, targetVal int) ValidationResult {
+	diff := sourceVal - targetVal
+	status, statusType := getValidationStatus(diff)
+
+	return ValidationResult{
+		Metric:     metric,
+		SourceVal:  sourceVal,
+		TargetVal:  targetVal,
+		Status:     status,
+		StatusType: statusType,
+		Difference: diff,
+	}
+}
+
 // RepositoryData holds all the metrics for a repository
 type RepositoryData struct {
 	Owner                 string
 	Name                  string
+	RenamedFrom           string `json:"renamed_from,omitempty"`
+	IsEmpty               bool   `json:"is_empty,omitempty"`
+	ForkParent            string `json:"fork_parent,omitempty"` // owner/name of the repository it was forked from
 	Issues                int
+	IssuesIncludePRs      bool `json:"issues_include_prs,omitempty"` // Issues counts pull requests too
 	PRs                   *api.PRCounts
 	Tags                  int
+	BranchCount           int
 	Releases              int
 	CommitCount           int
+	CommitComments        int
 	LatestCommitSHA       string
+	TreeSHA               string                    `json:"tree_sha,omitempty"` // Root tree of the default branch head
+	Branches              map[string]api.BranchHead `json:"branches,omitempty"`
+	CommitIdentities      []api.CommitIdentity      `json:"commit_identities,omitempty"` // Latest commits with --rewritten-history, nil when not retrieved
+	CommitSignatures      *api.SignatureStats       `json:"commit_signatures,omitempty"`
 	BranchProtectionRules int
+	ProtectedTagRules     int
 	Webhooks              int
 	LFSObjects            int
+	LFSPatterns           []string                                  `json:"lfs_patterns"`        // nil when not retrieved
+	LFSLocks              []string                                  `json:"lfs_locks,omitempty"` // Paths of the LFS file locks, nil when not retrieved
+	LargestBodies         []api.BodyLength                          `json:"largest_bodies,omitempty"`
+	Pages                 *api.PagesConfig                          `json:"pages,omitempty"`
+	IssueTemplates        []api.TemplateFile                        `json:"issue_templates"`    // Files of .github/ISSUE_TEMPLATE, nil when not retrieved
+	Metadata              *api.RepositoryMetadata                   `json:"metadata,omitempty"` // Description, homepage and topics, nil when not retrieved
+	Security              *api.SecurityStatus                       `json:"security,omitempty"`
 	MigrationArchive      *migrationarchive.MigrationArchiveMetrics `json:"migration_archive,omitempty"`
+	MigrationLog          *migrationlog.MigrationLogMetrics         `json:"migration_log,omitempty"`
+	RESTFallbacks         []string                                  `json:"rest_fallbacks,omitempty"` // Data counted with REST because GraphQL failed
+	// TransferRedirect is the repository the source name resolves to in transfer mode, empty when it no longer
+	// resolves; nil when not checked
+	TransferRedirect *string `json:"transfer_redirect,omitempty"`
+}
+
+// issuesExcludingPRs returns the issue count without pull requests, as migration archives and logs count issues
+func (d *RepositoryData) issuesExcludingPRs() int {
+	if d.IssuesIncludePRs && d.PRs != nil {
+		return d.Issues - d.PRs.Total
+	}
+	return d.Issues
 }
 
 // ValidationResult represents the comparison between source and target
@@ -71,64 +188,160 @@ type ValidationResult struct {
 	Metric     string
 	SourceVal  interface{}
 	TargetVal  interface{}
-	Status     string           // "✅ PASS", "❌ FAIL", "⚠️ WARN" - for display
-	StatusType ValidationStatus // Pass, Fail, Warn - for logic/testing
+	Status     string           // "✅ PASS", "❌ FAIL", "⚠️ WARN", "ℹ️ INFO", "🚫 TARGET UNAVAILABLE" - for display
+	StatusType ValidationStatus // Pass, Fail, Warn, Info, Unavailable - for logic/testing
 	Difference int              // How many items are missing in target (negative if target has more)
+	Note       string           // Shown with the difference, e.g. the reason of a known difference
 }
 
 // HasFailures reports whether any validation result failed so callers can set exit codes accurately.
 func HasFailures(results []ValidationResult) bool {
-	for _, result := range results {
-		if result.StatusType == ValidationStatusFail {
-			return true
-		}
-	}
-
-	return false
+	return Summarize(results).Failed > 0
 }
 
 // MigrationValidator handles the validation of GitHub organization migrations
 type MigrationValidator struct {
 	api        *api.GitHubAPI
+	options    ValidationOptions
 	SourceData *RepositoryData
 	TargetData *RepositoryData
+
+	// Requests that failed while the data was otherwise retrieved, kept per side as both are retrieved concurrently
+	sourceFailures []RequestFailure
+	targetFailures []RequestFailure
+
+	// Why no target data could be retrieved, when the results mark the target as unavailable
+	targetUnavailable error
+
+	// Time and API calls taken by each metric fetch, kept per side for the same reason
+	sourceTimings []MetricTiming
+	targetTimings []MetricTiming
+
+	// Span context of the validation in progress, parent of the metric fetch spans
+	spanContext context.Context
+
+	// Whether the source data is the migration archive alone, set by ValidateFromArchive
+	archiveOnly bool
 }
 
 // New creates a new MigrationValidator instance
 func New(githubAPI *api.GitHubAPI) *MigrationValidator {
+	return NewWithOptions(githubAPI, ValidationOptions{})
+}
+
+// NewWithOptions creates a new MigrationValidator instance with the given validation options
+func NewWithOptions(githubAPI *api.GitHubAPI, options ValidationOptions) *MigrationValidator {
 	return &MigrationValidator{
 		api:        githubAPI,
+		options:    options,
 		SourceData: &RepositoryData{},
 		TargetData: &RepositoryData{},
 	}
 }
 
+// issueOffset returns the number of additional issues expected in the target.
+// An explicit offset from the options always wins; otherwise the offset is 1 when the migration
+// log issue was found, 0 when the target was checked and has none, and MigrationLogIssueOffset
+// when the target was not checked.
+func (mv *MigrationValidator) issueOffset() int {
+	if mv.options.IssueOffset != nil {
+		return *mv.options.IssueOffset
+	}
+	// Transfers do not create a migration log issue
+	if mv.options.TransferMode {
+		return 0
+	}
+
+	if mv.TargetData.MigrationLog != nil {
+		if mv.TargetData.MigrationLog.Found {
+			return MigrationLogIssueOffset
+		}
+		return 0
+	}
+
+	return MigrationLogIssueOffset
+}
+
+// issueMetricName returns the metric name for an issue comparison, describing the expected offset
+func issueMetricName(prefix string, offset int) string {
+	switch offset {
+	case 0:
+		return prefix
+	case MigrationLogIssueOffset:
+		return fmt.Sprintf("%s (expected +%d for migration log)", prefix, offset)
+	default:
+		return fmt.Sprintf("%s (expected %+d)", prefix, offset)
+	}
+}
+
+// progress returns the writer progress messages and spinners are written to
+func (mv *MigrationValidator) progress() io.Writer {
+	if mv.options.Progress != nil {
+		return mv.options.Progress
+	}
+	return os.Stdout
+}
+
+// logger returns the logger of API errors and rate limit warnings, writing to the progress writer
+func (mv *MigrationValidator) logger() output.Logger {
+	return output.NewLogger(mv.progress())
+}
+
 // ValidateMigration performs the migration validation logic and returns results
 func (mv *MigrationValidator) ValidateMigration(sourceOwner, sourceRepo, targetOwner, targetRepo string) ([]ValidationResult, error) {
+	return mv.ValidateMigrationContext(context.Background(), sourceOwner, sourceRepo, targetOwner, targetRepo)
+}
+
+// ValidateMigrationContext is ValidateMigration with the validation span started under ctx
+func (mv *MigrationValidator) ValidateMigrationContext(ctx context.Context, sourceOwner, sourceRepo, targetOwner, targetRepo string) ([]ValidationResult, error) {
+	ctx, span := telemetry.StartRepositorySpan(ctx, sourceOwner+"/"+sourceRepo, targetOwner+"/"+targetRepo)
+	mv.spanContext = ctx
+
+	results, err := mv.validateMigration(sourceOwner, sourceRepo, targetOwner, targetRepo)
+	telemetry.EndSpan(span, err)
+	return results, err
+}
+
+func (mv *MigrationValidator) validateMigration(sourceOwner, sourceRepo, targetOwner, targetRepo string) ([]ValidationResult, error) {
+	// Detect renamed repositories before anything is fetched under the old name
+	originalSource := fmt.Sprintf("%s/%s", sourceOwner, sourceRepo)
+	sourceOwner, sourceRepo, err := mv.resolveRepositoryName(api.SourceClient, "source", sourceOwner, sourceRepo)
+	if err != nil {
+		return nil, err
+	}
+	originalTarget := fmt.Sprintf("%s/%s", targetOwner, targetRepo)
+	targetOwner, targetRepo, err = mv.resolveRepositoryName(api.TargetClient, "target", targetOwner, targetRepo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate access to both repositories before starting expensive operations
-	fmt.Println("Validating repository access...")
-	if err := mv.api.ValidateRepoAccess(api.SourceClient, sourceOwner, sourceRepo); err != nil {
-		return nil, fmt.Errorf("cannot access source repository %s/%s: %w", sourceOwner, sourceRepo, err)
+	fmt.Fprintln(mv.progress(), "Validating repository access...")
+	if err := mv.api.ValidateRepoAccess(mv.traceContext(), api.SourceClient, sourceOwner, sourceRepo); err != nil {
+		return nil, fmt.Errorf("cannot access source repository %s/%s: %w", sourceOwner, sourceRepo, mv.explainSSO(api.SourceClient, sourceOwner, err))
 	}
-	if err := mv.api.ValidateRepoAccess(api.TargetClient, targetOwner, targetRepo); err != nil {
-		return nil, fmt.Errorf("cannot access target repository %s/%s: %w", targetOwner, targetRepo, err)
+	// An inaccessible target, e.g. one not imported yet, is reported as unavailable once the source is retrieved.
+	// A token not authorized for SAML single sign-on is not: nothing can be validated until it is authorized.
+	targetAccessErr := mv.api.ValidateRepoAccess(mv.traceContext(), api.TargetClient, targetOwner, targetRepo)
+	if targetAccessErr != nil {
+		targetAccessErr = fmt.Errorf("cannot access target repository %s/%s: %w", targetOwner, targetRepo, mv.explainSSO(api.TargetClient, targetOwner, targetAccessErr))
+		var ssoErr *api.SSOError
+		if errors.As(targetAccessErr, &ssoErr) {
+			return nil, targetAccessErr
+		}
 	}
 
 	// Check rate limits before starting - warn if low
 	mv.checkAndWarnRateLimits()
 
-	fmt.Println("Starting migration validation...")
-	fmt.Printf("Source: %s/%s | Target: %s/%s\n", sourceOwner, sourceRepo, targetOwner, targetRepo)
-
-	// Create a multi printer. This allows multiple spinners to print simultaneously.
-	multi := pterm.DefaultMultiPrinter
-
-	// Create spinners for source and target with separate writers from the multi printer
-	sourceSpinner, _ := pterm.DefaultSpinner.WithWriter(multi.NewWriter()).Start(fmt.Sprintf("Preparing to retrieve data from %s/%s...", sourceOwner, sourceRepo))
-	targetSpinner, _ := pterm.DefaultSpinner.WithWriter(multi.NewWriter()).Start(fmt.Sprintf("Preparing to retrieve data from %s/%s...", targetOwner, targetRepo))
+	fmt.Fprintln(mv.progress(), "Starting migration validation...")
+	fmt.Fprintf(mv.progress(), "Source: %s/%s | Target: %s/%s\n", sourceOwner, sourceRepo, targetOwner, targetRepo)
 
-	// Start the multi printer
-	multi.Start()
+	// Start spinners for source and target, printing simultaneously
+	spinners, stopSpinners := output.StartSpinners(mv.progress(),
+		fmt.Sprintf("Preparing to retrieve data from %s/%s...", sourceOwner, sourceRepo),
+		fmt.Sprintf("Preparing to retrieve data from %s/%s...", targetOwner, targetRepo))
+	sourceSpinner, targetSpinner := spinners[0], spinners[1]
 
 	// Use WaitGroup to wait for both goroutines to complete
 	var wg sync.WaitGroup
@@ -147,54 +360,103 @@ func (mv *MigrationValidator) ValidateMigration(sourceOwner, sourceRepo, targetO
 	// Retrieve target repository data in a goroutine
 	go func() {
 		defer wg.Done()
-		targetErrorMsgs, targetErr = mv.retrieveTarget(targetOwner, targetRepo, targetSpinner)
+		if targetAccessErr != nil {
+			targetSpinner.Fail(fmt.Sprintf("Skipping %s/%s: repository is not accessible", targetOwner, targetRepo))
+			targetErr = targetAccessErr
+			return
+		}
+		targetErrorMsgs, targetErr = mv.retrieveTarget(targetOwner, targetRepo, sourceOwner, sourceRepo, targetSpinner)
 	}()
 
 	// Wait for both goroutines to complete
 	wg.Wait()
 
-	// Stop the multi printer
-	multi.Stop()
+	// Stop the spinners
+	stopSpinners()
+
+	// The target bodies of the longest source bodies are only known once the source is retrieved
+	if sourceErr == nil && targetErr == nil {
+		targetErrorMsgs = append(targetErrorMsgs, mv.retrieveTargetBodyLengths(targetOwner, targetRepo)...)
+	}
 
 	// Log any API errors (safe to call after spinners finish)
-	output.LogAPIErrors(sourceErrorMsgs, sourceOwner, sourceRepo, sourceErr)
-	output.LogAPIErrors(targetErrorMsgs, targetOwner, targetRepo, targetErr)
+	output.LogAPIErrors(mv.logger(), sourceErrorMsgs, sourceOwner, sourceRepo, sourceErr)
+	output.LogAPIErrors(mv.logger(), targetErrorMsgs, targetOwner, targetRepo, targetErr)
 
 	// Check for errors from both operations
 	if sourceErr != nil {
 		return nil, fmt.Errorf("failed to retrieve source data: %w", sourceErr)
 	}
+
+	// Record the names the user asked for when validation followed a rename
+	if originalSource != fmt.Sprintf("%s/%s", sourceOwner, sourceRepo) {
+		mv.SourceData.RenamedFrom = originalSource
+	}
+
 	if targetErr != nil {
-		return nil, fmt.Errorf("failed to retrieve target data: %w", targetErr)
+		var ssoErr *api.SSOError
+		if errors.As(targetErr, &ssoErr) {
+			return nil, fmt.Errorf("failed to retrieve target data: %w", targetErr)
+		}
+		if targetAccessErr == nil {
+			targetErr = fmt.Errorf("failed to retrieve target data: %w", targetErr)
+		}
+		return mv.targetUnavailableResults(targetOwner, targetRepo, targetErr), nil
+	}
+
+	if originalTarget != fmt.Sprintf("%s/%s", targetOwner, targetRepo) {
+		mv.TargetData.RenamedFrom = originalTarget
 	}
 
 	// Compare and validate the data
-	fmt.Println("\nValidating migration data...")
+	fmt.Fprintln(mv.progress(), "\nValidating migration data...")
 	results := mv.validateRepositoryData()
 
-	fmt.Println("Migration validation completed!")
+	fmt.Fprintln(mv.progress(), "Migration validation completed!")
 	return results, nil
 }
 
-// checks rate limits for both source and target clients (configurable via RATE_LIMIT_THRESHOLD env var, default 50).
-// Set threshold to 0 to disable rate limit warnings.
+// resolveRepositoryName checks whether a repository has been renamed and returns the name to validate against.
+// When the repository was renamed and FollowRenames is disabled, an error naming the canonical repository is returned.
+// Lookup failures are not fatal here; repository access is validated separately.
+func (mv *MigrationValidator) resolveRepositoryName(clientType api.ClientType, side, owner, name string) (string, string, error) {
+	canonicalOwner, canonicalName, err := mv.api.ResolveRepository(mv.traceContext(), clientType, owner, name)
+	if err != nil || canonicalName == "" {
+		return owner, name, nil
+	}
+
+	if strings.EqualFold(canonicalOwner, owner) && strings.EqualFold(canonicalName, name) {
+		return owner, name, nil
+	}
+
+	if !mv.options.FollowRenames {
+		return "", "", fmt.Errorf("%s repository %s/%s has been renamed to %s/%s; use the new name or pass --follow-renames to validate against it",
+			side, owner, name, canonicalOwner, canonicalName)
+	}
+
+	output.Message(mv.progress(), output.LevelInfo, fmt.Sprintf("The %s repository %s/%s has been renamed to %s/%s - validating against the new name",
+		side, owner, name, canonicalOwner, canonicalName))
+	return canonicalOwner, canonicalName, nil
+}
+
+// checks rate limits for both source and target clients, warning below the RateLimitThreshold option.
+// A threshold of 0 disables rate limit warnings.
 func (mv *MigrationValidator) checkAndWarnRateLimits() {
-	viper.SetDefault("RATE_LIMIT_THRESHOLD", 50)
-	threshold := viper.GetInt("RATE_LIMIT_THRESHOLD")
+	threshold := mv.options.RateLimitThreshold
 
-	sourceRL, sourceErr := mv.api.GetRateLimitStatus(api.SourceClient)
-	targetRL, targetErr := mv.api.GetRateLimitStatus(api.TargetClient)
+	sourceRL, sourceErr := mv.api.GetRateLimitStatus(mv.traceContext(), api.SourceClient)
+	targetRL, targetErr := mv.api.GetRateLimitStatus(mv.traceContext(), api.TargetClient)
 
 	if sourceErr != nil {
-		pterm.DefaultLogger.Warn("Source API rate limit check failed", pterm.DefaultLogger.Args("error", sourceErr.Error()))
+		mv.logger().Warn("Source API rate limit check failed", "error", sourceErr.Error())
 	} else {
-		output.LogRateLimitWarning("Source", sourceRL.Remaining, sourceRL.ResetAt, threshold)
+		output.LogRateLimitWarning(mv.logger(), "Source", sourceRL.Remaining, sourceRL.ResetAt, threshold)
 	}
 
 	if targetErr != nil {
-		pterm.DefaultLogger.Warn("Target API rate limit check failed", pterm.DefaultLogger.Args("error", targetErr.Error()))
+		mv.logger().Warn("Target API rate limit check failed", "error", targetErr.Error())
 	} else {
-		output.LogRateLimitWarning("Target", targetRL.Remaining, targetRL.ResetAt, threshold)
+		output.LogRateLimitWarning(mv.logger(), "Target", targetRL.Remaining, targetRL.ResetAt, threshold)
 	}
 }
 
@@ -202,147 +464,19 @@ func (mv *MigrationValidator) checkAndWarnRateLimits() {
 // Returns a slice of error messages for display after spinners finish, and an error if all requests failed.
 // An empty slice indicates all requests succeeded; callers should only expect error messages when
 // partial failures occur (some requests succeeded, some failed).
-func (mv *MigrationValidator) retrieveSource(owner, name string, spinner *pterm.SpinnerPrinter) ([]string, error) {
-	startTime := time.Now()
-	var failedRequests []string
-	var errorMessages []string
-	var successfulRequests int
-
-	mv.SourceData.Owner = owner
-	mv.SourceData.Name = name
-
-	// Get issue count
-	spinner.UpdateText(fmt.Sprintf("Fetching issues from %s/%s...", owner, name))
-	issues, err := mv.api.GetIssueCount(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "issues")
-		errorMessages = append(errorMessages, fmt.Sprintf("issues: %v", err))
-		mv.SourceData.Issues = 0
-	} else {
-		mv.SourceData.Issues = issues
-		successfulRequests++
-	}
-
-	// Get PR counts
-	spinner.UpdateText(fmt.Sprintf("Fetching pull requests from %s/%s...", owner, name))
-	prCounts, err := mv.api.GetPRCounts(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "pull requests")
-		errorMessages = append(errorMessages, fmt.Sprintf("pull requests: %v", err))
-		mv.SourceData.PRs = &api.PRCounts{Total: 0, Open: 0, Merged: 0, Closed: 0}
-	} else {
-		mv.SourceData.PRs = prCounts
-		successfulRequests++
-	}
-
-	// Get tag count
-	spinner.UpdateText(fmt.Sprintf("Fetching tags from %s/%s...", owner, name))
-	tags, err := mv.api.GetTagCount(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "tags")
-		errorMessages = append(errorMessages, fmt.Sprintf("tags: %v", err))
-		mv.SourceData.Tags = 0
-	} else {
-		mv.SourceData.Tags = tags
-		successfulRequests++
-	}
-
-	// Get release count
-	spinner.UpdateText(fmt.Sprintf("Fetching releases from %s/%s...", owner, name))
-	releases, err := mv.api.GetReleaseCount(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "releases")
-		errorMessages = append(errorMessages, fmt.Sprintf("releases: %v", err))
-		mv.SourceData.Releases = 0
-	} else {
-		mv.SourceData.Releases = releases
-		successfulRequests++
-	}
-
-	// Get commit count
-	spinner.UpdateText(fmt.Sprintf("Fetching commit count from %s/%s...", owner, name))
-	commitCount, err := mv.api.GetCommitCount(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "commits")
-		errorMessages = append(errorMessages, fmt.Sprintf("commits: %v", err))
-		mv.SourceData.CommitCount = 0
-	} else {
-		mv.SourceData.CommitCount = commitCount
-		successfulRequests++
-	}
-
-	// Get latest commit hash
-	spinner.UpdateText(fmt.Sprintf("Fetching latest commit hash from %s/%s...", owner, name))
-	latestCommitSHA, err := mv.api.GetLatestCommitHash(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "latest commit hash")
-		errorMessages = append(errorMessages, fmt.Sprintf("latest commit hash: %v", err))
-		mv.SourceData.LatestCommitSHA = ""
-	} else {
-		mv.SourceData.LatestCommitSHA = latestCommitSHA
-		successfulRequests++
-	}
-
-	// Get branch protection rules count
-	spinner.UpdateText(fmt.Sprintf("Fetching branch protection rules from %s/%s...", owner, name))
-	branchProtectionRules, err := mv.api.GetBranchProtectionRulesCount(api.SourceClient, owner, name)
+func (mv *MigrationValidator) retrieveSource(owner, name string, spinner output.Spinner) ([]string, error) {
+	mv.sourceTimings = nil
+	failures, errorMessages, err := mv.retrieve(api.SourceClient, owner, name, owner, name, mv.SourceData, spinner)
 	if err != nil {
-		failedRequests = append(failedRequests, "branch protection rules")
-		errorMessages = append(errorMessages, fmt.Sprintf("branch protection rules: %v", err))
-		mv.SourceData.BranchProtectionRules = 0
-	} else {
-		mv.SourceData.BranchProtectionRules = branchProtectionRules
-		successfulRequests++
-	}
-
-	// Get webhook count
-	spinner.UpdateText(fmt.Sprintf("Fetching webhooks from %s/%s...", owner, name))
-	webhooks, err := mv.api.GetWebhookCount(api.SourceClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "webhooks")
-		errorMessages = append(errorMessages, fmt.Sprintf("webhooks: %v", err))
-		mv.SourceData.Webhooks = 0
-	} else {
-		mv.SourceData.Webhooks = webhooks
-		successfulRequests++
-	}
-
-	// Get LFS object count (skip if NO_LFS flag is set)
-	if !viper.GetBool("NO_LFS") {
-		spinner.UpdateText(fmt.Sprintf("Fetching LFS objects from %s/%s...", owner, name))
-		sourceLFSObjects, err := mv.api.GetLFSObjects(api.SourceClient, owner, name)
-		if err != nil {
-			failedRequests = append(failedRequests, "LFS objects")
-			errorMessages = append(errorMessages, fmt.Sprintf("LFS objects: %v", err))
-			mv.SourceData.LFSObjects = 0
-		} else {
-			mv.SourceData.LFSObjects = len(sourceLFSObjects)
-			successfulRequests++
-		}
-	} else {
-		mv.SourceData.LFSObjects = 0
-	}
-
-	duration := time.Since(startTime)
-
-	// Determine success/failure status
-	if successfulRequests == 0 {
-		spinner.Fail(fmt.Sprintf("Failed to retrieve any data from %s/%s", owner, name))
-		return errorMessages, fmt.Errorf("all API requests failed for %s/%s", owner, name)
-	}
-
-	if len(failedRequests) > 0 {
-		spinner.Warning(fmt.Sprintf("%s/%s: %d OK, %d failed (%v) - missing: %v",
-			owner, name, successfulRequests, len(failedRequests), duration, failedRequests))
-	} else {
-		spinner.Success(fmt.Sprintf("%s/%s retrieved successfully (%v)", owner, name, duration))
+		return errorMessages, err
 	}
 
+	mv.sourceFailures = failures
 	return errorMessages, nil
 }
 
 // RetrieveSourceData is a public wrapper for retrieveSource for use by the export package
-func (mv *MigrationValidator) RetrieveSourceData(owner, name string, spinner *pterm.SpinnerPrinter) ([]string, error) {
+func (mv *MigrationValidator) RetrieveSourceData(owner, name string, spinner output.Spinner) ([]string, error) {
 	return mv.retrieveSource(owner, name, spinner)
 }
 
@@ -362,6 +496,30 @@ func (mv *MigrationValidator) SetSourceDataFromExport(exportData *RepositoryData
 
 // ValidateFromExport performs validation against target using pre-loaded source data from export
 func (mv *MigrationValidator) ValidateFromExport(targetOwner, targetRepo string) ([]ValidationResult, error) {
+	source := ""
+	if mv.SourceData != nil {
+		source = mv.SourceData.Owner + "/" + mv.SourceData.Name
+	}
+	ctx, span := telemetry.StartRepositorySpan(context.Background(), source, targetOwner+"/"+targetRepo)
+	mv.spanContext = ctx
+
+	results, err := mv.validateFromExport(targetOwner, targetRepo)
+	telemetry.EndSpan(span, err)
+	return results, err
+}
+
+// ValidateFromArchive validates the target against the migration archive set as the source data with
+// SetSourceDataFromExport, for sources that can no longer be queried, e.g. decommissioned servers. Only the
+// counts of the archive are compared with the target, as the archive has none of the other source data.
+func (mv *MigrationValidator) ValidateFromArchive(targetOwner, targetRepo string) ([]ValidationResult, error) {
+	if mv.SourceData == nil || mv.SourceData.MigrationArchive == nil {
+		return nil, fmt.Errorf("source data has no migration archive metrics")
+	}
+	mv.archiveOnly = true
+	return mv.ValidateFromExport(targetOwner, targetRepo)
+}
+
+func (mv *MigrationValidator) validateFromExport(targetOwner, targetRepo string) ([]ValidationResult, error) {
 	// Validate that source data is already loaded
 	if mv.SourceData == nil || mv.SourceData.Owner == "" || mv.SourceData.Name == "" {
 		return nil, fmt.Errorf("source data not properly loaded - call SetSourceDataFromExport with valid data first")
@@ -372,37 +530,65 @@ func (mv *MigrationValidator) ValidateFromExport(targetOwner, targetRepo string)
 		mv.SourceData.PRs = &api.PRCounts{Total: 0, Open: 0, Merged: 0, Closed: 0}
 	}
 
-	// Validate access to target repository before starting
-	fmt.Println("Validating repository access...")
-	if err := mv.api.ValidateRepoAccess(api.TargetClient, targetOwner, targetRepo); err != nil {
-		return nil, fmt.Errorf("cannot access target repository %s/%s: %w", targetOwner, targetRepo, err)
+	// Detect a renamed target repository before anything is fetched under the old name
+	originalTarget := fmt.Sprintf("%s/%s", targetOwner, targetRepo)
+	targetOwner, targetRepo, err := mv.resolveRepositoryName(api.TargetClient, "target", targetOwner, targetRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate access to target repository before starting, reporting an inaccessible target as unavailable
+	fmt.Fprintln(mv.progress(), "Validating repository access...")
+	if err := mv.api.ValidateRepoAccess(mv.traceContext(), api.TargetClient, targetOwner, targetRepo); err != nil {
+		err = fmt.Errorf("cannot access target repository %s/%s: %w", targetOwner, targetRepo, mv.explainSSO(api.TargetClient, targetOwner, err))
+		var ssoErr *api.SSOError
+		if errors.As(err, &ssoErr) {
+			return nil, err
+		}
+		return mv.targetUnavailableResults(targetOwner, targetRepo, err), nil
 	}
 
 	// Check rate limits before starting - warn if low
 	mv.checkAndWarnRateLimits()
 
-	fmt.Println("Starting migration validation from export...")
-	fmt.Printf("Source: %s/%s (from export) | Target: %s/%s\n",
-		mv.SourceData.Owner, mv.SourceData.Name, targetOwner, targetRepo)
+	fmt.Fprintln(mv.progress(), "Starting migration validation from export...")
+	origin := "export"
+	if mv.archiveOnly {
+		origin = "migration archive"
+	}
+	fmt.Fprintf(mv.progress(), "Source: %s/%s (from %s) | Target: %s/%s\n",
+		mv.SourceData.Owner, mv.SourceData.Name, origin, targetOwner, targetRepo)
 
 	// Create a spinner for target data retrieval
-	spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Fetching target data from %s/%s...", targetOwner, targetRepo))
+	spinner := output.StartSpinner(mv.progress(), fmt.Sprintf("Fetching target data from %s/%s...", targetOwner, targetRepo))
 
 	// Retrieve target data using existing functionality
-	errorMsgs, err := mv.retrieveTarget(targetOwner, targetRepo, spinner)
+	errorMsgs, err := mv.retrieveTarget(targetOwner, targetRepo, mv.SourceData.Owner, mv.SourceData.Name, spinner)
+	if err == nil {
+		errorMsgs = append(errorMsgs, mv.retrieveTargetBodyLengths(targetOwner, targetRepo)...)
+	}
 
 	// Log any API errors (safe to call after spinner finishes)
-	output.LogAPIErrors(errorMsgs, targetOwner, targetRepo, err)
+	output.LogAPIErrors(mv.logger(), errorMsgs, targetOwner, targetRepo, err)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve target data: %w", err)
+		err = fmt.Errorf("failed to retrieve target data: %w", err)
+		var ssoErr *api.SSOError
+		if errors.As(err, &ssoErr) {
+			return nil, err
+		}
+		return mv.targetUnavailableResults(targetOwner, targetRepo, err), nil
+	}
+
+	if originalTarget != fmt.Sprintf("%s/%s", targetOwner, targetRepo) {
+		mv.TargetData.RenamedFrom = originalTarget
 	}
 
 	// Compare and validate the data (same as ValidateMigration)
-	fmt.Println("\nValidating migration data...")
+	fmt.Fprintln(mv.progress(), "\nValidating migration data...")
 	results := mv.validateRepositoryData()
 
-	fmt.Println("Migration validation completed!")
+	fmt.Fprintln(mv.progress(), "Migration validation completed!")
 	return results, nil
 }
 
@@ -410,448 +596,360 @@ func (mv *MigrationValidator) ValidateFromExport(targetOwner, targetRepo string)
 // Handles individual API failures gracefully by logging errors and continuing with default values.
 // Returns a slice of error messages for display after spinners finish, and an error if all requests failed.
 // An empty slice indicates all requests succeeded; callers should only expect error messages when
-// partial failures occur (some requests succeeded, some failed).
-func (mv *MigrationValidator) retrieveTarget(owner, name string, spinner *pterm.SpinnerPrinter) ([]string, error) {
-	startTime := time.Now()
-	var failedRequests []string
-	var errorMessages []string
-	var successfulRequests int
-
-	mv.TargetData.Owner = owner
-	mv.TargetData.Name = name
-
-	// Get issue count
-	spinner.UpdateText(fmt.Sprintf("Fetching issues from %s/%s...", owner, name))
-	issues, err := mv.api.GetIssueCount(api.TargetClient, owner, name)
+// partial failures occur (some requests succeeded, some failed). sourceOwner and sourceName name the source
+// repository, whose LFS objects are looked up in the target.
+func (mv *MigrationValidator) retrieveTarget(owner, name, sourceOwner, sourceName string, spinner output.Spinner) ([]string, error) {
+	mv.targetTimings = nil
+	failures, errorMessages, err := mv.retrieve(api.TargetClient, owner, name, sourceOwner, sourceName, mv.TargetData, spinner)
 	if err != nil {
-		failedRequests = append(failedRequests, "issues")
-		errorMessages = append(errorMessages, fmt.Sprintf("issues: %v", err))
-		mv.TargetData.Issues = 0
-	} else {
-		mv.TargetData.Issues = issues
-		successfulRequests++
+		return errorMessages, err
 	}
 
-	// Get PR counts
-	spinner.UpdateText(fmt.Sprintf("Fetching pull requests from %s/%s...", owner, name))
-	prCounts, err := mv.api.GetPRCounts(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "pull requests")
-		errorMessages = append(errorMessages, fmt.Sprintf("pull requests: %v", err))
-		mv.TargetData.PRs = &api.PRCounts{Total: 0, Open: 0, Merged: 0, Closed: 0}
-	} else {
-		mv.TargetData.PRs = prCounts
-		successfulRequests++
+	mv.targetFailures = failures
+	return errorMessages, nil
+}
+
+// allRequestsFailedError returns the error for a repository from which no data could be retrieved.
+// It wraps the first request error of a known kind so callers can tell authentication, missing
+// repositories and rate limiting apart.
+func allRequestsFailedError(owner, name string, requestErrors []error) error {
+	for _, err := range requestErrors {
+		if api.ErrorCode(err) != api.ErrorCodeUnknown {
+			return fmt.Errorf("all API requests failed for %s/%s: %w", owner, name, err)
+		}
 	}
+	return fmt.Errorf("all API requests failed for %s/%s", owner, name)
+}
 
-	// Get tag count
-	spinner.UpdateText(fmt.Sprintf("Fetching tags from %s/%s...", owner, name))
-	tags, err := mv.api.GetTagCount(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "tags")
-		errorMessages = append(errorMessages, fmt.Sprintf("tags: %v", err))
-		mv.TargetData.Tags = 0
-	} else {
-		mv.TargetData.Tags = tags
-		successfulRequests++
+// PartialDataError returns an error wrapping api.ErrPartialData when some requests failed during the
+// last validation, so its results were computed with default values for the missing data
+func (mv *MigrationValidator) PartialDataError() error {
+	var missing []string
+	for _, failure := range mv.FailedRequests() {
+		missing = append(missing, failure.Side+" "+failure.Data)
 	}
 
-	// Get release count
-	spinner.UpdateText(fmt.Sprintf("Fetching releases from %s/%s...", owner, name))
-	releases, err := mv.api.GetReleaseCount(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "releases")
-		errorMessages = append(errorMessages, fmt.Sprintf("releases: %v", err))
-		mv.TargetData.Releases = 0
-	} else {
-		mv.TargetData.Releases = releases
-		successfulRequests++
+	if len(missing) == 0 {
+		return nil
 	}
+	return fmt.Errorf("%w: failed to retrieve %s", api.ErrPartialData, strings.Join(missing, ", "))
+}
 
-	// Get commit count
-	spinner.UpdateText(fmt.Sprintf("Fetching commit count from %s/%s...", owner, name))
-	commitCount, err := mv.api.GetCommitCount(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "commits")
-		errorMessages = append(errorMessages, fmt.Sprintf("commits: %v", err))
-		mv.TargetData.CommitCount = 0
-	} else {
-		mv.TargetData.CommitCount = commitCount
-		successfulRequests++
+// validateRepositoryData compares source and target repository data and returns validation results
+func (mv *MigrationValidator) validateRepositoryData() []ValidationResult {
+	fmt.Fprintln(mv.progress(), "Comparing repository data...")
+
+	metrics := repositoryMetrics
+	if mv.archiveOnly {
+		metrics = archiveOnlyMetrics
 	}
 
-	// Get latest commit hash
-	spinner.UpdateText(fmt.Sprintf("Fetching latest commit hash from %s/%s...", owner, name))
-	latestCommitSHA, err := mv.api.GetLatestCommitHash(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "latest commit hash")
-		errorMessages = append(errorMessages, fmt.Sprintf("latest commit hash: %v", err))
-		mv.TargetData.LatestCommitSHA = ""
-	} else {
-		mv.TargetData.LatestCommitSHA = latestCommitSHA
-		successfulRequests++
+	var results []ValidationResult
+	for _, metric := range metrics {
+		results = append(results, mv.compareMetric(metric)...)
 	}
+	return mv.applyIgnoreRules(results)
+}
 
-	// Get branch protection rules count
-	spinner.UpdateText(fmt.Sprintf("Fetching branch protection rules from %s/%s...", owner, name))
-	branchProtectionRules, err := mv.api.GetBranchProtectionRulesCount(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "branch protection rules")
-		errorMessages = append(errorMessages, fmt.Sprintf("branch protection rules: %v", err))
-		mv.TargetData.BranchProtectionRules = 0
-	} else {
-		mv.TargetData.BranchProtectionRules = branchProtectionRules
-		successfulRequests++
+// latestCommitResult compares the latest commit SHAs, which fail to match when commits are missing.
+// Returns false when either repository is empty, as an empty repository has no latest commit.
+func (mv *MigrationValidator) latestCommitResult() (ValidationResult, bool) {
+	if mv.SourceData.IsEmpty || mv.TargetData.IsEmpty {
+		return ValidationResult{}, false
 	}
 
-	// Get webhook count
-	spinner.UpdateText(fmt.Sprintf("Fetching webhooks from %s/%s...", owner, name))
-	webhooks, err := mv.api.GetWebhookCount(api.TargetClient, owner, name)
-	if err != nil {
-		failedRequests = append(failedRequests, "webhooks")
-		errorMessages = append(errorMessages, fmt.Sprintf("webhooks: %v", err))
-		mv.TargetData.Webhooks = 0
-	} else {
-		mv.TargetData.Webhooks = webhooks
-		successfulRequests++
-	}
-
-	// Get LFS object count and validate them (skip if NO_LFS flag is set)
-	if !viper.GetBool("NO_LFS") {
-		spinner.UpdateText(fmt.Sprintf("Validating LFS objects in %s/%s...", owner, name))
-
-		// First, get source LFS objects to validate against
-		sourceLFSObjects, sourceErr := mv.api.GetLFSObjects(api.SourceClient, mv.SourceData.Owner, mv.SourceData.Name)
-		if sourceErr != nil {
-			// If we can't get source LFS objects, fall back to just counting target objects
-			lfsObjects, err := mv.api.GetLFSObjectCount(api.TargetClient, owner, name)
-			if err != nil {
-				failedRequests = append(failedRequests, "LFS objects")
-				errorMessages = append(errorMessages, fmt.Sprintf("LFS objects: %v", err))
-				mv.TargetData.LFSObjects = 0
-			} else {
-				mv.TargetData.LFSObjects = lfsObjects
-				successfulRequests++
-			}
-		} else if len(sourceLFSObjects) > 0 {
-			// Validate that source LFS objects exist in target
-			existingCount, missingCount, err := mv.api.ValidateLFSObjects(api.TargetClient, owner, name, sourceLFSObjects)
-			if err != nil {
-				failedRequests = append(failedRequests, "LFS objects")
-				errorMessages = append(errorMessages, fmt.Sprintf("LFS objects validation: %v", err))
-				mv.TargetData.LFSObjects = 0
-			} else {
-				// Only count the objects that actually exist in target LFS storage
-				mv.TargetData.LFSObjects = existingCount
-				successfulRequests++
-
-				// Add a warning if some objects are missing
-				if missingCount > 0 {
-					warningMsg := fmt.Sprintf("LFS objects: %d found, %d missing from LFS storage", existingCount, missingCount)
-					errorMessages = append(errorMessages, warningMsg)
-				}
-			}
-		} else {
-			// No source LFS objects to validate
-			mv.TargetData.LFSObjects = 0
-			successfulRequests++
+	result := ValidationResult{
+		Metric:     "Latest Commit SHA",
+		SourceVal:  mv.SourceData.LatestCommitSHA,
+		TargetVal:  mv.TargetData.LatestCommitSHA,
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: 0, // Not applicable for SHA comparison
+	}
+	if mv.SourceData.LatestCommitSHA != mv.TargetData.LatestCommitSHA {
+		result.Status = ValidationStatusMessageFail
+		result.StatusType = ValidationStatusFail
+		if mv.options.RewrittenHistory {
+			result.Status, result.StatusType = ValidationStatusMessageInfo, ValidationStatusInfo
+			result.Note = rewrittenHistoryNote
 		}
-	} else {
-		mv.TargetData.LFSObjects = 0
 	}
+	return result, true
+}
 
-	duration := time.Since(startTime)
+// treeHashMetric is the metric name of the default branch tree comparison
+const treeHashMetric = "Default Branch Tree SHA"
 
-	// Determine success/failure status
-	if successfulRequests == 0 {
-		spinner.Fail(fmt.Sprintf("Failed to retrieve any data from %s/%s", owner, name))
-		return errorMessages, fmt.Errorf("all API requests failed for %s/%s", owner, name)
+// treeHashResult compares the root trees of the default branch heads, which are identical when the contents
+// are. Returns false when either repository is empty or its tree was not retrieved.
+func (mv *MigrationValidator) treeHashResult() (ValidationResult, bool) {
+	if mv.SourceData.IsEmpty || mv.TargetData.IsEmpty || mv.SourceData.TreeSHA == "" || mv.TargetData.TreeSHA == "" {
+		return ValidationResult{}, false
 	}
 
-	if len(failedRequests) > 0 {
-		spinner.Warning(fmt.Sprintf("%s/%s: %d OK, %d failed (%v) - missing: %v",
-			owner, name, successfulRequests, len(failedRequests), duration, failedRequests))
-	} else {
-		spinner.Success(fmt.Sprintf("%s/%s retrieved successfully (%v)", owner, name, duration))
+	result := ValidationResult{
+		Metric:     treeHashMetric,
+		SourceVal:  mv.SourceData.TreeSHA,
+		TargetVal:  mv.TargetData.TreeSHA,
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+	}
+	if mv.SourceData.TreeSHA != mv.TargetData.TreeSHA {
+		result.Status, result.StatusType = ValidationStatusMessageFail, ValidationStatusFail
 	}
+	return result, true
+}
 
-	return errorMessages, nil
+// archiveCounts are the counts of the migration archive compared with the source and the target
+var archiveCounts = []struct {
+	name    string
+	archive func(archive *migrationarchive.MigrationArchiveMetrics) int
+	data    func(data *RepositoryData) int
+}{
+	{"Issues", func(a *migrationarchive.MigrationArchiveMetrics) int { return a.Issues }, func(d *RepositoryData) int { return d.issuesExcludingPRs() }},
+	{"Pull Requests", func(a *migrationarchive.MigrationArchiveMetrics) int { return a.PullRequests }, func(d *RepositoryData) int { return d.PRs.Total }},
+	{"Protected Branches", func(a *migrationarchive.MigrationArchiveMetrics) int { return a.ProtectedBranches }, func(d *RepositoryData) int { return d.BranchProtectionRules }},
+	{"Releases", func(a *migrationarchive.MigrationArchiveMetrics) int { return a.Releases }, func(d *RepositoryData) int { return d.Releases }},
+	{"Commit Comments", func(a *migrationarchive.MigrationArchiveMetrics) int { return a.CommitComments }, func(d *RepositoryData) int { return d.CommitComments }},
 }
 
-// validateRepositoryData compares source and target repository data and returns validation results
-func (mv *MigrationValidator) validateRepositoryData() []ValidationResult {
-	fmt.Println("Comparing repository data...")
+// archiveResults compares the migration archive with the source API data, to check the export is complete,
+// and then with the target data, to check the import succeeded. Returns nothing without an archive, and only
+// the comparisons with the target when the archive is the only source data.
+func (mv *MigrationValidator) archiveResults() []ValidationResult {
+	archive := mv.SourceData.MigrationArchive
+	if archive == nil {
+		return nil
+	}
 
 	var results []ValidationResult
+	// Without source API data there is nothing to check the archive against
+	if !mv.archiveOnly {
+		for _, count := range archiveCounts {
+			// The archive is expected to hold what the source API reports, displayed in the target column
+			result := countResult("Archive vs Source "+count.name, count.archive(archive), count.data(mv.SourceData))
+			result.SourceVal, result.TargetVal = count.data(mv.SourceData), count.archive(archive)
+			results = append(results, result)
+		}
+	}
 
-	// Compare Issues (target should have source issues + migration log issue)
-	expectedTargetIssues := mv.SourceData.Issues + MigrationLogIssueOffset
-	issueDiff := expectedTargetIssues - mv.TargetData.Issues
-	issueStatus, issueStatusType := getValidationStatus(issueDiff)
-
-	results = append(results, ValidationResult{
-		Metric:     "Issues (expected +1 for migration log)",
-		SourceVal:  mv.SourceData.Issues,
-		TargetVal:  mv.TargetData.Issues,
-		Status:     issueStatus,
-		StatusType: issueStatusType,
-		Difference: issueDiff,
-	})
-
-	// Compare Total PRs
-	prDiff := mv.SourceData.PRs.Total - mv.TargetData.PRs.Total
-	prStatus, prStatusType := getValidationStatus(prDiff)
-
-	results = append(results, ValidationResult{
-		Metric:     "Pull Requests (Total)",
-		SourceVal:  mv.SourceData.PRs.Total,
-		TargetVal:  mv.TargetData.PRs.Total,
-		Status:     prStatus,
-		StatusType: prStatusType,
-		Difference: prDiff,
-	})
-
-	// Compare Open PRs
-	openPRDiff := mv.SourceData.PRs.Open - mv.TargetData.PRs.Open
-	openPRStatus, openPRStatusType := getValidationStatus(openPRDiff)
+	if result, ok := archiveReferencesResult(archive); ok {
+		results = append(results, result)
+	}
+	if result, ok := archiveSchemaResult(archive); ok {
+		results = append(results, result)
+	}
 
-	results = append(results, ValidationResult{
-		Metric:     "Pull Requests (Open)",
-		SourceVal:  mv.SourceData.PRs.Open,
-		TargetVal:  mv.TargetData.PRs.Open,
-		Status:     openPRStatus,
-		StatusType: openPRStatusType,
-		Difference: openPRDiff,
-	})
+	issueOffset := mv.issueOffset()
+	for _, count := range archiveCounts {
+		name, offset := "Archive vs Target "+count.name, 0
+		if count.name == "Issues" {
+			// The target has the migration log issue in addition to the archived issues
+			name, offset = issueMetricName(name, issueOffset), issueOffset
+		}
+		result := countResult(name, count.archive(archive)+offset, count.data(mv.TargetData))
+		result.SourceVal = count.archive(archive)
+		results = append(results, result)
+	}
+	return results
+}
 
-	// Compare Merged PRs
-	mergedPRDiff := mv.SourceData.PRs.Merged - mv.TargetData.PRs.Merged
-	mergedPRStatus, mergedPRStatusType := getValidationStatus(mergedPRDiff)
+// archiveReferencesMetric checks the files referenced inside the migration archive, such as attachments
+const archiveReferencesMetric = "Archive vs Source File References"
 
-	results = append(results, ValidationResult{
-		Metric:     "Pull Requests (Merged)",
-		SourceVal:  mv.SourceData.PRs.Merged,
-		TargetVal:  mv.TargetData.PRs.Merged,
-		Status:     mergedPRStatus,
-		StatusType: mergedPRStatusType,
-		Difference: mergedPRDiff,
-	})
+// maxListedBrokenReferences is the number of broken references named in the note of the result
+const maxListedBrokenReferences = 3
 
-	// Compare Tags
-	tagDiff := mv.SourceData.Tags - mv.TargetData.Tags
-	tagStatus, tagStatusType := getValidationStatus(tagDiff)
+// archiveReferencesResult fails when files referenced inside the archive are missing from it, or its urls.json
+// is missing or invalid, as the import of the archive would fail. Returns false for exports taken before the
+// references were checked.
+func archiveReferencesResult(archive *migrationarchive.MigrationArchiveMetrics) (ValidationResult, bool) {
+	broken := len(archive.BrokenReferences)
+	if archive.References == 0 && broken == 0 {
+		return ValidationResult{}, false
+	}
 
-	results = append(results, ValidationResult{
-		Metric:     "Tags",
-		SourceVal:  mv.SourceData.Tags,
-		TargetVal:  mv.TargetData.Tags,
-		Status:     tagStatus,
-		StatusType: tagStatusType,
-		Difference: tagDiff,
-	})
+	result := ValidationResult{
+		Metric:     archiveReferencesMetric,
+		SourceVal:  fmt.Sprintf("%d referenced", archive.References),
+		TargetVal:  fmt.Sprintf("%d broken", broken),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: broken,
+	}
+	if broken > 0 {
+		listed := archive.BrokenReferences[:min(broken, maxListedBrokenReferences)]
+		result.Status, result.StatusType = ValidationStatusMessageFail, ValidationStatusFail
+		result.Note = "broken: " + strings.Join(listed, ", ")
+		if broken > len(listed) {
+			result.Note += fmt.Sprintf(" and %d more", broken-len(listed))
+		}
+	}
+	return result, true
+}
 
-	// Compare Releases
-	releaseDiff := mv.SourceData.Releases - mv.TargetData.Releases
-	releaseStatus, releaseStatusType := getValidationStatus(releaseDiff)
+// archiveSchemaMetric checks the schema version of the migration archive against the versions its entities are
+// counted for
+const archiveSchemaMetric = "Archive vs Source Schema Version"
 
-	results = append(results, ValidationResult{
-		Metric:     "Releases",
-		SourceVal:  mv.SourceData.Releases,
-		TargetVal:  mv.TargetData.Releases,
-		Status:     releaseStatus,
-		StatusType: releaseStatusType,
-		Difference: releaseDiff,
-	})
-
-	// Compare Commit Count
-	commitDiff := mv.SourceData.CommitCount - mv.TargetData.CommitCount
-	commitStatus, commitStatusType := getValidationStatus(commitDiff)
+// archiveSchemaResult warns when the schema version of the archive is unknown or not one the counting was written
+// for, as the archive counts may then be wrong. Returns false for exports taken before the version was read.
+func archiveSchemaResult(archive *migrationarchive.MigrationArchiveMetrics) (ValidationResult, bool) {
+	if archive.SchemaVersion == "" && archive.SchemaWarning == "" {
+		return ValidationResult{}, false
+	}
 
-	results = append(results, ValidationResult{
-		Metric:     "Commits",
-		SourceVal:  mv.SourceData.CommitCount,
-		TargetVal:  mv.TargetData.CommitCount,
-		Status:     commitStatus,
-		StatusType: commitStatusType,
-		Difference: commitDiff,
-	})
+	version := archive.SchemaVersion
+	if version == "" {
+		version = "unknown"
+	}
+	result := ValidationResult{
+		Metric:     archiveSchemaMetric,
+		SourceVal:  version,
+		TargetVal:  fmt.Sprintf("%s - %s", migrationarchive.MinSupportedSchemaVersion, migrationarchive.MaxSupportedSchemaVersion),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+	}
+	if archive.SchemaWarning != "" {
+		result.Status, result.StatusType = ValidationStatusMessageWarn, ValidationStatusWarn
+		result.Note = archive.SchemaWarning
+	}
+	return result, true
+}
 
-	// Compare Branch Protection Rules
-	branchProtectionDiff := mv.SourceData.BranchProtectionRules - mv.TargetData.BranchProtectionRules
-	branchProtectionStatus, branchProtectionStatusType := getValidationStatus(branchProtectionDiff)
+// repositoryContentResult returns an informational result describing empty repositories.
+// Returns false when neither repository is empty.
+func (mv *MigrationValidator) repositoryContentResult() (ValidationResult, bool) {
+	describe := func(data *RepositoryData) string {
+		if data.IsEmpty {
+			return "Empty"
+		}
+		return "Has commits"
+	}
 
-	results = append(results, ValidationResult{
-		Metric:     "Branch Protection Rules",
-		SourceVal:  mv.SourceData.BranchProtectionRules,
-		TargetVal:  mv.TargetData.BranchProtectionRules,
-		Status:     branchProtectionStatus,
-		StatusType: branchProtectionStatusType,
-		Difference: branchProtectionDiff,
-	})
+	result := ValidationResult{
+		Metric:     "Repository Content",
+		SourceVal:  describe(mv.SourceData),
+		TargetVal:  describe(mv.TargetData),
+		Status:     ValidationStatusMessageInfo,
+		StatusType: ValidationStatusInfo,
+		Difference: 0,
+	}
 
-	// Compare Webhooks
-	webhooksDiff := mv.SourceData.Webhooks - mv.TargetData.Webhooks
-	webhooksStatus, webhooksStatusType := getValidationStatus(webhooksDiff)
+	switch {
+	case mv.SourceData.IsEmpty && mv.TargetData.IsEmpty:
+		result.SourceVal = "Empty"
+		result.TargetVal = "Empty (both empty)"
+	case mv.TargetData.IsEmpty:
+		result.TargetVal = "Empty (target empty but source has content)"
+	case mv.SourceData.IsEmpty:
+		result.TargetVal = "Has commits (source empty but target has content)"
+	default:
+		return ValidationResult{}, false
+	}
 
-	results = append(results, ValidationResult{
-		Metric:     "Webhooks",
-		SourceVal:  mv.SourceData.Webhooks,
-		TargetVal:  mv.TargetData.Webhooks,
-		Status:     webhooksStatus,
-		StatusType: webhooksStatusType,
-		Difference: webhooksDiff,
-	})
+	return result, true
+}
 
-	// Compare LFS Objects (skip if NO_LFS flag is set)
-	if !viper.GetBool("NO_LFS") {
-		lfsDiff := mv.SourceData.LFSObjects - mv.TargetData.LFSObjects
-		lfsStatus, lfsStatusType := getValidationStatus(lfsDiff)
+// validateMigrationLog reports whether the target has a migration log issue and compares the summary
+// counts parsed from it against the target data. Counts missing from the log are not compared.
+func (mv *MigrationValidator) validateMigrationLog() []ValidationResult {
+	var results []ValidationResult
+	migrationLog := mv.TargetData.MigrationLog
 
+	if !migrationLog.Found {
 		results = append(results, ValidationResult{
-			Metric:     "LFS Objects",
-			SourceVal:  mv.SourceData.LFSObjects,
-			TargetVal:  mv.TargetData.LFSObjects,
-			Status:     lfsStatus,
-			StatusType: lfsStatusType,
-			Difference: lfsDiff,
+			Metric:     "Migration Log Issue",
+			SourceVal:  "N/A",
+			TargetVal:  "Not found",
+			Status:     ValidationStatusMessageWarn,
+			StatusType: ValidationStatusWarn,
+			Difference: 0,
 		})
-	}
-
-	// Compare Latest Commit SHA
-	latestCommitStatus := ValidationStatusMessagePass
-	latestCommitStatusType := ValidationStatusPass
-
-	if mv.SourceData.LatestCommitSHA != mv.TargetData.LatestCommitSHA {
-		latestCommitStatus = ValidationStatusMessageFail
-		latestCommitStatusType = ValidationStatusFail
+		return results
 	}
 
 	results = append(results, ValidationResult{
-		Metric:     "Latest Commit SHA",
-		SourceVal:  mv.SourceData.LatestCommitSHA,
-		TargetVal:  mv.TargetData.LatestCommitSHA,
-		Status:     latestCommitStatus,
-		StatusType: latestCommitStatusType,
-		Difference: 0, // Not applicable for SHA comparison
+		Metric:     "Migration Log Issue",
+		SourceVal:  "N/A",
+		TargetVal:  fmt.Sprintf("#%d", migrationLog.IssueNumber),
+		Status:     ValidationStatusMessagePass,
+		StatusType: ValidationStatusPass,
+		Difference: 0,
 	})
 
-	// Add migration archive validation if available
-	if mv.SourceData.MigrationArchive != nil {
-		// First, compare migration archive with source API data to check migration completeness
-		archiveVsSourceIssuesDiff := mv.SourceData.MigrationArchive.Issues - mv.SourceData.Issues
-		archiveVsSourceIssuesStatus, archiveVsSourceIssuesStatusType := getValidationStatus(archiveVsSourceIssuesDiff)
-
-		results = append(results, ValidationResult{
-			Metric:     "Archive vs Source Issues",
-			SourceVal:  mv.SourceData.Issues,
-			TargetVal:  mv.SourceData.MigrationArchive.Issues,
-			Status:     archiveVsSourceIssuesStatus,
-			StatusType: archiveVsSourceIssuesStatusType,
-			Difference: archiveVsSourceIssuesDiff,
-		})
-
-		archiveVsSourcePRsDiff := mv.SourceData.MigrationArchive.PullRequests - mv.SourceData.PRs.Total
-		archiveVsSourcePRsStatus, archiveVsSourcePRsStatusType := getValidationStatus(archiveVsSourcePRsDiff)
-
-		results = append(results, ValidationResult{
-			Metric:     "Archive vs Source Pull Requests",
-			SourceVal:  mv.SourceData.PRs.Total,
-			TargetVal:  mv.SourceData.MigrationArchive.PullRequests,
-			Status:     archiveVsSourcePRsStatus,
-			StatusType: archiveVsSourcePRsStatusType,
-			Difference: archiveVsSourcePRsDiff,
-		})
-
-		archiveVsSourceBranchesDiff := mv.SourceData.MigrationArchive.ProtectedBranches - mv.SourceData.BranchProtectionRules
-		archiveVsSourceBranchesStatus, archiveVsSourceBranchesStatusType := getValidationStatus(archiveVsSourceBranchesDiff)
-
-		results = append(results, ValidationResult{
-			Metric:     "Archive vs Source Protected Branches",
-			SourceVal:  mv.SourceData.BranchProtectionRules,
-			TargetVal:  mv.SourceData.MigrationArchive.ProtectedBranches,
-			Status:     archiveVsSourceBranchesStatus,
-			StatusType: archiveVsSourceBranchesStatusType,
-			Difference: archiveVsSourceBranchesDiff,
-		})
-
-		archiveVsSourceReleasesDiff := mv.SourceData.MigrationArchive.Releases - mv.SourceData.Releases
-		archiveVsSourceReleasesStatus, archiveVsSourceReleasesStatusType := getValidationStatus(archiveVsSourceReleasesDiff)
-
-		results = append(results, ValidationResult{
-			Metric:     "Archive vs Source Releases",
-			SourceVal:  mv.SourceData.Releases,
-			TargetVal:  mv.SourceData.MigrationArchive.Releases,
-			Status:     archiveVsSourceReleasesStatus,
-			StatusType: archiveVsSourceReleasesStatusType,
-			Difference: archiveVsSourceReleasesDiff,
-		})
-
-		// Then, compare migration archive with target data to check migration success
-		expectedTargetFromArchive := mv.SourceData.MigrationArchive.Issues + MigrationLogIssueOffset
-		archiveToTargetIssuesDiff := expectedTargetFromArchive - mv.TargetData.Issues
-		archiveToTargetIssuesStatus, archiveToTargetIssuesStatusType := getValidationStatus(archiveToTargetIssuesDiff)
+	if migrationLog.Issues != nil {
+		// The migration log issue itself is not part of the logged issue count
+		issueOffset := mv.issueOffset()
+		targetIssues := mv.TargetData.issuesExcludingPRs()
+		logToTargetIssuesDiff := *migrationLog.Issues + issueOffset - targetIssues
+		logToTargetIssuesStatus, logToTargetIssuesStatusType := getValidationStatus(logToTargetIssuesDiff)
 
 		results = append(results, ValidationResult{
-			Metric:     "Archive vs Target Issues (expected +1 for migration log)",
-			SourceVal:  mv.SourceData.MigrationArchive.Issues,
-			TargetVal:  mv.TargetData.Issues,
-			Status:     archiveToTargetIssuesStatus,
-			StatusType: archiveToTargetIssuesStatusType,
-			Difference: archiveToTargetIssuesDiff,
+			Metric:     issueMetricName("Migration Log vs Target Issues", issueOffset),
+			SourceVal:  *migrationLog.Issues,
+			TargetVal:  targetIssues,
+			Status:     logToTargetIssuesStatus,
+			StatusType: logToTargetIssuesStatusType,
+			Difference: logToTargetIssuesDiff,
 		})
+	}
 
-		archiveToTargetPRsDiff := mv.SourceData.MigrationArchive.PullRequests - mv.TargetData.PRs.Total
-		archiveToTargetPRsStatus, archiveToTargetPRsStatusType := getValidationStatus(archiveToTargetPRsDiff)
+	if migrationLog.PullRequests != nil {
+		logToTargetPRsDiff := *migrationLog.PullRequests - mv.TargetData.PRs.Total
+		logToTargetPRsStatus, logToTargetPRsStatusType := getValidationStatus(logToTargetPRsDiff)
 
 		results = append(results, ValidationResult{
-			Metric:     "Archive vs Target Pull Requests",
-			SourceVal:  mv.SourceData.MigrationArchive.PullRequests,
+			Metric:     "Migration Log vs Target Pull Requests",
+			SourceVal:  *migrationLog.PullRequests,
 			TargetVal:  mv.TargetData.PRs.Total,
-			Status:     archiveToTargetPRsStatus,
-			StatusType: archiveToTargetPRsStatusType,
-			Difference: archiveToTargetPRsDiff,
+			Status:     logToTargetPRsStatus,
+			StatusType: logToTargetPRsStatusType,
+			Difference: logToTargetPRsDiff,
 		})
+	}
 
-		archiveToTargetBranchesDiff := mv.SourceData.MigrationArchive.ProtectedBranches - mv.TargetData.BranchProtectionRules
-		archiveToTargetBranchesStatus, archiveToTargetBranchesStatusType := getValidationStatus(archiveToTargetBranchesDiff)
+	if migrationLog.ProtectedBranches != nil {
+		logToTargetBranchesDiff := *migrationLog.ProtectedBranches - mv.TargetData.BranchProtectionRules
+		logToTargetBranchesStatus, logToTargetBranchesStatusType := getValidationStatus(logToTargetBranchesDiff)
 
 		results = append(results, ValidationResult{
-			Metric:     "Archive vs Target Protected Branches",
-			SourceVal:  mv.SourceData.MigrationArchive.ProtectedBranches,
+			Metric:     "Migration Log vs Target Protected Branches",
+			SourceVal:  *migrationLog.ProtectedBranches,
 			TargetVal:  mv.TargetData.BranchProtectionRules,
-			Status:     archiveToTargetBranchesStatus,
-			StatusType: archiveToTargetBranchesStatusType,
-			Difference: archiveToTargetBranchesDiff,
+			Status:     logToTargetBranchesStatus,
+			StatusType: logToTargetBranchesStatusType,
+			Difference: logToTargetBranchesDiff,
 		})
+	}
 
-		archiveToTargetReleasesDiff := mv.SourceData.MigrationArchive.Releases - mv.TargetData.Releases
-		archiveToTargetReleasesStatus, archiveToTargetReleasesStatusType := getValidationStatus(archiveToTargetReleasesDiff)
+	if migrationLog.Releases != nil {
+		logToTargetReleasesDiff := *migrationLog.Releases - mv.TargetData.Releases
+		logToTargetReleasesStatus, logToTargetReleasesStatusType := getValidationStatus(logToTargetReleasesDiff)
 
 		results = append(results, ValidationResult{
-			Metric:     "Archive vs Target Releases",
-			SourceVal:  mv.SourceData.MigrationArchive.Releases,
+			Metric:     "Migration Log vs Target Releases",
+			SourceVal:  *migrationLog.Releases,
 			TargetVal:  mv.TargetData.Releases,
-			Status:     archiveToTargetReleasesStatus,
-			StatusType: archiveToTargetReleasesStatusType,
-			Difference: archiveToTargetReleasesDiff,
+			Status:     logToTargetReleasesStatus,
+			StatusType: logToTargetReleasesStatusType,
+			Difference: logToTargetReleasesDiff,
 		})
 	}
 
 	return results
 }
 
-// PrintValidationResults prints a formatted report of the validation results
-func (mv *MigrationValidator) PrintValidationResults(results []ValidationResult) {
+// PrintValidationResults prints a formatted report of the validation results and returns their summary
+func (mv *MigrationValidator) PrintValidationResults(results []ValidationResult) Summary {
 	// Print header
-	pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgBlue)).WithTextStyle(pterm.NewStyle(pterm.FgWhite)).Println("📊 Migration Validation Report")
+	output.Header(output.Heading("📊 Migration Validation Report"))
 
 	// Print source/target info
-	sourceInfo := pterm.DefaultBox.WithTitle("Source Repository").WithTitleTopLeft().Sprint(fmt.Sprintf("Repository: %s/%s", mv.SourceData.Owner, mv.SourceData.Name))
-	targetInfo := pterm.DefaultBox.WithTitle("Target Repository").WithTitleTopLeft().Sprint(fmt.Sprintf("Repository: %s/%s", mv.TargetData.Owner, mv.TargetData.Name))
-
-	pterm.DefaultPanel.WithPanels([][]pterm.Panel{
-		{{Data: sourceInfo}, {Data: targetInfo}},
-	}).Render()
+	output.Boxes(
+		output.Box{Title: "Source Repository", Text: fmt.Sprintf("Repository: %s", describeRepository(mv.SourceData))},
+		output.Box{Title: "Target Repository", Text: fmt.Sprintf("Repository: %s", describeRepository(mv.TargetData))},
+	)
 
 	fmt.Println() // Add spacing
 
@@ -859,13 +957,23 @@ func (mv *MigrationValidator) PrintValidationResults(results []ValidationResult)
 	var standardResults []ValidationResult
 	var archiveVsSourceResults []ValidationResult
 	var archiveVsTargetResults []ValidationResult
+	var migrationLogResults []ValidationResult
+	var securityResults []ValidationResult
+	var branchResults []ValidationResult
 
 	for _, result := range results {
-		if strings.HasPrefix(result.Metric, "Archive vs Source") {
+		switch resultSection(result) {
+		case sectionSecurity:
+			securityResults = append(securityResults, result)
+		case sectionBranches:
+			branchResults = append(branchResults, result)
+		case sectionArchiveVsSource:
 			archiveVsSourceResults = append(archiveVsSourceResults, result)
-		} else if strings.HasPrefix(result.Metric, "Archive vs Target") {
+		case sectionArchiveVsTarget:
 			archiveVsTargetResults = append(archiveVsTargetResults, result)
-		} else {
+		case sectionMigrationLogVsTarget:
+			migrationLogResults = append(migrationLogResults, result)
+		default:
 			standardResults = append(standardResults, result)
 		}
 	}
@@ -884,10 +992,96 @@ func (mv *MigrationValidator) PrintValidationResults(results []ValidationResult)
 		mv.displayValidationTable("🎯 Migration Archive vs Target Validation", archiveVsTargetResults)
 	}
 
+	if len(migrationLogResults) > 0 {
+		fmt.Println()
+		mv.displayValidationTable("📝 Migration Log vs Target Validation", migrationLogResults)
+	}
+
+	if len(branchResults) > 0 {
+		fmt.Println()
+		mv.displayValidationTable("🌿 Branch Validation", branchResults)
+	}
+
+	if len(securityResults) > 0 {
+		fmt.Println()
+		mv.displayValidationTable("🛡️ Security Feature Parity (advisory)", securityResults)
+	}
+
 	fmt.Println() // Add spacing
 
+	// Explain why data is missing before the summary counts it
+	if len(mv.FailedRequests()) > 0 {
+		mv.displayFailedRequests()
+		fmt.Println()
+	}
+	if len(mv.restFallbackNotes()) > 0 {
+		mv.displayRESTFallbacks()
+		fmt.Println()
+	}
+
+	// Suggest how to fix the failures when requested with --explain
+	if mv.options.Explain && len(mv.Remediations(results)) > 0 {
+		mv.displayRemediations(results)
+		fmt.Println()
+	}
+
+	// Display how long each metric took to fetch when requested with --show-timings
+	if mv.options.ShowTimings && len(mv.Timings()) > 0 {
+		mv.displayTimings()
+		fmt.Println()
+	}
+
 	// Calculate and display summary for all results
-	mv.displayValidationSummary(results)
+	return mv.displayValidationSummary(results)
+}
+
+// Sections of the validation report, each displayed as its own table
+const (
+	sectionSourceVsTarget       = "source_vs_target"
+	sectionArchiveVsSource      = "archive_vs_source"
+	sectionArchiveVsTarget      = "archive_vs_target"
+	sectionMigrationLogVsTarget = "migration_log_vs_target"
+	sectionBranches             = "branches"
+	sectionSecurity             = "security"
+)
+
+// resultSection returns the section of the validation report that result belongs to
+func resultSection(result ValidationResult) string {
+	switch {
+	case strings.HasPrefix(result.Metric, securityMetricPrefix):
+		return sectionSecurity
+	case isBranchResult(result):
+		return sectionBranches
+	case strings.HasPrefix(result.Metric, "Archive vs Source"):
+		return sectionArchiveVsSource
+	case strings.HasPrefix(result.Metric, "Archive vs Target"):
+		return sectionArchiveVsTarget
+	case strings.HasPrefix(result.Metric, "Migration Log vs Target"):
+		return sectionMigrationLogVsTarget
+	default:
+		return sectionSourceVsTarget
+	}
+}
+
+// describeRepository returns the repository name for display, noting the original name if it was renamed
+func describeRepository(data *RepositoryData) string {
+	return repositoryName(data) + renamedFromSuffix(data)
+}
+
+// repositoryName returns OWNER/NAME, or the name alone for sources without an owner such as Subversion repositories
+func repositoryName(data *RepositoryData) string {
+	if data.Owner == "" {
+		return data.Name
+	}
+	return fmt.Sprintf("%s/%s", data.Owner, data.Name)
+}
+
+// renamedFromSuffix returns a note about the original repository name, or an empty string if it was not renamed
+func renamedFromSuffix(data *RepositoryData) string {
+	if data.RenamedFrom == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (renamed from %s)", data.RenamedFrom)
 }
 
 // displayValidationTable displays a validation table with the given title and results
@@ -897,7 +1091,7 @@ func (mv *MigrationValidator) displayValidationTable(title string, results []Val
 	}
 
 	// Print section title
-	pterm.DefaultSection.Println(title)
+	output.Section(output.Heading(title))
 
 	// Determine appropriate headers based on the validation type
 	var headers []string
@@ -905,6 +1099,8 @@ func (mv *MigrationValidator) displayValidationTable(title string, results []Val
 		headers = []string{"Metric", "Status", "Source API Value", "Archive Value", "Difference"}
 	} else if strings.Contains(title, "Archive vs Target") {
 		headers = []string{"Metric", "Status", "Archive Value", "Target Value", "Difference"}
+	} else if strings.Contains(title, "Migration Log vs Target") {
+		headers = []string{"Metric", "Status", "Migration Log Value", "Target Value", "Difference"}
 	} else {
 		headers = []string{"Metric", "Status", "Source Value", "Target Value", "Difference"}
 	}
@@ -913,71 +1109,87 @@ func (mv *MigrationValidator) displayValidationTable(title string, results []Val
 	tableData := [][]string{headers}
 
 	for _, result := range results {
-		diffStr := ""
-		if result.Difference > 0 {
-			diffStr = fmt.Sprintf("Missing: %d", result.Difference)
-		} else if result.Difference < 0 {
-			diffStr = fmt.Sprintf("Extra: %d", -result.Difference)
-		} else if result.Metric == "Latest Commit SHA" {
-			diffStr = "N/A"
-		} else {
-			diffStr = "Perfect match"
-		}
-
 		tableData = append(tableData, []string{
 			result.Metric,
-			result.Status,
+			result.StatusType.Label(),
 			fmt.Sprintf("%v", result.SourceVal),
 			fmt.Sprintf("%v", result.TargetVal),
-			diffStr,
+			formatDifference(result),
 		})
 	}
 
-	// Create and display the table
-	table := pterm.DefaultTable.WithHasHeader().WithData(tableData)
-	table.Render()
+	// Create and display the table, fitted to the terminal width
+	output.RenderTable(tableData, false)
 }
 
-// displayValidationSummary calculates and displays the overall validation summary
-func (mv *MigrationValidator) displayValidationSummary(results []ValidationResult) {
-	// Calculate summary
-	passCount := 0
-	failCount := 0
-	warnCount := 0
+// formatDifference returns the display text for the Difference column of a validation result
+func formatDifference(result ValidationResult) string {
+	if result.Note != "" {
+		return fmt.Sprintf("%s (%s)", formatCountDifference(result), result.Note)
+	}
+	return formatCountDifference(result)
+}
 
-	for _, result := range results {
-		switch result.StatusType {
-		case ValidationStatusPass:
-			passCount++
-		case ValidationStatusFail:
-			failCount++
-		case ValidationStatusWarn:
-			warnCount++
-		}
+// formatCountDifference returns the number of missing or extra items of a result, or why it has none
+func formatCountDifference(result ValidationResult) string {
+	switch {
+	case result.Difference > 0:
+		return fmt.Sprintf("Missing: %d", result.Difference)
+	case result.Difference < 0:
+		return fmt.Sprintf("Extra: %d", -result.Difference)
+	case result.StatusType == ValidationStatusUnavailable, result.TargetVal == missingTeamValue, isSecurityEnabledResult(result),
+		strings.HasPrefix(result.Metric, branchMetricPrefix):
+		return "N/A"
+	case result.Metric == "Latest Commit SHA" || result.Metric == "Migration Log Issue" || result.Metric == "Repository Content" || result.Metric == pagesMetric,
+		result.Metric == truncationMetric, result.Metric == forkMetric, result.Metric == transferRedirectMetric,
+		result.Metric == descriptionMetric, result.Metric == homepageMetric, result.Metric == issueTemplatesMetric,
+		result.Metric == treeHashMetric, result.Metric == commitIdentitiesMetric:
+		return "N/A"
+	default:
+		return "Perfect match"
 	}
+}
+
+// displayValidationSummary displays the counts and verdict of the validation results, and returns them
+func (mv *MigrationValidator) displayValidationSummary(results []ValidationResult) Summary {
+	summary := Summarize(results)
 
 	// Print summary with colored boxes
-	summaryData := []pterm.BulletListItem{
-		{Level: 0, Text: fmt.Sprintf("Passed: %d", passCount), TextStyle: pterm.NewStyle(pterm.FgGreen)},
-		{Level: 0, Text: fmt.Sprintf("Failed: %d", failCount), TextStyle: pterm.NewStyle(pterm.FgRed)},
-		{Level: 0, Text: fmt.Sprintf("Warnings: %d", warnCount), TextStyle: pterm.NewStyle(pterm.FgYellow)},
+	summaryData := []output.BulletItem{
+		{Text: fmt.Sprintf("Passed: %d", summary.Passed), Color: output.ColorGreen},
+		{Text: fmt.Sprintf("Failed: %d", summary.Failed), Color: output.ColorRed},
+		{Text: fmt.Sprintf("Warnings: %d", summary.Warnings), Color: output.ColorYellow},
+	}
+	if summary.Info > 0 {
+		summaryData = append(summaryData, output.BulletItem{Text: fmt.Sprintf("Info: %d", summary.Info), Color: output.ColorCyan})
+	}
+	if summary.Unavailable > 0 {
+		summaryData = append(summaryData, output.BulletItem{Text: fmt.Sprintf("Target unavailable: %d", summary.Unavailable), Color: output.ColorMagenta})
+	}
+	if retries := api.RetryStatistics(); retries.Retries > 0 {
+		summaryData = append(summaryData, output.BulletItem{Text: fmt.Sprintf("Retried requests: %d retries, %d recovered, %d failed",
+			retries.Retries, retries.Recovered, retries.Failed), Color: output.ColorGray})
 	}
 
-	pterm.DefaultBulletList.WithItems(summaryData).WithBullet("📊").Render()
+	output.BulletList(summaryData, output.Bullet("📊"))
 
 	fmt.Println() // Add spacing
 
 	// Final status with prominent styling
-	if failCount > 0 {
-		pterm.Error.Println("❌ Migration validation FAILED - Some data is missing in target")
-	} else if warnCount > 0 {
-		pterm.Warning.Println("⚠️ Migration validation completed with WARNINGS - Target has more data than source")
-	} else {
-		pterm.Success.Println("✅ Migration validation PASSED - All data matches!")
+	message := output.Heading(summary.Verdict.Message())
+	switch summary.Verdict {
+	case VerdictIncomplete, VerdictFailed:
+		output.Message(nil, output.LevelError, message)
+	case VerdictWarnings:
+		output.Message(nil, output.LevelWarning, message)
+	default:
+		output.Message(nil, output.LevelSuccess, message)
 	}
 
 	fmt.Println() // Add spacing
 	mv.outputMarkdownResults(results)
+
+	return summary
 }
 
 type markdownOutputOptions struct {
@@ -1001,7 +1213,7 @@ func (mv *MigrationValidator) printMarkdownTable(results []ValidationResult, opt
 	writer := opt.writer
 
 	if opt.announce {
-		pterm.DefaultSection.Println("📋 Markdown Table (Copy-Paste Ready)")
+		output.Section(output.Heading("📋 Markdown Table (Copy-Paste Ready)"))
 	}
 
 	if opt.includeCodeFence {
@@ -1010,80 +1222,70 @@ func (mv *MigrationValidator) printMarkdownTable(results []ValidationResult, opt
 
 	fmt.Fprintln(writer, "# Migration Validation Report")
 	fmt.Fprintln(writer)
-	fmt.Fprintf(writer, "**Source:** `%s/%s`  \n", mv.SourceData.Owner, mv.SourceData.Name)
-	fmt.Fprintf(writer, "**Target:** `%s/%s`  \n\n", mv.TargetData.Owner, mv.TargetData.Name)
+	fmt.Fprintf(writer, "**Source:** `%s`%s  \n", repositoryName(mv.SourceData), renamedFromSuffix(mv.SourceData))
+	fmt.Fprintf(writer, "**Target:** `%s`%s  \n\n", repositoryName(mv.TargetData), renamedFromSuffix(mv.TargetData))
 
 	fmt.Fprintln(writer, "| Metric | Status | Source Value | Target Value | Difference |")
 	fmt.Fprintln(writer, "|--------|--------|--------------|--------------|------------|")
 
 	for _, result := range results {
-		diffStr := ""
-		if result.Difference > 0 {
-			diffStr = fmt.Sprintf("Missing: %d", result.Difference)
-		} else if result.Difference < 0 {
-			diffStr = fmt.Sprintf("Extra: %d", -result.Difference)
-		} else if result.Metric == "Latest Commit SHA" {
-			diffStr = "N/A"
-		} else {
-			diffStr = "Perfect match"
-		}
-
 		fmt.Fprintf(writer, "| %s | %s | %v | %v | %s |\n",
 			result.Metric,
-			result.Status,
+			result.StatusType.Label(),
 			result.SourceVal,
 			result.TargetVal,
-			diffStr)
+			formatDifference(result))
 	}
 
-	// Calculate summary for markdown
-	passCount := 0
-	failCount := 0
-	warnCount := 0
-
-	for _, result := range results {
-		switch result.StatusType {
-		case ValidationStatusPass:
-			passCount++
-		case ValidationStatusFail:
-			failCount++
-		case ValidationStatusWarn:
-			warnCount++
-		}
-	}
+	summary := Summarize(results)
 
 	fmt.Fprintln(writer)
 	fmt.Fprintln(writer, "## Summary")
 	fmt.Fprintln(writer)
-	fmt.Fprintf(writer, "- **Passed:** %d  \n", passCount)
-	fmt.Fprintf(writer, "- **Failed:** %d  \n", failCount)
-	fmt.Fprintf(writer, "- **Warnings:** %d  \n\n", warnCount)
-
-	if failCount > 0 {
-		fmt.Fprintln(writer, "**Result:** ❌ Migration validation FAILED - Some data is missing in target")
-	} else if warnCount > 0 {
-		fmt.Fprintln(writer, "**Result:** ⚠️ Migration validation completed with WARNINGS - Target has more data than source")
-	} else {
-		fmt.Fprintln(writer, "**Result:** ✅ Migration validation PASSED - All data matches!")
+	fmt.Fprintf(writer, "- **Passed:** %d  \n", summary.Passed)
+	fmt.Fprintf(writer, "- **Failed:** %d  \n", summary.Failed)
+	fmt.Fprintf(writer, "- **Warnings:** %d  \n", summary.Warnings)
+	if summary.Info > 0 {
+		fmt.Fprintf(writer, "- **Info:** %d  \n", summary.Info)
+	}
+	if summary.Unavailable > 0 {
+		fmt.Fprintf(writer, "- **Target Unavailable:** %d  \n", summary.Unavailable)
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "**Result:** "+output.Heading(summary.Verdict.Message()))
+
+	mv.writeMarkdownFailedRequests(writer)
+	mv.writeMarkdownRESTFallbacks(writer)
+	if mv.options.Explain {
+		mv.writeMarkdownRemediations(writer, results)
+	}
+	if mv.options.ShowTimings {
+		mv.writeMarkdownTimings(writer)
 	}
 
 	if opt.includeCodeFence {
 		fmt.Fprintln(writer, "```")
 		if opt.announce {
-			pterm.Info.Println("💡 Tip: You can select and copy the entire markdown section above to paste into documentation, issues, or pull requests!")
+			output.Message(nil, output.LevelInfo, output.Heading("💡 Tip: You can select and copy the entire markdown section above to paste into documentation, issues, or pull requests!"))
 		}
 	}
 }
 
-func (mv *MigrationValidator) writeMarkdownToFile(results []ValidationResult, path string) error {
+// MarkdownReport returns the markdown validation report without code fences, as written by --markdown-file
+func (mv *MigrationValidator) MarkdownReport(results []ValidationResult) string {
 	var buffer bytes.Buffer
 	mv.printMarkdownTable(results, markdownOutputOptions{writer: &buffer, includeCodeFence: false, announce: false})
-	return os.WriteFile(path, buffer.Bytes(), 0o644)
+	return buffer.String()
+}
+
+func (mv *MigrationValidator) writeMarkdownToFile(results []ValidationResult, path string) error {
+	return os.WriteFile(path, []byte(mv.MarkdownReport(results)), 0o644)
 }
 
 func (mv *MigrationValidator) outputMarkdownResults(results []ValidationResult) {
-	markdownTable := viper.GetBool("MARKDOWN_TABLE")
-	markdownFile := viper.GetString("MARKDOWN_FILE")
+	markdownTable := mv.options.MarkdownTable
+	markdownFile := mv.options.MarkdownFile
 
 	if markdownTable {
 		mv.printMarkdownTable(results, markdownOutputOptions{writer: os.Stdout, includeCodeFence: true, announce: true})
@@ -1095,15 +1297,15 @@ func (mv *MigrationValidator) outputMarkdownResults(results []ValidationResult)
 
 	if dir := filepath.Dir(markdownFile); dir != "." {
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			pterm.Error.Printf("Directory %q does not exist for markdown file\n", dir)
+			output.Message(nil, output.LevelError, fmt.Sprintf("Directory %q does not exist for markdown file", dir))
 			return
 		}
 	}
 
 	if err := mv.writeMarkdownToFile(results, markdownFile); err != nil {
-		pterm.Error.Printf("Failed to write markdown file %s: %v\n", markdownFile, err)
+		output.Message(nil, output.LevelError, fmt.Sprintf("Failed to write markdown file %s: %v", markdownFile, err))
 		return
 	}
 
-	pterm.Success.Printf("📁 Markdown report saved to %s\n", markdownFile)
+	output.Message(nil, output.LevelSuccess, fmt.Sprintf(output.Heading("📁 Markdown report saved to %s"), markdownFile))
 }
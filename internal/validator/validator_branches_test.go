@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupBranchValidator() *MigrationValidator {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{Branches: map[string]api.BranchHead{
+		"main":        {SHA: "aaa1111222233334444", Commits: 120},
+		"develop":     {SHA: "bbb2222", Commits: 135},
+		"release/1.0": {SHA: "ccc3333", Commits: 80},
+	}}
+	mv.TargetData = &RepositoryData{Branches: map[string]api.BranchHead{
+		"main":    {SHA: "aaa1111222233334444", Commits: 120},
+		"develop": {SHA: "ddd4444", Commits: 130},
+	}}
+	return mv
+}
+
+func TestBranchResults_All(t *testing.T) {
+	mv := setupBranchValidator()
+	mv.options.Branches = "all"
+
+	results := mv.branchResults()
+
+	assert.Len(t, results, 2, "matching branches are not listed")
+	assert.Equal(t, "Branch: develop", results[0].Metric)
+	assert.Equal(t, "135 commits @ bbb2222", results[0].SourceVal)
+	assert.Equal(t, "130 commits @ ddd4444", results[0].TargetVal)
+	assert.Equal(t, ValidationStatusFail, results[0].StatusType)
+	assert.Equal(t, "Missing: 5", formatDifference(results[0]))
+
+	assert.Equal(t, "Branch: release/1.0", results[1].Metric)
+	assert.Equal(t, missingBranchValue, results[1].TargetVal)
+	assert.Equal(t, ValidationStatusFail, results[1].StatusType)
+}
+
+func TestBranchResults_List(t *testing.T) {
+	mv := setupBranchValidator()
+	mv.options.Branches = "main, relase/1.0"
+
+	results := mv.branchResults()
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Selected Branches", results[0].Metric)
+	assert.Equal(t, ValidationStatusPass, results[0].StatusType, "only main exists on both sides")
+	assert.Equal(t, 1, results[0].SourceVal)
+
+	assert.Equal(t, "Branch: relase/1.0", results[1].Metric)
+	assert.Equal(t, missingBranchValue, results[1].SourceVal)
+	assert.Equal(t, ValidationStatusWarn, results[1].StatusType, "a branch missing from the source is a warning")
+}
+
+func TestBranchResults_SameHeadDifferentSHA(t *testing.T) {
+	mv := setupBranchValidator()
+	mv.options.Branches = "develop"
+	mv.TargetData.Branches["develop"] = api.BranchHead{SHA: "eee5555", Commits: 135}
+
+	results := mv.branchResults()
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, ValidationStatusFail, results[1].StatusType)
+	assert.Equal(t, "N/A", formatDifference(results[1]))
+}
+
+func TestBranchResults_NotSelected(t *testing.T) {
+	assert.Empty(t, setupBranchValidator().branchResults())
+}
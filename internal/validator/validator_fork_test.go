@@ -0,0 +1,25 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForkResult(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{ForkParent: "upstream-org/project"}
+	mv.TargetData = &RepositoryData{}
+
+	result, ok := mv.forkResult()
+
+	assert.True(t, ok)
+	assert.Equal(t, "Fork of upstream-org/project", result.SourceVal)
+	assert.Equal(t, "Not a fork", result.TargetVal)
+	assert.Equal(t, ValidationStatusInfo, result.StatusType)
+	assert.Equal(t, "N/A", formatDifference(result))
+
+	mv.SourceData.ForkParent = ""
+	_, ok = mv.forkResult()
+	assert.False(t, ok, "no row when neither repository is a fork")
+}
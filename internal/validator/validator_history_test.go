@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitIdentitiesResult(t *testing.T) {
+	commits := []api.CommitIdentity{
+		{AuthorName: "Mona", AuthorEmail: "mona@example.com", AuthoredDate: time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{AuthorName: "Hubot", AuthorEmail: "hubot@example.com", AuthoredDate: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{CommitIdentities: commits}
+	mv.TargetData = &RepositoryData{CommitIdentities: append([]api.CommitIdentity(nil), commits...)}
+
+	result, ok := mv.commitIdentitiesResult()
+	assert.True(t, ok)
+	assert.Equal(t, "Commit Authors and Dates (last 100 commits)", result.Metric)
+	assert.Equal(t, ValidationStatusPass, result.StatusType)
+	assert.Equal(t, "N/A", formatDifference(result))
+
+	mv.TargetData.CommitIdentities = commits[1:]
+	result, _ = mv.commitIdentitiesResult()
+	assert.Equal(t, ValidationStatusFail, result.StatusType)
+	assert.Equal(t, 2, result.Difference, "a missing commit shifts the rest of the sequence")
+	assert.Equal(t, "first difference at commit 1: Mona <mona@example.com> 2025-03-02T09:00:00Z vs Hubot <hubot@example.com> 2025-03-01T09:00:00Z", result.Note)
+
+	mv.TargetData.CommitIdentities = nil
+	_, ok = mv.commitIdentitiesResult()
+	assert.False(t, ok, "no row without --rewritten-history")
+}
+
+func TestRewrittenHistory_SHAMismatchIsInfo(t *testing.T) {
+	mv := NewWithOptions(nil, ValidationOptions{RewrittenHistory: true})
+	mv.SourceData = &RepositoryData{LatestCommitSHA: "aaa", Branches: map[string]api.BranchHead{"main": {SHA: "aaa", Commits: 10}}}
+	mv.TargetData = &RepositoryData{LatestCommitSHA: "bbb", Branches: map[string]api.BranchHead{"main": {SHA: "bbb", Commits: 10}}}
+
+	result, ok := mv.latestCommitResult()
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusInfo, result.StatusType)
+	assert.Equal(t, "N/A (history rewritten, SHAs are expected to differ)", formatDifference(result))
+
+	mv.options.Branches = "main"
+	results := mv.branchResults()
+	assert.Len(t, results, 1, "only the count of the listed branches, as the head differs by SHA only")
+
+	mv.TargetData.Branches["main"] = api.BranchHead{SHA: "bbb", Commits: 9}
+	results = mv.branchResults()
+	assert.Len(t, results, 2)
+	assert.Equal(t, ValidationStatusFail, results[1].StatusType, "missing commits still fail")
+}
+
+func TestSquashedImportResult(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{CommitCount: 1200, TreeSHA: "tree-1"}
+	mv.TargetData = &RepositoryData{CommitCount: 1, TreeSHA: "tree-1"}
+
+	result, ok := mv.squashedImportResult()
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusFail, result.StatusType)
+	assert.Equal(t, "1 commits, same tree", result.TargetVal)
+	assert.Equal(t, "Missing: 1199 (the target has the contents of the source without its history)", formatDifference(result))
+
+	remediations := NewWithOptions(nil, ValidationOptions{}).Remediations([]ValidationResult{result})
+	assert.Len(t, remediations, 1)
+	assert.Equal(t, "squashed-import", remediations[0].Rule.ID)
+
+	mv.TargetData.CommitCount = 1100
+	_, ok = mv.squashedImportResult()
+	assert.False(t, ok, "a few missing commits are an incomplete push, not a squashed import")
+
+	mv.TargetData.CommitCount, mv.TargetData.TreeSHA = 1, "tree-2"
+	_, ok = mv.squashedImportResult()
+	assert.False(t, ok, "different contents")
+}
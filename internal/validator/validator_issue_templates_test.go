@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueTemplatesResult(t *testing.T) {
+	bug := api.TemplateFile{Name: "bug_report.yml", SHA: "badc0de"}
+	config := api.TemplateFile{Name: "config.yml", SHA: "c0ffee"}
+	feature := api.TemplateFile{Name: "feature_request.md", SHA: "feed"}
+
+	tests := []struct {
+		name               string
+		source             []api.TemplateFile
+		target             []api.TemplateFile
+		expectedOK         bool
+		expectedStatusType ValidationStatus
+		expectedDifference string
+	}{
+		{name: "not retrieved", source: []api.TemplateFile{bug}},
+		{name: "no templates", source: []api.TemplateFile{}, target: []api.TemplateFile{}},
+		{name: "identical", source: []api.TemplateFile{bug, config}, target: []api.TemplateFile{bug, config},
+			expectedOK: true, expectedStatusType: ValidationStatusPass, expectedDifference: "N/A"},
+		{name: "missing and changed", source: []api.TemplateFile{bug, config, feature},
+			target:     []api.TemplateFile{bug, {Name: "config.yml", SHA: "0ld"}},
+			expectedOK: true, expectedStatusType: ValidationStatusFail,
+			expectedDifference: "Missing: 1 (missing: feature_request.md; changed: config.yml)"},
+		{name: "changed", source: []api.TemplateFile{config}, target: []api.TemplateFile{{Name: "config.yml", SHA: "0ld"}},
+			expectedOK: true, expectedStatusType: ValidationStatusFail, expectedDifference: "N/A (changed: config.yml)"},
+		{name: "extra", source: []api.TemplateFile{bug}, target: []api.TemplateFile{bug, feature},
+			expectedOK: true, expectedStatusType: ValidationStatusWarn, expectedDifference: "Extra: 1 (extra: feature_request.md)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := New(nil)
+			mv.SourceData = &RepositoryData{IssueTemplates: tt.source}
+			mv.TargetData = &RepositoryData{IssueTemplates: tt.target}
+
+			result, ok := mv.issueTemplatesResult()
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.expectedStatusType, result.StatusType)
+			assert.Equal(t, tt.expectedDifference, formatDifference(result))
+		})
+	}
+}
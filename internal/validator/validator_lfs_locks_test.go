@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFSLocksResult(t *testing.T) {
+	tests := []struct {
+		name               string
+		source             []string
+		target             []string
+		expectedOK         bool
+		expectedStatusType ValidationStatus
+		expectedDiff       int
+		expectedTargetVal  interface{}
+	}{
+		{
+			name:               "locks recreated",
+			source:             []string{"art/hero.psd", "art/villain.psd"},
+			target:             []string{"art/villain.psd", "art/hero.psd"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusPass,
+			expectedTargetVal:  2,
+		},
+		{
+			name:               "locks not recreated",
+			source:             []string{"art/villain.psd", "art/hero.psd"},
+			target:             []string{},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       2,
+			expectedTargetVal:  "0 (missing: art/hero.psd, art/villain.psd)",
+		},
+		{
+			name:               "many locks missing",
+			source:             []string{"a", "b", "c", "d", "e", "f", "g"},
+			target:             []string{"a"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       6,
+			expectedTargetVal:  "1 (missing: b, c, d, e, f and 1 more)",
+		},
+		{
+			name:               "extra lock on target",
+			source:             []string{"art/hero.psd"},
+			target:             []string{"art/hero.psd", "art/new.psd"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       -1,
+			expectedTargetVal:  2,
+		},
+		{
+			name:   "no locks",
+			source: []string{},
+			target: []string{},
+		},
+		{
+			name:   "source locks not exported",
+			target: []string{"art/hero.psd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := New(nil)
+			mv.SourceData = &RepositoryData{LFSLocks: tt.source}
+			mv.TargetData = &RepositoryData{LFSLocks: tt.target}
+
+			result, ok := mv.lfsLocksResult()
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, lfsLocksMetric, result.Metric)
+			assert.Equal(t, tt.expectedStatusType, result.StatusType)
+			assert.Equal(t, tt.expectedDiff, result.Difference)
+			assert.Equal(t, tt.expectedTargetVal, result.TargetVal)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFSPatternsResult(t *testing.T) {
+	tests := []struct {
+		name               string
+		source             []string
+		target             []string
+		expectedOK         bool
+		expectedStatusType ValidationStatus
+		expectedDiff       int
+		expectedTargetVal  string
+	}{
+		{
+			name:               "same patterns in a different order",
+			source:             []string{"*.psd", "*.zip"},
+			target:             []string{"*.zip", "*.psd"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusPass,
+			expectedTargetVal:  "*.psd, *.zip",
+		},
+		{
+			name:               "pattern missing on target",
+			source:             []string{"*.psd", "*.zip"},
+			target:             []string{"*.psd"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       1,
+			expectedTargetVal:  "*.psd",
+		},
+		{
+			name:               "target no longer uses LFS",
+			source:             []string{"*.psd"},
+			target:             []string{},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       1,
+			expectedTargetVal:  "None",
+		},
+		{
+			name:               "extra pattern on target",
+			source:             []string{"*.psd"},
+			target:             []string{"*.psd", "*.bin"},
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusWarn,
+			expectedDiff:       -1,
+			expectedTargetVal:  "*.bin, *.psd",
+		},
+		{
+			name:   "neither uses LFS",
+			source: []string{},
+			target: []string{},
+		},
+		{
+			name:   "target not retrieved",
+			source: []string{"*.psd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := New(nil)
+			mv.SourceData = &RepositoryData{LFSPatterns: tt.source}
+			mv.TargetData = &RepositoryData{LFSPatterns: tt.target}
+
+			result, ok := mv.lfsPatternsResult()
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, lfsPatternsMetric, result.Metric)
+			assert.Equal(t, tt.expectedStatusType, result.StatusType)
+			assert.Equal(t, tt.expectedDiff, result.Difference)
+			assert.Equal(t, tt.expectedTargetVal, result.TargetVal)
+		})
+	}
+}
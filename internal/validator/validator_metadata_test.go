@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataResults(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{}
+	mv.TargetData = &RepositoryData{Metadata: &api.RepositoryMetadata{}}
+
+	assert.Empty(t, mv.metadataResults(), "no rows when the metadata of either side was not retrieved")
+
+	mv.SourceData.Metadata = &api.RepositoryMetadata{
+		Description: "Payments service",
+		HomepageURL: "https://docs.example.com/payments",
+		Topics:      []string{"payments", "go", "pci"},
+	}
+	mv.TargetData.Metadata = &api.RepositoryMetadata{
+		Description: "Payments service",
+		Topics:      []string{"go"},
+	}
+	results := mv.metadataResults()
+
+	require.Len(t, results, 3)
+	assert.Equal(t, descriptionMetric, results[0].Metric)
+	assert.Equal(t, ValidationStatusPass, results[0].StatusType)
+	assert.Equal(t, "N/A", formatDifference(results[0]))
+
+	assert.Equal(t, homepageMetric, results[1].Metric)
+	assert.Equal(t, ValidationStatusWarn, results[1].StatusType, "metadata differences are advisory")
+	assert.Equal(t, "https://docs.example.com/payments", results[1].SourceVal)
+	assert.Equal(t, "None", results[1].TargetVal)
+
+	assert.Equal(t, topicsMetric, results[2].Metric)
+	assert.Equal(t, ValidationStatusWarn, results[2].StatusType)
+	assert.Equal(t, "go, payments, pci", results[2].SourceVal)
+	assert.Equal(t, "go", results[2].TargetVal)
+	assert.Equal(t, "Missing: 2", formatDifference(results[2]))
+}
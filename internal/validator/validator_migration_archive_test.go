@@ -58,6 +58,7 @@ func TestValidateRepositoryData_WithMigrationArchive(t *testing.T) {
 			PullRequests:      29,
 			ProtectedBranches: 1,
 			Releases:          25,
+			CommitComments:    3,
 		},
 	}
 
@@ -92,6 +93,10 @@ func TestValidateRepositoryData_WithMigrationArchive(t *testing.T) {
 			// Expected target: 6 + 1 = 7, actual target: 7, difference = 0
 			assert.Equal(t, 0, result.Difference)
 		}
+		if result.Metric == "Archive vs Target Commit Comments" {
+			// Archive has 3, target has none
+			assert.Equal(t, 3, result.Difference)
+		}
 	}
 
 	assert.True(t, hasArchiveVsSource, "Should have archive vs source validation")
@@ -138,6 +143,56 @@ func TestValidateRepositoryData_WithoutMigrationArchive(t *testing.T) {
 	assert.Equal(t, len(expectedValidationMetrics), len(results), "Should have standard validation metrics count")
 }
 
+func TestValidateRepositoryData_ArchiveOnly(t *testing.T) {
+	sourceData := &RepositoryData{
+		Owner: "source-org",
+		Name:  "source-repo",
+		PRs:   &api.PRCounts{},
+		MigrationArchive: &migrationarchive.MigrationArchiveMetrics{
+			Issues:       6,
+			PullRequests: 29,
+			Releases:     25,
+			References:   4,
+		},
+	}
+	targetData := &RepositoryData{
+		Owner:       "target-org",
+		Name:        "target-repo",
+		Issues:      7,
+		PRs:         &api.PRCounts{Total: 28},
+		Tags:        25,
+		Releases:    25,
+		CommitCount: 64,
+	}
+
+	validator := setupTestValidator(sourceData, targetData)
+	validator.archiveOnly = true
+	results := validator.validateRepositoryData()
+
+	metrics := make([]string, 0, len(results))
+	for _, result := range results {
+		metrics = append(metrics, result.Metric)
+	}
+	assert.Equal(t, []string{
+		archiveReferencesMetric,
+		"Archive vs Target Issues (expected +1 for migration log)",
+		"Archive vs Target Pull Requests",
+		"Archive vs Target Protected Branches",
+		"Archive vs Target Releases",
+		"Archive vs Target Commit Comments",
+	}, metrics, "only the archive is compared with the target, without source API data")
+	assert.Equal(t, ValidationStatusFail, results[2].StatusType)
+	assert.Equal(t, 1, results[2].Difference)
+}
+
+func TestValidateFromArchive_WithoutArchive(t *testing.T) {
+	validator := New(nil)
+	validator.SetSourceDataFromExport(&RepositoryData{Owner: "source-org", Name: "source-repo"})
+
+	_, err := validator.ValidateFromArchive("target-org", "target-repo")
+	assert.EqualError(t, err, "source data has no migration archive metrics")
+}
+
 func TestDisplayValidationTable_Headers(t *testing.T) {
 	validator := New(nil)
 
@@ -171,3 +226,42 @@ func TestDisplayValidationTable_Headers(t *testing.T) {
 		})
 	}
 }
+
+func TestArchiveReferencesResult(t *testing.T) {
+	_, ok := archiveReferencesResult(&migrationarchive.MigrationArchiveMetrics{Issues: 3})
+	assert.False(t, ok, "exports taken before references were checked have no result")
+
+	result, ok := archiveReferencesResult(&migrationarchive.MigrationArchiveMetrics{References: 12})
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusPass, result.StatusType)
+	assert.Equal(t, "12 referenced", result.SourceVal)
+
+	result, _ = archiveReferencesResult(&migrationarchive.MigrationArchiveMetrics{References: 12, BrokenReferences: []string{
+		"tarball://root/attachments/1/a.png", "tarball://root/attachments/2/b.png",
+		"tarball://root/release_assets/3/c.zip", "urls.json (missing)",
+	}})
+	assert.Equal(t, ValidationStatusFail, result.StatusType)
+	assert.Equal(t, "Missing: 4 (broken: tarball://root/attachments/1/a.png, tarball://root/attachments/2/b.png, tarball://root/release_assets/3/c.zip and 1 more)",
+		formatDifference(result))
+}
+
+func TestArchiveSchemaResult(t *testing.T) {
+	_, ok := archiveSchemaResult(&migrationarchive.MigrationArchiveMetrics{Issues: 3})
+	assert.False(t, ok, "exports taken before the schema version was read have no result")
+
+	result, ok := archiveSchemaResult(&migrationarchive.MigrationArchiveMetrics{SchemaVersion: "1.2.0"})
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusPass, result.StatusType)
+	assert.Equal(t, "1.2.0", result.SourceVal)
+	assert.Equal(t, sectionArchiveVsSource, resultSection(result))
+
+	result, _ = archiveSchemaResult(&migrationarchive.MigrationArchiveMetrics{
+		SchemaVersion: "2.0.0", SchemaWarning: "schema version 2.0.0 is newer than 1.2.0: counts may be wrong",
+	})
+	assert.Equal(t, ValidationStatusWarn, result.StatusType)
+	assert.Equal(t, "schema version 2.0.0 is newer than 1.2.0: counts may be wrong", result.Note)
+
+	result, _ = archiveSchemaResult(&migrationarchive.MigrationArchiveMetrics{SchemaWarning: "schema.json is missing"})
+	assert.Equal(t, ValidationStatusWarn, result.StatusType)
+	assert.Equal(t, "unknown", result.SourceVal)
+}
@@ -0,0 +1,197 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/migrationlog"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMigrationLogTestData returns matching source and target data for migration log tests
+func newMigrationLogTestData() (*RepositoryData, *RepositoryData) {
+	sourceData := &RepositoryData{
+		Owner:                 "source-org",
+		Name:                  "source-repo",
+		Issues:                6,
+		PRs:                   &api.PRCounts{Total: 29, Open: 0, Merged: 27, Closed: 2},
+		Tags:                  25,
+		Releases:              25,
+		CommitCount:           64,
+		LatestCommitSHA:       "abc123",
+		BranchProtectionRules: 1,
+	}
+
+	targetData := &RepositoryData{
+		Owner:                 "target-org",
+		Name:                  "target-repo",
+		Issues:                7, // 6 migrated + 1 migration log
+		PRs:                   &api.PRCounts{Total: 29, Open: 0, Merged: 27, Closed: 2},
+		Tags:                  25,
+		Releases:              25,
+		CommitCount:           64,
+		LatestCommitSHA:       "abc123",
+		BranchProtectionRules: 1,
+	}
+
+	return sourceData, targetData
+}
+
+func findResult(results []ValidationResult, metric string) *ValidationResult {
+	for i := range results {
+		if results[i].Metric == metric {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+func TestValidateRepositoryData_WithMigrationLog(t *testing.T) {
+	sourceData, targetData := newMigrationLogTestData()
+	issues, pullRequests, releases := 6, 28, 25
+	targetData.MigrationLog = &migrationlog.MigrationLogMetrics{
+		Found:        true,
+		IssueNumber:  7,
+		Issues:       &issues,
+		PullRequests: &pullRequests,
+		Releases:     &releases,
+	}
+
+	validator := setupTestValidator(sourceData, targetData)
+	results := validator.validateRepositoryData()
+
+	logIssue := findResult(results, "Migration Log Issue")
+	if assert.NotNil(t, logIssue, "Should report the migration log issue") {
+		assert.Equal(t, ValidationStatusPass, logIssue.StatusType)
+		assert.Equal(t, "#7", logIssue.TargetVal)
+	}
+
+	logIssues := findResult(results, "Migration Log vs Target Issues (expected +1 for migration log)")
+	if assert.NotNil(t, logIssues) {
+		assert.Equal(t, ValidationStatusPass, logIssues.StatusType)
+		assert.Equal(t, 0, logIssues.Difference)
+	}
+
+	logPRs := findResult(results, "Migration Log vs Target Pull Requests")
+	if assert.NotNil(t, logPRs) {
+		// Target has one more PR than the log reported
+		assert.Equal(t, ValidationStatusWarn, logPRs.StatusType)
+		assert.Equal(t, -1, logPRs.Difference)
+	}
+
+	assert.NotNil(t, findResult(results, "Migration Log vs Target Releases"))
+	assert.Nil(t, findResult(results, "Migration Log vs Target Protected Branches"),
+		"Counts missing from the migration log should not be compared")
+}
+
+func TestValidateRepositoryData_MigrationLogWithPRsCountedAsIssues(t *testing.T) {
+	sourceData, targetData := newMigrationLogTestData()
+	issues := 6
+	targetData.Issues += targetData.PRs.Total
+	targetData.IssuesIncludePRs = true
+	targetData.MigrationLog = &migrationlog.MigrationLogMetrics{Found: true, IssueNumber: 7, Issues: &issues}
+
+	validator := setupTestValidator(sourceData, targetData)
+	results := validator.validateRepositoryData()
+
+	// The migration log counts issues without pull requests
+	logIssues := findResult(results, "Migration Log vs Target Issues (expected +1 for migration log)")
+	if assert.NotNil(t, logIssues) {
+		assert.Equal(t, ValidationStatusPass, logIssues.StatusType)
+		assert.Equal(t, 7, logIssues.TargetVal)
+	}
+}
+
+func TestValidateRepositoryData_MigrationLogNotFound(t *testing.T) {
+	sourceData, targetData := newMigrationLogTestData()
+	targetData.MigrationLog = &migrationlog.MigrationLogMetrics{Found: false}
+
+	validator := setupTestValidator(sourceData, targetData)
+	results := validator.validateRepositoryData()
+
+	logIssue := findResult(results, "Migration Log Issue")
+	if assert.NotNil(t, logIssue) {
+		assert.Equal(t, ValidationStatusWarn, logIssue.StatusType)
+		assert.Equal(t, "Not found", logIssue.TargetVal)
+	}
+
+	for _, result := range results {
+		assert.NotContains(t, result.Metric, "Migration Log vs Target")
+	}
+}
+
+func TestFormatDifference(t *testing.T) {
+	assert.Equal(t, "Missing: 2", formatDifference(ValidationResult{Metric: "Tags", Difference: 2}))
+	assert.Equal(t, "Extra: 3", formatDifference(ValidationResult{Metric: "Tags", Difference: -3}))
+	assert.Equal(t, "Perfect match", formatDifference(ValidationResult{Metric: "Tags"}))
+	assert.Equal(t, "N/A", formatDifference(ValidationResult{Metric: "Latest Commit SHA"}))
+	assert.Equal(t, "N/A", formatDifference(ValidationResult{Metric: "Migration Log Issue"}))
+}
+
+func TestIssueOffset(t *testing.T) {
+	zero, three := 0, 3
+
+	tests := []struct {
+		name           string
+		options        ValidationOptions
+		migrationLog   *migrationlog.MigrationLogMetrics
+		expectedOffset int
+		expectedMetric string
+	}{
+		{
+			name:           "not checked uses default offset",
+			expectedOffset: 1,
+			expectedMetric: "Issues (expected +1 for migration log)",
+		},
+		{
+			name:           "migration log found",
+			migrationLog:   &migrationlog.MigrationLogMetrics{Found: true},
+			expectedOffset: 1,
+			expectedMetric: "Issues (expected +1 for migration log)",
+		},
+		{
+			name:           "migration log not found",
+			migrationLog:   &migrationlog.MigrationLogMetrics{Found: false},
+			expectedOffset: 0,
+			expectedMetric: "Issues",
+		},
+		{
+			name:           "explicit zero offset overrides detection",
+			options:        ValidationOptions{IssueOffset: &zero},
+			migrationLog:   &migrationlog.MigrationLogMetrics{Found: true},
+			expectedOffset: 0,
+			expectedMetric: "Issues",
+		},
+		{
+			name:           "explicit offset for pre-existing issues",
+			options:        ValidationOptions{IssueOffset: &three},
+			migrationLog:   &migrationlog.MigrationLogMetrics{Found: true},
+			expectedOffset: 3,
+			expectedMetric: "Issues (expected +3)",
+		},
+		{
+			name:           "transfer mode has no migration log",
+			options:        ValidationOptions{TransferMode: true},
+			expectedOffset: 0,
+			expectedMetric: "Issues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceData, targetData := newMigrationLogTestData()
+			targetData.MigrationLog = tt.migrationLog
+			targetData.Issues = sourceData.Issues + tt.expectedOffset
+
+			validator := setupTestValidator(sourceData, targetData)
+			validator.options = tt.options
+
+			assert.Equal(t, tt.expectedOffset, validator.issueOffset())
+
+			results := validator.validateRepositoryData()
+			assert.Equal(t, tt.expectedMetric, results[0].Metric)
+			assert.Equal(t, ValidationStatusPass, results[0].StatusType)
+		})
+	}
+}
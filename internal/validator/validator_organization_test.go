@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOrganizationData(t *testing.T) {
+	source := &OrganizationData{
+		Login:        "source-org",
+		Repositories: 40,
+		Teams:        map[string]int{"admins": 2, "developers": 12, "readers": 0, "security": 3},
+		Webhooks:     2,
+		Projects:     5,
+	}
+	target := &OrganizationData{
+		Login:        "target-org",
+		Repositories: 40,
+		Teams:        map[string]int{"admins": 2, "developers": 10, "security": 3},
+		Webhooks:     0,
+		Projects:     5,
+	}
+
+	results := validateOrganizationData(source, target)
+
+	byMetric := make(map[string]ValidationResult)
+	for _, result := range results {
+		byMetric[result.Metric] = result
+	}
+
+	assert.Len(t, results, 7, "five organization metrics and one row per mismatched team")
+	assert.Equal(t, ValidationStatusPass, byMetric["Repositories"].StatusType)
+	assert.Equal(t, 1, byMetric["Teams"].Difference)
+	assert.Equal(t, 17, byMetric["Team Memberships"].SourceVal)
+	assert.Equal(t, 15, byMetric["Team Memberships"].TargetVal)
+	assert.Equal(t, ValidationStatusFail, byMetric["Organization Webhooks"].StatusType)
+	assert.Equal(t, ValidationStatusPass, byMetric["Organization Projects"].StatusType)
+
+	assert.Equal(t, 2, byMetric["Team Members: developers"].Difference)
+	readers := byMetric["Team Members: readers"]
+	assert.Equal(t, missingTeamValue, readers.TargetVal)
+	assert.Equal(t, ValidationStatusFail, readers.StatusType)
+	assert.Equal(t, "N/A", formatDifference(readers), "an empty missing team has no members to count")
+
+	_, listed := byMetric["Team Members: admins"]
+	assert.False(t, listed, "teams with matching memberships are not listed")
+}
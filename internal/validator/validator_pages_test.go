@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagesResult(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{}
+	mv.TargetData = &RepositoryData{}
+
+	_, ok := mv.pagesResult()
+	assert.False(t, ok, "no row when neither repository has Pages enabled")
+
+	mv.SourceData.Pages = &api.PagesConfig{BuildType: "legacy", Branch: "gh-pages", Path: "/", CustomDomain: "docs.example.com"}
+	result, ok := mv.pagesResult()
+
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusInfo, result.StatusType, "Pages is reported, never failed")
+	assert.Equal(t, "gh-pages /, custom domain docs.example.com", result.SourceVal)
+	assert.Equal(t, "Disabled", result.TargetVal)
+	assert.Equal(t, "N/A", formatDifference(result))
+}
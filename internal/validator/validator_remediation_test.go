@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemediations(t *testing.T) {
+	mv := New(nil)
+	results := []ValidationResult{
+		countResult("Releases", 5, 3),
+		countResult("Tags", 4, 4),
+		countResult("Webhooks", 1, 2),
+		countResult("Issues (expected +1)", 10, 9),
+		{Metric: branchMetricPrefix + "feature/login", StatusType: ValidationStatusFail},
+		countResult("Team Memberships", 3, 1),
+	}
+
+	remediations := mv.Remediations(results)
+
+	var ids []string
+	for _, remediation := range remediations {
+		ids = append(ids, remediation.Rule.ID)
+	}
+	assert.Equal(t, []string{"missing-releases", "missing-issues", "diverged-branch"}, ids,
+		"only failures matching a rule are explained")
+	assert.Equal(t, "Releases", remediations[0].Metric)
+}
+
+func TestRemediations_CustomRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`rules:
+  - id: releases-from-ghes
+    metric: Releases
+    cause: GHES 3.8 exports do not include releases
+    next_step: Copy releases with the release-sync workflow
+  - id: missing-team-members
+    metric: Team Memberships
+    cause: Users have not been invited to the target organization
+    next_step: Reclaim mannequins, then re-add team members
+`), 0o644))
+
+	rules, err := LoadRemediationRules(path)
+	require.NoError(t, err)
+
+	mv := NewWithOptions(nil, ValidationOptions{Explain: true, RemediationRules: rules})
+	results := []ValidationResult{countResult("Releases", 5, 3), countResult("Team Memberships", 3, 1)}
+
+	remediations := mv.Remediations(results)
+	require.Len(t, remediations, 2)
+	assert.Equal(t, "releases-from-ghes", remediations[0].Rule.ID, "custom rules take precedence")
+	assert.Equal(t, "missing-team-members", remediations[1].Rule.ID)
+
+	var buffer bytes.Buffer
+	mv.writeMarkdownRemediations(&buffer, results)
+	assert.Contains(t, buffer.String(), "| Releases | releases-from-ghes | GHES 3.8 exports do not include releases | Copy releases with the release-sync workflow |")
+}
+
+func TestLoadRemediationRules_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rules:\n  - id: no-metric\n    next_step: Do something\n"), 0o644))
+
+	_, err := LoadRemediationRules(path)
+	assert.EqualError(t, err, "remediation rule 1 in "+path+" needs a metric and a next_step")
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRenameTestAPI returns an API whose source and target clients answer repository lookups with the
+// repositories keyed by the requested path, e.g. /repos/source-org/old-name, and 404 for any other
+func newRenameTestAPI(t *testing.T, repositories map[string]string) *api.GitHubAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := repositories[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	githubAPI, err := api.NewGitHubAPI(
+		api.ClientConfig{Token: "source-token", APIURL: server.URL},
+		api.ClientConfig{Token: "target-token", APIURL: server.URL},
+	)
+	require.NoError(t, err)
+	return githubAPI
+}
+
+func TestResolveRepositoryName(t *testing.T) {
+	githubAPI := newRenameTestAPI(t, map[string]string{
+		"/repos/source-org/old-name": `{"name": "new-name", "owner": {"login": "source-org"}}`,
+		"/repos/target-org/repo":     `{"name": "Repo", "owner": {"login": "Target-Org"}}`,
+		"/repos/target-org/old-name": `{"name": "new-name", "owner": {"login": "other-org"}}`,
+	})
+
+	tests := []struct {
+		name          string
+		clientType    api.ClientType
+		side          string
+		owner, repo   string
+		followRenames bool
+		expectedOwner string
+		expectedRepo  string
+		expectedError string
+		expectedInfo  string
+	}{
+		{
+			name:       "not renamed, names compared case-insensitively",
+			clientType: api.TargetClient, side: "target", owner: "target-org", repo: "repo",
+			expectedOwner: "target-org", expectedRepo: "repo",
+		},
+		{
+			name:       "renamed target without --follow-renames",
+			clientType: api.TargetClient, side: "target", owner: "target-org", repo: "old-name",
+			expectedError: "target repository target-org/old-name has been renamed to other-org/new-name; use the new name or pass --follow-renames to validate against it",
+		},
+		{
+			name:       "renamed target with --follow-renames",
+			clientType: api.TargetClient, side: "target", owner: "target-org", repo: "old-name", followRenames: true,
+			expectedOwner: "other-org", expectedRepo: "new-name",
+			expectedInfo: "The target repository target-org/old-name has been renamed to other-org/new-name - validating against the new name",
+		},
+		{
+			name:       "renamed source without --follow-renames",
+			clientType: api.SourceClient, side: "source", owner: "source-org", repo: "old-name",
+			expectedError: "source repository source-org/old-name has been renamed to source-org/new-name",
+		},
+		{
+			name:       "renamed source with --follow-renames",
+			clientType: api.SourceClient, side: "source", owner: "source-org", repo: "old-name", followRenames: true,
+			expectedOwner: "source-org", expectedRepo: "new-name",
+			expectedInfo: "The source repository source-org/old-name has been renamed to source-org/new-name",
+		},
+		{
+			name:       "lookup failure keeps the requested name",
+			clientType: api.SourceClient, side: "source", owner: "source-org", repo: "missing",
+			expectedOwner: "source-org", expectedRepo: "missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var progress bytes.Buffer
+			mv := NewWithOptions(githubAPI, ValidationOptions{FollowRenames: tt.followRenames, Progress: &progress})
+
+			owner, repo, err := mv.resolveRepositoryName(tt.clientType, tt.side, tt.owner, tt.repo)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOwner, owner)
+			assert.Equal(t, tt.expectedRepo, repo)
+			if tt.expectedInfo != "" {
+				assert.Contains(t, progress.String(), tt.expectedInfo)
+			} else {
+				assert.Empty(t, progress.String())
+			}
+		})
+	}
+}
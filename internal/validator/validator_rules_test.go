@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadValidationRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`comparisons:
+  - metric: Commits
+    compare: at-least
+  - metric: Commit Comments
+    compare: percentage
+    tolerance: 5
+`), 0o644))
+
+	rules, err := LoadValidationRules(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []ComparisonRule{
+		{Metric: "Commits", Compare: CompareAtLeast},
+		{Metric: "Commit Comments", Compare: ComparePercentage, Tolerance: 5},
+	}, rules.Comparisons)
+}
+
+func TestLoadValidationRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         string
+		expectedError string
+	}{
+		{
+			name:          "not a count metric",
+			rules:         "comparisons:\n  - metric: Latest Commit SHA\n    compare: exact\n",
+			expectedError: `comparison rule 1 in %s: metric "Latest Commit SHA" is not a count metric`,
+		},
+		{
+			name:          "unknown comparison",
+			rules:         "comparisons:\n  - metric: Tags\n    compare: fuzzy\n",
+			expectedError: `comparison rule 1 in %s: unknown comparison "fuzzy", expected one of: advisory, at-least, exact, percentage`,
+		},
+		{
+			name:          "tolerance of another comparison",
+			rules:         "comparisons:\n  - metric: Tags\n    compare: exact\n    tolerance: 10\n",
+			expectedError: "comparison rule 1 in %s: tolerance must be a positive percentage, and is only used by the percentage comparison",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.rules), 0o644))
+
+			_, err := LoadValidationRules(path)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf(tt.expectedError, path))
+		})
+	}
+}
+
+func TestCompareMetric_ComparisonRules(t *testing.T) {
+	tests := []struct {
+		name               string
+		rule               ComparisonRule
+		metric             string
+		sourceVal          int
+		targetVal          int
+		expectedStatusType ValidationStatus
+	}{
+		{name: "at-least passes extra commits", rule: ComparisonRule{Metric: "Commits", Compare: CompareAtLeast},
+			metric: "Commits", sourceVal: 100, targetVal: 120, expectedStatusType: ValidationStatusPass},
+		{name: "at-least fails missing commits", rule: ComparisonRule{Metric: "Commits", Compare: CompareAtLeast},
+			metric: "Commits", sourceVal: 100, targetVal: 99, expectedStatusType: ValidationStatusFail},
+		{name: "exact warns on extra tags", rule: ComparisonRule{Metric: "Tags", Compare: CompareExact},
+			metric: "Tags", sourceVal: 10, targetVal: 11, expectedStatusType: ValidationStatusWarn},
+		{name: "advisory warns on missing tags", rule: ComparisonRule{Metric: "Tags", Compare: CompareAdvisory},
+			metric: "Tags", sourceVal: 10, targetVal: 9, expectedStatusType: ValidationStatusWarn},
+		{name: "percentage within tolerance", rule: ComparisonRule{Metric: "Commit Comments", Compare: ComparePercentage, Tolerance: 5},
+			metric: "Commit Comments", sourceVal: 200, targetVal: 190, expectedStatusType: ValidationStatusPass},
+		{name: "percentage beyond tolerance", rule: ComparisonRule{Metric: "Commit Comments", Compare: ComparePercentage, Tolerance: 5},
+			metric: "Commit Comments", sourceVal: 200, targetVal: 189, expectedStatusType: ValidationStatusFail},
+		{name: "rule of another metric", rule: ComparisonRule{Metric: "Tags", Compare: CompareAtLeast},
+			metric: "Commits", sourceVal: 100, targetVal: 120, expectedStatusType: ValidationStatusWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := NewWithOptions(nil, ValidationOptions{ComparisonRules: []ComparisonRule{tt.rule}})
+			mv.SourceData = &RepositoryData{CommitCount: tt.sourceVal, Tags: tt.sourceVal, CommitComments: tt.sourceVal}
+			mv.TargetData = &RepositoryData{CommitCount: tt.targetVal, Tags: tt.targetVal, CommitComments: tt.targetVal}
+
+			var metric repositoryMetric
+			for _, m := range repositoryMetrics {
+				if m.name == tt.metric {
+					metric = m
+				}
+			}
+			results := mv.compareMetric(metric)
+
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.expectedStatusType, results[0].StatusType)
+			assert.Equal(t, tt.sourceVal-tt.targetVal, results[0].Difference)
+		})
+	}
+}
+
+func TestApplyIgnoreRules(t *testing.T) {
+	results := []ValidationResult{
+		countResult("Releases", 5, 3),
+		countResult("Webhooks", 2, 0),
+		countResult("Tags", 4, 4),
+		countResult("Issues (expected +1 for migration log)", 11, 10),
+	}
+
+	mv := NewWithOptions(nil, ValidationOptions{IgnoreRules: []IgnoreRule{
+		{Metric: "Releases", Reason: "releases are recreated by the release-sync workflow"},
+		{Metric: "Webhooks", Reason: "webhooks point to the old CI", Repositories: []string{"other-org/repo"}},
+		{Metric: "Tags", Reason: "never applied to passing results"},
+		{Metric: "Issues*", Reason: "spam issues were deleted", Repositories: []string{"Source-Org/Repo"}},
+	}})
+	mv.SourceData = &RepositoryData{Owner: "source-org", Name: "repo"}
+	mv.TargetData = &RepositoryData{Owner: "target-org", Name: "repo"}
+
+	results = mv.applyIgnoreRules(results)
+
+	assert.Equal(t, ValidationStatusInfo, results[0].StatusType)
+	assert.Equal(t, ValidationStatusMessageInfo, results[0].Status)
+	assert.Equal(t, "Missing: 2 (known difference: releases are recreated by the release-sync workflow)", formatDifference(results[0]))
+	assert.Equal(t, ValidationStatusFail, results[1].StatusType, "rules of other repositories do not apply")
+	assert.Equal(t, ValidationStatusPass, results[2].StatusType)
+	assert.Empty(t, results[2].Note)
+	assert.Equal(t, ValidationStatusInfo, results[3].StatusType, "prefixes and repositories match case-insensitively")
+	assert.Equal(t, "known difference: spam issues were deleted", results[3].Note)
+}
+
+func TestLoadValidationRules_Ignore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`ignore:
+  - metric: Releases
+    reason: Releases are recreated by the release-sync workflow
+    repositories:
+      - my-org/legacy-app
+`), 0o644))
+
+	rules, err := LoadValidationRules(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []IgnoreRule{{
+		Metric:       "Releases",
+		Reason:       "Releases are recreated by the release-sync workflow",
+		Repositories: []string{"my-org/legacy-app"},
+	}}, rules.Ignore)
+
+	require.NoError(t, os.WriteFile(path, []byte("ignore:\n  - metric: Releases\n"), 0o644))
+	_, err = LoadValidationRules(path)
+	assert.EqualError(t, err, "ignore rule 1 in "+path+" needs a metric and a reason")
+}
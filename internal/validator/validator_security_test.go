@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityResults(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{Security: &api.SecurityStatus{
+		DependabotAlerts: api.SecurityFeature{Enabled: true, OpenAlerts: 7},
+		SecretScanning:   api.SecurityFeature{Enabled: true, OpenAlerts: 2},
+	}}
+	mv.TargetData = &RepositoryData{Security: &api.SecurityStatus{
+		DependabotAlerts: api.SecurityFeature{Enabled: true, OpenAlerts: 4},
+		CodeScanning:     api.SecurityFeature{Enabled: true, OpenAlerts: 1},
+	}}
+
+	results := mv.securityResults()
+
+	byMetric := make(map[string]ValidationResult)
+	for _, result := range results {
+		byMetric[result.Metric] = result
+	}
+
+	assert.Len(t, results, 4, "one row per feature and open alerts only for features enabled on both sides")
+	assert.Equal(t, ValidationStatusPass, byMetric["Security: Dependabot Enabled"].StatusType)
+	assert.Equal(t, ValidationStatusWarn, byMetric["Security: Dependabot Open Alerts"].StatusType, "alert differences are advisory")
+	assert.Equal(t, 3, byMetric["Security: Dependabot Open Alerts"].Difference)
+
+	secretScanning := byMetric["Security: Secret Scanning Enabled"]
+	assert.Equal(t, ValidationStatusWarn, secretScanning.StatusType, "a feature not enabled on the target is a warning")
+	assert.Equal(t, "Disabled", secretScanning.TargetVal)
+	assert.Equal(t, "N/A", formatDifference(secretScanning))
+
+	assert.Equal(t, ValidationStatusPass, byMetric["Security: Code Scanning Enabled"].StatusType, "enabling a feature only on the target is fine")
+	assert.False(t, HasFailures(results))
+}
+
+func TestSecurityResults_NotChecked(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{}
+	mv.TargetData = &RepositoryData{Security: &api.SecurityStatus{}}
+
+	assert.Empty(t, mv.securityResults())
+}
@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignaturesResult(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{CommitSignatures: &api.SignatureStats{Commits: 100, Signed: 90, Verified: 85}}
+	mv.TargetData = &RepositoryData{CommitSignatures: &api.SignatureStats{Commits: 100, Signed: 90, Verified: 40}}
+
+	result, ok := mv.signaturesResult()
+
+	assert.True(t, ok)
+	assert.Equal(t, "Verified Commit Signatures (last 100 commits)", result.Metric)
+	assert.Equal(t, "85% (85/100)", result.SourceVal)
+	assert.Equal(t, "40% (40/100)", result.TargetVal)
+	assert.Equal(t, ValidationStatusWarn, result.StatusType, "unverified signatures are advisory")
+	assert.Equal(t, 45, result.Difference)
+
+	mv.TargetData.CommitSignatures.Verified = 90
+	result, _ = mv.signaturesResult()
+	assert.Equal(t, ValidationStatusPass, result.StatusType, "more verified signatures on the target is fine")
+
+	mv.TargetData.CommitSignatures = nil
+	_, ok = mv.signaturesResult()
+	assert.False(t, ok, "no row when a side was not checked")
+}
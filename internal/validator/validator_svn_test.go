@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSVNData(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{Name: "https://svn.example.com/repo", CommitCount: 120, Tags: 5}
+	mv.TargetData = &RepositoryData{Owner: "target-org", Name: "target-repo", CommitCount: 118, Tags: 6}
+
+	results := mv.validateSVNData()
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Commits (SVN, advisory)", results[0].Metric)
+	assert.Equal(t, ValidationStatusWarn, results[0].StatusType, "missing commits are advisory, not a failure")
+	assert.Equal(t, 2, results[0].Difference)
+	assert.Equal(t, "Tags (SVN, advisory)", results[1].Metric)
+	assert.Equal(t, ValidationStatusWarn, results[1].StatusType)
+	assert.Equal(t, -1, results[1].Difference)
+	assert.False(t, HasFailures(results))
+
+	mv.TargetData.CommitCount = 120
+	assert.Equal(t, ValidationStatusPass, mv.validateSVNData()[0].StatusType)
+}
+
+func TestDescribeRepository_WithoutOwner(t *testing.T) {
+	assert.Equal(t, "https://svn.example.com/repo", describeRepository(&RepositoryData{Name: "https://svn.example.com/repo"}))
+	assert.Equal(t, "source-org/source-repo", describeRepository(&RepositoryData{Owner: "source-org", Name: "source-repo"}))
+}
@@ -2,18 +2,79 @@ package validator
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/pterm/pterm"
-	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/migrationarchive"
+	"mona-actions/gh-migration-validator/internal/output"
 )
 
+// discardOutput discards the printed reports until the test ends
+func discardOutput(t *testing.T) {
+	printer := output.CurrentPrinter()
+	output.SetPrinter(output.NewTextPrinter(io.Discard))
+	t.Cleanup(func() { output.SetPrinter(printer) })
+}
+
+// newValidationTestAPI returns an API answering the queries a whole validation needs to run: repository access,
+// the rate limit and, for --check-truncation, issue #1 with a body of 1000 characters on the source and 100 on
+// the target. REST requests are answered with the repositories keyed by path, e.g. /repos/source-org/repo. Other
+// requests fail, and are reported as failed requests.
+func newValidationTestAPI(t *testing.T, repositories map[string]string) *api.GitHubAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if response, ok := repositories[r.URL.Path]; ok {
+			w.Write([]byte(response))
+			return
+		}
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(body.Query, "{rateLimit"):
+			w.Write([]byte(`{"data": {"rateLimit": {"remaining": 5000, "resetAt": "2099-01-01T00:00:00Z"}}}`))
+		case strings.Contains(body.Query, "issues(first: 100"):
+			fmt.Fprintf(w, `{"data": {"repository": {"issues": {"nodes": [{"number": 1, "body": %q}], "pageInfo": {"hasNextPage": false}}}}}`, strings.Repeat("a", 1000))
+		case strings.Contains(body.Query, "pullRequests(first: 100"):
+			w.Write([]byte(`{"data": {"repository": {"pullRequests": {"nodes": [], "pageInfo": {"hasNextPage": false}}}}}`))
+		case strings.Contains(body.Query, "issueOrPullRequest"):
+			fmt.Fprintf(w, `{"data": {"repository": {"issueOrPullRequest": {"body": %q}}}}`, strings.Repeat("a", 100))
+		case strings.Contains(body.Query, "{id}"):
+			w.Write([]byte(`{"data": {"repository": {"id": "R_1"}}}`))
+		default:
+			w.Write([]byte(`{"data": null, "errors": [{"message": "not stubbed"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	githubAPI, err := api.NewGitHubAPI(
+		api.ClientConfig{Token: "source-token", APIURL: server.URL},
+		api.ClientConfig{Token: "target-token", APIURL: server.URL},
+	)
+	require.NoError(t, err)
+	return githubAPI
+}
+
 // expectedValidationMetrics defines all the metrics that should be validated
 // This eliminates magic numbers in tests and ensures consistency when validation dimensions change
 var expectedValidationMetrics = []string{
@@ -24,7 +85,10 @@ var expectedValidationMetrics = []string{
 	"Tags",
 	"Releases",
 	"Commits",
+	"Commit Comments",
+	"Branches",
 	"Branch Protection Rules",
+	"Protected Tag Rules",
 	"Webhooks",
 	"LFS Objects",
 	"Latest Commit SHA",
@@ -80,10 +144,13 @@ func TestValidateRepositoryData_PerfectMatch(t *testing.T) {
 		Issues:                10,
 		PRs:                   &api.PRCounts{Total: 5, Open: 2, Merged: 2, Closed: 1},
 		Tags:                  3,
+		BranchCount:           4,
 		Releases:              2,
 		CommitCount:           100,
+		CommitComments:        5,
 		LatestCommitSHA:       "abc123",
 		BranchProtectionRules: 4,
+		ProtectedTagRules:     2,
 		Webhooks:              2,
 		LFSObjects:            5,
 	}
@@ -94,10 +161,13 @@ func TestValidateRepositoryData_PerfectMatch(t *testing.T) {
 		Issues:                11, // Expected: source + 1 for migration log
 		PRs:                   &api.PRCounts{Total: 5, Open: 2, Merged: 2, Closed: 1},
 		Tags:                  3,
+		BranchCount:           4,
 		Releases:              2,
 		CommitCount:           100,
+		CommitComments:        5,
 		LatestCommitSHA:       "abc123",
 		BranchProtectionRules: 4,
+		ProtectedTagRules:     2,
 		Webhooks:              2,
 		LFSObjects:            5,
 	}
@@ -151,10 +221,13 @@ func TestValidateRepositoryData_MissingData(t *testing.T) {
 		Issues:                10,
 		PRs:                   &api.PRCounts{Total: 5, Open: 2, Merged: 2, Closed: 1},
 		Tags:                  3,
+		BranchCount:           4,
 		Releases:              2,
 		CommitCount:           100,
+		CommitComments:        5,
 		LatestCommitSHA:       "abc123",
 		BranchProtectionRules: 4,
+		ProtectedTagRules:     2,
 		Webhooks:              3,
 		LFSObjects:            10,
 	}
@@ -165,10 +238,13 @@ func TestValidateRepositoryData_MissingData(t *testing.T) {
 		Issues:                8,                                                      // Missing 3 (should be 11, but is 8)
 		PRs:                   &api.PRCounts{Total: 3, Open: 1, Merged: 1, Closed: 1}, // Missing 2 total PRs
 		Tags:                  2,                                                      // Missing 1 tag
+		BranchCount:           3,                                                      // Missing 1 branch
 		Releases:              1,                                                      // Missing 1 release
 		CommitCount:           90,                                                     // Missing 10 commits
+		CommitComments:        4,                                                      // Missing 1 comment
 		LatestCommitSHA:       "def456",                                               // Different commit SHA
 		BranchProtectionRules: 3,                                                      // Missing 1 rule
+		ProtectedTagRules:     1,                                                      // Missing 1 rule
 		Webhooks:              1,                                                      // Missing 2 webhooks
 		LFSObjects:            5,                                                      // Missing 5 LFS objects
 	}
@@ -217,10 +293,13 @@ func TestValidateRepositoryData_ExtraData(t *testing.T) {
 		Issues:                10,
 		PRs:                   &api.PRCounts{Total: 5, Open: 2, Merged: 2, Closed: 1},
 		Tags:                  3,
+		BranchCount:           4,
 		Releases:              2,
 		CommitCount:           100,
+		CommitComments:        5,
 		LatestCommitSHA:       "abc123",
 		BranchProtectionRules: 4,
+		ProtectedTagRules:     2,
 		Webhooks:              2,
 		LFSObjects:            5,
 	}
@@ -231,10 +310,13 @@ func TestValidateRepositoryData_ExtraData(t *testing.T) {
 		Issues:                13,                                                     // 2 extra (should be 11, but is 13)
 		PRs:                   &api.PRCounts{Total: 7, Open: 3, Merged: 3, Closed: 1}, // 2 extra PRs
 		Tags:                  5,                                                      // 2 extra tags
+		BranchCount:           6,                                                      // 2 extra branches
 		Releases:              4,                                                      // 2 extra releases
 		CommitCount:           110,                                                    // 10 extra commits
+		CommitComments:        7,                                                      // 2 extra comments
 		LatestCommitSHA:       "abc123",                                               // Same commit SHA
 		BranchProtectionRules: 6,                                                      // 2 extra rules
+		ProtectedTagRules:     3,                                                      // 1 extra rule
 		Webhooks:              5,                                                      // 3 extra webhooks
 		LFSObjects:            8,                                                      // 3 extra LFS objects
 	}
@@ -274,9 +356,8 @@ func TestValidateRepositoryData_ExtraData(t *testing.T) {
 }
 
 func TestPrintValidationResults(t *testing.T) {
-	// Disable pterm output for testing to avoid cluttering test output
-	pterm.DisableOutput()
-	defer pterm.EnableOutput()
+	// Discard the report to avoid cluttering test output
+	discardOutput(t)
 
 	validator := setupTestValidator(
 		&RepositoryData{
@@ -322,7 +403,7 @@ func TestPrintValidationResults(t *testing.T) {
 	}, "PrintValidationResults should not panic")
 
 	// Test that the function processes results correctly
-	// We can't easily test the exact output due to pterm formatting,
+	// We can't easily test the exact output due to the report formatting,
 	// but we can ensure it doesn't crash with various result combinations
 }
 
@@ -702,9 +783,7 @@ func TestSetSourceDataFromExport_NilPRCounts(t *testing.T) {
 }
 
 func TestValidateFromExport_NoSourceData(t *testing.T) {
-	// Disable pterm output for testing
-	pterm.DisableOutput()
-	defer pterm.EnableOutput()
+	discardOutput(t)
 
 	validator := &MigrationValidator{
 		api:        nil,
@@ -833,7 +912,7 @@ func TestRetrieveSourceData_PublicMethodExists(t *testing.T) {
 
 	// If this compiles, it means the public method exists with the correct signature
 	// We use a type assertion to verify the method signature without calling it
-	var method func(string, string, *pterm.SpinnerPrinter) ([]string, error) = validator.RetrieveSourceData
+	var method func(string, string, output.Spinner) ([]string, error) = validator.RetrieveSourceData
 
 	assert.NotNil(t, method, "RetrieveSourceData method should exist")
 
@@ -938,17 +1017,11 @@ func BenchmarkValidateRepositoryData(b *testing.B) {
 }
 
 func TestOutputMarkdownResults_MissingDirectory(t *testing.T) {
-	// Ensure viper state is isolated
-	viper.Reset()
-	defer viper.Reset()
-
 	tempRoot := t.TempDir()
 	missingDir := filepath.Join(tempRoot, "does-not-exist", "report.md")
 
-	viper.Set("MARKDOWN_FILE", missingDir)
-	viper.Set("MARKDOWN_TABLE", false)
-
 	mv := &MigrationValidator{
+		options:    ValidationOptions{MarkdownFile: missingDir},
 		SourceData: &RepositoryData{Owner: "src", Name: "repo"},
 		TargetData: &RepositoryData{Owner: "tgt", Name: "repo"},
 	}
@@ -984,10 +1057,6 @@ func BenchmarkSetSourceDataFromExport(b *testing.B) {
 }
 
 func TestValidateRepositoryData_NoLFSFlag(t *testing.T) {
-	// Set the NO_LFS flag
-	viper.Set("NO_LFS", true)
-	defer viper.Set("NO_LFS", false) // Reset after test
-
 	sourceData := &RepositoryData{
 		Owner:                 "source-org",
 		Name:                  "test-repo",
@@ -1017,6 +1086,7 @@ func TestValidateRepositoryData_NoLFSFlag(t *testing.T) {
 	}
 
 	validator := setupTestValidator(sourceData, targetData)
+	validator.options.NoLFS = true
 	results := validator.validateRepositoryData()
 
 	// Verify that LFS Objects is NOT in the results
@@ -1043,7 +1113,10 @@ func TestValidateRepositoryData_NoLFSFlag(t *testing.T) {
 		"Tags",
 		"Releases",
 		"Commits",
+		"Commit Comments",
+		"Branches",
 		"Branch Protection Rules",
+		"Protected Tag Rules",
 		"Webhooks",
 		"Latest Commit SHA",
 	}
@@ -1053,3 +1126,245 @@ func TestValidateRepositoryData_NoLFSFlag(t *testing.T) {
 			"Metric at position %d should be %s", i, expectedMetric)
 	}
 }
+
+func TestValidateRepositoryData_EmptyRepositories(t *testing.T) {
+	tests := []struct {
+		name               string
+		sourceEmpty        bool
+		targetEmpty        bool
+		sourceCommits      int
+		expectedTargetVal  string
+		expectCommitResult bool
+		expectedCommitType ValidationStatus
+	}{
+		{
+			name:               "both empty",
+			sourceEmpty:        true,
+			targetEmpty:        true,
+			expectedTargetVal:  "Empty (both empty)",
+			expectCommitResult: false,
+		},
+		{
+			name:               "target empty but source has content",
+			targetEmpty:        true,
+			sourceCommits:      10,
+			expectedTargetVal:  "Empty (target empty but source has content)",
+			expectCommitResult: true,
+			expectedCommitType: ValidationStatusFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceData := &RepositoryData{
+				Owner:       "source-org",
+				Name:        "test-repo",
+				IsEmpty:     tt.sourceEmpty,
+				Issues:      0,
+				PRs:         &api.PRCounts{},
+				CommitCount: tt.sourceCommits,
+			}
+			if !tt.sourceEmpty {
+				sourceData.LatestCommitSHA = "abc123"
+			}
+			targetData := &RepositoryData{
+				Owner:   "target-org",
+				Name:    "test-repo",
+				IsEmpty: tt.targetEmpty,
+				Issues:  1,
+				PRs:     &api.PRCounts{},
+			}
+
+			validator := setupTestValidator(sourceData, targetData)
+			results := validator.validateRepositoryData()
+
+			var contentResult, commitResult *ValidationResult
+			for i := range results {
+				switch results[i].Metric {
+				case "Repository Content":
+					contentResult = &results[i]
+				case "Commits":
+					commitResult = &results[i]
+				case "Latest Commit SHA":
+					t.Errorf("Latest Commit SHA should not be compared when a repository is empty")
+				}
+			}
+
+			if assert.NotNil(t, contentResult, "Should report repository content") {
+				assert.Equal(t, ValidationStatusInfo, contentResult.StatusType)
+				assert.Equal(t, ValidationStatusMessageInfo, contentResult.Status)
+				assert.Equal(t, tt.expectedTargetVal, contentResult.TargetVal)
+			}
+
+			if tt.expectCommitResult {
+				if assert.NotNil(t, commitResult) {
+					assert.Equal(t, tt.expectedCommitType, commitResult.StatusType)
+				}
+			} else {
+				assert.Nil(t, commitResult, "Commits should not be compared when both repositories are empty")
+				assert.False(t, HasFailures(results), "Two empty repositories should not fail validation")
+			}
+		})
+	}
+}
+
+func TestPartialDataError(t *testing.T) {
+	mv := New(nil)
+	assert.NoError(t, mv.PartialDataError())
+
+	mv.sourceFailures = requestFailures("source", []string{"tags"}, []error{errors.New("boom")})
+	mv.targetFailures = requestFailures("target", []string{"webhooks", "LFS objects"}, []error{errors.New("boom"), errors.New("boom")})
+
+	err := mv.PartialDataError()
+	assert.ErrorIs(t, err, api.ErrPartialData)
+	assert.EqualError(t, err, "partial data: failed to retrieve source tags, target webhooks, target LFS objects")
+}
+
+func TestAllRequestsFailedError(t *testing.T) {
+	err := allRequestsFailedError("owner", "repo", []error{
+		errors.New("connection reset"),
+		fmt.Errorf("failed to query: %w", api.ErrAuth),
+	})
+	assert.ErrorIs(t, err, api.ErrAuth)
+	assert.EqualError(t, err, "all API requests failed for owner/repo: failed to query: authentication failed")
+
+	err = allRequestsFailedError("owner", "repo", []error{errors.New("connection reset")})
+	assert.EqualError(t, err, "all API requests failed for owner/repo")
+}
+
+func TestMetricTimer(t *testing.T) {
+	mv := New(nil)
+
+	sourceTimer := mv.newMetricTimer(api.SourceClient)
+	sourceTimer.Start("issues")
+	sourceTimer.Stop(nil)
+
+	targetTimer := mv.newMetricTimer(api.TargetClient)
+	targetTimer.Start("tags")
+	targetTimer.Stop(errors.New("not found"))
+
+	timings := mv.Timings()
+	assert.Len(t, timings, 2)
+	assert.Equal(t, "source", timings[0].Side)
+	assert.Equal(t, "issues", timings[0].Metric)
+	assert.Equal(t, "target", timings[1].Side)
+	assert.Equal(t, "tags", timings[1].Metric)
+	assert.Equal(t, 0, timings[1].APICalls)
+}
+
+func TestMarkdownReport_ShowTimings(t *testing.T) {
+	mv := setupTestValidator(&RepositoryData{Owner: "source-org", Name: "repo"}, &RepositoryData{Owner: "target-org", Name: "repo"})
+	mv.sourceTimings = []MetricTiming{{Side: "source", Metric: "issues", Duration: 1500 * time.Millisecond, APICalls: 2, Cost: 3}}
+
+	assert.NotContains(t, mv.MarkdownReport(nil), "## Performance")
+
+	mv.options.ShowTimings = true
+
+	report := mv.MarkdownReport(nil)
+	assert.Contains(t, report, "## Performance")
+	assert.Contains(t, report, "| source | issues | 1.5s | 2 | 3 |")
+}
+
+func TestTargetUnavailableResults(t *testing.T) {
+	mv := setupTestValidator(&RepositoryData{
+		Owner:       "source-org",
+		Name:        "repo",
+		Issues:      5,
+		PRs:         &api.PRCounts{Total: 3},
+		CommitCount: 10,
+		MigrationArchive: &migrationarchive.MigrationArchiveMetrics{
+			Issues: 5,
+		},
+	}, &RepositoryData{})
+
+	notFound := fmt.Errorf("cannot access target repository target-org/repo: %w", api.ErrNotFound)
+	var results []ValidationResult
+	captureOutput(func() {
+		results = mv.targetUnavailableResults("target-org", "repo", notFound)
+	})
+
+	assert.ErrorIs(t, mv.TargetUnavailableError(), api.ErrNotFound)
+	assert.True(t, HasUnavailableTarget(results))
+	assert.Equal(t, "target-org", mv.TargetData.Owner)
+
+	for _, result := range results {
+		if strings.HasPrefix(result.Metric, "Archive vs Source") {
+			assert.NotEqual(t, ValidationStatusUnavailable, result.StatusType, "archive vs source comparisons do not need the target")
+			continue
+		}
+		assert.Equal(t, ValidationStatusUnavailable, result.StatusType, result.Metric)
+		assert.Equal(t, ValidationStatusMessageUnavailable, result.Status)
+		assert.Equal(t, "Unavailable", result.TargetVal)
+		assert.Equal(t, "N/A", formatDifference(result))
+	}
+
+	report := mv.MarkdownReport(results)
+	assert.Contains(t, report, "🚫 TARGET UNAVAILABLE")
+	assert.Contains(t, report, "**Result:** 🚫 Migration validation INCOMPLETE - Target repository is unavailable")
+}
+
+func TestFailedRequests(t *testing.T) {
+	mv := setupTestValidator(&RepositoryData{Owner: "source-org", Name: "repo"}, &RepositoryData{Owner: "target-org", Name: "repo"})
+	mv.targetFailures = requestFailures("target", []string{"webhooks", "tags"}, []error{
+		errors.New("Resource protected by organization SAML enforcement."),
+		errors.New("Could not resolve to a Repository with the name 'target-org/repo'."),
+	})
+
+	failures := mv.FailedRequests()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, api.CauseSAMLEnforcement, failures[0].Cause)
+	assert.Equal(t, "webhooks (SAML enforcement), tags (not found)", describeFailures(failures))
+
+	report := mv.MarkdownReport(nil)
+	assert.Contains(t, report, "## Failed Requests")
+	assert.Contains(t, report, "| target | webhooks | SAML enforcement | "+api.CauseSAMLEnforcement.Hint()+" |")
+}
+
+func TestRESTFallbackNotes(t *testing.T) {
+	mv := setupTestValidator(&RepositoryData{Owner: "source-org", Name: "repo"}, &RepositoryData{Owner: "target-org", Name: "repo"})
+	assert.NotContains(t, mv.MarkdownReport(nil), "## Counted with the REST API")
+
+	mv.SourceData.RESTFallbacks = []string{"issues", "pull requests"}
+	assert.Equal(t, []string{"Source source-org/repo: issues, pull requests"}, mv.restFallbackNotes())
+
+	report := mv.MarkdownReport(nil)
+	assert.Contains(t, report, "## Counted with the REST API")
+	assert.Contains(t, report, "- Source source-org/repo: issues, pull requests")
+}
+
+func TestSSOError(t *testing.T) {
+	mv := New(nil)
+
+	err := mv.ssoError(api.TargetClient, "target-org", []error{
+		errors.New("connection reset"),
+		errors.New("Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization."),
+	})
+
+	var ssoErr *api.SSOError
+	assert.ErrorAs(t, err, &ssoErr)
+	assert.Equal(t, "target-org", ssoErr.Organization)
+	assert.ErrorIs(t, err, api.ErrAuth)
+
+	assert.NoError(t, mv.ssoError(api.TargetClient, "target-org", []error{errors.New("connection reset")}))
+}
+
+func TestTreeHashResult(t *testing.T) {
+	mv := setupTestValidator(
+		&RepositoryData{LatestCommitSHA: "aaa", TreeSHA: "tree-1"},
+		&RepositoryData{LatestCommitSHA: "bbb", TreeSHA: "tree-1"},
+	)
+
+	result, ok := mv.treeHashResult()
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusPass, result.StatusType, "identical contents pass although the commit SHAs differ")
+	assert.Equal(t, "N/A", formatDifference(result))
+
+	mv.TargetData.TreeSHA = "tree-2"
+	result, ok = mv.treeHashResult()
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStatusFail, result.StatusType)
+
+	mv.TargetData.TreeSHA = ""
+	_, ok = mv.treeHashResult()
+	assert.False(t, ok, "no row when a tree was not retrieved")
+}
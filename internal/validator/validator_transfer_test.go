@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"io"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/output"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferRedirectResult(t *testing.T) {
+	redirect := func(name string) *string { return &name }
+
+	tests := []struct {
+		name               string
+		redirect           *string
+		expectedOK         bool
+		expectedStatusType ValidationStatus
+		expectedTargetVal  interface{}
+	}{
+		{
+			name:               "source name redirects to target",
+			redirect:           redirect("New-Org/repo"),
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusPass,
+			expectedTargetVal:  "New-Org/repo",
+		},
+		{
+			name:               "source name no longer resolves",
+			redirect:           redirect(""),
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusFail,
+			expectedTargetVal:  "Not found",
+		},
+		{
+			name:               "source name reused by another repository",
+			redirect:           redirect("old-org/repo"),
+			expectedOK:         true,
+			expectedStatusType: ValidationStatusFail,
+			expectedTargetVal:  "old-org/repo",
+		},
+		{
+			name: "redirect not checked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := New(nil)
+			mv.SourceData = &RepositoryData{Owner: "old-org", Name: "repo"}
+			mv.TargetData = &RepositoryData{Owner: "new-org", Name: "repo", TransferRedirect: tt.redirect}
+
+			result, ok := mv.transferRedirectResult()
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, transferRedirectMetric, result.Metric)
+			assert.Equal(t, "old-org/repo", result.SourceVal)
+			assert.Equal(t, tt.expectedStatusType, result.StatusType)
+			assert.Equal(t, tt.expectedTargetVal, result.TargetVal)
+			assert.Equal(t, "N/A", formatDifference(result))
+		})
+	}
+}
+
+// TestRetrieveTarget_SourceRepository checks the target fetches look up the source repository they are given,
+// not SourceData, which the source retrieval resets while the target is retrieved
+func TestRetrieveTarget_SourceRepository(t *testing.T) {
+	mv := NewWithOptions(newValidationTestAPI(t, map[string]string{
+		"/repos/source-org/repo": `{"name": "repo", "owner": {"login": "target-org"}}`,
+	}), ValidationOptions{TransferMode: true, NoLFS: true, Progress: io.Discard})
+
+	_, err := mv.retrieveTarget("target-org", "repo", "source-org", "repo", output.StartSpinner(io.Discard, ""))
+	require.NoError(t, err)
+
+	require.NotNil(t, mv.TargetData.TransferRedirect)
+	assert.Equal(t, "target-org/repo", *mv.TargetData.TransferRedirect)
+}
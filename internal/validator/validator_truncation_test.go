@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"io"
+	"testing"
+
+	"mona-actions/gh-migration-validator/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncationResult(t *testing.T) {
+	mv := New(nil)
+	mv.SourceData = &RepositoryData{LargestBodies: []api.BodyLength{
+		{Number: 12, Length: 150000},
+		{Number: 40, Length: 90000},
+		{Number: 7, Length: 20000},
+		{Number: 3, Length: 10000},
+	}}
+	mv.TargetData = &RepositoryData{LargestBodies: []api.BodyLength{
+		{Number: 12, Length: 65536},
+		{Number: 40, Length: 65536},
+		{Number: 7, Length: 19500}, // Rewritten links, not truncated
+	}}
+
+	result, ok := mv.truncationResult()
+
+	assert.True(t, ok)
+	assert.Equal(t, "4 longest checked", result.SourceVal)
+	assert.Equal(t, "2 truncated: #12, #40", result.TargetVal, "items missing on the target are not reported as truncated")
+	assert.Equal(t, ValidationStatusWarn, result.StatusType)
+	assert.Equal(t, "N/A", formatDifference(result))
+
+	mv.TargetData.LargestBodies = []api.BodyLength{{Number: 12, Length: 150000}, {Number: 40, Length: 90000}}
+	result, _ = mv.truncationResult()
+	assert.Equal(t, "None truncated", result.TargetVal)
+	assert.Equal(t, ValidationStatusPass, result.StatusType)
+
+	mv.TargetData.LargestBodies = nil
+	_, ok = mv.truncationResult()
+	assert.False(t, ok, "no row when the target bodies were not retrieved")
+}
+
+// TestValidateMigration_CheckTruncation runs a whole validation, whose target body lengths can only be fetched
+// once the longest source bodies are known, after both retrievals
+func TestValidateMigration_CheckTruncation(t *testing.T) {
+	discardOutput(t)
+	mv := NewWithOptions(newValidationTestAPI(t, nil), ValidationOptions{CheckTruncation: 5, NoLFS: true, Progress: io.Discard})
+
+	results, err := mv.ValidateMigration("source-org", "repo", "target-org", "repo")
+	require.NoError(t, err)
+
+	var truncation *ValidationResult
+	for i := range results {
+		if results[i].Metric == truncationMetric {
+			truncation = &results[i]
+		}
+	}
+	require.NotNil(t, truncation, "the truncation row is reported")
+	assert.Equal(t, "1 longest checked", truncation.SourceVal)
+	assert.Equal(t, "1 truncated: #1", truncation.TargetVal)
+	assert.Equal(t, ValidationStatusWarn, truncation.StatusType)
+}
@@ -0,0 +1,217 @@
+// Package xlsx writes spreadsheets in the Office Open XML (XLSX) format read by Excel, with one worksheet per
+// table. It only supports what the reports need: text and number cells, and a bold, frozen header row.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxSheetNameLength is the longest worksheet name Excel accepts
+const maxSheetNameLength = 31
+
+// Sheet is a worksheet of a workbook, whose first row is its header
+type Sheet struct {
+	Name string
+	Rows [][]interface{} // Cells are strings, integers or floats; other values are written as text
+}
+
+// WriteFile writes the sheets to an XLSX workbook at path, creating its directory when needed
+func WriteFile(path string, sheets []Sheet) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create XLSX file: %w", err)
+	}
+	defer file.Close()
+
+	if err := Write(file, sheets); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// part is a file of the zip package of a workbook
+type part struct {
+	name    string
+	content string
+}
+
+// Write writes the sheets as an XLSX workbook to w
+func Write(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("a workbook needs at least one sheet")
+	}
+
+	names := make([]string, len(sheets))
+	seen := make(map[string]bool)
+	for i, sheet := range sheets {
+		names[i] = sheetName(sheet.Name)
+		if seen[strings.ToLower(names[i])] {
+			return fmt.Errorf("duplicate sheet name %q", names[i])
+		}
+		seen[strings.ToLower(names[i])] = true
+	}
+
+	archive := zip.NewWriter(w)
+	files := []part{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", workbook(names)},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships(len(sheets))},
+		{"xl/styles.xml", styles},
+	}
+	for i, sheet := range sheets {
+		files = append(files, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(sheet.Rows)})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		if _, err := io.WriteString(writer, file.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	return archive.Close()
+}
+
+// sheetName returns name without the characters Excel rejects in sheet names, and truncated to its limit
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > maxSheetNameLength {
+		name = string(runes[:maxSheetNameLength])
+	}
+	if name == "" {
+		name = "Sheet"
+	}
+	return name
+}
+
+// worksheet returns the XML of a worksheet with rows, the first one bold and frozen as a header
+func worksheet(rows [][]interface{}) string {
+	var builder strings.Builder
+	builder.WriteString(xml.Header)
+	builder.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(rows) > 1 {
+		builder.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	builder.WriteString(`<sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&builder, `<row r="%d">`, i+1)
+		for j, value := range row {
+			reference := columnName(j) + strconv.Itoa(i+1)
+			style := ""
+			if i == 0 {
+				style = ` s="1"`
+			}
+			switch v := value.(type) {
+			case int, int64, float64:
+				fmt.Fprintf(&builder, `<c r="%s"%s><v>%v</v></c>`, reference, style, v)
+			case nil:
+				continue
+			default:
+				fmt.Fprintf(&builder, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, style, escape(fmt.Sprint(v)))
+			}
+		}
+		builder.WriteString(`</row>`)
+	}
+	builder.WriteString(`</sheetData></worksheet>`)
+	return builder.String()
+}
+
+// columnName returns the letters of the zero-based column index, e.g. A, Z, AA
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// escape returns text escaped for XML, without the control characters XML cannot contain
+func escape(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, text)
+
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
+
+// contentTypes returns the content types of the parts of a workbook with count sheets
+func contentTypes(count int) string {
+	var builder strings.Builder
+	builder.WriteString(xml.Header)
+	builder.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	builder.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	builder.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	builder.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	builder.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&builder, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	builder.WriteString(`</Types>`)
+	return builder.String()
+}
+
+// workbook returns the workbook part listing the sheets by name
+func workbook(names []string) string {
+	var builder strings.Builder
+	builder.WriteString(xml.Header)
+	builder.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&builder, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(name), i+1, i+1)
+	}
+	builder.WriteString(`</sheets></workbook>`)
+	return builder.String()
+}
+
+// workbookRelationships returns the relationships of the workbook to its count sheets and its styles
+func workbookRelationships(count int) string {
+	var builder strings.Builder
+	builder.WriteString(xml.Header)
+	builder.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&builder, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&builder, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, count+1)
+	builder.WriteString(`</Relationships>`)
+	return builder.String()
+}
+
+// rootRelationships points to the workbook part of the package
+const rootRelationships = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles defines the default cell style (0) and the bold header style (1)
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
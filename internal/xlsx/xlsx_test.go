@@ -0,0 +1,71 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readParts returns the content of every file of a workbook by name
+func readParts(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		parts[file.Name] = string(content)
+
+		// Every part must be well-formed XML
+		decoder := xml.NewDecoder(strings.NewReader(parts[file.Name]))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else {
+				require.NoError(t, err, file.Name)
+			}
+		}
+	}
+	return parts
+}
+
+func TestWrite(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Write(&buffer, []Sheet{
+		{Name: "Counts", Rows: [][]interface{}{{"Metric", "Count"}, {"Issues", 42}, {"Notes <&>", 1.5}}},
+		{Name: "Pull Requests: open/closed", Rows: [][]interface{}{{"State"}}},
+	})
+	require.NoError(t, err)
+
+	parts := readParts(t, buffer.Bytes())
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/styles.xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Counts" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Pull Requests- open-closed" sheetId="2" r:id="rId2"/>`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Metric</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>42</v></c>`)
+	assert.Contains(t, sheet, `<t xml:space="preserve">Notes &lt;&amp;&gt;</t>`)
+	assert.Contains(t, sheet, `state="frozen"`)
+}
+
+func TestWrite_Invalid(t *testing.T) {
+	assert.EqualError(t, Write(io.Discard, nil), "a workbook needs at least one sheet")
+	assert.EqualError(t, Write(io.Discard, []Sheet{{Name: "Counts"}, {Name: "counts"}}), `duplicate sheet name "counts"`)
+}
+
+func TestColumnName(t *testing.T) {
+	for index, expected := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, expected, columnName(index))
+	}
+}
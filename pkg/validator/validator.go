@@ -0,0 +1,239 @@
+// Package validator validates GitHub repository migrations from Go programs, without running the CLI.
+// It reads no configuration from flags, environment variables or files: everything is set in Options.
+package validator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+)
+
+// Repository names a repository by owner and name
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+// String returns the repository as owner/name
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// Credentials authenticate with a GitHub instance, with a token or a GitHub App installation
+type Credentials struct {
+	Token          string
+	Hostname       string // GitHub Enterprise Server or GHE.com hostname, empty for GitHub.com
+	APIURL         string // REST API base URL used instead of the one derived from Hostname, e.g. of an API gateway
+	AppID          string
+	PrivateKey     []byte
+	InstallationID int64
+}
+
+// configured reports whether a token or a GitHub App is set
+func (c Credentials) configured() bool {
+	return c.Token != "" || c.AppID != ""
+}
+
+// Retry controls how requests failing with a transient error are retried
+type Retry struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retrying
+	Backoff    time.Duration // Delay before the first retry, doubled for every further retry
+	Jitter     time.Duration // Maximum random delay added to every backoff
+}
+
+// Options configures a validation
+type Options struct {
+	Source Repository
+	Target Repository
+
+	SourceCredentials Credentials
+	// TargetCredentials authenticate with the target instance, the source credentials when no token or
+	// GitHub App is set
+	TargetCredentials Credentials
+
+	// IssueOffset is the number of additional issues expected in the target. When nil, it is 1 when the
+	// migration log issue is found in the target and 0 otherwise.
+	IssueOffset *int
+	// FollowRenames validates against the new name when a repository has been renamed instead of failing
+	FollowRenames bool
+	// Explain adds the likely cause and next step of each failed result to the report
+	Explain bool
+	// NoLFS skips the LFS object and pattern comparisons
+	NoLFS bool
+	// CheckSecurity compares security features, which needs the security_events scope
+	CheckSecurity bool
+	// CheckLFSLocks compares the paths of the LFS file locks, which are not migrated
+	CheckLFSLocks bool
+	// CheckTruncation compares the bodies of that many of the longest source issues and pull requests
+	CheckTruncation int
+	// Branches compares every branch ("all") or a comma-separated list of branches
+	Branches string
+	// CountPRsAsIssues adds pull requests to the issue counts, for sources whose issue counts include them
+	CountPRsAsIssues bool
+	// Retry controls retries of transient failures, the CLI defaults when nil
+	Retry *Retry
+	// RunID tags every request with this ID, in its User-Agent and X-GHMV-Run-Id header, when set
+	RunID string
+	// Progress receives the progress messages and spinners of the validation, discarded when nil
+	Progress io.Writer
+}
+
+// Status is the outcome of a result: pass, fail, warn, info or unavailable
+type Status string
+
+const (
+	StatusPass        Status = "pass"
+	StatusFail        Status = "fail"
+	StatusWarn        Status = "warn"
+	StatusInfo        Status = "info"
+	StatusUnavailable Status = "unavailable" // The target repository could not be retrieved
+)
+
+// Result compares one metric between the source and the target
+type Result struct {
+	Metric     string `json:"metric"`
+	Source     any    `json:"source"`
+	Target     any    `json:"target"`
+	Difference int    `json:"difference"` // Items missing in the target, negative when the target has more
+	Status     Status `json:"status"`
+	Cause      string `json:"cause,omitempty"`     // With Options.Explain, the likely cause of a failure
+	NextStep   string `json:"next_step,omitempty"` // With Options.Explain, how to fix a failure
+}
+
+// Report is the outcome of a validation
+type Report struct {
+	Source      string   `json:"source"`
+	Target      string   `json:"target"`
+	Results     []Result `json:"results"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Warnings    int      `json:"warnings"`
+	Info        int      `json:"info"`
+	Unavailable int      `json:"unavailable"`
+	// Verdict is passed, warnings (the target has more data than the source), failed (data is missing in
+	// the target) or incomplete (the target repository is unavailable)
+	Verdict string `json:"verdict"`
+	// Markdown is the report the CLI writes with --markdown-file
+	Markdown string `json:"-"`
+}
+
+// Succeeded reports whether nothing is missing in an available target
+func (r *Report) Succeeded() bool {
+	return r.Verdict == validator.VerdictPassed.String() || r.Verdict == validator.VerdictWarnings.String()
+}
+
+// ValidateRepos compares the source repository of opts with its migrated target repository. An error is
+// returned when the repositories cannot be compared; an unavailable target is reported in the results.
+func ValidateRepos(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Source.Owner == "" || opts.Source.Name == "" {
+		return nil, fmt.Errorf("source repository owner and name are required")
+	}
+	if opts.Target.Owner == "" || opts.Target.Name == "" {
+		return nil, fmt.Errorf("target repository owner and name are required")
+	}
+	if !opts.SourceCredentials.configured() {
+		return nil, fmt.Errorf("source credentials need a token or GitHub App")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	githubAPI, err := api.NewGitHubAPI(clientConfigs(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = io.Discard
+	}
+	mv := validator.NewWithOptions(githubAPI, validator.ValidationOptions{
+		IssueOffset:      opts.IssueOffset,
+		FollowRenames:    opts.FollowRenames,
+		Explain:          opts.Explain,
+		Progress:         progress,
+		NoLFS:            opts.NoLFS,
+		CheckSecurity:    opts.CheckSecurity,
+		CheckLFSLocks:    opts.CheckLFSLocks,
+		CheckTruncation:  opts.CheckTruncation,
+		Branches:         opts.Branches,
+		CountPRsAsIssues: opts.CountPRsAsIssues,
+	})
+
+	results, err := mv.ValidateMigrationContext(ctx, opts.Source.Owner, opts.Source.Name, opts.Target.Owner, opts.Target.Name)
+	if err != nil {
+		return nil, err
+	}
+	return newReport(mv, opts, results), nil
+}
+
+// clientConfigs returns the API client configurations of the source and target credentials
+func clientConfigs(opts Options) (api.ClientConfig, api.ClientConfig) {
+	retry := api.DefaultRetryConfig()
+	if opts.Retry != nil {
+		retry = api.RetryConfig{MaxRetries: max(opts.Retry.MaxRetries, 0), Backoff: opts.Retry.Backoff, Jitter: opts.Retry.Jitter}
+	}
+
+	config := func(credentials Credentials, side string) api.ClientConfig {
+		return api.ClientConfig{
+			Token:          credentials.Token,
+			Hostname:       credentials.Hostname,
+			APIURL:         credentials.APIURL,
+			AppID:          credentials.AppID,
+			PrivateKey:     credentials.PrivateKey,
+			InstallationID: credentials.InstallationID,
+			Retry:          retry,
+			UserAgent:      api.UserAgent("", side, opts.RunID),
+			RunID:          opts.RunID,
+		}
+	}
+
+	target := opts.TargetCredentials
+	if !target.configured() {
+		target = opts.SourceCredentials
+	}
+	return config(opts.SourceCredentials, "source"), config(target, "target")
+}
+
+// newReport converts the results of a validation to a report
+func newReport(mv *validator.MigrationValidator, opts Options, results []validator.ValidationResult) *Report {
+	summary := validator.Summarize(results)
+	report := &Report{
+		Source:      opts.Source.String(),
+		Target:      opts.Target.String(),
+		Results:     make([]Result, 0, len(results)),
+		Passed:      summary.Passed,
+		Failed:      summary.Failed,
+		Warnings:    summary.Warnings,
+		Info:        summary.Info,
+		Unavailable: summary.Unavailable,
+		Verdict:     summary.Verdict.String(),
+		Markdown:    mv.MarkdownReport(results),
+	}
+
+	remediations := make(map[string]validator.RemediationRule)
+	if opts.Explain {
+		for _, remediation := range mv.Remediations(results) {
+			remediations[remediation.Metric] = remediation.Rule
+		}
+	}
+
+	for _, result := range results {
+		rule := remediations[result.Metric]
+		report.Results = append(report.Results, Result{
+			Metric:     result.Metric,
+			Source:     result.SourceVal,
+			Target:     result.TargetVal,
+			Difference: result.Difference,
+			Status:     Status(strings.ToLower(result.StatusType.String())),
+			Cause:      rule.Cause,
+			NextStep:   rule.NextStep,
+		})
+	}
+	return report
+}
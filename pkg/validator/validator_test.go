@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mona-actions/gh-migration-validator/internal/api"
+	"mona-actions/gh-migration-validator/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRepos_InvalidOptions(t *testing.T) {
+	source := Repository{Owner: "source-org", Name: "repo"}
+	target := Repository{Owner: "target-org", Name: "repo"}
+	credentials := Credentials{Token: "token"}
+
+	tests := []struct {
+		name string
+		opts Options
+		err  string
+	}{
+		{"missing source", Options{Target: target, SourceCredentials: credentials}, "source repository"},
+		{"missing target", Options{Source: source, SourceCredentials: credentials}, "target repository"},
+		{"missing credentials", Options{Source: source, Target: target}, "source credentials"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateRepos(context.Background(), tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestValidateRepos_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ValidateRepos(ctx, Options{
+		Source:            Repository{Owner: "source-org", Name: "repo"},
+		Target:            Repository{Owner: "target-org", Name: "repo"},
+		SourceCredentials: Credentials{Token: "token"},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientConfigs(t *testing.T) {
+	opts := Options{SourceCredentials: Credentials{Token: "source-token", Hostname: "ghes.example.com"}}
+
+	source, target := clientConfigs(opts)
+	assert.Equal(t, "source-token", source.Token)
+	assert.Equal(t, "source-token", target.Token, "the target reuses the source credentials when none are set")
+	assert.Equal(t, api.DefaultRetryConfig(), source.Retry)
+	assert.Equal(t, "gh-migration-validator (source)", source.UserAgent)
+
+	opts.TargetCredentials = Credentials{Token: "target-token"}
+	opts.Retry = &Retry{MaxRetries: -1, Backoff: time.Millisecond}
+	opts.RunID = "cutover-42"
+	source, target = clientConfigs(opts)
+	assert.Equal(t, "gh-migration-validator (target; run cutover-42)", target.UserAgent)
+	assert.Equal(t, "cutover-42", target.RunID)
+	assert.Equal(t, "target-token", target.Token)
+	assert.Equal(t, "", target.Hostname)
+	assert.Equal(t, api.RetryConfig{MaxRetries: 0, Backoff: time.Millisecond}, source.Retry)
+}
+
+func TestNewReport(t *testing.T) {
+	mv := validator.New(nil)
+	mv.SourceData = &validator.RepositoryData{Owner: "source-org", Name: "repo"}
+	mv.TargetData = &validator.RepositoryData{Owner: "target-org", Name: "repo"}
+	results := []validator.ValidationResult{
+		{Metric: "Tags", SourceVal: 3, TargetVal: 3, StatusType: validator.ValidationStatusPass},
+		{Metric: "Releases", SourceVal: 2, TargetVal: 1, Difference: 1, StatusType: validator.ValidationStatusFail},
+	}
+	opts := Options{
+		Source:  Repository{Owner: "source-org", Name: "repo"},
+		Target:  Repository{Owner: "target-org", Name: "repo"},
+		Explain: true,
+	}
+
+	report := newReport(mv, opts, results)
+
+	assert.Equal(t, "source-org/repo", report.Source)
+	assert.Equal(t, "target-org/repo", report.Target)
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, "failed", report.Verdict)
+	assert.False(t, report.Succeeded())
+	assert.Contains(t, report.Markdown, "Releases")
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, StatusPass, report.Results[0].Status)
+	assert.Empty(t, report.Results[0].NextStep, "passed results are not explained")
+	assert.Equal(t, StatusFail, report.Results[1].Status)
+	assert.Equal(t, 1, report.Results[1].Difference)
+	assert.NotEmpty(t, report.Results[1].NextStep)
+}